@@ -211,12 +211,12 @@ type GPSEphemeris capnp.Struct
 const GPSEphemeris_TypeID = 0xd67148628f889f75
 
 func NewGPSEphemeris(s *capnp.Segment) (GPSEphemeris, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 5})
 	return GPSEphemeris(st), err
 }
 
 func NewRootGPSEphemeris(s *capnp.Segment) (GPSEphemeris, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 5})
 	return GPSEphemeris(st), err
 }
 
@@ -356,12 +356,60 @@ func (s GPSEphemeris) SetSquareRootOfSemiMajorAxis(v float64) {
 	capnp.Struct(s).SetUint64(0, math.Float64bits(v))
 }
 
+func (s GPSEphemeris) MessageType() NavMessageType {
+	return NavMessageType(capnp.Struct(s).Uint16(8))
+}
+
+func (s GPSEphemeris) SetMessageType(v NavMessageType) {
+	capnp.Struct(s).SetUint16(8, uint16(v))
+}
+
+func (s GPSEphemeris) ADot() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(16))
+}
+
+func (s GPSEphemeris) SetADot(v float64) {
+	capnp.Struct(s).SetUint64(16, math.Float64bits(v))
+}
+
+func (s GPSEphemeris) DeltaNDot() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(24))
+}
+
+func (s GPSEphemeris) SetDeltaNDot(v float64) {
+	capnp.Struct(s).SetUint64(24, math.Float64bits(v))
+}
+
+func (s GPSEphemeris) InterSignalCorrections() (capnp.Float64List, error) {
+	p, err := capnp.Struct(s).Ptr(4)
+	return capnp.Float64List(p.List()), err
+}
+
+func (s GPSEphemeris) HasInterSignalCorrections() bool {
+	return capnp.Struct(s).HasPtr(4)
+}
+
+func (s GPSEphemeris) SetInterSignalCorrections(v capnp.Float64List) error {
+	return capnp.Struct(s).SetPtr(4, v.ToPtr())
+}
+
+// NewInterSignalCorrections sets the interSignalCorrections field to a newly
+// allocated capnp.Float64List, preferring placement in s's segment.
+func (s GPSEphemeris) NewInterSignalCorrections(n int32) (capnp.Float64List, error) {
+	l, err := capnp.NewFloat64List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.Float64List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(4, l.ToPtr())
+	return l, err
+}
+
 // GPSEphemeris_List is a list of GPSEphemeris.
 type GPSEphemeris_List = capnp.StructList[GPSEphemeris]
 
 // NewGPSEphemeris creates a new list of GPSEphemeris.
 func NewGPSEphemeris_List(s *capnp.Segment, sz int32) (GPSEphemeris_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 5}, sz)
 	return capnp.StructList[GPSEphemeris](l), err
 }
 
@@ -385,1551 +433,4972 @@ func (p GPSEphemeris_Future) Toc() GPSTime_Future {
 	return GPSTime_Future{Future: p.Future.Field(3, nil)}
 }
 
-type RINEXHeader capnp.Struct
+type GalileoEphemeris capnp.Struct
 
-// RINEXHeader_TypeID is the unique identifier for the type RINEXHeader.
-const RINEXHeader_TypeID = 0x8861b2182dea79c8
+// GalileoEphemeris_TypeID is the unique identifier for the type GalileoEphemeris.
+const GalileoEphemeris_TypeID = 0xd20a074e28e674ba
 
-func NewRINEXHeader(s *capnp.Segment) (RINEXHeader, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 6})
-	return RINEXHeader(st), err
+func NewGalileoEphemeris(s *capnp.Segment) (GalileoEphemeris, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 40, PointerCount: 4})
+	return GalileoEphemeris(st), err
 }
 
-func NewRootRINEXHeader(s *capnp.Segment) (RINEXHeader, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 6})
-	return RINEXHeader(st), err
+func NewRootGalileoEphemeris(s *capnp.Segment) (GalileoEphemeris, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 40, PointerCount: 4})
+	return GalileoEphemeris(st), err
 }
 
-func ReadRootRINEXHeader(msg *capnp.Message) (RINEXHeader, error) {
+func ReadRootGalileoEphemeris(msg *capnp.Message) (GalileoEphemeris, error) {
 	root, err := msg.Root()
-	return RINEXHeader(root.Struct()), err
+	return GalileoEphemeris(root.Struct()), err
 }
 
-func (s RINEXHeader) String() string {
-	str, _ := text.Marshal(0x8861b2182dea79c8, capnp.Struct(s))
+func (s GalileoEphemeris) String() string {
+	str, _ := text.Marshal(0xd20a074e28e674ba, capnp.Struct(s))
 	return str
 }
 
-func (s RINEXHeader) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s GalileoEphemeris) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (RINEXHeader) DecodeFromPtr(p capnp.Ptr) RINEXHeader {
-	return RINEXHeader(capnp.Struct{}.DecodeFromPtr(p))
+func (GalileoEphemeris) DecodeFromPtr(p capnp.Ptr) GalileoEphemeris {
+	return GalileoEphemeris(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s RINEXHeader) ToPtr() capnp.Ptr {
+func (s GalileoEphemeris) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s RINEXHeader) IsValid() bool {
+func (s GalileoEphemeris) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s RINEXHeader) Message() *capnp.Message {
+func (s GalileoEphemeris) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s RINEXHeader) Segment() *capnp.Segment {
+func (s GalileoEphemeris) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s RINEXHeader) Version() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(0))
-}
-
-func (s RINEXHeader) SetVersion(v float64) {
-	capnp.Struct(s).SetUint64(0, math.Float64bits(v))
-}
-
-func (s RINEXHeader) Type() (string, error) {
+func (s GalileoEphemeris) BaseEphemeris() (BaseEphemeris, error) {
 	p, err := capnp.Struct(s).Ptr(0)
-	return p.Text(), err
+	return BaseEphemeris(p.Struct()), err
 }
 
-func (s RINEXHeader) HasType() bool {
+func (s GalileoEphemeris) HasBaseEphemeris() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s RINEXHeader) TypeBytes() ([]byte, error) {
-	p, err := capnp.Struct(s).Ptr(0)
-	return p.TextBytes(), err
+func (s GalileoEphemeris) SetBaseEphemeris(v BaseEphemeris) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
 
-func (s RINEXHeader) SetType(v string) error {
-	return capnp.Struct(s).SetText(0, v)
+// NewBaseEphemeris sets the baseEphemeris field to a newly
+// allocated BaseEphemeris struct, preferring placement in s's segment.
+func (s GalileoEphemeris) NewBaseEphemeris() (BaseEphemeris, error) {
+	ss, err := NewBaseEphemeris(capnp.Struct(s).Segment())
+	if err != nil {
+		return BaseEphemeris{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
 }
 
-func (s RINEXHeader) SatelliteSystem() (string, error) {
+func (s GalileoEphemeris) EphemerisData() (Ephemeris, error) {
 	p, err := capnp.Struct(s).Ptr(1)
-	return p.Text(), err
+	return Ephemeris(p.Struct()), err
 }
 
-func (s RINEXHeader) HasSatelliteSystem() bool {
+func (s GalileoEphemeris) HasEphemerisData() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
-func (s RINEXHeader) SatelliteSystemBytes() ([]byte, error) {
-	p, err := capnp.Struct(s).Ptr(1)
-	return p.TextBytes(), err
+func (s GalileoEphemeris) SetEphemerisData(v Ephemeris) error {
+	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
 }
 
-func (s RINEXHeader) SetSatelliteSystem(v string) error {
-	return capnp.Struct(s).SetText(1, v)
+// NewEphemerisData sets the ephemerisData field to a newly
+// allocated Ephemeris struct, preferring placement in s's segment.
+func (s GalileoEphemeris) NewEphemerisData() (Ephemeris, error) {
+	ss, err := NewEphemeris(capnp.Struct(s).Segment())
+	if err != nil {
+		return Ephemeris{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, capnp.Struct(ss).ToPtr())
+	return ss, err
 }
 
-func (s RINEXHeader) ProgramName() (string, error) {
+func (s GalileoEphemeris) Toe() (GPSTime, error) {
 	p, err := capnp.Struct(s).Ptr(2)
-	return p.Text(), err
+	return GPSTime(p.Struct()), err
 }
 
-func (s RINEXHeader) HasProgramName() bool {
+func (s GalileoEphemeris) HasToe() bool {
 	return capnp.Struct(s).HasPtr(2)
 }
 
-func (s RINEXHeader) ProgramNameBytes() ([]byte, error) {
-	p, err := capnp.Struct(s).Ptr(2)
-	return p.TextBytes(), err
+func (s GalileoEphemeris) SetToe(v GPSTime) error {
+	return capnp.Struct(s).SetPtr(2, capnp.Struct(v).ToPtr())
 }
 
-func (s RINEXHeader) SetProgramName(v string) error {
-	return capnp.Struct(s).SetText(2, v)
+// NewToe sets the toe field to a newly
+// allocated GPSTime struct, preferring placement in s's segment.
+func (s GalileoEphemeris) NewToe() (GPSTime, error) {
+	ss, err := NewGPSTime(capnp.Struct(s).Segment())
+	if err != nil {
+		return GPSTime{}, err
+	}
+	err = capnp.Struct(s).SetPtr(2, capnp.Struct(ss).ToPtr())
+	return ss, err
 }
 
-func (s RINEXHeader) Agency() (string, error) {
+func (s GalileoEphemeris) Toc() (GPSTime, error) {
 	p, err := capnp.Struct(s).Ptr(3)
-	return p.Text(), err
+	return GPSTime(p.Struct()), err
 }
 
-func (s RINEXHeader) HasAgency() bool {
+func (s GalileoEphemeris) HasToc() bool {
 	return capnp.Struct(s).HasPtr(3)
 }
 
-func (s RINEXHeader) AgencyBytes() ([]byte, error) {
-	p, err := capnp.Struct(s).Ptr(3)
-	return p.TextBytes(), err
+func (s GalileoEphemeris) SetToc(v GPSTime) error {
+	return capnp.Struct(s).SetPtr(3, capnp.Struct(v).ToPtr())
 }
 
-func (s RINEXHeader) SetAgency(v string) error {
-	return capnp.Struct(s).SetText(3, v)
+// NewToc sets the toc field to a newly
+// allocated GPSTime struct, preferring placement in s's segment.
+func (s GalileoEphemeris) NewToc() (GPSTime, error) {
+	ss, err := NewGPSTime(capnp.Struct(s).Segment())
+	if err != nil {
+		return GPSTime{}, err
+	}
+	err = capnp.Struct(s).SetPtr(3, capnp.Struct(ss).ToPtr())
+	return ss, err
 }
 
-func (s RINEXHeader) Date() (Time, error) {
-	p, err := capnp.Struct(s).Ptr(4)
-	return Time(p.Struct()), err
+func (s GalileoEphemeris) SquareRootOfSemiMajorAxis() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(0))
 }
 
-func (s RINEXHeader) HasDate() bool {
-	return capnp.Struct(s).HasPtr(4)
+func (s GalileoEphemeris) SetSquareRootOfSemiMajorAxis(v float64) {
+	capnp.Struct(s).SetUint64(0, math.Float64bits(v))
 }
 
-func (s RINEXHeader) SetDate(v Time) error {
-	return capnp.Struct(s).SetPtr(4, capnp.Struct(v).ToPtr())
+func (s GalileoEphemeris) MessageType() NavMessageType {
+	return NavMessageType(capnp.Struct(s).Uint16(8))
 }
 
-// NewDate sets the date field to a newly
-// allocated Time struct, preferring placement in s's segment.
-func (s RINEXHeader) NewDate() (Time, error) {
-	ss, err := NewTime(capnp.Struct(s).Segment())
-	if err != nil {
-		return Time{}, err
-	}
-	err = capnp.Struct(s).SetPtr(4, capnp.Struct(ss).ToPtr())
-	return ss, err
+func (s GalileoEphemeris) SetMessageType(v NavMessageType) {
+	capnp.Struct(s).SetUint16(8, uint16(v))
 }
 
-func (s RINEXHeader) Comments() (capnp.TextList, error) {
-	p, err := capnp.Struct(s).Ptr(5)
-	return capnp.TextList(p.List()), err
+func (s GalileoEphemeris) DataSources() uint16 {
+	return capnp.Struct(s).Uint16(10)
 }
 
-func (s RINEXHeader) HasComments() bool {
-	return capnp.Struct(s).HasPtr(5)
+func (s GalileoEphemeris) SetDataSources(v uint16) {
+	capnp.Struct(s).SetUint16(10, v)
 }
 
-func (s RINEXHeader) SetComments(v capnp.TextList) error {
-	return capnp.Struct(s).SetPtr(5, v.ToPtr())
+func (s GalileoEphemeris) Sisa() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(16))
 }
 
-// NewComments sets the comments field to a newly
-// allocated capnp.TextList, preferring placement in s's segment.
-func (s RINEXHeader) NewComments(n int32) (capnp.TextList, error) {
-	l, err := capnp.NewTextList(capnp.Struct(s).Segment(), n)
-	if err != nil {
-		return capnp.TextList{}, err
-	}
-	err = capnp.Struct(s).SetPtr(5, l.ToPtr())
-	return l, err
+func (s GalileoEphemeris) SetSisa(v float64) {
+	capnp.Struct(s).SetUint64(16, math.Float64bits(v))
 }
-func (s RINEXHeader) LeapSeconds() int32 {
-	return int32(capnp.Struct(s).Uint32(8))
+
+func (s GalileoEphemeris) BgdE5aE1() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(24))
 }
 
-func (s RINEXHeader) SetLeapSeconds(v int32) {
-	capnp.Struct(s).SetUint32(8, uint32(v))
+func (s GalileoEphemeris) SetBgdE5aE1(v float64) {
+	capnp.Struct(s).SetUint64(24, math.Float64bits(v))
 }
 
-// RINEXHeader_List is a list of RINEXHeader.
-type RINEXHeader_List = capnp.StructList[RINEXHeader]
+func (s GalileoEphemeris) BgdE5bE1() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(32))
+}
 
-// NewRINEXHeader creates a new list of RINEXHeader.
-func NewRINEXHeader_List(s *capnp.Segment, sz int32) (RINEXHeader_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 6}, sz)
-	return capnp.StructList[RINEXHeader](l), err
+func (s GalileoEphemeris) SetBgdE5bE1(v float64) {
+	capnp.Struct(s).SetUint64(32, math.Float64bits(v))
 }
 
-// RINEXHeader_Future is a wrapper for a RINEXHeader promised by a client call.
-type RINEXHeader_Future struct{ *capnp.Future }
+// GalileoEphemeris_List is a list of GalileoEphemeris.
+type GalileoEphemeris_List = capnp.StructList[GalileoEphemeris]
 
-func (f RINEXHeader_Future) Struct() (RINEXHeader, error) {
+// NewGalileoEphemeris creates a new list of GalileoEphemeris.
+func NewGalileoEphemeris_List(s *capnp.Segment, sz int32) (GalileoEphemeris_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 40, PointerCount: 4}, sz)
+	return capnp.StructList[GalileoEphemeris](l), err
+}
+
+// GalileoEphemeris_Future is a wrapper for a GalileoEphemeris promised by a client call.
+type GalileoEphemeris_Future struct{ *capnp.Future }
+
+func (f GalileoEphemeris_Future) Struct() (GalileoEphemeris, error) {
 	p, err := f.Future.Ptr()
-	return RINEXHeader(p.Struct()), err
+	return GalileoEphemeris(p.Struct()), err
 }
-func (p RINEXHeader_Future) Date() Time_Future {
-	return Time_Future{Future: p.Future.Field(4, nil)}
+func (p GalileoEphemeris_Future) BaseEphemeris() BaseEphemeris_Future {
+	return BaseEphemeris_Future{Future: p.Future.Field(0, nil)}
+}
+func (p GalileoEphemeris_Future) EphemerisData() Ephemeris_Future {
+	return Ephemeris_Future{Future: p.Future.Field(1, nil)}
+}
+func (p GalileoEphemeris_Future) Toe() GPSTime_Future {
+	return GPSTime_Future{Future: p.Future.Field(2, nil)}
+}
+func (p GalileoEphemeris_Future) Toc() GPSTime_Future {
+	return GPSTime_Future{Future: p.Future.Field(3, nil)}
 }
 
-type Ephemeris capnp.Struct
+type BeiDouEphemeris capnp.Struct
 
-// Ephemeris_TypeID is the unique identifier for the type Ephemeris.
-const Ephemeris_TypeID = 0x9691bc6bef5f044a
+// BeiDouEphemeris_TypeID is the unique identifier for the type BeiDouEphemeris.
+const BeiDouEphemeris_TypeID = 0xc6f81a529b1ee75c
 
-func NewEphemeris(s *capnp.Segment) (Ephemeris, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 264, PointerCount: 2})
-	return Ephemeris(st), err
+func NewBeiDouEphemeris(s *capnp.Segment) (BeiDouEphemeris, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 64, PointerCount: 4})
+	return BeiDouEphemeris(st), err
 }
 
-func NewRootEphemeris(s *capnp.Segment) (Ephemeris, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 264, PointerCount: 2})
-	return Ephemeris(st), err
+func NewRootBeiDouEphemeris(s *capnp.Segment) (BeiDouEphemeris, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 64, PointerCount: 4})
+	return BeiDouEphemeris(st), err
 }
 
-func ReadRootEphemeris(msg *capnp.Message) (Ephemeris, error) {
+func ReadRootBeiDouEphemeris(msg *capnp.Message) (BeiDouEphemeris, error) {
 	root, err := msg.Root()
-	return Ephemeris(root.Struct()), err
+	return BeiDouEphemeris(root.Struct()), err
 }
 
-func (s Ephemeris) String() string {
-	str, _ := text.Marshal(0x9691bc6bef5f044a, capnp.Struct(s))
+func (s BeiDouEphemeris) String() string {
+	str, _ := text.Marshal(0xc6f81a529b1ee75c, capnp.Struct(s))
 	return str
 }
 
-func (s Ephemeris) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s BeiDouEphemeris) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (Ephemeris) DecodeFromPtr(p capnp.Ptr) Ephemeris {
-	return Ephemeris(capnp.Struct{}.DecodeFromPtr(p))
+func (BeiDouEphemeris) DecodeFromPtr(p capnp.Ptr) BeiDouEphemeris {
+	return BeiDouEphemeris(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s Ephemeris) ToPtr() capnp.Ptr {
+func (s BeiDouEphemeris) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s Ephemeris) IsValid() bool {
+func (s BeiDouEphemeris) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s Ephemeris) Message() *capnp.Message {
+func (s BeiDouEphemeris) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s Ephemeris) Segment() *capnp.Segment {
+func (s BeiDouEphemeris) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s Ephemeris) SvId() uint16 {
-	return capnp.Struct(s).Uint16(0)
-}
-
-func (s Ephemeris) SetSvId(v uint16) {
-	capnp.Struct(s).SetUint16(0, v)
+func (s BeiDouEphemeris) BaseEphemeris() (BaseEphemeris, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return BaseEphemeris(p.Struct()), err
 }
 
-func (s Ephemeris) Year() uint16 {
-	return capnp.Struct(s).Uint16(2)
+func (s BeiDouEphemeris) HasBaseEphemeris() bool {
+	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s Ephemeris) SetYear(v uint16) {
-	capnp.Struct(s).SetUint16(2, v)
+func (s BeiDouEphemeris) SetBaseEphemeris(v BaseEphemeris) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
 
-func (s Ephemeris) Month() uint16 {
-	return capnp.Struct(s).Uint16(4)
+// NewBaseEphemeris sets the baseEphemeris field to a newly
+// allocated BaseEphemeris struct, preferring placement in s's segment.
+func (s BeiDouEphemeris) NewBaseEphemeris() (BaseEphemeris, error) {
+	ss, err := NewBaseEphemeris(capnp.Struct(s).Segment())
+	if err != nil {
+		return BaseEphemeris{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
 }
 
-func (s Ephemeris) SetMonth(v uint16) {
-	capnp.Struct(s).SetUint16(4, v)
+func (s BeiDouEphemeris) EphemerisData() (Ephemeris, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return Ephemeris(p.Struct()), err
 }
 
-func (s Ephemeris) Day() uint16 {
-	return capnp.Struct(s).Uint16(6)
+func (s BeiDouEphemeris) HasEphemerisData() bool {
+	return capnp.Struct(s).HasPtr(1)
 }
 
-func (s Ephemeris) SetDay(v uint16) {
-	capnp.Struct(s).SetUint16(6, v)
+func (s BeiDouEphemeris) SetEphemerisData(v Ephemeris) error {
+	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
 }
 
-func (s Ephemeris) Hour() uint16 {
-	return capnp.Struct(s).Uint16(8)
+// NewEphemerisData sets the ephemerisData field to a newly
+// allocated Ephemeris struct, preferring placement in s's segment.
+func (s BeiDouEphemeris) NewEphemerisData() (Ephemeris, error) {
+	ss, err := NewEphemeris(capnp.Struct(s).Segment())
+	if err != nil {
+		return Ephemeris{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, capnp.Struct(ss).ToPtr())
+	return ss, err
 }
 
-func (s Ephemeris) SetHour(v uint16) {
-	capnp.Struct(s).SetUint16(8, v)
+func (s BeiDouEphemeris) Toe() (GPSTime, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return GPSTime(p.Struct()), err
 }
 
-func (s Ephemeris) Minute() uint16 {
-	return capnp.Struct(s).Uint16(10)
+func (s BeiDouEphemeris) HasToe() bool {
+	return capnp.Struct(s).HasPtr(2)
 }
 
-func (s Ephemeris) SetMinute(v uint16) {
-	capnp.Struct(s).SetUint16(10, v)
+func (s BeiDouEphemeris) SetToe(v GPSTime) error {
+	return capnp.Struct(s).SetPtr(2, capnp.Struct(v).ToPtr())
 }
 
-func (s Ephemeris) Second() float32 {
-	return math.Float32frombits(capnp.Struct(s).Uint32(12))
+// NewToe sets the toe field to a newly
+// allocated GPSTime struct, preferring placement in s's segment.
+func (s BeiDouEphemeris) NewToe() (GPSTime, error) {
+	ss, err := NewGPSTime(capnp.Struct(s).Segment())
+	if err != nil {
+		return GPSTime{}, err
+	}
+	err = capnp.Struct(s).SetPtr(2, capnp.Struct(ss).ToPtr())
+	return ss, err
 }
 
-func (s Ephemeris) SetSecond(v float32) {
-	capnp.Struct(s).SetUint32(12, math.Float32bits(v))
+func (s BeiDouEphemeris) Toc() (GPSTime, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return GPSTime(p.Struct()), err
 }
 
-func (s Ephemeris) Af0() float64 {
+func (s BeiDouEphemeris) HasToc() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s BeiDouEphemeris) SetToc(v GPSTime) error {
+	return capnp.Struct(s).SetPtr(3, capnp.Struct(v).ToPtr())
+}
+
+// NewToc sets the toc field to a newly
+// allocated GPSTime struct, preferring placement in s's segment.
+func (s BeiDouEphemeris) NewToc() (GPSTime, error) {
+	ss, err := NewGPSTime(capnp.Struct(s).Segment())
+	if err != nil {
+		return GPSTime{}, err
+	}
+	err = capnp.Struct(s).SetPtr(3, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s BeiDouEphemeris) SquareRootOfSemiMajorAxis() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(0))
+}
+
+func (s BeiDouEphemeris) SetSquareRootOfSemiMajorAxis(v float64) {
+	capnp.Struct(s).SetUint64(0, math.Float64bits(v))
+}
+
+func (s BeiDouEphemeris) MessageType() NavMessageType {
+	return NavMessageType(capnp.Struct(s).Uint16(8))
+}
+
+func (s BeiDouEphemeris) SetMessageType(v NavMessageType) {
+	capnp.Struct(s).SetUint16(8, uint16(v))
+}
+
+func (s BeiDouEphemeris) Aode() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(16))
 }
 
-func (s Ephemeris) SetAf0(v float64) {
+func (s BeiDouEphemeris) SetAode(v float64) {
 	capnp.Struct(s).SetUint64(16, math.Float64bits(v))
 }
 
-func (s Ephemeris) Af1() float64 {
+func (s BeiDouEphemeris) Aodc() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(24))
 }
 
-func (s Ephemeris) SetAf1(v float64) {
+func (s BeiDouEphemeris) SetAodc(v float64) {
 	capnp.Struct(s).SetUint64(24, math.Float64bits(v))
 }
 
-func (s Ephemeris) Af2() float64 {
+func (s BeiDouEphemeris) Tgd1() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(32))
 }
 
-func (s Ephemeris) SetAf2(v float64) {
+func (s BeiDouEphemeris) SetTgd1(v float64) {
 	capnp.Struct(s).SetUint64(32, math.Float64bits(v))
 }
 
-func (s Ephemeris) Iode() float64 {
+func (s BeiDouEphemeris) Tgd2() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(40))
 }
 
-func (s Ephemeris) SetIode(v float64) {
+func (s BeiDouEphemeris) SetTgd2(v float64) {
 	capnp.Struct(s).SetUint64(40, math.Float64bits(v))
 }
 
-func (s Ephemeris) Crs() float64 {
+func (s BeiDouEphemeris) ADot() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(48))
 }
 
-func (s Ephemeris) SetCrs(v float64) {
+func (s BeiDouEphemeris) SetADot(v float64) {
 	capnp.Struct(s).SetUint64(48, math.Float64bits(v))
 }
 
-func (s Ephemeris) DeltaN() float64 {
+func (s BeiDouEphemeris) DeltaNDot() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(56))
 }
 
-func (s Ephemeris) SetDeltaN(v float64) {
+func (s BeiDouEphemeris) SetDeltaNDot(v float64) {
 	capnp.Struct(s).SetUint64(56, math.Float64bits(v))
 }
 
-func (s Ephemeris) M0() float64 {
+// BeiDouEphemeris_List is a list of BeiDouEphemeris.
+type BeiDouEphemeris_List = capnp.StructList[BeiDouEphemeris]
+
+// NewBeiDouEphemeris creates a new list of BeiDouEphemeris.
+func NewBeiDouEphemeris_List(s *capnp.Segment, sz int32) (BeiDouEphemeris_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 64, PointerCount: 4}, sz)
+	return capnp.StructList[BeiDouEphemeris](l), err
+}
+
+// BeiDouEphemeris_Future is a wrapper for a BeiDouEphemeris promised by a client call.
+type BeiDouEphemeris_Future struct{ *capnp.Future }
+
+func (f BeiDouEphemeris_Future) Struct() (BeiDouEphemeris, error) {
+	p, err := f.Future.Ptr()
+	return BeiDouEphemeris(p.Struct()), err
+}
+func (p BeiDouEphemeris_Future) BaseEphemeris() BaseEphemeris_Future {
+	return BaseEphemeris_Future{Future: p.Future.Field(0, nil)}
+}
+func (p BeiDouEphemeris_Future) EphemerisData() Ephemeris_Future {
+	return Ephemeris_Future{Future: p.Future.Field(1, nil)}
+}
+func (p BeiDouEphemeris_Future) Toe() GPSTime_Future {
+	return GPSTime_Future{Future: p.Future.Field(2, nil)}
+}
+func (p BeiDouEphemeris_Future) Toc() GPSTime_Future {
+	return GPSTime_Future{Future: p.Future.Field(3, nil)}
+}
+
+type SBASEphemeris capnp.Struct
+
+// SBASEphemeris_TypeID is the unique identifier for the type SBASEphemeris.
+const SBASEphemeris_TypeID = 0x9b1c8905533fc36b
+
+func NewSBASEphemeris(s *capnp.Segment) (SBASEphemeris, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 128, PointerCount: 2})
+	return SBASEphemeris(st), err
+}
+
+func NewRootSBASEphemeris(s *capnp.Segment) (SBASEphemeris, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 128, PointerCount: 2})
+	return SBASEphemeris(st), err
+}
+
+func ReadRootSBASEphemeris(msg *capnp.Message) (SBASEphemeris, error) {
+	root, err := msg.Root()
+	return SBASEphemeris(root.Struct()), err
+}
+
+func (s SBASEphemeris) String() string {
+	str, _ := text.Marshal(0x9b1c8905533fc36b, capnp.Struct(s))
+	return str
+}
+
+func (s SBASEphemeris) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (SBASEphemeris) DecodeFromPtr(p capnp.Ptr) SBASEphemeris {
+	return SBASEphemeris(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s SBASEphemeris) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s SBASEphemeris) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s SBASEphemeris) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s SBASEphemeris) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s SBASEphemeris) BaseEphemeris() (BaseEphemeris, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return BaseEphemeris(p.Struct()), err
+}
+
+func (s SBASEphemeris) HasBaseEphemeris() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s SBASEphemeris) SetBaseEphemeris(v BaseEphemeris) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+}
+
+// NewBaseEphemeris sets the baseEphemeris field to a newly
+// allocated BaseEphemeris struct, preferring placement in s's segment.
+func (s SBASEphemeris) NewBaseEphemeris() (BaseEphemeris, error) {
+	ss, err := NewBaseEphemeris(capnp.Struct(s).Segment())
+	if err != nil {
+		return BaseEphemeris{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s SBASEphemeris) SatelliteId() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s SBASEphemeris) SetSatelliteId(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+func (s SBASEphemeris) Toc() (GPSTime, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return GPSTime(p.Struct()), err
+}
+
+func (s SBASEphemeris) HasToc() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s SBASEphemeris) SetToc(v GPSTime) error {
+	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
+}
+
+// NewToc sets the toc field to a newly
+// allocated GPSTime struct, preferring placement in s's segment.
+func (s SBASEphemeris) NewToc() (GPSTime, error) {
+	ss, err := NewGPSTime(capnp.Struct(s).Segment())
+	if err != nil {
+		return GPSTime{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s SBASEphemeris) ClockBias() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(8))
+}
+
+func (s SBASEphemeris) SetClockBias(v float64) {
+	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
+}
+
+func (s SBASEphemeris) RelativeFrequencyBias() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(16))
+}
+
+func (s SBASEphemeris) SetRelativeFrequencyBias(v float64) {
+	capnp.Struct(s).SetUint64(16, math.Float64bits(v))
+}
+
+func (s SBASEphemeris) TransmissionTime() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(24))
+}
+
+func (s SBASEphemeris) SetTransmissionTime(v float64) {
+	capnp.Struct(s).SetUint64(24, math.Float64bits(v))
+}
+
+func (s SBASEphemeris) PositionX() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(32))
+}
+
+func (s SBASEphemeris) SetPositionX(v float64) {
+	capnp.Struct(s).SetUint64(32, math.Float64bits(v))
+}
+
+func (s SBASEphemeris) VelocityX() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(40))
+}
+
+func (s SBASEphemeris) SetVelocityX(v float64) {
+	capnp.Struct(s).SetUint64(40, math.Float64bits(v))
+}
+
+func (s SBASEphemeris) AccelerationX() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(48))
+}
+
+func (s SBASEphemeris) SetAccelerationX(v float64) {
+	capnp.Struct(s).SetUint64(48, math.Float64bits(v))
+}
+
+func (s SBASEphemeris) PositionY() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(56))
+}
+
+func (s SBASEphemeris) SetPositionY(v float64) {
+	capnp.Struct(s).SetUint64(56, math.Float64bits(v))
+}
+
+func (s SBASEphemeris) VelocityY() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(64))
 }
 
-func (s Ephemeris) SetM0(v float64) {
+func (s SBASEphemeris) SetVelocityY(v float64) {
 	capnp.Struct(s).SetUint64(64, math.Float64bits(v))
 }
 
-func (s Ephemeris) Cuc() float64 {
+func (s SBASEphemeris) AccelerationY() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(72))
 }
 
-func (s Ephemeris) SetCuc(v float64) {
+func (s SBASEphemeris) SetAccelerationY(v float64) {
 	capnp.Struct(s).SetUint64(72, math.Float64bits(v))
 }
 
-func (s Ephemeris) Ecc() float64 {
+func (s SBASEphemeris) PositionZ() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(80))
 }
 
-func (s Ephemeris) SetEcc(v float64) {
+func (s SBASEphemeris) SetPositionZ(v float64) {
 	capnp.Struct(s).SetUint64(80, math.Float64bits(v))
 }
 
-func (s Ephemeris) Cus() float64 {
+func (s SBASEphemeris) VelocityZ() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(88))
 }
 
-func (s Ephemeris) SetCus(v float64) {
+func (s SBASEphemeris) SetVelocityZ(v float64) {
 	capnp.Struct(s).SetUint64(88, math.Float64bits(v))
 }
 
-func (s Ephemeris) A() float64 {
+func (s SBASEphemeris) AccelerationZ() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(96))
 }
 
-func (s Ephemeris) SetA(v float64) {
+func (s SBASEphemeris) SetAccelerationZ(v float64) {
 	capnp.Struct(s).SetUint64(96, math.Float64bits(v))
 }
 
-func (s Ephemeris) Toe() float64 {
+func (s SBASEphemeris) Health() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(104))
 }
 
-func (s Ephemeris) SetToe(v float64) {
+func (s SBASEphemeris) SetHealth(v float64) {
 	capnp.Struct(s).SetUint64(104, math.Float64bits(v))
 }
 
-func (s Ephemeris) Cic() float64 {
+func (s SBASEphemeris) UraIndex() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(112))
 }
 
-func (s Ephemeris) SetCic(v float64) {
+func (s SBASEphemeris) SetUraIndex(v float64) {
 	capnp.Struct(s).SetUint64(112, math.Float64bits(v))
 }
 
-func (s Ephemeris) Omega0() float64 {
+func (s SBASEphemeris) Iodn() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(120))
 }
 
-func (s Ephemeris) SetOmega0(v float64) {
+func (s SBASEphemeris) SetIodn(v float64) {
 	capnp.Struct(s).SetUint64(120, math.Float64bits(v))
 }
 
-func (s Ephemeris) Cis() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(128))
+// SBASEphemeris_List is a list of SBASEphemeris.
+type SBASEphemeris_List = capnp.StructList[SBASEphemeris]
+
+// NewSBASEphemeris creates a new list of SBASEphemeris.
+func NewSBASEphemeris_List(s *capnp.Segment, sz int32) (SBASEphemeris_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 128, PointerCount: 2}, sz)
+	return capnp.StructList[SBASEphemeris](l), err
+}
+
+// SBASEphemeris_Future is a wrapper for a SBASEphemeris promised by a client call.
+type SBASEphemeris_Future struct{ *capnp.Future }
+
+func (f SBASEphemeris_Future) Struct() (SBASEphemeris, error) {
+	p, err := f.Future.Ptr()
+	return SBASEphemeris(p.Struct()), err
+}
+func (p SBASEphemeris_Future) BaseEphemeris() BaseEphemeris_Future {
+	return BaseEphemeris_Future{Future: p.Future.Field(0, nil)}
+}
+func (p SBASEphemeris_Future) Toc() GPSTime_Future {
+	return GPSTime_Future{Future: p.Future.Field(1, nil)}
+}
+
+type RINEXHeader capnp.Struct
+
+// RINEXHeader_TypeID is the unique identifier for the type RINEXHeader.
+const RINEXHeader_TypeID = 0x8861b2182dea79c8
+
+func NewRINEXHeader(s *capnp.Segment) (RINEXHeader, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 9})
+	return RINEXHeader(st), err
+}
+
+func NewRootRINEXHeader(s *capnp.Segment) (RINEXHeader, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 9})
+	return RINEXHeader(st), err
+}
+
+func ReadRootRINEXHeader(msg *capnp.Message) (RINEXHeader, error) {
+	root, err := msg.Root()
+	return RINEXHeader(root.Struct()), err
+}
+
+func (s RINEXHeader) String() string {
+	str, _ := text.Marshal(0x8861b2182dea79c8, capnp.Struct(s))
+	return str
+}
+
+func (s RINEXHeader) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (RINEXHeader) DecodeFromPtr(p capnp.Ptr) RINEXHeader {
+	return RINEXHeader(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s RINEXHeader) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s RINEXHeader) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s RINEXHeader) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s RINEXHeader) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s RINEXHeader) Version() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(0))
+}
+
+func (s RINEXHeader) SetVersion(v float64) {
+	capnp.Struct(s).SetUint64(0, math.Float64bits(v))
+}
+
+func (s RINEXHeader) Type() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s RINEXHeader) HasType() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s RINEXHeader) TypeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s RINEXHeader) SetType(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s RINEXHeader) SatelliteSystem() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s RINEXHeader) HasSatelliteSystem() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s RINEXHeader) SatelliteSystemBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s RINEXHeader) SetSatelliteSystem(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s RINEXHeader) ProgramName() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s RINEXHeader) HasProgramName() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s RINEXHeader) ProgramNameBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s RINEXHeader) SetProgramName(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+func (s RINEXHeader) Agency() (string, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.Text(), err
+}
+
+func (s RINEXHeader) HasAgency() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s RINEXHeader) AgencyBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s RINEXHeader) SetAgency(v string) error {
+	return capnp.Struct(s).SetText(3, v)
+}
+
+func (s RINEXHeader) Date() (Time, error) {
+	p, err := capnp.Struct(s).Ptr(4)
+	return Time(p.Struct()), err
+}
+
+func (s RINEXHeader) HasDate() bool {
+	return capnp.Struct(s).HasPtr(4)
+}
+
+func (s RINEXHeader) SetDate(v Time) error {
+	return capnp.Struct(s).SetPtr(4, capnp.Struct(v).ToPtr())
+}
+
+// NewDate sets the date field to a newly
+// allocated Time struct, preferring placement in s's segment.
+func (s RINEXHeader) NewDate() (Time, error) {
+	ss, err := NewTime(capnp.Struct(s).Segment())
+	if err != nil {
+		return Time{}, err
+	}
+	err = capnp.Struct(s).SetPtr(4, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s RINEXHeader) Comments() (capnp.TextList, error) {
+	p, err := capnp.Struct(s).Ptr(5)
+	return capnp.TextList(p.List()), err
+}
+
+func (s RINEXHeader) HasComments() bool {
+	return capnp.Struct(s).HasPtr(5)
+}
+
+func (s RINEXHeader) SetComments(v capnp.TextList) error {
+	return capnp.Struct(s).SetPtr(5, v.ToPtr())
+}
+
+// NewComments sets the comments field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s RINEXHeader) NewComments(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = capnp.Struct(s).SetPtr(5, l.ToPtr())
+	return l, err
+}
+func (s RINEXHeader) LeapSeconds() int32 {
+	return int32(capnp.Struct(s).Uint32(8))
+}
+
+func (s RINEXHeader) SetLeapSeconds(v int32) {
+	capnp.Struct(s).SetUint32(8, uint32(v))
+}
+
+func (s RINEXHeader) FutureLeapSeconds() int32 {
+	return int32(capnp.Struct(s).Uint32(12))
+}
+
+func (s RINEXHeader) SetFutureLeapSeconds(v int32) {
+	capnp.Struct(s).SetUint32(12, uint32(v))
+}
+
+func (s RINEXHeader) LeapSecondsWeek() int32 {
+	return int32(capnp.Struct(s).Uint32(16))
+}
+
+func (s RINEXHeader) SetLeapSecondsWeek(v int32) {
+	capnp.Struct(s).SetUint32(16, uint32(v))
+}
+
+func (s RINEXHeader) LeapSecondsDay() int32 {
+	return int32(capnp.Struct(s).Uint32(20))
+}
+
+func (s RINEXHeader) SetLeapSecondsDay(v int32) {
+	capnp.Struct(s).SetUint32(20, uint32(v))
+}
+
+func (s RINEXHeader) LeapSecondsTimeSystem() (string, error) {
+	p, err := capnp.Struct(s).Ptr(6)
+	return p.Text(), err
+}
+
+func (s RINEXHeader) HasLeapSecondsTimeSystem() bool {
+	return capnp.Struct(s).HasPtr(6)
+}
+
+func (s RINEXHeader) LeapSecondsTimeSystemBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(6)
+	return p.TextBytes(), err
+}
+
+func (s RINEXHeader) SetLeapSecondsTimeSystem(v string) error {
+	return capnp.Struct(s).SetText(6, v)
+}
+
+func (s RINEXHeader) IonosphericCorrections() (IonosphericCorrection_List, error) {
+	p, err := capnp.Struct(s).Ptr(7)
+	return IonosphericCorrection_List(p.List()), err
+}
+
+func (s RINEXHeader) HasIonosphericCorrections() bool {
+	return capnp.Struct(s).HasPtr(7)
+}
+
+func (s RINEXHeader) SetIonosphericCorrections(v IonosphericCorrection_List) error {
+	return capnp.Struct(s).SetPtr(7, v.ToPtr())
+}
+
+// NewIonosphericCorrections sets the ionosphericCorrections field to a newly
+// allocated IonosphericCorrection_List, preferring placement in s's segment.
+func (s RINEXHeader) NewIonosphericCorrections(n int32) (IonosphericCorrection_List, error) {
+	l, err := NewIonosphericCorrection_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return IonosphericCorrection_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(7, l.ToPtr())
+	return l, err
+}
+func (s RINEXHeader) TimeSystemCorrections() (TimeSystemCorrection_List, error) {
+	p, err := capnp.Struct(s).Ptr(8)
+	return TimeSystemCorrection_List(p.List()), err
+}
+
+func (s RINEXHeader) HasTimeSystemCorrections() bool {
+	return capnp.Struct(s).HasPtr(8)
+}
+
+func (s RINEXHeader) SetTimeSystemCorrections(v TimeSystemCorrection_List) error {
+	return capnp.Struct(s).SetPtr(8, v.ToPtr())
+}
+
+// NewTimeSystemCorrections sets the timeSystemCorrections field to a newly
+// allocated TimeSystemCorrection_List, preferring placement in s's segment.
+func (s RINEXHeader) NewTimeSystemCorrections(n int32) (TimeSystemCorrection_List, error) {
+	l, err := NewTimeSystemCorrection_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return TimeSystemCorrection_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(8, l.ToPtr())
+	return l, err
+}
+
+// RINEXHeader_List is a list of RINEXHeader.
+type RINEXHeader_List = capnp.StructList[RINEXHeader]
+
+// NewRINEXHeader creates a new list of RINEXHeader.
+func NewRINEXHeader_List(s *capnp.Segment, sz int32) (RINEXHeader_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 9}, sz)
+	return capnp.StructList[RINEXHeader](l), err
+}
+
+// RINEXHeader_Future is a wrapper for a RINEXHeader promised by a client call.
+type RINEXHeader_Future struct{ *capnp.Future }
+
+func (f RINEXHeader_Future) Struct() (RINEXHeader, error) {
+	p, err := f.Future.Ptr()
+	return RINEXHeader(p.Struct()), err
+}
+func (p RINEXHeader_Future) Date() Time_Future {
+	return Time_Future{Future: p.Future.Field(4, nil)}
+}
+
+type IonosphericCorrection capnp.Struct
+
+// IonosphericCorrection_TypeID is the unique identifier for the type IonosphericCorrection.
+const IonosphericCorrection_TypeID = 0x9bea4ce8cd38c878
+
+func NewIonosphericCorrection(s *capnp.Segment) (IonosphericCorrection, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return IonosphericCorrection(st), err
+}
+
+func NewRootIonosphericCorrection(s *capnp.Segment) (IonosphericCorrection, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return IonosphericCorrection(st), err
+}
+
+func ReadRootIonosphericCorrection(msg *capnp.Message) (IonosphericCorrection, error) {
+	root, err := msg.Root()
+	return IonosphericCorrection(root.Struct()), err
+}
+
+func (s IonosphericCorrection) String() string {
+	str, _ := text.Marshal(0x9bea4ce8cd38c878, capnp.Struct(s))
+	return str
+}
+
+func (s IonosphericCorrection) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (IonosphericCorrection) DecodeFromPtr(p capnp.Ptr) IonosphericCorrection {
+	return IonosphericCorrection(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s IonosphericCorrection) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s IonosphericCorrection) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s IonosphericCorrection) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s IonosphericCorrection) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s IonosphericCorrection) CorrectionType() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s IonosphericCorrection) HasCorrectionType() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s IonosphericCorrection) CorrectionTypeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s IonosphericCorrection) SetCorrectionType(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s IonosphericCorrection) Parameters() (capnp.Float64List, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return capnp.Float64List(p.List()), err
+}
+
+func (s IonosphericCorrection) HasParameters() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s IonosphericCorrection) SetParameters(v capnp.Float64List) error {
+	return capnp.Struct(s).SetPtr(1, v.ToPtr())
+}
+
+// NewParameters sets the parameters field to a newly
+// allocated capnp.Float64List, preferring placement in s's segment.
+func (s IonosphericCorrection) NewParameters(n int32) (capnp.Float64List, error) {
+	l, err := capnp.NewFloat64List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.Float64List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
+	return l, err
+}
+func (s IonosphericCorrection) TimeMark() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s IonosphericCorrection) HasTimeMark() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s IonosphericCorrection) TimeMarkBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s IonosphericCorrection) SetTimeMark(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+func (s IonosphericCorrection) SatelliteId() (string, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.Text(), err
+}
+
+func (s IonosphericCorrection) HasSatelliteId() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s IonosphericCorrection) SatelliteIdBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s IonosphericCorrection) SetSatelliteId(v string) error {
+	return capnp.Struct(s).SetText(3, v)
+}
+
+// IonosphericCorrection_List is a list of IonosphericCorrection.
+type IonosphericCorrection_List = capnp.StructList[IonosphericCorrection]
+
+// NewIonosphericCorrection creates a new list of IonosphericCorrection.
+func NewIonosphericCorrection_List(s *capnp.Segment, sz int32) (IonosphericCorrection_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return capnp.StructList[IonosphericCorrection](l), err
+}
+
+// IonosphericCorrection_Future is a wrapper for a IonosphericCorrection promised by a client call.
+type IonosphericCorrection_Future struct{ *capnp.Future }
+
+func (f IonosphericCorrection_Future) Struct() (IonosphericCorrection, error) {
+	p, err := f.Future.Ptr()
+	return IonosphericCorrection(p.Struct()), err
+}
+
+type TimeSystemCorrection capnp.Struct
+
+// TimeSystemCorrection_TypeID is the unique identifier for the type TimeSystemCorrection.
+const TimeSystemCorrection_TypeID = 0xe1f97e4a71b66e6f
+
+func NewTimeSystemCorrection(s *capnp.Segment) (TimeSystemCorrection, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 3})
+	return TimeSystemCorrection(st), err
+}
+
+func NewRootTimeSystemCorrection(s *capnp.Segment) (TimeSystemCorrection, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 3})
+	return TimeSystemCorrection(st), err
+}
+
+func ReadRootTimeSystemCorrection(msg *capnp.Message) (TimeSystemCorrection, error) {
+	root, err := msg.Root()
+	return TimeSystemCorrection(root.Struct()), err
+}
+
+func (s TimeSystemCorrection) String() string {
+	str, _ := text.Marshal(0xe1f97e4a71b66e6f, capnp.Struct(s))
+	return str
+}
+
+func (s TimeSystemCorrection) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (TimeSystemCorrection) DecodeFromPtr(p capnp.Ptr) TimeSystemCorrection {
+	return TimeSystemCorrection(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s TimeSystemCorrection) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s TimeSystemCorrection) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s TimeSystemCorrection) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s TimeSystemCorrection) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s TimeSystemCorrection) CorrectionType() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s TimeSystemCorrection) HasCorrectionType() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s TimeSystemCorrection) CorrectionTypeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s TimeSystemCorrection) SetCorrectionType(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s TimeSystemCorrection) A0() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(0))
+}
+
+func (s TimeSystemCorrection) SetA0(v float64) {
+	capnp.Struct(s).SetUint64(0, math.Float64bits(v))
+}
+
+func (s TimeSystemCorrection) A1() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(8))
+}
+
+func (s TimeSystemCorrection) SetA1(v float64) {
+	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
+}
+
+func (s TimeSystemCorrection) ReferenceTime() int32 {
+	return int32(capnp.Struct(s).Uint32(16))
+}
+
+func (s TimeSystemCorrection) SetReferenceTime(v int32) {
+	capnp.Struct(s).SetUint32(16, uint32(v))
+}
+
+func (s TimeSystemCorrection) ReferenceWeek() int32 {
+	return int32(capnp.Struct(s).Uint32(20))
+}
+
+func (s TimeSystemCorrection) SetReferenceWeek(v int32) {
+	capnp.Struct(s).SetUint32(20, uint32(v))
+}
+
+func (s TimeSystemCorrection) ReferenceDate() (Time, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return Time(p.Struct()), err
+}
+
+func (s TimeSystemCorrection) HasReferenceDate() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s TimeSystemCorrection) SetReferenceDate(v Time) error {
+	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
+}
+
+// NewReferenceDate sets the referenceDate field to a newly
+// allocated Time struct, preferring placement in s's segment.
+func (s TimeSystemCorrection) NewReferenceDate() (Time, error) {
+	ss, err := NewTime(capnp.Struct(s).Segment())
+	if err != nil {
+		return Time{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s TimeSystemCorrection) Source() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s TimeSystemCorrection) HasSource() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s TimeSystemCorrection) SourceBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s TimeSystemCorrection) SetSource(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+func (s TimeSystemCorrection) UtcIdentifier() int32 {
+	return int32(capnp.Struct(s).Uint32(24))
+}
+
+func (s TimeSystemCorrection) SetUtcIdentifier(v int32) {
+	capnp.Struct(s).SetUint32(24, uint32(v))
+}
+
+// TimeSystemCorrection_List is a list of TimeSystemCorrection.
+type TimeSystemCorrection_List = capnp.StructList[TimeSystemCorrection]
+
+// NewTimeSystemCorrection creates a new list of TimeSystemCorrection.
+func NewTimeSystemCorrection_List(s *capnp.Segment, sz int32) (TimeSystemCorrection_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 3}, sz)
+	return capnp.StructList[TimeSystemCorrection](l), err
+}
+
+// TimeSystemCorrection_Future is a wrapper for a TimeSystemCorrection promised by a client call.
+type TimeSystemCorrection_Future struct{ *capnp.Future }
+
+func (f TimeSystemCorrection_Future) Struct() (TimeSystemCorrection, error) {
+	p, err := f.Future.Ptr()
+	return TimeSystemCorrection(p.Struct()), err
+}
+func (p TimeSystemCorrection_Future) ReferenceDate() Time_Future {
+	return Time_Future{Future: p.Future.Field(1, nil)}
+}
+
+type Ephemeris capnp.Struct
+
+// Ephemeris_TypeID is the unique identifier for the type Ephemeris.
+const Ephemeris_TypeID = 0x9691bc6bef5f044a
+
+func NewEphemeris(s *capnp.Segment) (Ephemeris, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 264, PointerCount: 2})
+	return Ephemeris(st), err
+}
+
+func NewRootEphemeris(s *capnp.Segment) (Ephemeris, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 264, PointerCount: 2})
+	return Ephemeris(st), err
+}
+
+func ReadRootEphemeris(msg *capnp.Message) (Ephemeris, error) {
+	root, err := msg.Root()
+	return Ephemeris(root.Struct()), err
+}
+
+func (s Ephemeris) String() string {
+	str, _ := text.Marshal(0x9691bc6bef5f044a, capnp.Struct(s))
+	return str
+}
+
+func (s Ephemeris) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Ephemeris) DecodeFromPtr(p capnp.Ptr) Ephemeris {
+	return Ephemeris(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Ephemeris) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Ephemeris) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Ephemeris) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Ephemeris) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Ephemeris) SvId() uint16 {
+	return capnp.Struct(s).Uint16(0)
+}
+
+func (s Ephemeris) SetSvId(v uint16) {
+	capnp.Struct(s).SetUint16(0, v)
+}
+
+func (s Ephemeris) Year() uint16 {
+	return capnp.Struct(s).Uint16(2)
+}
+
+func (s Ephemeris) SetYear(v uint16) {
+	capnp.Struct(s).SetUint16(2, v)
+}
+
+func (s Ephemeris) Month() uint16 {
+	return capnp.Struct(s).Uint16(4)
+}
+
+func (s Ephemeris) SetMonth(v uint16) {
+	capnp.Struct(s).SetUint16(4, v)
+}
+
+func (s Ephemeris) Day() uint16 {
+	return capnp.Struct(s).Uint16(6)
+}
+
+func (s Ephemeris) SetDay(v uint16) {
+	capnp.Struct(s).SetUint16(6, v)
+}
+
+func (s Ephemeris) Hour() uint16 {
+	return capnp.Struct(s).Uint16(8)
+}
+
+func (s Ephemeris) SetHour(v uint16) {
+	capnp.Struct(s).SetUint16(8, v)
+}
+
+func (s Ephemeris) Minute() uint16 {
+	return capnp.Struct(s).Uint16(10)
+}
+
+func (s Ephemeris) SetMinute(v uint16) {
+	capnp.Struct(s).SetUint16(10, v)
+}
+
+func (s Ephemeris) Second() float32 {
+	return math.Float32frombits(capnp.Struct(s).Uint32(12))
+}
+
+func (s Ephemeris) SetSecond(v float32) {
+	capnp.Struct(s).SetUint32(12, math.Float32bits(v))
+}
+
+func (s Ephemeris) Af0() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(16))
+}
+
+func (s Ephemeris) SetAf0(v float64) {
+	capnp.Struct(s).SetUint64(16, math.Float64bits(v))
+}
+
+func (s Ephemeris) Af1() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(24))
+}
+
+func (s Ephemeris) SetAf1(v float64) {
+	capnp.Struct(s).SetUint64(24, math.Float64bits(v))
+}
+
+func (s Ephemeris) Af2() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(32))
+}
+
+func (s Ephemeris) SetAf2(v float64) {
+	capnp.Struct(s).SetUint64(32, math.Float64bits(v))
+}
+
+func (s Ephemeris) Iode() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(40))
+}
+
+func (s Ephemeris) SetIode(v float64) {
+	capnp.Struct(s).SetUint64(40, math.Float64bits(v))
+}
+
+func (s Ephemeris) Crs() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(48))
+}
+
+func (s Ephemeris) SetCrs(v float64) {
+	capnp.Struct(s).SetUint64(48, math.Float64bits(v))
+}
+
+func (s Ephemeris) DeltaN() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(56))
+}
+
+func (s Ephemeris) SetDeltaN(v float64) {
+	capnp.Struct(s).SetUint64(56, math.Float64bits(v))
+}
+
+func (s Ephemeris) M0() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(64))
+}
+
+func (s Ephemeris) SetM0(v float64) {
+	capnp.Struct(s).SetUint64(64, math.Float64bits(v))
+}
+
+func (s Ephemeris) Cuc() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(72))
+}
+
+func (s Ephemeris) SetCuc(v float64) {
+	capnp.Struct(s).SetUint64(72, math.Float64bits(v))
+}
+
+func (s Ephemeris) Ecc() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(80))
+}
+
+func (s Ephemeris) SetEcc(v float64) {
+	capnp.Struct(s).SetUint64(80, math.Float64bits(v))
+}
+
+func (s Ephemeris) Cus() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(88))
+}
+
+func (s Ephemeris) SetCus(v float64) {
+	capnp.Struct(s).SetUint64(88, math.Float64bits(v))
+}
+
+func (s Ephemeris) A() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(96))
+}
+
+func (s Ephemeris) SetA(v float64) {
+	capnp.Struct(s).SetUint64(96, math.Float64bits(v))
+}
+
+func (s Ephemeris) Toe() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(104))
+}
+
+func (s Ephemeris) SetToe(v float64) {
+	capnp.Struct(s).SetUint64(104, math.Float64bits(v))
+}
+
+func (s Ephemeris) Cic() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(112))
+}
+
+func (s Ephemeris) SetCic(v float64) {
+	capnp.Struct(s).SetUint64(112, math.Float64bits(v))
+}
+
+func (s Ephemeris) Omega0() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(120))
+}
+
+func (s Ephemeris) SetOmega0(v float64) {
+	capnp.Struct(s).SetUint64(120, math.Float64bits(v))
+}
+
+func (s Ephemeris) Cis() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(128))
+}
+
+func (s Ephemeris) SetCis(v float64) {
+	capnp.Struct(s).SetUint64(128, math.Float64bits(v))
+}
+
+func (s Ephemeris) I0() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(136))
+}
+
+func (s Ephemeris) SetI0(v float64) {
+	capnp.Struct(s).SetUint64(136, math.Float64bits(v))
+}
+
+func (s Ephemeris) Crc() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(144))
+}
+
+func (s Ephemeris) SetCrc(v float64) {
+	capnp.Struct(s).SetUint64(144, math.Float64bits(v))
+}
+
+func (s Ephemeris) Omega() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(152))
+}
+
+func (s Ephemeris) SetOmega(v float64) {
+	capnp.Struct(s).SetUint64(152, math.Float64bits(v))
+}
+
+func (s Ephemeris) OmegaDot() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(160))
+}
+
+func (s Ephemeris) SetOmegaDot(v float64) {
+	capnp.Struct(s).SetUint64(160, math.Float64bits(v))
+}
+
+func (s Ephemeris) IDot() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(168))
+}
+
+func (s Ephemeris) SetIDot(v float64) {
+	capnp.Struct(s).SetUint64(168, math.Float64bits(v))
+}
+
+func (s Ephemeris) CodesL2() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(176))
+}
+
+func (s Ephemeris) SetCodesL2(v float64) {
+	capnp.Struct(s).SetUint64(176, math.Float64bits(v))
+}
+
+func (s Ephemeris) L2() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(184))
+}
+
+func (s Ephemeris) SetL2(v float64) {
+	capnp.Struct(s).SetUint64(184, math.Float64bits(v))
+}
+
+func (s Ephemeris) SvAcc() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(192))
+}
+
+func (s Ephemeris) SetSvAcc(v float64) {
+	capnp.Struct(s).SetUint64(192, math.Float64bits(v))
+}
+
+func (s Ephemeris) SvHealth() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(200))
+}
+
+func (s Ephemeris) SetSvHealth(v float64) {
+	capnp.Struct(s).SetUint64(200, math.Float64bits(v))
+}
+
+func (s Ephemeris) Tgd() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(208))
+}
+
+func (s Ephemeris) SetTgd(v float64) {
+	capnp.Struct(s).SetUint64(208, math.Float64bits(v))
+}
+
+func (s Ephemeris) Iodc() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(216))
+}
+
+func (s Ephemeris) SetIodc(v float64) {
+	capnp.Struct(s).SetUint64(216, math.Float64bits(v))
+}
+
+func (s Ephemeris) TransmissionTime() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(224))
+}
+
+func (s Ephemeris) SetTransmissionTime(v float64) {
+	capnp.Struct(s).SetUint64(224, math.Float64bits(v))
+}
+
+func (s Ephemeris) FitInterval() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(232))
+}
+
+func (s Ephemeris) SetFitInterval(v float64) {
+	capnp.Struct(s).SetUint64(232, math.Float64bits(v))
+}
+
+func (s Ephemeris) Toc() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(240))
+}
+
+func (s Ephemeris) SetToc(v float64) {
+	capnp.Struct(s).SetUint64(240, math.Float64bits(v))
+}
+
+func (s Ephemeris) IonoCoeffsValid() bool {
+	return capnp.Struct(s).Bit(1984)
+}
+
+func (s Ephemeris) SetIonoCoeffsValid(v bool) {
+	capnp.Struct(s).SetBit(1984, v)
+}
+
+func (s Ephemeris) IonoAlpha() (capnp.Float64List, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return capnp.Float64List(p.List()), err
+}
+
+func (s Ephemeris) HasIonoAlpha() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s Ephemeris) SetIonoAlpha(v capnp.Float64List) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+
+// NewIonoAlpha sets the ionoAlpha field to a newly
+// allocated capnp.Float64List, preferring placement in s's segment.
+func (s Ephemeris) NewIonoAlpha(n int32) (capnp.Float64List, error) {
+	l, err := capnp.NewFloat64List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.Float64List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
+}
+func (s Ephemeris) IonoBeta() (capnp.Float64List, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return capnp.Float64List(p.List()), err
+}
+
+func (s Ephemeris) HasIonoBeta() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s Ephemeris) SetIonoBeta(v capnp.Float64List) error {
+	return capnp.Struct(s).SetPtr(1, v.ToPtr())
+}
+
+// NewIonoBeta sets the ionoBeta field to a newly
+// allocated capnp.Float64List, preferring placement in s's segment.
+func (s Ephemeris) NewIonoBeta(n int32) (capnp.Float64List, error) {
+	l, err := capnp.NewFloat64List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.Float64List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
+	return l, err
+}
+func (s Ephemeris) TowCount() uint32 {
+	return capnp.Struct(s).Uint32(252)
+}
+
+func (s Ephemeris) SetTowCount(v uint32) {
+	capnp.Struct(s).SetUint32(252, v)
+}
+
+func (s Ephemeris) ToeWeek() uint16 {
+	return capnp.Struct(s).Uint16(250)
+}
+
+func (s Ephemeris) SetToeWeek(v uint16) {
+	capnp.Struct(s).SetUint16(250, v)
+}
+
+func (s Ephemeris) TocWeek() uint16 {
+	return capnp.Struct(s).Uint16(256)
+}
+
+func (s Ephemeris) SetTocWeek(v uint16) {
+	capnp.Struct(s).SetUint16(256, v)
+}
+
+// Ephemeris_List is a list of Ephemeris.
+type Ephemeris_List = capnp.StructList[Ephemeris]
+
+// NewEphemeris creates a new list of Ephemeris.
+func NewEphemeris_List(s *capnp.Segment, sz int32) (Ephemeris_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 264, PointerCount: 2}, sz)
+	return capnp.StructList[Ephemeris](l), err
+}
+
+// Ephemeris_Future is a wrapper for a Ephemeris promised by a client call.
+type Ephemeris_Future struct{ *capnp.Future }
+
+func (f Ephemeris_Future) Struct() (Ephemeris, error) {
+	p, err := f.Future.Ptr()
+	return Ephemeris(p.Struct()), err
+}
+
+type RINEXEphemeris capnp.Struct
+
+// RINEXEphemeris_TypeID is the unique identifier for the type RINEXEphemeris.
+const RINEXEphemeris_TypeID = 0xeca2c2c553ea12f6
+
+func NewRINEXEphemeris(s *capnp.Segment) (RINEXEphemeris, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 152, PointerCount: 3})
+	return RINEXEphemeris(st), err
+}
+
+func NewRootRINEXEphemeris(s *capnp.Segment) (RINEXEphemeris, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 152, PointerCount: 3})
+	return RINEXEphemeris(st), err
+}
+
+func ReadRootRINEXEphemeris(msg *capnp.Message) (RINEXEphemeris, error) {
+	root, err := msg.Root()
+	return RINEXEphemeris(root.Struct()), err
+}
+
+func (s RINEXEphemeris) String() string {
+	str, _ := text.Marshal(0xeca2c2c553ea12f6, capnp.Struct(s))
+	return str
+}
+
+func (s RINEXEphemeris) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (RINEXEphemeris) DecodeFromPtr(p capnp.Ptr) RINEXEphemeris {
+	return RINEXEphemeris(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s RINEXEphemeris) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s RINEXEphemeris) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s RINEXEphemeris) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s RINEXEphemeris) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s RINEXEphemeris) Header() (RINEXHeader, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return RINEXHeader(p.Struct()), err
+}
+
+func (s RINEXEphemeris) HasHeader() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s RINEXEphemeris) SetHeader(v RINEXHeader) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+}
+
+// NewHeader sets the header field to a newly
+// allocated RINEXHeader struct, preferring placement in s's segment.
+func (s RINEXEphemeris) NewHeader() (RINEXHeader, error) {
+	ss, err := NewRINEXHeader(capnp.Struct(s).Segment())
+	if err != nil {
+		return RINEXHeader{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s RINEXEphemeris) SatelliteId() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s RINEXEphemeris) SetSatelliteId(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+func (s RINEXEphemeris) Epoch() (Time, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return Time(p.Struct()), err
+}
+
+func (s RINEXEphemeris) HasEpoch() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s RINEXEphemeris) SetEpoch(v Time) error {
+	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
+}
+
+// NewEpoch sets the epoch field to a newly
+// allocated Time struct, preferring placement in s's segment.
+func (s RINEXEphemeris) NewEpoch() (Time, error) {
+	ss, err := NewTime(capnp.Struct(s).Segment())
+	if err != nil {
+		return Time{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s RINEXEphemeris) ClockBias() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(8))
+}
+
+func (s RINEXEphemeris) SetClockBias(v float64) {
+	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) RelativeFrequencyBias() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(16))
+}
+
+func (s RINEXEphemeris) SetRelativeFrequencyBias(v float64) {
+	capnp.Struct(s).SetUint64(16, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) MessageFrameTime() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(24))
+}
+
+func (s RINEXEphemeris) SetMessageFrameTime(v float64) {
+	capnp.Struct(s).SetUint64(24, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) PositionX() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(32))
+}
+
+func (s RINEXEphemeris) SetPositionX(v float64) {
+	capnp.Struct(s).SetUint64(32, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) VelocityX() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(40))
+}
+
+func (s RINEXEphemeris) SetVelocityX(v float64) {
+	capnp.Struct(s).SetUint64(40, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) AccelerationX() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(48))
+}
+
+func (s RINEXEphemeris) SetAccelerationX(v float64) {
+	capnp.Struct(s).SetUint64(48, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) PositionY() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(56))
+}
+
+func (s RINEXEphemeris) SetPositionY(v float64) {
+	capnp.Struct(s).SetUint64(56, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) VelocityY() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(64))
+}
+
+func (s RINEXEphemeris) SetVelocityY(v float64) {
+	capnp.Struct(s).SetUint64(64, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) AccelerationY() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(72))
+}
+
+func (s RINEXEphemeris) SetAccelerationY(v float64) {
+	capnp.Struct(s).SetUint64(72, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) PositionZ() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(80))
+}
+
+func (s RINEXEphemeris) SetPositionZ(v float64) {
+	capnp.Struct(s).SetUint64(80, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) VelocityZ() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(88))
+}
+
+func (s RINEXEphemeris) SetVelocityZ(v float64) {
+	capnp.Struct(s).SetUint64(88, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) AccelerationZ() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(96))
+}
+
+func (s RINEXEphemeris) SetAccelerationZ(v float64) {
+	capnp.Struct(s).SetUint64(96, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) Health() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(104))
+}
+
+func (s RINEXEphemeris) SetHealth(v float64) {
+	capnp.Struct(s).SetUint64(104, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) FrequencyChannelOffset() int32 {
+	return int32(capnp.Struct(s).Uint32(4))
+}
+
+func (s RINEXEphemeris) SetFrequencyChannelOffset(v int32) {
+	capnp.Struct(s).SetUint32(4, uint32(v))
+}
+
+func (s RINEXEphemeris) InformationAge() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(112))
+}
+
+func (s RINEXEphemeris) SetInformationAge(v float64) {
+	capnp.Struct(s).SetUint64(112, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) StatusFlags() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(120))
+}
+
+func (s RINEXEphemeris) SetStatusFlags(v float64) {
+	capnp.Struct(s).SetUint64(120, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) GroupDelayDifference() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(128))
+}
+
+func (s RINEXEphemeris) SetGroupDelayDifference(v float64) {
+	capnp.Struct(s).SetUint64(128, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) Urai() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(136))
+}
+
+func (s RINEXEphemeris) SetUrai(v float64) {
+	capnp.Struct(s).SetUint64(136, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) HealthFlags() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(144))
+}
+
+func (s RINEXEphemeris) SetHealthFlags(v float64) {
+	capnp.Struct(s).SetUint64(144, math.Float64bits(v))
+}
+
+func (s RINEXEphemeris) BaseEphemeris() (BaseEphemeris, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return BaseEphemeris(p.Struct()), err
+}
+
+func (s RINEXEphemeris) HasBaseEphemeris() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s RINEXEphemeris) SetBaseEphemeris(v BaseEphemeris) error {
+	return capnp.Struct(s).SetPtr(2, capnp.Struct(v).ToPtr())
+}
+
+// NewBaseEphemeris sets the baseEphemeris field to a newly
+// allocated BaseEphemeris struct, preferring placement in s's segment.
+func (s RINEXEphemeris) NewBaseEphemeris() (BaseEphemeris, error) {
+	ss, err := NewBaseEphemeris(capnp.Struct(s).Segment())
+	if err != nil {
+		return BaseEphemeris{}, err
+	}
+	err = capnp.Struct(s).SetPtr(2, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+// RINEXEphemeris_List is a list of RINEXEphemeris.
+type RINEXEphemeris_List = capnp.StructList[RINEXEphemeris]
+
+// NewRINEXEphemeris creates a new list of RINEXEphemeris.
+func NewRINEXEphemeris_List(s *capnp.Segment, sz int32) (RINEXEphemeris_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 152, PointerCount: 3}, sz)
+	return capnp.StructList[RINEXEphemeris](l), err
+}
+
+// RINEXEphemeris_Future is a wrapper for a RINEXEphemeris promised by a client call.
+type RINEXEphemeris_Future struct{ *capnp.Future }
+
+func (f RINEXEphemeris_Future) Struct() (RINEXEphemeris, error) {
+	p, err := f.Future.Ptr()
+	return RINEXEphemeris(p.Struct()), err
+}
+func (p RINEXEphemeris_Future) Header() RINEXHeader_Future {
+	return RINEXHeader_Future{Future: p.Future.Field(0, nil)}
+}
+func (p RINEXEphemeris_Future) Epoch() Time_Future {
+	return Time_Future{Future: p.Future.Field(1, nil)}
+}
+func (p RINEXEphemeris_Future) BaseEphemeris() BaseEphemeris_Future {
+	return BaseEphemeris_Future{Future: p.Future.Field(2, nil)}
+}
+
+type NavFile capnp.Struct
+
+// NavFile_TypeID is the unique identifier for the type NavFile.
+const NavFile_TypeID = 0xf7dee27edbaf9972
+
+func NewNavFile(s *capnp.Segment) (NavFile, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 8})
+	return NavFile(st), err
+}
+
+func NewRootNavFile(s *capnp.Segment) (NavFile, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 8})
+	return NavFile(st), err
+}
+
+func ReadRootNavFile(msg *capnp.Message) (NavFile, error) {
+	root, err := msg.Root()
+	return NavFile(root.Struct()), err
+}
+
+func (s NavFile) String() string {
+	str, _ := text.Marshal(0xf7dee27edbaf9972, capnp.Struct(s))
+	return str
+}
+
+func (s NavFile) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (NavFile) DecodeFromPtr(p capnp.Ptr) NavFile {
+	return NavFile(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s NavFile) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s NavFile) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s NavFile) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s NavFile) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s NavFile) Header() (RINEXHeader, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return RINEXHeader(p.Struct()), err
+}
+
+func (s NavFile) HasHeader() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s NavFile) SetHeader(v RINEXHeader) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+}
+
+// NewHeader sets the header field to a newly
+// allocated RINEXHeader struct, preferring placement in s's segment.
+func (s NavFile) NewHeader() (RINEXHeader, error) {
+	ss, err := NewRINEXHeader(capnp.Struct(s).Segment())
+	if err != nil {
+		return RINEXHeader{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s NavFile) Gps() (GPSEphemeris_List, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return GPSEphemeris_List(p.List()), err
+}
+
+func (s NavFile) HasGps() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s NavFile) SetGps(v GPSEphemeris_List) error {
+	return capnp.Struct(s).SetPtr(1, v.ToPtr())
+}
+
+// NewGps sets the gps field to a newly
+// allocated GPSEphemeris_List, preferring placement in s's segment.
+func (s NavFile) NewGps(n int32) (GPSEphemeris_List, error) {
+	l, err := NewGPSEphemeris_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return GPSEphemeris_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
+	return l, err
+}
+func (s NavFile) Glonass() (RINEXEphemeris_List, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return RINEXEphemeris_List(p.List()), err
+}
+
+func (s NavFile) HasGlonass() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s NavFile) SetGlonass(v RINEXEphemeris_List) error {
+	return capnp.Struct(s).SetPtr(2, v.ToPtr())
+}
+
+// NewGlonass sets the glonass field to a newly
+// allocated RINEXEphemeris_List, preferring placement in s's segment.
+func (s NavFile) NewGlonass(n int32) (RINEXEphemeris_List, error) {
+	l, err := NewRINEXEphemeris_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return RINEXEphemeris_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(2, l.ToPtr())
+	return l, err
+}
+func (s NavFile) Galileo() (GalileoEphemeris_List, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return GalileoEphemeris_List(p.List()), err
+}
+
+func (s NavFile) HasGalileo() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s NavFile) SetGalileo(v GalileoEphemeris_List) error {
+	return capnp.Struct(s).SetPtr(3, v.ToPtr())
+}
+
+// NewGalileo sets the galileo field to a newly
+// allocated GalileoEphemeris_List, preferring placement in s's segment.
+func (s NavFile) NewGalileo(n int32) (GalileoEphemeris_List, error) {
+	l, err := NewGalileoEphemeris_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return GalileoEphemeris_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(3, l.ToPtr())
+	return l, err
+}
+func (s NavFile) BeiDou() (BeiDouEphemeris_List, error) {
+	p, err := capnp.Struct(s).Ptr(4)
+	return BeiDouEphemeris_List(p.List()), err
+}
+
+func (s NavFile) HasBeiDou() bool {
+	return capnp.Struct(s).HasPtr(4)
+}
+
+func (s NavFile) SetBeiDou(v BeiDouEphemeris_List) error {
+	return capnp.Struct(s).SetPtr(4, v.ToPtr())
+}
+
+// NewBeiDou sets the beiDou field to a newly
+// allocated BeiDouEphemeris_List, preferring placement in s's segment.
+func (s NavFile) NewBeiDou(n int32) (BeiDouEphemeris_List, error) {
+	l, err := NewBeiDouEphemeris_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return BeiDouEphemeris_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(4, l.ToPtr())
+	return l, err
+}
+func (s NavFile) Qzss() (GPSEphemeris_List, error) {
+	p, err := capnp.Struct(s).Ptr(5)
+	return GPSEphemeris_List(p.List()), err
+}
+
+func (s NavFile) HasQzss() bool {
+	return capnp.Struct(s).HasPtr(5)
+}
+
+func (s NavFile) SetQzss(v GPSEphemeris_List) error {
+	return capnp.Struct(s).SetPtr(5, v.ToPtr())
+}
+
+// NewQzss sets the qzss field to a newly
+// allocated GPSEphemeris_List, preferring placement in s's segment.
+func (s NavFile) NewQzss(n int32) (GPSEphemeris_List, error) {
+	l, err := NewGPSEphemeris_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return GPSEphemeris_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(5, l.ToPtr())
+	return l, err
+}
+func (s NavFile) NavIC() (GPSEphemeris_List, error) {
+	p, err := capnp.Struct(s).Ptr(6)
+	return GPSEphemeris_List(p.List()), err
+}
+
+func (s NavFile) HasNavIC() bool {
+	return capnp.Struct(s).HasPtr(6)
+}
+
+func (s NavFile) SetNavIC(v GPSEphemeris_List) error {
+	return capnp.Struct(s).SetPtr(6, v.ToPtr())
+}
+
+// NewNavIC sets the navIC field to a newly
+// allocated GPSEphemeris_List, preferring placement in s's segment.
+func (s NavFile) NewNavIC(n int32) (GPSEphemeris_List, error) {
+	l, err := NewGPSEphemeris_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return GPSEphemeris_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(6, l.ToPtr())
+	return l, err
+}
+func (s NavFile) Sbas() (SBASEphemeris_List, error) {
+	p, err := capnp.Struct(s).Ptr(7)
+	return SBASEphemeris_List(p.List()), err
+}
+
+func (s NavFile) HasSbas() bool {
+	return capnp.Struct(s).HasPtr(7)
+}
+
+func (s NavFile) SetSbas(v SBASEphemeris_List) error {
+	return capnp.Struct(s).SetPtr(7, v.ToPtr())
+}
+
+// NewSbas sets the sbas field to a newly
+// allocated SBASEphemeris_List, preferring placement in s's segment.
+func (s NavFile) NewSbas(n int32) (SBASEphemeris_List, error) {
+	l, err := NewSBASEphemeris_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return SBASEphemeris_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(7, l.ToPtr())
+	return l, err
+}
+
+// NavFile_List is a list of NavFile.
+type NavFile_List = capnp.StructList[NavFile]
+
+// NewNavFile creates a new list of NavFile.
+func NewNavFile_List(s *capnp.Segment, sz int32) (NavFile_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 8}, sz)
+	return capnp.StructList[NavFile](l), err
+}
+
+// NavFile_Future is a wrapper for a NavFile promised by a client call.
+type NavFile_Future struct{ *capnp.Future }
+
+func (f NavFile_Future) Struct() (NavFile, error) {
+	p, err := f.Future.Ptr()
+	return NavFile(p.Struct()), err
+}
+func (p NavFile_Future) Header() RINEXHeader_Future {
+	return RINEXHeader_Future{Future: p.Future.Field(0, nil)}
+}
+
+type GroupedEphemerides capnp.Struct
+
+// GroupedEphemerides_TypeID is the unique identifier for the type GroupedEphemerides.
+const GroupedEphemerides_TypeID = 0x8a11dc8313cd9d6d
+
+func NewGroupedEphemerides(s *capnp.Segment) (GroupedEphemerides, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6})
+	return GroupedEphemerides(st), err
+}
+
+func NewRootGroupedEphemerides(s *capnp.Segment) (GroupedEphemerides, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6})
+	return GroupedEphemerides(st), err
+}
+
+func ReadRootGroupedEphemerides(msg *capnp.Message) (GroupedEphemerides, error) {
+	root, err := msg.Root()
+	return GroupedEphemerides(root.Struct()), err
+}
+
+func (s GroupedEphemerides) String() string {
+	str, _ := text.Marshal(0x8a11dc8313cd9d6d, capnp.Struct(s))
+	return str
+}
+
+func (s GroupedEphemerides) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GroupedEphemerides) DecodeFromPtr(p capnp.Ptr) GroupedEphemerides {
+	return GroupedEphemerides(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GroupedEphemerides) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GroupedEphemerides) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GroupedEphemerides) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GroupedEphemerides) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GroupedEphemerides) SatelliteId() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s GroupedEphemerides) SetSatelliteId(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+func (s GroupedEphemerides) SortedEphemerides() (RINEXEphemeris_List, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return RINEXEphemeris_List(p.List()), err
+}
+
+func (s GroupedEphemerides) HasSortedEphemerides() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GroupedEphemerides) SetSortedEphemerides(v RINEXEphemeris_List) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+
+// NewSortedEphemerides sets the sortedEphemerides field to a newly
+// allocated RINEXEphemeris_List, preferring placement in s's segment.
+func (s GroupedEphemerides) NewSortedEphemerides(n int32) (RINEXEphemeris_List, error) {
+	l, err := NewRINEXEphemeris_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return RINEXEphemeris_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
+}
+func (s GroupedEphemerides) Prn() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s GroupedEphemerides) HasPrn() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s GroupedEphemerides) PrnBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s GroupedEphemerides) SetPrn(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s GroupedEphemerides) Gps() (GPSEphemeris_List, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return GPSEphemeris_List(p.List()), err
+}
+
+func (s GroupedEphemerides) HasGps() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s GroupedEphemerides) SetGps(v GPSEphemeris_List) error {
+	return capnp.Struct(s).SetPtr(2, v.ToPtr())
+}
+
+// NewGps sets the gps field to a newly
+// allocated GPSEphemeris_List, preferring placement in s's segment.
+func (s GroupedEphemerides) NewGps(n int32) (GPSEphemeris_List, error) {
+	l, err := NewGPSEphemeris_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return GPSEphemeris_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(2, l.ToPtr())
+	return l, err
+}
+func (s GroupedEphemerides) Galileo() (GalileoEphemeris_List, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return GalileoEphemeris_List(p.List()), err
+}
+
+func (s GroupedEphemerides) HasGalileo() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s GroupedEphemerides) SetGalileo(v GalileoEphemeris_List) error {
+	return capnp.Struct(s).SetPtr(3, v.ToPtr())
+}
+
+// NewGalileo sets the galileo field to a newly
+// allocated GalileoEphemeris_List, preferring placement in s's segment.
+func (s GroupedEphemerides) NewGalileo(n int32) (GalileoEphemeris_List, error) {
+	l, err := NewGalileoEphemeris_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return GalileoEphemeris_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(3, l.ToPtr())
+	return l, err
+}
+func (s GroupedEphemerides) BeiDou() (BeiDouEphemeris_List, error) {
+	p, err := capnp.Struct(s).Ptr(4)
+	return BeiDouEphemeris_List(p.List()), err
+}
+
+func (s GroupedEphemerides) HasBeiDou() bool {
+	return capnp.Struct(s).HasPtr(4)
+}
+
+func (s GroupedEphemerides) SetBeiDou(v BeiDouEphemeris_List) error {
+	return capnp.Struct(s).SetPtr(4, v.ToPtr())
+}
+
+// NewBeiDou sets the beiDou field to a newly
+// allocated BeiDouEphemeris_List, preferring placement in s's segment.
+func (s GroupedEphemerides) NewBeiDou(n int32) (BeiDouEphemeris_List, error) {
+	l, err := NewBeiDouEphemeris_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return BeiDouEphemeris_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(4, l.ToPtr())
+	return l, err
+}
+func (s GroupedEphemerides) Sbas() (SBASEphemeris_List, error) {
+	p, err := capnp.Struct(s).Ptr(5)
+	return SBASEphemeris_List(p.List()), err
+}
+
+func (s GroupedEphemerides) HasSbas() bool {
+	return capnp.Struct(s).HasPtr(5)
+}
+
+func (s GroupedEphemerides) SetSbas(v SBASEphemeris_List) error {
+	return capnp.Struct(s).SetPtr(5, v.ToPtr())
+}
+
+// NewSbas sets the sbas field to a newly
+// allocated SBASEphemeris_List, preferring placement in s's segment.
+func (s GroupedEphemerides) NewSbas(n int32) (SBASEphemeris_List, error) {
+	l, err := NewSBASEphemeris_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return SBASEphemeris_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(5, l.ToPtr())
+	return l, err
+}
+
+// GroupedEphemerides_List is a list of GroupedEphemerides.
+type GroupedEphemerides_List = capnp.StructList[GroupedEphemerides]
+
+// NewGroupedEphemerides creates a new list of GroupedEphemerides.
+func NewGroupedEphemerides_List(s *capnp.Segment, sz int32) (GroupedEphemerides_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6}, sz)
+	return capnp.StructList[GroupedEphemerides](l), err
+}
+
+// GroupedEphemerides_Future is a wrapper for a GroupedEphemerides promised by a client call.
+type GroupedEphemerides_Future struct{ *capnp.Future }
+
+func (f GroupedEphemerides_Future) Struct() (GroupedEphemerides, error) {
+	p, err := f.Future.Ptr()
+	return GroupedEphemerides(p.Struct()), err
+}
+
+type SP3FormatEphemeris capnp.Struct
+
+// SP3FormatEphemeris_TypeID is the unique identifier for the type SP3FormatEphemeris.
+const SP3FormatEphemeris_TypeID = 0xe5b14b55893ef9cb
+
+func NewSP3FormatEphemeris(s *capnp.Segment) (SP3FormatEphemeris, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return SP3FormatEphemeris(st), err
+}
+
+func NewRootSP3FormatEphemeris(s *capnp.Segment) (SP3FormatEphemeris, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return SP3FormatEphemeris(st), err
+}
+
+func ReadRootSP3FormatEphemeris(msg *capnp.Message) (SP3FormatEphemeris, error) {
+	root, err := msg.Root()
+	return SP3FormatEphemeris(root.Struct()), err
+}
+
+func (s SP3FormatEphemeris) String() string {
+	str, _ := text.Marshal(0xe5b14b55893ef9cb, capnp.Struct(s))
+	return str
+}
+
+func (s SP3FormatEphemeris) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (SP3FormatEphemeris) DecodeFromPtr(p capnp.Ptr) SP3FormatEphemeris {
+	return SP3FormatEphemeris(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s SP3FormatEphemeris) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s SP3FormatEphemeris) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s SP3FormatEphemeris) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s SP3FormatEphemeris) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s SP3FormatEphemeris) Header() (SP3Header, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return SP3Header(p.Struct()), err
+}
+
+func (s SP3FormatEphemeris) HasHeader() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s SP3FormatEphemeris) SetHeader(v SP3Header) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+}
+
+// NewHeader sets the header field to a newly
+// allocated SP3Header struct, preferring placement in s's segment.
+func (s SP3FormatEphemeris) NewHeader() (SP3Header, error) {
+	ss, err := NewSP3Header(capnp.Struct(s).Segment())
+	if err != nil {
+		return SP3Header{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s SP3FormatEphemeris) Epochs() (SP3Epoch_List, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return SP3Epoch_List(p.List()), err
+}
+
+func (s SP3FormatEphemeris) HasEpochs() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s SP3FormatEphemeris) SetEpochs(v SP3Epoch_List) error {
+	return capnp.Struct(s).SetPtr(1, v.ToPtr())
+}
+
+// NewEpochs sets the epochs field to a newly
+// allocated SP3Epoch_List, preferring placement in s's segment.
+func (s SP3FormatEphemeris) NewEpochs(n int32) (SP3Epoch_List, error) {
+	l, err := NewSP3Epoch_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return SP3Epoch_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
+	return l, err
+}
+
+// SP3FormatEphemeris_List is a list of SP3FormatEphemeris.
+type SP3FormatEphemeris_List = capnp.StructList[SP3FormatEphemeris]
+
+// NewSP3FormatEphemeris creates a new list of SP3FormatEphemeris.
+func NewSP3FormatEphemeris_List(s *capnp.Segment, sz int32) (SP3FormatEphemeris_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return capnp.StructList[SP3FormatEphemeris](l), err
+}
+
+// SP3FormatEphemeris_Future is a wrapper for a SP3FormatEphemeris promised by a client call.
+type SP3FormatEphemeris_Future struct{ *capnp.Future }
+
+func (f SP3FormatEphemeris_Future) Struct() (SP3FormatEphemeris, error) {
+	p, err := f.Future.Ptr()
+	return SP3FormatEphemeris(p.Struct()), err
+}
+func (p SP3FormatEphemeris_Future) Header() SP3Header_Future {
+	return SP3Header_Future{Future: p.Future.Field(0, nil)}
+}
+
+type SP3Header capnp.Struct
+
+// SP3Header_TypeID is the unique identifier for the type SP3Header.
+const SP3Header_TypeID = 0xd5b36c059384fab0
+
+func NewSP3Header(s *capnp.Segment) (SP3Header, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 56, PointerCount: 12})
+	return SP3Header(st), err
+}
+
+func NewRootSP3Header(s *capnp.Segment) (SP3Header, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 56, PointerCount: 12})
+	return SP3Header(st), err
+}
+
+func ReadRootSP3Header(msg *capnp.Message) (SP3Header, error) {
+	root, err := msg.Root()
+	return SP3Header(root.Struct()), err
+}
+
+func (s SP3Header) String() string {
+	str, _ := text.Marshal(0xd5b36c059384fab0, capnp.Struct(s))
+	return str
+}
+
+func (s SP3Header) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (SP3Header) DecodeFromPtr(p capnp.Ptr) SP3Header {
+	return SP3Header(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s SP3Header) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s SP3Header) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s SP3Header) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s SP3Header) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s SP3Header) Version() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s SP3Header) HasVersion() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s SP3Header) VersionBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SP3Header) SetVersion(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s SP3Header) Start() (Time, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return Time(p.Struct()), err
+}
+
+func (s SP3Header) HasStart() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s SP3Header) SetStart(v Time) error {
+	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
+}
+
+// NewStart sets the start field to a newly
+// allocated Time struct, preferring placement in s's segment.
+func (s SP3Header) NewStart() (Time, error) {
+	ss, err := NewTime(capnp.Struct(s).Segment())
+	if err != nil {
+		return Time{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s SP3Header) NumberOfEpochs() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s SP3Header) SetNumberOfEpochs(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+func (s SP3Header) DataUsed() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s SP3Header) HasDataUsed() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s SP3Header) DataUsedBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s SP3Header) SetDataUsed(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+func (s SP3Header) CoordinateSystem() (string, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.Text(), err
+}
+
+func (s SP3Header) HasCoordinateSystem() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s SP3Header) CoordinateSystemBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s SP3Header) SetCoordinateSystem(v string) error {
+	return capnp.Struct(s).SetText(3, v)
+}
+
+func (s SP3Header) OrbitType() (string, error) {
+	p, err := capnp.Struct(s).Ptr(4)
+	return p.Text(), err
+}
+
+func (s SP3Header) HasOrbitType() bool {
+	return capnp.Struct(s).HasPtr(4)
+}
+
+func (s SP3Header) OrbitTypeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(4)
+	return p.TextBytes(), err
+}
+
+func (s SP3Header) SetOrbitType(v string) error {
+	return capnp.Struct(s).SetText(4, v)
+}
+
+func (s SP3Header) Agency() (string, error) {
+	p, err := capnp.Struct(s).Ptr(5)
+	return p.Text(), err
+}
+
+func (s SP3Header) HasAgency() bool {
+	return capnp.Struct(s).HasPtr(5)
+}
+
+func (s SP3Header) AgencyBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(5)
+	return p.TextBytes(), err
+}
+
+func (s SP3Header) SetAgency(v string) error {
+	return capnp.Struct(s).SetText(5, v)
+}
+
+func (s SP3Header) GpsWeek() int32 {
+	return int32(capnp.Struct(s).Uint32(4))
+}
+
+func (s SP3Header) SetGpsWeek(v int32) {
+	capnp.Struct(s).SetUint32(4, uint32(v))
+}
+
+func (s SP3Header) SecondsOfWeek() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(8))
+}
+
+func (s SP3Header) SetSecondsOfWeek(v float64) {
+	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
+}
+
+func (s SP3Header) EpochInterval() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(16))
+}
+
+func (s SP3Header) SetEpochInterval(v float64) {
+	capnp.Struct(s).SetUint64(16, math.Float64bits(v))
+}
+
+func (s SP3Header) ModifiedJulianDay() int32 {
+	return int32(capnp.Struct(s).Uint32(24))
+}
+
+func (s SP3Header) SetModifiedJulianDay(v int32) {
+	capnp.Struct(s).SetUint32(24, uint32(v))
+}
+
+func (s SP3Header) FractionalDay() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(32))
+}
+
+func (s SP3Header) SetFractionalDay(v float64) {
+	capnp.Struct(s).SetUint64(32, math.Float64bits(v))
+}
+
+func (s SP3Header) PositionVelocityFlag() (string, error) {
+	p, err := capnp.Struct(s).Ptr(6)
+	return p.Text(), err
+}
+
+func (s SP3Header) HasPositionVelocityFlag() bool {
+	return capnp.Struct(s).HasPtr(6)
+}
+
+func (s SP3Header) PositionVelocityFlagBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(6)
+	return p.TextBytes(), err
+}
+
+func (s SP3Header) SetPositionVelocityFlag(v string) error {
+	return capnp.Struct(s).SetText(6, v)
+}
+
+func (s SP3Header) NumberOfSatellites() int32 {
+	return int32(capnp.Struct(s).Uint32(28))
+}
+
+func (s SP3Header) SetNumberOfSatellites(v int32) {
+	capnp.Struct(s).SetUint32(28, uint32(v))
+}
+
+func (s SP3Header) Satellites() (capnp.TextList, error) {
+	p, err := capnp.Struct(s).Ptr(7)
+	return capnp.TextList(p.List()), err
+}
+
+func (s SP3Header) HasSatellites() bool {
+	return capnp.Struct(s).HasPtr(7)
+}
+
+func (s SP3Header) SetSatellites(v capnp.TextList) error {
+	return capnp.Struct(s).SetPtr(7, v.ToPtr())
+}
+
+// NewSatellites sets the satellites field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s SP3Header) NewSatellites(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = capnp.Struct(s).SetPtr(7, l.ToPtr())
+	return l, err
+}
+func (s SP3Header) AccuracyExponents() (capnp.Int32List, error) {
+	p, err := capnp.Struct(s).Ptr(8)
+	return capnp.Int32List(p.List()), err
+}
+
+func (s SP3Header) HasAccuracyExponents() bool {
+	return capnp.Struct(s).HasPtr(8)
+}
+
+func (s SP3Header) SetAccuracyExponents(v capnp.Int32List) error {
+	return capnp.Struct(s).SetPtr(8, v.ToPtr())
+}
+
+// NewAccuracyExponents sets the accuracyExponents field to a newly
+// allocated capnp.Int32List, preferring placement in s's segment.
+func (s SP3Header) NewAccuracyExponents(n int32) (capnp.Int32List, error) {
+	l, err := capnp.NewInt32List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.Int32List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(8, l.ToPtr())
+	return l, err
+}
+func (s SP3Header) FileType() (string, error) {
+	p, err := capnp.Struct(s).Ptr(9)
+	return p.Text(), err
+}
+
+func (s SP3Header) HasFileType() bool {
+	return capnp.Struct(s).HasPtr(9)
+}
+
+func (s SP3Header) FileTypeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(9)
+	return p.TextBytes(), err
+}
+
+func (s SP3Header) SetFileType(v string) error {
+	return capnp.Struct(s).SetText(9, v)
+}
+
+func (s SP3Header) TimeSystem() (string, error) {
+	p, err := capnp.Struct(s).Ptr(10)
+	return p.Text(), err
+}
+
+func (s SP3Header) HasTimeSystem() bool {
+	return capnp.Struct(s).HasPtr(10)
+}
+
+func (s SP3Header) TimeSystemBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(10)
+	return p.TextBytes(), err
+}
+
+func (s SP3Header) SetTimeSystem(v string) error {
+	return capnp.Struct(s).SetText(10, v)
+}
+
+func (s SP3Header) PositionBase() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(40))
+}
+
+func (s SP3Header) SetPositionBase(v float64) {
+	capnp.Struct(s).SetUint64(40, math.Float64bits(v))
+}
+
+func (s SP3Header) ClockBase() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(48))
+}
+
+func (s SP3Header) SetClockBase(v float64) {
+	capnp.Struct(s).SetUint64(48, math.Float64bits(v))
+}
+
+func (s SP3Header) Comments() (capnp.TextList, error) {
+	p, err := capnp.Struct(s).Ptr(11)
+	return capnp.TextList(p.List()), err
+}
+
+func (s SP3Header) HasComments() bool {
+	return capnp.Struct(s).HasPtr(11)
+}
+
+func (s SP3Header) SetComments(v capnp.TextList) error {
+	return capnp.Struct(s).SetPtr(11, v.ToPtr())
+}
+
+// NewComments sets the comments field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s SP3Header) NewComments(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = capnp.Struct(s).SetPtr(11, l.ToPtr())
+	return l, err
+}
+
+// SP3Header_List is a list of SP3Header.
+type SP3Header_List = capnp.StructList[SP3Header]
+
+// NewSP3Header creates a new list of SP3Header.
+func NewSP3Header_List(s *capnp.Segment, sz int32) (SP3Header_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 56, PointerCount: 12}, sz)
+	return capnp.StructList[SP3Header](l), err
+}
+
+// SP3Header_Future is a wrapper for a SP3Header promised by a client call.
+type SP3Header_Future struct{ *capnp.Future }
+
+func (f SP3Header_Future) Struct() (SP3Header, error) {
+	p, err := f.Future.Ptr()
+	return SP3Header(p.Struct()), err
+}
+func (p SP3Header_Future) Start() Time_Future {
+	return Time_Future{Future: p.Future.Field(1, nil)}
+}
+
+type SP3Epoch capnp.Struct
+
+// SP3Epoch_TypeID is the unique identifier for the type SP3Epoch.
+const SP3Epoch_TypeID = 0x9261b240a2cc4a68
+
+func NewSP3Epoch(s *capnp.Segment) (SP3Epoch, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return SP3Epoch(st), err
+}
+
+func NewRootSP3Epoch(s *capnp.Segment) (SP3Epoch, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return SP3Epoch(st), err
+}
+
+func ReadRootSP3Epoch(msg *capnp.Message) (SP3Epoch, error) {
+	root, err := msg.Root()
+	return SP3Epoch(root.Struct()), err
+}
+
+func (s SP3Epoch) String() string {
+	str, _ := text.Marshal(0x9261b240a2cc4a68, capnp.Struct(s))
+	return str
+}
+
+func (s SP3Epoch) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (SP3Epoch) DecodeFromPtr(p capnp.Ptr) SP3Epoch {
+	return SP3Epoch(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s SP3Epoch) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s SP3Epoch) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s SP3Epoch) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s SP3Epoch) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s SP3Epoch) Time() (Time, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return Time(p.Struct()), err
+}
+
+func (s SP3Epoch) HasTime() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s SP3Epoch) SetTime(v Time) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+}
+
+// NewTime sets the time field to a newly
+// allocated Time struct, preferring placement in s's segment.
+func (s SP3Epoch) NewTime() (Time, error) {
+	ss, err := NewTime(capnp.Struct(s).Segment())
+	if err != nil {
+		return Time{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s SP3Epoch) Entries() (SP3Entry_List, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return SP3Entry_List(p.List()), err
+}
+
+func (s SP3Epoch) HasEntries() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s SP3Epoch) SetEntries(v SP3Entry_List) error {
+	return capnp.Struct(s).SetPtr(1, v.ToPtr())
+}
+
+// NewEntries sets the entries field to a newly
+// allocated SP3Entry_List, preferring placement in s's segment.
+func (s SP3Epoch) NewEntries(n int32) (SP3Entry_List, error) {
+	l, err := NewSP3Entry_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return SP3Entry_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
+	return l, err
+}
+func (s SP3Epoch) GpsTime() (GPSTime, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return GPSTime(p.Struct()), err
+}
+
+func (s SP3Epoch) HasGpsTime() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s SP3Epoch) SetGpsTime(v GPSTime) error {
+	return capnp.Struct(s).SetPtr(2, capnp.Struct(v).ToPtr())
+}
+
+// NewGpsTime sets the gpsTime field to a newly
+// allocated GPSTime struct, preferring placement in s's segment.
+func (s SP3Epoch) NewGpsTime() (GPSTime, error) {
+	ss, err := NewGPSTime(capnp.Struct(s).Segment())
+	if err != nil {
+		return GPSTime{}, err
+	}
+	err = capnp.Struct(s).SetPtr(2, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+// SP3Epoch_List is a list of SP3Epoch.
+type SP3Epoch_List = capnp.StructList[SP3Epoch]
+
+// NewSP3Epoch creates a new list of SP3Epoch.
+func NewSP3Epoch_List(s *capnp.Segment, sz int32) (SP3Epoch_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return capnp.StructList[SP3Epoch](l), err
+}
+
+// SP3Epoch_Future is a wrapper for a SP3Epoch promised by a client call.
+type SP3Epoch_Future struct{ *capnp.Future }
+
+func (f SP3Epoch_Future) Struct() (SP3Epoch, error) {
+	p, err := f.Future.Ptr()
+	return SP3Epoch(p.Struct()), err
+}
+func (p SP3Epoch_Future) Time() Time_Future {
+	return Time_Future{Future: p.Future.Field(0, nil)}
+}
+func (p SP3Epoch_Future) GpsTime() GPSTime_Future {
+	return GPSTime_Future{Future: p.Future.Field(2, nil)}
+}
+
+type SP3Entry capnp.Struct
+
+// SP3Entry_TypeID is the unique identifier for the type SP3Entry.
+const SP3Entry_TypeID = 0xdfc8474e015f357d
+
+func NewSP3Entry(s *capnp.Segment) (SP3Entry, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 80, PointerCount: 5})
+	return SP3Entry(st), err
+}
+
+func NewRootSP3Entry(s *capnp.Segment) (SP3Entry, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 80, PointerCount: 5})
+	return SP3Entry(st), err
+}
+
+func ReadRootSP3Entry(msg *capnp.Message) (SP3Entry, error) {
+	root, err := msg.Root()
+	return SP3Entry(root.Struct()), err
+}
+
+func (s SP3Entry) String() string {
+	str, _ := text.Marshal(0xdfc8474e015f357d, capnp.Struct(s))
+	return str
+}
+
+func (s SP3Entry) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (SP3Entry) DecodeFromPtr(p capnp.Ptr) SP3Entry {
+	return SP3Entry(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s SP3Entry) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s SP3Entry) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s SP3Entry) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s SP3Entry) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s SP3Entry) SatelliteVehicleNumber() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s SP3Entry) HasSatelliteVehicleNumber() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s SP3Entry) SatelliteVehicleNumberBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SP3Entry) SetSatelliteVehicleNumber(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s SP3Entry) XPosition() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(0))
+}
+
+func (s SP3Entry) SetXPosition(v float64) {
+	capnp.Struct(s).SetUint64(0, math.Float64bits(v))
+}
+
+func (s SP3Entry) YPosition() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(8))
+}
+
+func (s SP3Entry) SetYPosition(v float64) {
+	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
+}
+
+func (s SP3Entry) ZPosition() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(16))
+}
+
+func (s SP3Entry) SetZPosition(v float64) {
+	capnp.Struct(s).SetUint64(16, math.Float64bits(v))
+}
+
+func (s SP3Entry) ClockBias() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(24))
+}
+
+func (s SP3Entry) SetClockBias(v float64) {
+	capnp.Struct(s).SetUint64(24, math.Float64bits(v))
+}
+
+func (s SP3Entry) HasVelocity() bool {
+	return capnp.Struct(s).Bit(256)
+}
+
+func (s SP3Entry) SetHasVelocity(v bool) {
+	capnp.Struct(s).SetBit(256, v)
+}
+
+func (s SP3Entry) XVelocity() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(40))
+}
+
+func (s SP3Entry) SetXVelocity(v float64) {
+	capnp.Struct(s).SetUint64(40, math.Float64bits(v))
+}
+
+func (s SP3Entry) YVelocity() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(48))
+}
+
+func (s SP3Entry) SetYVelocity(v float64) {
+	capnp.Struct(s).SetUint64(48, math.Float64bits(v))
+}
+
+func (s SP3Entry) ZVelocity() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(56))
+}
+
+func (s SP3Entry) SetZVelocity(v float64) {
+	capnp.Struct(s).SetUint64(56, math.Float64bits(v))
+}
+
+func (s SP3Entry) ClockRate() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(64))
+}
+
+func (s SP3Entry) SetClockRate(v float64) {
+	capnp.Struct(s).SetUint64(64, math.Float64bits(v))
+}
+
+func (s SP3Entry) PositionStdDevExponents() (capnp.Int32List, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return capnp.Int32List(p.List()), err
+}
+
+func (s SP3Entry) HasPositionStdDevExponents() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s SP3Entry) SetPositionStdDevExponents(v capnp.Int32List) error {
+	return capnp.Struct(s).SetPtr(1, v.ToPtr())
+}
+
+// NewPositionStdDevExponents sets the positionStdDevExponents field to a newly
+// allocated capnp.Int32List, preferring placement in s's segment.
+func (s SP3Entry) NewPositionStdDevExponents(n int32) (capnp.Int32List, error) {
+	l, err := capnp.NewInt32List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.Int32List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
+	return l, err
+}
+func (s SP3Entry) ClockStdDevExponent() int32 {
+	return int32(capnp.Struct(s).Uint32(36))
+}
+
+func (s SP3Entry) SetClockStdDevExponent(v int32) {
+	capnp.Struct(s).SetUint32(36, uint32(v))
+}
+
+func (s SP3Entry) VelocityStdDevExponents() (capnp.Int32List, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return capnp.Int32List(p.List()), err
+}
+
+func (s SP3Entry) HasVelocityStdDevExponents() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s SP3Entry) SetVelocityStdDevExponents(v capnp.Int32List) error {
+	return capnp.Struct(s).SetPtr(2, v.ToPtr())
+}
+
+// NewVelocityStdDevExponents sets the velocityStdDevExponents field to a newly
+// allocated capnp.Int32List, preferring placement in s's segment.
+func (s SP3Entry) NewVelocityStdDevExponents(n int32) (capnp.Int32List, error) {
+	l, err := capnp.NewInt32List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.Int32List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(2, l.ToPtr())
+	return l, err
+}
+func (s SP3Entry) ClockRateStdDevExponent() int32 {
+	return int32(capnp.Struct(s).Uint32(72))
+}
+
+func (s SP3Entry) SetClockRateStdDevExponent(v int32) {
+	capnp.Struct(s).SetUint32(72, uint32(v))
+}
+
+func (s SP3Entry) ClockEvent() bool {
+	return capnp.Struct(s).Bit(257)
+}
+
+func (s SP3Entry) SetClockEvent(v bool) {
+	capnp.Struct(s).SetBit(257, v)
+}
+
+func (s SP3Entry) ClockPredicted() bool {
+	return capnp.Struct(s).Bit(258)
+}
+
+func (s SP3Entry) SetClockPredicted(v bool) {
+	capnp.Struct(s).SetBit(258, v)
+}
+
+func (s SP3Entry) Maneuver() bool {
+	return capnp.Struct(s).Bit(259)
+}
+
+func (s SP3Entry) SetManeuver(v bool) {
+	capnp.Struct(s).SetBit(259, v)
+}
+
+func (s SP3Entry) OrbitPredicted() bool {
+	return capnp.Struct(s).Bit(260)
+}
+
+func (s SP3Entry) SetOrbitPredicted(v bool) {
+	capnp.Struct(s).SetBit(260, v)
+}
+
+func (s SP3Entry) PositionCorrelation() (SP3Correlation, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return SP3Correlation(p.Struct()), err
+}
+
+func (s SP3Entry) HasPositionCorrelation() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s SP3Entry) SetPositionCorrelation(v SP3Correlation) error {
+	return capnp.Struct(s).SetPtr(3, capnp.Struct(v).ToPtr())
+}
+
+// NewPositionCorrelation sets the positionCorrelation field to a newly
+// allocated SP3Correlation struct, preferring placement in s's segment.
+func (s SP3Entry) NewPositionCorrelation() (SP3Correlation, error) {
+	ss, err := NewSP3Correlation(capnp.Struct(s).Segment())
+	if err != nil {
+		return SP3Correlation{}, err
+	}
+	err = capnp.Struct(s).SetPtr(3, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s SP3Entry) VelocityCorrelation() (SP3Correlation, error) {
+	p, err := capnp.Struct(s).Ptr(4)
+	return SP3Correlation(p.Struct()), err
+}
+
+func (s SP3Entry) HasVelocityCorrelation() bool {
+	return capnp.Struct(s).HasPtr(4)
+}
+
+func (s SP3Entry) SetVelocityCorrelation(v SP3Correlation) error {
+	return capnp.Struct(s).SetPtr(4, capnp.Struct(v).ToPtr())
+}
+
+// NewVelocityCorrelation sets the velocityCorrelation field to a newly
+// allocated SP3Correlation struct, preferring placement in s's segment.
+func (s SP3Entry) NewVelocityCorrelation() (SP3Correlation, error) {
+	ss, err := NewSP3Correlation(capnp.Struct(s).Segment())
+	if err != nil {
+		return SP3Correlation{}, err
+	}
+	err = capnp.Struct(s).SetPtr(4, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+// SP3Entry_List is a list of SP3Entry.
+type SP3Entry_List = capnp.StructList[SP3Entry]
+
+// NewSP3Entry creates a new list of SP3Entry.
+func NewSP3Entry_List(s *capnp.Segment, sz int32) (SP3Entry_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 80, PointerCount: 5}, sz)
+	return capnp.StructList[SP3Entry](l), err
+}
+
+// SP3Entry_Future is a wrapper for a SP3Entry promised by a client call.
+type SP3Entry_Future struct{ *capnp.Future }
+
+func (f SP3Entry_Future) Struct() (SP3Entry, error) {
+	p, err := f.Future.Ptr()
+	return SP3Entry(p.Struct()), err
+}
+func (p SP3Entry_Future) PositionCorrelation() SP3Correlation_Future {
+	return SP3Correlation_Future{Future: p.Future.Field(3, nil)}
+}
+func (p SP3Entry_Future) VelocityCorrelation() SP3Correlation_Future {
+	return SP3Correlation_Future{Future: p.Future.Field(4, nil)}
+}
+
+type SP3Correlation capnp.Struct
+
+// SP3Correlation_TypeID is the unique identifier for the type SP3Correlation.
+const SP3Correlation_TypeID = 0xe3f4f87b754f39a6
+
+func NewSP3Correlation(s *capnp.Segment) (SP3Correlation, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 40, PointerCount: 0})
+	return SP3Correlation(st), err
+}
+
+func NewRootSP3Correlation(s *capnp.Segment) (SP3Correlation, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 40, PointerCount: 0})
+	return SP3Correlation(st), err
+}
+
+func ReadRootSP3Correlation(msg *capnp.Message) (SP3Correlation, error) {
+	root, err := msg.Root()
+	return SP3Correlation(root.Struct()), err
+}
+
+func (s SP3Correlation) String() string {
+	str, _ := text.Marshal(0xe3f4f87b754f39a6, capnp.Struct(s))
+	return str
+}
+
+func (s SP3Correlation) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (SP3Correlation) DecodeFromPtr(p capnp.Ptr) SP3Correlation {
+	return SP3Correlation(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s SP3Correlation) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s SP3Correlation) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s SP3Correlation) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s SP3Correlation) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s SP3Correlation) XStdDev() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s SP3Correlation) SetXStdDev(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+func (s SP3Correlation) YStdDev() int32 {
+	return int32(capnp.Struct(s).Uint32(4))
+}
+
+func (s SP3Correlation) SetYStdDev(v int32) {
+	capnp.Struct(s).SetUint32(4, uint32(v))
+}
+
+func (s SP3Correlation) ZStdDev() int32 {
+	return int32(capnp.Struct(s).Uint32(8))
+}
+
+func (s SP3Correlation) SetZStdDev(v int32) {
+	capnp.Struct(s).SetUint32(8, uint32(v))
+}
+
+func (s SP3Correlation) ClockStdDev() int32 {
+	return int32(capnp.Struct(s).Uint32(12))
+}
+
+func (s SP3Correlation) SetClockStdDev(v int32) {
+	capnp.Struct(s).SetUint32(12, uint32(v))
+}
+
+func (s SP3Correlation) XyCorrelation() int32 {
+	return int32(capnp.Struct(s).Uint32(16))
+}
+
+func (s SP3Correlation) SetXyCorrelation(v int32) {
+	capnp.Struct(s).SetUint32(16, uint32(v))
+}
+
+func (s SP3Correlation) XzCorrelation() int32 {
+	return int32(capnp.Struct(s).Uint32(20))
+}
+
+func (s SP3Correlation) SetXzCorrelation(v int32) {
+	capnp.Struct(s).SetUint32(20, uint32(v))
+}
+
+func (s SP3Correlation) XClockCorrelation() int32 {
+	return int32(capnp.Struct(s).Uint32(24))
+}
+
+func (s SP3Correlation) SetXClockCorrelation(v int32) {
+	capnp.Struct(s).SetUint32(24, uint32(v))
+}
+
+func (s SP3Correlation) YzCorrelation() int32 {
+	return int32(capnp.Struct(s).Uint32(28))
+}
+
+func (s SP3Correlation) SetYzCorrelation(v int32) {
+	capnp.Struct(s).SetUint32(28, uint32(v))
+}
+
+func (s SP3Correlation) YClockCorrelation() int32 {
+	return int32(capnp.Struct(s).Uint32(32))
+}
+
+func (s SP3Correlation) SetYClockCorrelation(v int32) {
+	capnp.Struct(s).SetUint32(32, uint32(v))
+}
+
+func (s SP3Correlation) ZClockCorrelation() int32 {
+	return int32(capnp.Struct(s).Uint32(36))
+}
+
+func (s SP3Correlation) SetZClockCorrelation(v int32) {
+	capnp.Struct(s).SetUint32(36, uint32(v))
+}
+
+// SP3Correlation_List is a list of SP3Correlation.
+type SP3Correlation_List = capnp.StructList[SP3Correlation]
+
+// NewSP3Correlation creates a new list of SP3Correlation.
+func NewSP3Correlation_List(s *capnp.Segment, sz int32) (SP3Correlation_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 40, PointerCount: 0}, sz)
+	return capnp.StructList[SP3Correlation](l), err
+}
+
+// SP3Correlation_Future is a wrapper for a SP3Correlation promised by a client call.
+type SP3Correlation_Future struct{ *capnp.Future }
+
+func (f SP3Correlation_Future) Struct() (SP3Correlation, error) {
+	p, err := f.Future.Ptr()
+	return SP3Correlation(p.Struct()), err
+}
+
+type ObservationHeader capnp.Struct
+
+// ObservationHeader_TypeID is the unique identifier for the type ObservationHeader.
+const ObservationHeader_TypeID = 0xbb184c3bcbcd867f
+
+func NewObservationHeader(s *capnp.Segment) (ObservationHeader, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 22})
+	return ObservationHeader(st), err
+}
+
+func NewRootObservationHeader(s *capnp.Segment) (ObservationHeader, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 22})
+	return ObservationHeader(st), err
+}
+
+func ReadRootObservationHeader(msg *capnp.Message) (ObservationHeader, error) {
+	root, err := msg.Root()
+	return ObservationHeader(root.Struct()), err
+}
+
+func (s ObservationHeader) String() string {
+	str, _ := text.Marshal(0xbb184c3bcbcd867f, capnp.Struct(s))
+	return str
+}
+
+func (s ObservationHeader) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (ObservationHeader) DecodeFromPtr(p capnp.Ptr) ObservationHeader {
+	return ObservationHeader(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s ObservationHeader) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s ObservationHeader) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s ObservationHeader) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s ObservationHeader) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s ObservationHeader) Version() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(0))
+}
+
+func (s ObservationHeader) SetVersion(v float64) {
+	capnp.Struct(s).SetUint64(0, math.Float64bits(v))
+}
+
+func (s ObservationHeader) Type() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s ObservationHeader) HasType() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s ObservationHeader) TypeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s ObservationHeader) SetType(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s ObservationHeader) SatelliteSystem() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s ObservationHeader) HasSatelliteSystem() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s ObservationHeader) SatelliteSystemBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s ObservationHeader) SetSatelliteSystem(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s ObservationHeader) ProgramName() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s ObservationHeader) HasProgramName() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s ObservationHeader) ProgramNameBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s ObservationHeader) SetProgramName(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+func (s ObservationHeader) Agency() (string, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.Text(), err
+}
+
+func (s ObservationHeader) HasAgency() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s ObservationHeader) AgencyBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s ObservationHeader) SetAgency(v string) error {
+	return capnp.Struct(s).SetText(3, v)
+}
+
+func (s ObservationHeader) Date() (Time, error) {
+	p, err := capnp.Struct(s).Ptr(4)
+	return Time(p.Struct()), err
+}
+
+func (s ObservationHeader) HasDate() bool {
+	return capnp.Struct(s).HasPtr(4)
+}
+
+func (s ObservationHeader) SetDate(v Time) error {
+	return capnp.Struct(s).SetPtr(4, capnp.Struct(v).ToPtr())
+}
+
+// NewDate sets the date field to a newly
+// allocated Time struct, preferring placement in s's segment.
+func (s ObservationHeader) NewDate() (Time, error) {
+	ss, err := NewTime(capnp.Struct(s).Segment())
+	if err != nil {
+		return Time{}, err
+	}
+	err = capnp.Struct(s).SetPtr(4, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s ObservationHeader) MarkerName() (string, error) {
+	p, err := capnp.Struct(s).Ptr(5)
+	return p.Text(), err
+}
+
+func (s ObservationHeader) HasMarkerName() bool {
+	return capnp.Struct(s).HasPtr(5)
+}
+
+func (s ObservationHeader) MarkerNameBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(5)
+	return p.TextBytes(), err
+}
+
+func (s ObservationHeader) SetMarkerName(v string) error {
+	return capnp.Struct(s).SetText(5, v)
+}
+
+func (s ObservationHeader) MarkerNumber() (string, error) {
+	p, err := capnp.Struct(s).Ptr(6)
+	return p.Text(), err
 }
 
-func (s Ephemeris) SetCis(v float64) {
-	capnp.Struct(s).SetUint64(128, math.Float64bits(v))
+func (s ObservationHeader) HasMarkerNumber() bool {
+	return capnp.Struct(s).HasPtr(6)
 }
 
-func (s Ephemeris) I0() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(136))
+func (s ObservationHeader) MarkerNumberBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(6)
+	return p.TextBytes(), err
 }
 
-func (s Ephemeris) SetI0(v float64) {
-	capnp.Struct(s).SetUint64(136, math.Float64bits(v))
+func (s ObservationHeader) SetMarkerNumber(v string) error {
+	return capnp.Struct(s).SetText(6, v)
 }
 
-func (s Ephemeris) Crc() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(144))
+func (s ObservationHeader) Observer() (string, error) {
+	p, err := capnp.Struct(s).Ptr(7)
+	return p.Text(), err
 }
 
-func (s Ephemeris) SetCrc(v float64) {
-	capnp.Struct(s).SetUint64(144, math.Float64bits(v))
+func (s ObservationHeader) HasObserver() bool {
+	return capnp.Struct(s).HasPtr(7)
 }
 
-func (s Ephemeris) Omega() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(152))
+func (s ObservationHeader) ObserverBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(7)
+	return p.TextBytes(), err
 }
 
-func (s Ephemeris) SetOmega(v float64) {
-	capnp.Struct(s).SetUint64(152, math.Float64bits(v))
+func (s ObservationHeader) SetObserver(v string) error {
+	return capnp.Struct(s).SetText(7, v)
 }
 
-func (s Ephemeris) OmegaDot() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(160))
+func (s ObservationHeader) ObserverAgency() (string, error) {
+	p, err := capnp.Struct(s).Ptr(8)
+	return p.Text(), err
 }
 
-func (s Ephemeris) SetOmegaDot(v float64) {
-	capnp.Struct(s).SetUint64(160, math.Float64bits(v))
+func (s ObservationHeader) HasObserverAgency() bool {
+	return capnp.Struct(s).HasPtr(8)
 }
 
-func (s Ephemeris) IDot() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(168))
+func (s ObservationHeader) ObserverAgencyBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(8)
+	return p.TextBytes(), err
 }
 
-func (s Ephemeris) SetIDot(v float64) {
-	capnp.Struct(s).SetUint64(168, math.Float64bits(v))
+func (s ObservationHeader) SetObserverAgency(v string) error {
+	return capnp.Struct(s).SetText(8, v)
 }
 
-func (s Ephemeris) CodesL2() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(176))
+func (s ObservationHeader) ReceiverNumber() (string, error) {
+	p, err := capnp.Struct(s).Ptr(9)
+	return p.Text(), err
 }
 
-func (s Ephemeris) SetCodesL2(v float64) {
-	capnp.Struct(s).SetUint64(176, math.Float64bits(v))
+func (s ObservationHeader) HasReceiverNumber() bool {
+	return capnp.Struct(s).HasPtr(9)
 }
 
-func (s Ephemeris) L2() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(184))
+func (s ObservationHeader) ReceiverNumberBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(9)
+	return p.TextBytes(), err
 }
 
-func (s Ephemeris) SetL2(v float64) {
-	capnp.Struct(s).SetUint64(184, math.Float64bits(v))
+func (s ObservationHeader) SetReceiverNumber(v string) error {
+	return capnp.Struct(s).SetText(9, v)
 }
 
-func (s Ephemeris) SvAcc() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(192))
+func (s ObservationHeader) ReceiverType() (string, error) {
+	p, err := capnp.Struct(s).Ptr(10)
+	return p.Text(), err
 }
 
-func (s Ephemeris) SetSvAcc(v float64) {
-	capnp.Struct(s).SetUint64(192, math.Float64bits(v))
+func (s ObservationHeader) HasReceiverType() bool {
+	return capnp.Struct(s).HasPtr(10)
 }
 
-func (s Ephemeris) SvHealth() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(200))
+func (s ObservationHeader) ReceiverTypeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(10)
+	return p.TextBytes(), err
 }
 
-func (s Ephemeris) SetSvHealth(v float64) {
-	capnp.Struct(s).SetUint64(200, math.Float64bits(v))
+func (s ObservationHeader) SetReceiverType(v string) error {
+	return capnp.Struct(s).SetText(10, v)
 }
 
-func (s Ephemeris) Tgd() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(208))
+func (s ObservationHeader) ReceiverVersion() (string, error) {
+	p, err := capnp.Struct(s).Ptr(11)
+	return p.Text(), err
 }
 
-func (s Ephemeris) SetTgd(v float64) {
-	capnp.Struct(s).SetUint64(208, math.Float64bits(v))
+func (s ObservationHeader) HasReceiverVersion() bool {
+	return capnp.Struct(s).HasPtr(11)
 }
 
-func (s Ephemeris) Iodc() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(216))
+func (s ObservationHeader) ReceiverVersionBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(11)
+	return p.TextBytes(), err
 }
 
-func (s Ephemeris) SetIodc(v float64) {
-	capnp.Struct(s).SetUint64(216, math.Float64bits(v))
+func (s ObservationHeader) SetReceiverVersion(v string) error {
+	return capnp.Struct(s).SetText(11, v)
 }
 
-func (s Ephemeris) TransmissionTime() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(224))
+func (s ObservationHeader) AntennaNumber() (string, error) {
+	p, err := capnp.Struct(s).Ptr(12)
+	return p.Text(), err
 }
 
-func (s Ephemeris) SetTransmissionTime(v float64) {
-	capnp.Struct(s).SetUint64(224, math.Float64bits(v))
+func (s ObservationHeader) HasAntennaNumber() bool {
+	return capnp.Struct(s).HasPtr(12)
 }
 
-func (s Ephemeris) FitInterval() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(232))
+func (s ObservationHeader) AntennaNumberBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(12)
+	return p.TextBytes(), err
 }
 
-func (s Ephemeris) SetFitInterval(v float64) {
-	capnp.Struct(s).SetUint64(232, math.Float64bits(v))
+func (s ObservationHeader) SetAntennaNumber(v string) error {
+	return capnp.Struct(s).SetText(12, v)
 }
 
-func (s Ephemeris) Toc() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(240))
+func (s ObservationHeader) AntennaType() (string, error) {
+	p, err := capnp.Struct(s).Ptr(13)
+	return p.Text(), err
 }
 
-func (s Ephemeris) SetToc(v float64) {
-	capnp.Struct(s).SetUint64(240, math.Float64bits(v))
+func (s ObservationHeader) HasAntennaType() bool {
+	return capnp.Struct(s).HasPtr(13)
 }
 
-func (s Ephemeris) IonoCoeffsValid() bool {
-	return capnp.Struct(s).Bit(1984)
+func (s ObservationHeader) AntennaTypeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(13)
+	return p.TextBytes(), err
 }
 
-func (s Ephemeris) SetIonoCoeffsValid(v bool) {
-	capnp.Struct(s).SetBit(1984, v)
+func (s ObservationHeader) SetAntennaType(v string) error {
+	return capnp.Struct(s).SetText(13, v)
 }
 
-func (s Ephemeris) IonoAlpha() (capnp.Float64List, error) {
-	p, err := capnp.Struct(s).Ptr(0)
+func (s ObservationHeader) ApproximatePosition() (capnp.Float64List, error) {
+	p, err := capnp.Struct(s).Ptr(14)
 	return capnp.Float64List(p.List()), err
 }
 
-func (s Ephemeris) HasIonoAlpha() bool {
-	return capnp.Struct(s).HasPtr(0)
+func (s ObservationHeader) HasApproximatePosition() bool {
+	return capnp.Struct(s).HasPtr(14)
 }
 
-func (s Ephemeris) SetIonoAlpha(v capnp.Float64List) error {
-	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+func (s ObservationHeader) SetApproximatePosition(v capnp.Float64List) error {
+	return capnp.Struct(s).SetPtr(14, v.ToPtr())
 }
 
-// NewIonoAlpha sets the ionoAlpha field to a newly
+// NewApproximatePosition sets the approximatePosition field to a newly
 // allocated capnp.Float64List, preferring placement in s's segment.
-func (s Ephemeris) NewIonoAlpha(n int32) (capnp.Float64List, error) {
+func (s ObservationHeader) NewApproximatePosition(n int32) (capnp.Float64List, error) {
 	l, err := capnp.NewFloat64List(capnp.Struct(s).Segment(), n)
 	if err != nil {
 		return capnp.Float64List{}, err
 	}
-	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	err = capnp.Struct(s).SetPtr(14, l.ToPtr())
 	return l, err
 }
-func (s Ephemeris) IonoBeta() (capnp.Float64List, error) {
-	p, err := capnp.Struct(s).Ptr(1)
+func (s ObservationHeader) AntennaDelta() (capnp.Float64List, error) {
+	p, err := capnp.Struct(s).Ptr(15)
 	return capnp.Float64List(p.List()), err
 }
 
-func (s Ephemeris) HasIonoBeta() bool {
-	return capnp.Struct(s).HasPtr(1)
+func (s ObservationHeader) HasAntennaDelta() bool {
+	return capnp.Struct(s).HasPtr(15)
 }
 
-func (s Ephemeris) SetIonoBeta(v capnp.Float64List) error {
-	return capnp.Struct(s).SetPtr(1, v.ToPtr())
+func (s ObservationHeader) SetAntennaDelta(v capnp.Float64List) error {
+	return capnp.Struct(s).SetPtr(15, v.ToPtr())
 }
 
-// NewIonoBeta sets the ionoBeta field to a newly
+// NewAntennaDelta sets the antennaDelta field to a newly
 // allocated capnp.Float64List, preferring placement in s's segment.
-func (s Ephemeris) NewIonoBeta(n int32) (capnp.Float64List, error) {
+func (s ObservationHeader) NewAntennaDelta(n int32) (capnp.Float64List, error) {
 	l, err := capnp.NewFloat64List(capnp.Struct(s).Segment(), n)
 	if err != nil {
 		return capnp.Float64List{}, err
 	}
-	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
+	err = capnp.Struct(s).SetPtr(15, l.ToPtr())
 	return l, err
 }
-func (s Ephemeris) TowCount() uint32 {
-	return capnp.Struct(s).Uint32(252)
-}
-
-func (s Ephemeris) SetTowCount(v uint32) {
-	capnp.Struct(s).SetUint32(252, v)
-}
-
-func (s Ephemeris) ToeWeek() uint16 {
-	return capnp.Struct(s).Uint16(250)
-}
-
-func (s Ephemeris) SetToeWeek(v uint16) {
-	capnp.Struct(s).SetUint16(250, v)
-}
-
-func (s Ephemeris) TocWeek() uint16 {
-	return capnp.Struct(s).Uint16(256)
-}
-
-func (s Ephemeris) SetTocWeek(v uint16) {
-	capnp.Struct(s).SetUint16(256, v)
-}
-
-// Ephemeris_List is a list of Ephemeris.
-type Ephemeris_List = capnp.StructList[Ephemeris]
-
-// NewEphemeris creates a new list of Ephemeris.
-func NewEphemeris_List(s *capnp.Segment, sz int32) (Ephemeris_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 264, PointerCount: 2}, sz)
-	return capnp.StructList[Ephemeris](l), err
+func (s ObservationHeader) ObservationTypes() (ObservationTypes_List, error) {
+	p, err := capnp.Struct(s).Ptr(16)
+	return ObservationTypes_List(p.List()), err
 }
 
-// Ephemeris_Future is a wrapper for a Ephemeris promised by a client call.
-type Ephemeris_Future struct{ *capnp.Future }
-
-func (f Ephemeris_Future) Struct() (Ephemeris, error) {
-	p, err := f.Future.Ptr()
-	return Ephemeris(p.Struct()), err
+func (s ObservationHeader) HasObservationTypes() bool {
+	return capnp.Struct(s).HasPtr(16)
 }
 
-type RINEXEphemeris capnp.Struct
-
-// RINEXEphemeris_TypeID is the unique identifier for the type RINEXEphemeris.
-const RINEXEphemeris_TypeID = 0xeca2c2c553ea12f6
-
-func NewRINEXEphemeris(s *capnp.Segment) (RINEXEphemeris, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 120, PointerCount: 2})
-	return RINEXEphemeris(st), err
+func (s ObservationHeader) SetObservationTypes(v ObservationTypes_List) error {
+	return capnp.Struct(s).SetPtr(16, v.ToPtr())
 }
 
-func NewRootRINEXEphemeris(s *capnp.Segment) (RINEXEphemeris, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 120, PointerCount: 2})
-	return RINEXEphemeris(st), err
+// NewObservationTypes sets the observationTypes field to a newly
+// allocated ObservationTypes_List, preferring placement in s's segment.
+func (s ObservationHeader) NewObservationTypes(n int32) (ObservationTypes_List, error) {
+	l, err := NewObservationTypes_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return ObservationTypes_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(16, l.ToPtr())
+	return l, err
 }
-
-func ReadRootRINEXEphemeris(msg *capnp.Message) (RINEXEphemeris, error) {
-	root, err := msg.Root()
-	return RINEXEphemeris(root.Struct()), err
+func (s ObservationHeader) SignalStrengthUnit() (string, error) {
+	p, err := capnp.Struct(s).Ptr(17)
+	return p.Text(), err
 }
 
-func (s RINEXEphemeris) String() string {
-	str, _ := text.Marshal(0xeca2c2c553ea12f6, capnp.Struct(s))
-	return str
+func (s ObservationHeader) HasSignalStrengthUnit() bool {
+	return capnp.Struct(s).HasPtr(17)
 }
 
-func (s RINEXEphemeris) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
-	return capnp.Struct(s).EncodeAsPtr(seg)
+func (s ObservationHeader) SignalStrengthUnitBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(17)
+	return p.TextBytes(), err
 }
 
-func (RINEXEphemeris) DecodeFromPtr(p capnp.Ptr) RINEXEphemeris {
-	return RINEXEphemeris(capnp.Struct{}.DecodeFromPtr(p))
+func (s ObservationHeader) SetSignalStrengthUnit(v string) error {
+	return capnp.Struct(s).SetText(17, v)
 }
 
-func (s RINEXEphemeris) ToPtr() capnp.Ptr {
-	return capnp.Struct(s).ToPtr()
-}
-func (s RINEXEphemeris) IsValid() bool {
-	return capnp.Struct(s).IsValid()
+func (s ObservationHeader) Interval() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(8))
 }
 
-func (s RINEXEphemeris) Message() *capnp.Message {
-	return capnp.Struct(s).Message()
+func (s ObservationHeader) SetInterval(v float64) {
+	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
 }
 
-func (s RINEXEphemeris) Segment() *capnp.Segment {
-	return capnp.Struct(s).Segment()
-}
-func (s RINEXEphemeris) Header() (RINEXHeader, error) {
-	p, err := capnp.Struct(s).Ptr(0)
-	return RINEXHeader(p.Struct()), err
+func (s ObservationHeader) TimeOfFirstObservation() (Time, error) {
+	p, err := capnp.Struct(s).Ptr(18)
+	return Time(p.Struct()), err
 }
 
-func (s RINEXEphemeris) HasHeader() bool {
-	return capnp.Struct(s).HasPtr(0)
+func (s ObservationHeader) HasTimeOfFirstObservation() bool {
+	return capnp.Struct(s).HasPtr(18)
 }
 
-func (s RINEXEphemeris) SetHeader(v RINEXHeader) error {
-	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+func (s ObservationHeader) SetTimeOfFirstObservation(v Time) error {
+	return capnp.Struct(s).SetPtr(18, capnp.Struct(v).ToPtr())
 }
 
-// NewHeader sets the header field to a newly
-// allocated RINEXHeader struct, preferring placement in s's segment.
-func (s RINEXEphemeris) NewHeader() (RINEXHeader, error) {
-	ss, err := NewRINEXHeader(capnp.Struct(s).Segment())
+// NewTimeOfFirstObservation sets the timeOfFirstObservation field to a newly
+// allocated Time struct, preferring placement in s's segment.
+func (s ObservationHeader) NewTimeOfFirstObservation() (Time, error) {
+	ss, err := NewTime(capnp.Struct(s).Segment())
 	if err != nil {
-		return RINEXHeader{}, err
+		return Time{}, err
 	}
-	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	err = capnp.Struct(s).SetPtr(18, capnp.Struct(ss).ToPtr())
 	return ss, err
 }
 
-func (s RINEXEphemeris) SatelliteId() int32 {
-	return int32(capnp.Struct(s).Uint32(0))
-}
-
-func (s RINEXEphemeris) SetSatelliteId(v int32) {
-	capnp.Struct(s).SetUint32(0, uint32(v))
-}
-
-func (s RINEXEphemeris) Epoch() (Time, error) {
-	p, err := capnp.Struct(s).Ptr(1)
+func (s ObservationHeader) TimeOfLastObservation() (Time, error) {
+	p, err := capnp.Struct(s).Ptr(19)
 	return Time(p.Struct()), err
 }
 
-func (s RINEXEphemeris) HasEpoch() bool {
-	return capnp.Struct(s).HasPtr(1)
+func (s ObservationHeader) HasTimeOfLastObservation() bool {
+	return capnp.Struct(s).HasPtr(19)
 }
 
-func (s RINEXEphemeris) SetEpoch(v Time) error {
-	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
+func (s ObservationHeader) SetTimeOfLastObservation(v Time) error {
+	return capnp.Struct(s).SetPtr(19, capnp.Struct(v).ToPtr())
 }
 
-// NewEpoch sets the epoch field to a newly
+// NewTimeOfLastObservation sets the timeOfLastObservation field to a newly
 // allocated Time struct, preferring placement in s's segment.
-func (s RINEXEphemeris) NewEpoch() (Time, error) {
+func (s ObservationHeader) NewTimeOfLastObservation() (Time, error) {
 	ss, err := NewTime(capnp.Struct(s).Segment())
 	if err != nil {
 		return Time{}, err
 	}
-	err = capnp.Struct(s).SetPtr(1, capnp.Struct(ss).ToPtr())
+	err = capnp.Struct(s).SetPtr(19, capnp.Struct(ss).ToPtr())
 	return ss, err
 }
 
-func (s RINEXEphemeris) ClockBias() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(8))
+func (s ObservationHeader) TimeSystem() (string, error) {
+	p, err := capnp.Struct(s).Ptr(20)
+	return p.Text(), err
 }
 
-func (s RINEXEphemeris) SetClockBias(v float64) {
-	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
+func (s ObservationHeader) HasTimeSystem() bool {
+	return capnp.Struct(s).HasPtr(20)
 }
 
-func (s RINEXEphemeris) RelativeFrequencyBias() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(16))
+func (s ObservationHeader) TimeSystemBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(20)
+	return p.TextBytes(), err
 }
 
-func (s RINEXEphemeris) SetRelativeFrequencyBias(v float64) {
-	capnp.Struct(s).SetUint64(16, math.Float64bits(v))
+func (s ObservationHeader) SetTimeSystem(v string) error {
+	return capnp.Struct(s).SetText(20, v)
 }
 
-func (s RINEXEphemeris) MessageFrameTime() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(24))
+func (s ObservationHeader) LeapSeconds() int32 {
+	return int32(capnp.Struct(s).Uint32(16))
 }
 
-func (s RINEXEphemeris) SetMessageFrameTime(v float64) {
-	capnp.Struct(s).SetUint64(24, math.Float64bits(v))
+func (s ObservationHeader) SetLeapSeconds(v int32) {
+	capnp.Struct(s).SetUint32(16, uint32(v))
 }
 
-func (s RINEXEphemeris) PositionX() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(32))
+func (s ObservationHeader) Comments() (capnp.TextList, error) {
+	p, err := capnp.Struct(s).Ptr(21)
+	return capnp.TextList(p.List()), err
 }
 
-func (s RINEXEphemeris) SetPositionX(v float64) {
-	capnp.Struct(s).SetUint64(32, math.Float64bits(v))
+func (s ObservationHeader) HasComments() bool {
+	return capnp.Struct(s).HasPtr(21)
 }
 
-func (s RINEXEphemeris) VelocityX() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(40))
+func (s ObservationHeader) SetComments(v capnp.TextList) error {
+	return capnp.Struct(s).SetPtr(21, v.ToPtr())
 }
 
-func (s RINEXEphemeris) SetVelocityX(v float64) {
-	capnp.Struct(s).SetUint64(40, math.Float64bits(v))
+// NewComments sets the comments field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s ObservationHeader) NewComments(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = capnp.Struct(s).SetPtr(21, l.ToPtr())
+	return l, err
 }
 
-func (s RINEXEphemeris) AccelerationX() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(48))
-}
+// ObservationHeader_List is a list of ObservationHeader.
+type ObservationHeader_List = capnp.StructList[ObservationHeader]
 
-func (s RINEXEphemeris) SetAccelerationX(v float64) {
-	capnp.Struct(s).SetUint64(48, math.Float64bits(v))
+// NewObservationHeader creates a new list of ObservationHeader.
+func NewObservationHeader_List(s *capnp.Segment, sz int32) (ObservationHeader_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 22}, sz)
+	return capnp.StructList[ObservationHeader](l), err
 }
 
-func (s RINEXEphemeris) PositionY() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(56))
-}
+// ObservationHeader_Future is a wrapper for a ObservationHeader promised by a client call.
+type ObservationHeader_Future struct{ *capnp.Future }
 
-func (s RINEXEphemeris) SetPositionY(v float64) {
-	capnp.Struct(s).SetUint64(56, math.Float64bits(v))
+func (f ObservationHeader_Future) Struct() (ObservationHeader, error) {
+	p, err := f.Future.Ptr()
+	return ObservationHeader(p.Struct()), err
+}
+func (p ObservationHeader_Future) Date() Time_Future {
+	return Time_Future{Future: p.Future.Field(4, nil)}
+}
+func (p ObservationHeader_Future) TimeOfFirstObservation() Time_Future {
+	return Time_Future{Future: p.Future.Field(18, nil)}
+}
+func (p ObservationHeader_Future) TimeOfLastObservation() Time_Future {
+	return Time_Future{Future: p.Future.Field(19, nil)}
 }
 
-func (s RINEXEphemeris) VelocityY() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(64))
+type ObservationFile capnp.Struct
+
+// ObservationFile_TypeID is the unique identifier for the type ObservationFile.
+const ObservationFile_TypeID = 0x9cb7e476bf04cc4e
+
+func NewObservationFile(s *capnp.Segment) (ObservationFile, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return ObservationFile(st), err
 }
 
-func (s RINEXEphemeris) SetVelocityY(v float64) {
-	capnp.Struct(s).SetUint64(64, math.Float64bits(v))
+func NewRootObservationFile(s *capnp.Segment) (ObservationFile, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return ObservationFile(st), err
 }
 
-func (s RINEXEphemeris) AccelerationY() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(72))
+func ReadRootObservationFile(msg *capnp.Message) (ObservationFile, error) {
+	root, err := msg.Root()
+	return ObservationFile(root.Struct()), err
 }
 
-func (s RINEXEphemeris) SetAccelerationY(v float64) {
-	capnp.Struct(s).SetUint64(72, math.Float64bits(v))
+func (s ObservationFile) String() string {
+	str, _ := text.Marshal(0x9cb7e476bf04cc4e, capnp.Struct(s))
+	return str
 }
 
-func (s RINEXEphemeris) PositionZ() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(80))
+func (s ObservationFile) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (s RINEXEphemeris) SetPositionZ(v float64) {
-	capnp.Struct(s).SetUint64(80, math.Float64bits(v))
+func (ObservationFile) DecodeFromPtr(p capnp.Ptr) ObservationFile {
+	return ObservationFile(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s RINEXEphemeris) VelocityZ() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(88))
+func (s ObservationFile) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s ObservationFile) IsValid() bool {
+	return capnp.Struct(s).IsValid()
 }
 
-func (s RINEXEphemeris) SetVelocityZ(v float64) {
-	capnp.Struct(s).SetUint64(88, math.Float64bits(v))
+func (s ObservationFile) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
 }
 
-func (s RINEXEphemeris) AccelerationZ() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(96))
+func (s ObservationFile) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s ObservationFile) Header() (ObservationHeader, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return ObservationHeader(p.Struct()), err
 }
 
-func (s RINEXEphemeris) SetAccelerationZ(v float64) {
-	capnp.Struct(s).SetUint64(96, math.Float64bits(v))
+func (s ObservationFile) HasHeader() bool {
+	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s RINEXEphemeris) Health() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(104))
+func (s ObservationFile) SetHeader(v ObservationHeader) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
 
-func (s RINEXEphemeris) SetHealth(v float64) {
-	capnp.Struct(s).SetUint64(104, math.Float64bits(v))
+// NewHeader sets the header field to a newly
+// allocated ObservationHeader struct, preferring placement in s's segment.
+func (s ObservationFile) NewHeader() (ObservationHeader, error) {
+	ss, err := NewObservationHeader(capnp.Struct(s).Segment())
+	if err != nil {
+		return ObservationHeader{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
 }
 
-func (s RINEXEphemeris) FrequencyChannelOffset() int32 {
-	return int32(capnp.Struct(s).Uint32(4))
+func (s ObservationFile) Epochs() (ObservationEpoch_List, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return ObservationEpoch_List(p.List()), err
 }
 
-func (s RINEXEphemeris) SetFrequencyChannelOffset(v int32) {
-	capnp.Struct(s).SetUint32(4, uint32(v))
+func (s ObservationFile) HasEpochs() bool {
+	return capnp.Struct(s).HasPtr(1)
 }
 
-func (s RINEXEphemeris) InformationAge() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(112))
+func (s ObservationFile) SetEpochs(v ObservationEpoch_List) error {
+	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
 
-func (s RINEXEphemeris) SetInformationAge(v float64) {
-	capnp.Struct(s).SetUint64(112, math.Float64bits(v))
+// NewEpochs sets the epochs field to a newly
+// allocated ObservationEpoch_List, preferring placement in s's segment.
+func (s ObservationFile) NewEpochs(n int32) (ObservationEpoch_List, error) {
+	l, err := NewObservationEpoch_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return ObservationEpoch_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
+	return l, err
 }
 
-// RINEXEphemeris_List is a list of RINEXEphemeris.
-type RINEXEphemeris_List = capnp.StructList[RINEXEphemeris]
+// ObservationFile_List is a list of ObservationFile.
+type ObservationFile_List = capnp.StructList[ObservationFile]
 
-// NewRINEXEphemeris creates a new list of RINEXEphemeris.
-func NewRINEXEphemeris_List(s *capnp.Segment, sz int32) (RINEXEphemeris_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 120, PointerCount: 2}, sz)
-	return capnp.StructList[RINEXEphemeris](l), err
+// NewObservationFile creates a new list of ObservationFile.
+func NewObservationFile_List(s *capnp.Segment, sz int32) (ObservationFile_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return capnp.StructList[ObservationFile](l), err
 }
 
-// RINEXEphemeris_Future is a wrapper for a RINEXEphemeris promised by a client call.
-type RINEXEphemeris_Future struct{ *capnp.Future }
+// ObservationFile_Future is a wrapper for a ObservationFile promised by a client call.
+type ObservationFile_Future struct{ *capnp.Future }
 
-func (f RINEXEphemeris_Future) Struct() (RINEXEphemeris, error) {
+func (f ObservationFile_Future) Struct() (ObservationFile, error) {
 	p, err := f.Future.Ptr()
-	return RINEXEphemeris(p.Struct()), err
-}
-func (p RINEXEphemeris_Future) Header() RINEXHeader_Future {
-	return RINEXHeader_Future{Future: p.Future.Field(0, nil)}
+	return ObservationFile(p.Struct()), err
 }
-func (p RINEXEphemeris_Future) Epoch() Time_Future {
-	return Time_Future{Future: p.Future.Field(1, nil)}
+func (p ObservationFile_Future) Header() ObservationHeader_Future {
+	return ObservationHeader_Future{Future: p.Future.Field(0, nil)}
 }
 
-type GroupedEphemerides capnp.Struct
+type ArchiveIndex capnp.Struct
 
-// GroupedEphemerides_TypeID is the unique identifier for the type GroupedEphemerides.
-const GroupedEphemerides_TypeID = 0x8a11dc8313cd9d6d
+// ArchiveIndex_TypeID is the unique identifier for the type ArchiveIndex.
+const ArchiveIndex_TypeID = 0xb6de990322e1d01e
 
-func NewGroupedEphemerides(s *capnp.Segment) (GroupedEphemerides, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
-	return GroupedEphemerides(st), err
+func NewArchiveIndex(s *capnp.Segment) (ArchiveIndex, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return ArchiveIndex(st), err
 }
 
-func NewRootGroupedEphemerides(s *capnp.Segment) (GroupedEphemerides, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
-	return GroupedEphemerides(st), err
+func NewRootArchiveIndex(s *capnp.Segment) (ArchiveIndex, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return ArchiveIndex(st), err
 }
 
-func ReadRootGroupedEphemerides(msg *capnp.Message) (GroupedEphemerides, error) {
+func ReadRootArchiveIndex(msg *capnp.Message) (ArchiveIndex, error) {
 	root, err := msg.Root()
-	return GroupedEphemerides(root.Struct()), err
+	return ArchiveIndex(root.Struct()), err
 }
 
-func (s GroupedEphemerides) String() string {
-	str, _ := text.Marshal(0x8a11dc8313cd9d6d, capnp.Struct(s))
+func (s ArchiveIndex) String() string {
+	str, _ := text.Marshal(0xb6de990322e1d01e, capnp.Struct(s))
 	return str
 }
 
-func (s GroupedEphemerides) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s ArchiveIndex) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (GroupedEphemerides) DecodeFromPtr(p capnp.Ptr) GroupedEphemerides {
-	return GroupedEphemerides(capnp.Struct{}.DecodeFromPtr(p))
+func (ArchiveIndex) DecodeFromPtr(p capnp.Ptr) ArchiveIndex {
+	return ArchiveIndex(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s GroupedEphemerides) ToPtr() capnp.Ptr {
+func (s ArchiveIndex) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s GroupedEphemerides) IsValid() bool {
+func (s ArchiveIndex) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s GroupedEphemerides) Message() *capnp.Message {
+func (s ArchiveIndex) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s GroupedEphemerides) Segment() *capnp.Segment {
+func (s ArchiveIndex) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s GroupedEphemerides) SatelliteId() int32 {
-	return int32(capnp.Struct(s).Uint32(0))
-}
-
-func (s GroupedEphemerides) SetSatelliteId(v int32) {
-	capnp.Struct(s).SetUint32(0, uint32(v))
-}
-
-func (s GroupedEphemerides) SortedEphemerides() (RINEXEphemeris_List, error) {
+func (s ArchiveIndex) Entries() (ArchiveIndexEntry_List, error) {
 	p, err := capnp.Struct(s).Ptr(0)
-	return RINEXEphemeris_List(p.List()), err
+	return ArchiveIndexEntry_List(p.List()), err
 }
 
-func (s GroupedEphemerides) HasSortedEphemerides() bool {
+func (s ArchiveIndex) HasEntries() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s GroupedEphemerides) SetSortedEphemerides(v RINEXEphemeris_List) error {
+func (s ArchiveIndex) SetEntries(v ArchiveIndexEntry_List) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
 
-// NewSortedEphemerides sets the sortedEphemerides field to a newly
-// allocated RINEXEphemeris_List, preferring placement in s's segment.
-func (s GroupedEphemerides) NewSortedEphemerides(n int32) (RINEXEphemeris_List, error) {
-	l, err := NewRINEXEphemeris_List(capnp.Struct(s).Segment(), n)
+// NewEntries sets the entries field to a newly
+// allocated ArchiveIndexEntry_List, preferring placement in s's segment.
+func (s ArchiveIndex) NewEntries(n int32) (ArchiveIndexEntry_List, error) {
+	l, err := NewArchiveIndexEntry_List(capnp.Struct(s).Segment(), n)
 	if err != nil {
-		return RINEXEphemeris_List{}, err
+		return ArchiveIndexEntry_List{}, err
 	}
 	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
 	return l, err
 }
 
-// GroupedEphemerides_List is a list of GroupedEphemerides.
-type GroupedEphemerides_List = capnp.StructList[GroupedEphemerides]
+// ArchiveIndex_List is a list of ArchiveIndex.
+type ArchiveIndex_List = capnp.StructList[ArchiveIndex]
 
-// NewGroupedEphemerides creates a new list of GroupedEphemerides.
-func NewGroupedEphemerides_List(s *capnp.Segment, sz int32) (GroupedEphemerides_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
-	return capnp.StructList[GroupedEphemerides](l), err
+// NewArchiveIndex creates a new list of ArchiveIndex.
+func NewArchiveIndex_List(s *capnp.Segment, sz int32) (ArchiveIndex_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[ArchiveIndex](l), err
 }
 
-// GroupedEphemerides_Future is a wrapper for a GroupedEphemerides promised by a client call.
-type GroupedEphemerides_Future struct{ *capnp.Future }
+// ArchiveIndex_Future is a wrapper for a ArchiveIndex promised by a client call.
+type ArchiveIndex_Future struct{ *capnp.Future }
 
-func (f GroupedEphemerides_Future) Struct() (GroupedEphemerides, error) {
+func (f ArchiveIndex_Future) Struct() (ArchiveIndex, error) {
 	p, err := f.Future.Ptr()
-	return GroupedEphemerides(p.Struct()), err
+	return ArchiveIndex(p.Struct()), err
 }
 
-type SP3FormatEphemeris capnp.Struct
+type ArchiveIndexEntry capnp.Struct
 
-// SP3FormatEphemeris_TypeID is the unique identifier for the type SP3FormatEphemeris.
-const SP3FormatEphemeris_TypeID = 0xe5b14b55893ef9cb
+// ArchiveIndexEntry_TypeID is the unique identifier for the type ArchiveIndexEntry.
+const ArchiveIndexEntry_TypeID = 0xcd6d1146e9cde5fc
 
-func NewSP3FormatEphemeris(s *capnp.Segment) (SP3FormatEphemeris, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
-	return SP3FormatEphemeris(st), err
+func NewArchiveIndexEntry(s *capnp.Segment) (ArchiveIndexEntry, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 3})
+	return ArchiveIndexEntry(st), err
 }
 
-func NewRootSP3FormatEphemeris(s *capnp.Segment) (SP3FormatEphemeris, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
-	return SP3FormatEphemeris(st), err
+func NewRootArchiveIndexEntry(s *capnp.Segment) (ArchiveIndexEntry, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 3})
+	return ArchiveIndexEntry(st), err
 }
 
-func ReadRootSP3FormatEphemeris(msg *capnp.Message) (SP3FormatEphemeris, error) {
+func ReadRootArchiveIndexEntry(msg *capnp.Message) (ArchiveIndexEntry, error) {
 	root, err := msg.Root()
-	return SP3FormatEphemeris(root.Struct()), err
+	return ArchiveIndexEntry(root.Struct()), err
 }
 
-func (s SP3FormatEphemeris) String() string {
-	str, _ := text.Marshal(0xe5b14b55893ef9cb, capnp.Struct(s))
+func (s ArchiveIndexEntry) String() string {
+	str, _ := text.Marshal(0xcd6d1146e9cde5fc, capnp.Struct(s))
 	return str
 }
 
-func (s SP3FormatEphemeris) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s ArchiveIndexEntry) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (SP3FormatEphemeris) DecodeFromPtr(p capnp.Ptr) SP3FormatEphemeris {
-	return SP3FormatEphemeris(capnp.Struct{}.DecodeFromPtr(p))
+func (ArchiveIndexEntry) DecodeFromPtr(p capnp.Ptr) ArchiveIndexEntry {
+	return ArchiveIndexEntry(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s SP3FormatEphemeris) ToPtr() capnp.Ptr {
+func (s ArchiveIndexEntry) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s SP3FormatEphemeris) IsValid() bool {
+func (s ArchiveIndexEntry) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s SP3FormatEphemeris) Message() *capnp.Message {
+func (s ArchiveIndexEntry) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s SP3FormatEphemeris) Segment() *capnp.Segment {
+func (s ArchiveIndexEntry) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s SP3FormatEphemeris) Header() (SP3Header, error) {
+func (s ArchiveIndexEntry) Prn() (string, error) {
 	p, err := capnp.Struct(s).Ptr(0)
-	return SP3Header(p.Struct()), err
+	return p.Text(), err
 }
 
-func (s SP3FormatEphemeris) HasHeader() bool {
+func (s ArchiveIndexEntry) HasPrn() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s SP3FormatEphemeris) SetHeader(v SP3Header) error {
-	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+func (s ArchiveIndexEntry) PrnBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
 }
 
-// NewHeader sets the header field to a newly
-// allocated SP3Header struct, preferring placement in s's segment.
-func (s SP3FormatEphemeris) NewHeader() (SP3Header, error) {
-	ss, err := NewSP3Header(capnp.Struct(s).Segment())
+func (s ArchiveIndexEntry) SetPrn(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s ArchiveIndexEntry) Start() (GPSTime, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return GPSTime(p.Struct()), err
+}
+
+func (s ArchiveIndexEntry) HasStart() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s ArchiveIndexEntry) SetStart(v GPSTime) error {
+	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
+}
+
+// NewStart sets the start field to a newly
+// allocated GPSTime struct, preferring placement in s's segment.
+func (s ArchiveIndexEntry) NewStart() (GPSTime, error) {
+	ss, err := NewGPSTime(capnp.Struct(s).Segment())
 	if err != nil {
-		return SP3Header{}, err
+		return GPSTime{}, err
 	}
-	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	err = capnp.Struct(s).SetPtr(1, capnp.Struct(ss).ToPtr())
 	return ss, err
 }
 
-func (s SP3FormatEphemeris) Epochs() (SP3Epoch_List, error) {
-	p, err := capnp.Struct(s).Ptr(1)
-	return SP3Epoch_List(p.List()), err
+func (s ArchiveIndexEntry) End() (GPSTime, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return GPSTime(p.Struct()), err
 }
 
-func (s SP3FormatEphemeris) HasEpochs() bool {
-	return capnp.Struct(s).HasPtr(1)
+func (s ArchiveIndexEntry) HasEnd() bool {
+	return capnp.Struct(s).HasPtr(2)
 }
 
-func (s SP3FormatEphemeris) SetEpochs(v SP3Epoch_List) error {
-	return capnp.Struct(s).SetPtr(1, v.ToPtr())
+func (s ArchiveIndexEntry) SetEnd(v GPSTime) error {
+	return capnp.Struct(s).SetPtr(2, capnp.Struct(v).ToPtr())
 }
 
-// NewEpochs sets the epochs field to a newly
-// allocated SP3Epoch_List, preferring placement in s's segment.
-func (s SP3FormatEphemeris) NewEpochs(n int32) (SP3Epoch_List, error) {
-	l, err := NewSP3Epoch_List(capnp.Struct(s).Segment(), n)
+// NewEnd sets the end field to a newly
+// allocated GPSTime struct, preferring placement in s's segment.
+func (s ArchiveIndexEntry) NewEnd() (GPSTime, error) {
+	ss, err := NewGPSTime(capnp.Struct(s).Segment())
 	if err != nil {
-		return SP3Epoch_List{}, err
+		return GPSTime{}, err
 	}
-	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
-	return l, err
+	err = capnp.Struct(s).SetPtr(2, capnp.Struct(ss).ToPtr())
+	return ss, err
 }
 
-// SP3FormatEphemeris_List is a list of SP3FormatEphemeris.
-type SP3FormatEphemeris_List = capnp.StructList[SP3FormatEphemeris]
+func (s ArchiveIndexEntry) Offset() uint64 {
+	return capnp.Struct(s).Uint64(0)
+}
 
-// NewSP3FormatEphemeris creates a new list of SP3FormatEphemeris.
-func NewSP3FormatEphemeris_List(s *capnp.Segment, sz int32) (SP3FormatEphemeris_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
-	return capnp.StructList[SP3FormatEphemeris](l), err
+func (s ArchiveIndexEntry) SetOffset(v uint64) {
+	capnp.Struct(s).SetUint64(0, v)
 }
 
-// SP3FormatEphemeris_Future is a wrapper for a SP3FormatEphemeris promised by a client call.
-type SP3FormatEphemeris_Future struct{ *capnp.Future }
+func (s ArchiveIndexEntry) Size() uint64 {
+	return capnp.Struct(s).Uint64(8)
+}
 
-func (f SP3FormatEphemeris_Future) Struct() (SP3FormatEphemeris, error) {
+func (s ArchiveIndexEntry) SetSize(v uint64) {
+	capnp.Struct(s).SetUint64(8, v)
+}
+
+// ArchiveIndexEntry_List is a list of ArchiveIndexEntry.
+type ArchiveIndexEntry_List = capnp.StructList[ArchiveIndexEntry]
+
+// NewArchiveIndexEntry creates a new list of ArchiveIndexEntry.
+func NewArchiveIndexEntry_List(s *capnp.Segment, sz int32) (ArchiveIndexEntry_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 3}, sz)
+	return capnp.StructList[ArchiveIndexEntry](l), err
+}
+
+// ArchiveIndexEntry_Future is a wrapper for a ArchiveIndexEntry promised by a client call.
+type ArchiveIndexEntry_Future struct{ *capnp.Future }
+
+func (f ArchiveIndexEntry_Future) Struct() (ArchiveIndexEntry, error) {
 	p, err := f.Future.Ptr()
-	return SP3FormatEphemeris(p.Struct()), err
+	return ArchiveIndexEntry(p.Struct()), err
 }
-func (p SP3FormatEphemeris_Future) Header() SP3Header_Future {
-	return SP3Header_Future{Future: p.Future.Field(0, nil)}
+func (p ArchiveIndexEntry_Future) Start() GPSTime_Future {
+	return GPSTime_Future{Future: p.Future.Field(1, nil)}
+}
+func (p ArchiveIndexEntry_Future) End() GPSTime_Future {
+	return GPSTime_Future{Future: p.Future.Field(2, nil)}
 }
 
-type SP3Header capnp.Struct
+type ObservationTypes capnp.Struct
 
-// SP3Header_TypeID is the unique identifier for the type SP3Header.
-const SP3Header_TypeID = 0xd5b36c059384fab0
+// ObservationTypes_TypeID is the unique identifier for the type ObservationTypes.
+const ObservationTypes_TypeID = 0xc411e87839521ad9
 
-func NewSP3Header(s *capnp.Segment) (SP3Header, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 40, PointerCount: 6})
-	return SP3Header(st), err
+func NewObservationTypes(s *capnp.Segment) (ObservationTypes, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return ObservationTypes(st), err
 }
 
-func NewRootSP3Header(s *capnp.Segment) (SP3Header, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 40, PointerCount: 6})
-	return SP3Header(st), err
+func NewRootObservationTypes(s *capnp.Segment) (ObservationTypes, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return ObservationTypes(st), err
 }
 
-func ReadRootSP3Header(msg *capnp.Message) (SP3Header, error) {
+func ReadRootObservationTypes(msg *capnp.Message) (ObservationTypes, error) {
 	root, err := msg.Root()
-	return SP3Header(root.Struct()), err
+	return ObservationTypes(root.Struct()), err
 }
 
-func (s SP3Header) String() string {
-	str, _ := text.Marshal(0xd5b36c059384fab0, capnp.Struct(s))
+func (s ObservationTypes) String() string {
+	str, _ := text.Marshal(0xc411e87839521ad9, capnp.Struct(s))
 	return str
 }
 
-func (s SP3Header) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s ObservationTypes) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (SP3Header) DecodeFromPtr(p capnp.Ptr) SP3Header {
-	return SP3Header(capnp.Struct{}.DecodeFromPtr(p))
+func (ObservationTypes) DecodeFromPtr(p capnp.Ptr) ObservationTypes {
+	return ObservationTypes(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s SP3Header) ToPtr() capnp.Ptr {
+func (s ObservationTypes) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s SP3Header) IsValid() bool {
+func (s ObservationTypes) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s SP3Header) Message() *capnp.Message {
+func (s ObservationTypes) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s SP3Header) Segment() *capnp.Segment {
+func (s ObservationTypes) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s SP3Header) Version() (string, error) {
+func (s ObservationTypes) System() (string, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.Text(), err
 }
 
-func (s SP3Header) HasVersion() bool {
+func (s ObservationTypes) HasSystem() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s SP3Header) VersionBytes() ([]byte, error) {
+func (s ObservationTypes) SystemBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
 }
 
-func (s SP3Header) SetVersion(v string) error {
+func (s ObservationTypes) SetSystem(v string) error {
 	return capnp.Struct(s).SetText(0, v)
 }
 
-func (s SP3Header) Start() (Time, error) {
+func (s ObservationTypes) Codes() (capnp.TextList, error) {
 	p, err := capnp.Struct(s).Ptr(1)
-	return Time(p.Struct()), err
+	return capnp.TextList(p.List()), err
 }
 
-func (s SP3Header) HasStart() bool {
+func (s ObservationTypes) HasCodes() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
-func (s SP3Header) SetStart(v Time) error {
-	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
+func (s ObservationTypes) SetCodes(v capnp.TextList) error {
+	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
 
-// NewStart sets the start field to a newly
-// allocated Time struct, preferring placement in s's segment.
-func (s SP3Header) NewStart() (Time, error) {
-	ss, err := NewTime(capnp.Struct(s).Segment())
+// NewCodes sets the codes field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s ObservationTypes) NewCodes(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(capnp.Struct(s).Segment(), n)
 	if err != nil {
-		return Time{}, err
+		return capnp.TextList{}, err
 	}
-	err = capnp.Struct(s).SetPtr(1, capnp.Struct(ss).ToPtr())
-	return ss, err
+	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
+	return l, err
 }
 
-func (s SP3Header) NumberOfEpochs() int32 {
-	return int32(capnp.Struct(s).Uint32(0))
+// ObservationTypes_List is a list of ObservationTypes.
+type ObservationTypes_List = capnp.StructList[ObservationTypes]
+
+// NewObservationTypes creates a new list of ObservationTypes.
+func NewObservationTypes_List(s *capnp.Segment, sz int32) (ObservationTypes_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return capnp.StructList[ObservationTypes](l), err
 }
 
-func (s SP3Header) SetNumberOfEpochs(v int32) {
-	capnp.Struct(s).SetUint32(0, uint32(v))
+// ObservationTypes_Future is a wrapper for a ObservationTypes promised by a client call.
+type ObservationTypes_Future struct{ *capnp.Future }
+
+func (f ObservationTypes_Future) Struct() (ObservationTypes, error) {
+	p, err := f.Future.Ptr()
+	return ObservationTypes(p.Struct()), err
 }
 
-func (s SP3Header) DataUsed() (string, error) {
-	p, err := capnp.Struct(s).Ptr(2)
-	return p.Text(), err
+type ObservationEpoch capnp.Struct
+
+// ObservationEpoch_TypeID is the unique identifier for the type ObservationEpoch.
+const ObservationEpoch_TypeID = 0xe835d571cf672ea0
+
+func NewObservationEpoch(s *capnp.Segment) (ObservationEpoch, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 4})
+	return ObservationEpoch(st), err
 }
 
-func (s SP3Header) HasDataUsed() bool {
-	return capnp.Struct(s).HasPtr(2)
+func NewRootObservationEpoch(s *capnp.Segment) (ObservationEpoch, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 4})
+	return ObservationEpoch(st), err
 }
 
-func (s SP3Header) DataUsedBytes() ([]byte, error) {
-	p, err := capnp.Struct(s).Ptr(2)
-	return p.TextBytes(), err
+func ReadRootObservationEpoch(msg *capnp.Message) (ObservationEpoch, error) {
+	root, err := msg.Root()
+	return ObservationEpoch(root.Struct()), err
 }
 
-func (s SP3Header) SetDataUsed(v string) error {
-	return capnp.Struct(s).SetText(2, v)
+func (s ObservationEpoch) String() string {
+	str, _ := text.Marshal(0xe835d571cf672ea0, capnp.Struct(s))
+	return str
 }
 
-func (s SP3Header) CoordinateSystem() (string, error) {
-	p, err := capnp.Struct(s).Ptr(3)
-	return p.Text(), err
+func (s ObservationEpoch) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (s SP3Header) HasCoordinateSystem() bool {
-	return capnp.Struct(s).HasPtr(3)
+func (ObservationEpoch) DecodeFromPtr(p capnp.Ptr) ObservationEpoch {
+	return ObservationEpoch(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s SP3Header) CoordinateSystemBytes() ([]byte, error) {
-	p, err := capnp.Struct(s).Ptr(3)
-	return p.TextBytes(), err
+func (s ObservationEpoch) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s ObservationEpoch) IsValid() bool {
+	return capnp.Struct(s).IsValid()
 }
 
-func (s SP3Header) SetCoordinateSystem(v string) error {
-	return capnp.Struct(s).SetText(3, v)
+func (s ObservationEpoch) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
 }
 
-func (s SP3Header) OrbitType() (string, error) {
-	p, err := capnp.Struct(s).Ptr(4)
-	return p.Text(), err
+func (s ObservationEpoch) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s ObservationEpoch) Epoch() (Time, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return Time(p.Struct()), err
 }
 
-func (s SP3Header) HasOrbitType() bool {
-	return capnp.Struct(s).HasPtr(4)
+func (s ObservationEpoch) HasEpoch() bool {
+	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s SP3Header) OrbitTypeBytes() ([]byte, error) {
-	p, err := capnp.Struct(s).Ptr(4)
-	return p.TextBytes(), err
+func (s ObservationEpoch) SetEpoch(v Time) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
 
-func (s SP3Header) SetOrbitType(v string) error {
-	return capnp.Struct(s).SetText(4, v)
+// NewEpoch sets the epoch field to a newly
+// allocated Time struct, preferring placement in s's segment.
+func (s ObservationEpoch) NewEpoch() (Time, error) {
+	ss, err := NewTime(capnp.Struct(s).Segment())
+	if err != nil {
+		return Time{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
 }
 
-func (s SP3Header) Agency() (string, error) {
-	p, err := capnp.Struct(s).Ptr(5)
-	return p.Text(), err
+func (s ObservationEpoch) GpsTime() (GPSTime, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return GPSTime(p.Struct()), err
 }
 
-func (s SP3Header) HasAgency() bool {
-	return capnp.Struct(s).HasPtr(5)
+func (s ObservationEpoch) HasGpsTime() bool {
+	return capnp.Struct(s).HasPtr(1)
 }
 
-func (s SP3Header) AgencyBytes() ([]byte, error) {
-	p, err := capnp.Struct(s).Ptr(5)
-	return p.TextBytes(), err
+func (s ObservationEpoch) SetGpsTime(v GPSTime) error {
+	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
 }
 
-func (s SP3Header) SetAgency(v string) error {
-	return capnp.Struct(s).SetText(5, v)
+// NewGpsTime sets the gpsTime field to a newly
+// allocated GPSTime struct, preferring placement in s's segment.
+func (s ObservationEpoch) NewGpsTime() (GPSTime, error) {
+	ss, err := NewGPSTime(capnp.Struct(s).Segment())
+	if err != nil {
+		return GPSTime{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, capnp.Struct(ss).ToPtr())
+	return ss, err
 }
 
-func (s SP3Header) GpsWeek() int32 {
-	return int32(capnp.Struct(s).Uint32(4))
+func (s ObservationEpoch) EventFlag() uint8 {
+	return capnp.Struct(s).Uint8(0)
 }
 
-func (s SP3Header) SetGpsWeek(v int32) {
-	capnp.Struct(s).SetUint32(4, uint32(v))
+func (s ObservationEpoch) SetEventFlag(v uint8) {
+	capnp.Struct(s).SetUint8(0, v)
 }
 
-func (s SP3Header) SecondsOfWeek() float64 {
+func (s ObservationEpoch) ReceiverClockOffset() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(8))
 }
 
-func (s SP3Header) SetSecondsOfWeek(v float64) {
+func (s ObservationEpoch) SetReceiverClockOffset(v float64) {
 	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
 }
 
-func (s SP3Header) EpochInterval() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(16))
+func (s ObservationEpoch) Satellites() (SatelliteObservation_List, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return SatelliteObservation_List(p.List()), err
 }
 
-func (s SP3Header) SetEpochInterval(v float64) {
-	capnp.Struct(s).SetUint64(16, math.Float64bits(v))
+func (s ObservationEpoch) HasSatellites() bool {
+	return capnp.Struct(s).HasPtr(2)
 }
 
-func (s SP3Header) ModifiedJulianDay() int32 {
-	return int32(capnp.Struct(s).Uint32(24))
+func (s ObservationEpoch) SetSatellites(v SatelliteObservation_List) error {
+	return capnp.Struct(s).SetPtr(2, v.ToPtr())
 }
 
-func (s SP3Header) SetModifiedJulianDay(v int32) {
-	capnp.Struct(s).SetUint32(24, uint32(v))
+// NewSatellites sets the satellites field to a newly
+// allocated SatelliteObservation_List, preferring placement in s's segment.
+func (s ObservationEpoch) NewSatellites(n int32) (SatelliteObservation_List, error) {
+	l, err := NewSatelliteObservation_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return SatelliteObservation_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(2, l.ToPtr())
+	return l, err
+}
+func (s ObservationEpoch) EventRecords() (capnp.TextList, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return capnp.TextList(p.List()), err
 }
 
-func (s SP3Header) FractionalDay() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(32))
+func (s ObservationEpoch) HasEventRecords() bool {
+	return capnp.Struct(s).HasPtr(3)
 }
 
-func (s SP3Header) SetFractionalDay(v float64) {
-	capnp.Struct(s).SetUint64(32, math.Float64bits(v))
+func (s ObservationEpoch) SetEventRecords(v capnp.TextList) error {
+	return capnp.Struct(s).SetPtr(3, v.ToPtr())
 }
 
-// SP3Header_List is a list of SP3Header.
-type SP3Header_List = capnp.StructList[SP3Header]
+// NewEventRecords sets the eventRecords field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s ObservationEpoch) NewEventRecords(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = capnp.Struct(s).SetPtr(3, l.ToPtr())
+	return l, err
+}
 
-// NewSP3Header creates a new list of SP3Header.
-func NewSP3Header_List(s *capnp.Segment, sz int32) (SP3Header_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 40, PointerCount: 6}, sz)
-	return capnp.StructList[SP3Header](l), err
+// ObservationEpoch_List is a list of ObservationEpoch.
+type ObservationEpoch_List = capnp.StructList[ObservationEpoch]
+
+// NewObservationEpoch creates a new list of ObservationEpoch.
+func NewObservationEpoch_List(s *capnp.Segment, sz int32) (ObservationEpoch_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 4}, sz)
+	return capnp.StructList[ObservationEpoch](l), err
 }
 
-// SP3Header_Future is a wrapper for a SP3Header promised by a client call.
-type SP3Header_Future struct{ *capnp.Future }
+// ObservationEpoch_Future is a wrapper for a ObservationEpoch promised by a client call.
+type ObservationEpoch_Future struct{ *capnp.Future }
 
-func (f SP3Header_Future) Struct() (SP3Header, error) {
+func (f ObservationEpoch_Future) Struct() (ObservationEpoch, error) {
 	p, err := f.Future.Ptr()
-	return SP3Header(p.Struct()), err
+	return ObservationEpoch(p.Struct()), err
 }
-func (p SP3Header_Future) Start() Time_Future {
-	return Time_Future{Future: p.Future.Field(1, nil)}
+func (p ObservationEpoch_Future) Epoch() Time_Future {
+	return Time_Future{Future: p.Future.Field(0, nil)}
+}
+func (p ObservationEpoch_Future) GpsTime() GPSTime_Future {
+	return GPSTime_Future{Future: p.Future.Field(1, nil)}
 }
 
-type SP3Epoch capnp.Struct
+type SatelliteObservation capnp.Struct
 
-// SP3Epoch_TypeID is the unique identifier for the type SP3Epoch.
-const SP3Epoch_TypeID = 0x9261b240a2cc4a68
+// SatelliteObservation_TypeID is the unique identifier for the type SatelliteObservation.
+const SatelliteObservation_TypeID = 0xe9f50d7a73032eaa
 
-func NewSP3Epoch(s *capnp.Segment) (SP3Epoch, error) {
+func NewSatelliteObservation(s *capnp.Segment) (SatelliteObservation, error) {
 	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
-	return SP3Epoch(st), err
+	return SatelliteObservation(st), err
 }
 
-func NewRootSP3Epoch(s *capnp.Segment) (SP3Epoch, error) {
+func NewRootSatelliteObservation(s *capnp.Segment) (SatelliteObservation, error) {
 	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
-	return SP3Epoch(st), err
+	return SatelliteObservation(st), err
 }
 
-func ReadRootSP3Epoch(msg *capnp.Message) (SP3Epoch, error) {
+func ReadRootSatelliteObservation(msg *capnp.Message) (SatelliteObservation, error) {
 	root, err := msg.Root()
-	return SP3Epoch(root.Struct()), err
+	return SatelliteObservation(root.Struct()), err
 }
 
-func (s SP3Epoch) String() string {
-	str, _ := text.Marshal(0x9261b240a2cc4a68, capnp.Struct(s))
+func (s SatelliteObservation) String() string {
+	str, _ := text.Marshal(0xe9f50d7a73032eaa, capnp.Struct(s))
 	return str
 }
 
-func (s SP3Epoch) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s SatelliteObservation) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (SP3Epoch) DecodeFromPtr(p capnp.Ptr) SP3Epoch {
-	return SP3Epoch(capnp.Struct{}.DecodeFromPtr(p))
+func (SatelliteObservation) DecodeFromPtr(p capnp.Ptr) SatelliteObservation {
+	return SatelliteObservation(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s SP3Epoch) ToPtr() capnp.Ptr {
+func (s SatelliteObservation) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s SP3Epoch) IsValid() bool {
+func (s SatelliteObservation) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s SP3Epoch) Message() *capnp.Message {
+func (s SatelliteObservation) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s SP3Epoch) Segment() *capnp.Segment {
+func (s SatelliteObservation) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s SP3Epoch) Time() (Time, error) {
+func (s SatelliteObservation) Prn() (string, error) {
 	p, err := capnp.Struct(s).Ptr(0)
-	return Time(p.Struct()), err
+	return p.Text(), err
 }
 
-func (s SP3Epoch) HasTime() bool {
+func (s SatelliteObservation) HasPrn() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s SP3Epoch) SetTime(v Time) error {
-	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+func (s SatelliteObservation) PrnBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
 }
 
-// NewTime sets the time field to a newly
-// allocated Time struct, preferring placement in s's segment.
-func (s SP3Epoch) NewTime() (Time, error) {
-	ss, err := NewTime(capnp.Struct(s).Segment())
-	if err != nil {
-		return Time{}, err
-	}
-	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
-	return ss, err
+func (s SatelliteObservation) SetPrn(v string) error {
+	return capnp.Struct(s).SetText(0, v)
 }
 
-func (s SP3Epoch) Entries() (SP3Entry_List, error) {
+func (s SatelliteObservation) Measurements() (Measurement_List, error) {
 	p, err := capnp.Struct(s).Ptr(1)
-	return SP3Entry_List(p.List()), err
+	return Measurement_List(p.List()), err
 }
 
-func (s SP3Epoch) HasEntries() bool {
+func (s SatelliteObservation) HasMeasurements() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
-func (s SP3Epoch) SetEntries(v SP3Entry_List) error {
+func (s SatelliteObservation) SetMeasurements(v Measurement_List) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
 
-// NewEntries sets the entries field to a newly
-// allocated SP3Entry_List, preferring placement in s's segment.
-func (s SP3Epoch) NewEntries(n int32) (SP3Entry_List, error) {
-	l, err := NewSP3Entry_List(capnp.Struct(s).Segment(), n)
+// NewMeasurements sets the measurements field to a newly
+// allocated Measurement_List, preferring placement in s's segment.
+func (s SatelliteObservation) NewMeasurements(n int32) (Measurement_List, error) {
+	l, err := NewMeasurement_List(capnp.Struct(s).Segment(), n)
 	if err != nil {
-		return SP3Entry_List{}, err
+		return Measurement_List{}, err
 	}
 	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
 	return l, err
 }
 
-// SP3Epoch_List is a list of SP3Epoch.
-type SP3Epoch_List = capnp.StructList[SP3Epoch]
+// SatelliteObservation_List is a list of SatelliteObservation.
+type SatelliteObservation_List = capnp.StructList[SatelliteObservation]
 
-// NewSP3Epoch creates a new list of SP3Epoch.
-func NewSP3Epoch_List(s *capnp.Segment, sz int32) (SP3Epoch_List, error) {
+// NewSatelliteObservation creates a new list of SatelliteObservation.
+func NewSatelliteObservation_List(s *capnp.Segment, sz int32) (SatelliteObservation_List, error) {
 	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
-	return capnp.StructList[SP3Epoch](l), err
+	return capnp.StructList[SatelliteObservation](l), err
 }
 
-// SP3Epoch_Future is a wrapper for a SP3Epoch promised by a client call.
-type SP3Epoch_Future struct{ *capnp.Future }
+// SatelliteObservation_Future is a wrapper for a SatelliteObservation promised by a client call.
+type SatelliteObservation_Future struct{ *capnp.Future }
 
-func (f SP3Epoch_Future) Struct() (SP3Epoch, error) {
+func (f SatelliteObservation_Future) Struct() (SatelliteObservation, error) {
 	p, err := f.Future.Ptr()
-	return SP3Epoch(p.Struct()), err
-}
-func (p SP3Epoch_Future) Time() Time_Future {
-	return Time_Future{Future: p.Future.Field(0, nil)}
+	return SatelliteObservation(p.Struct()), err
 }
 
-type SP3Entry capnp.Struct
+type Measurement capnp.Struct
 
-// SP3Entry_TypeID is the unique identifier for the type SP3Entry.
-const SP3Entry_TypeID = 0xdfc8474e015f357d
+// Measurement_TypeID is the unique identifier for the type Measurement.
+const Measurement_TypeID = 0x945effe9a6c09d43
 
-func NewSP3Entry(s *capnp.Segment) (SP3Entry, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1})
-	return SP3Entry(st), err
+func NewMeasurement(s *capnp.Segment) (Measurement, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return Measurement(st), err
 }
 
-func NewRootSP3Entry(s *capnp.Segment) (SP3Entry, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1})
-	return SP3Entry(st), err
+func NewRootMeasurement(s *capnp.Segment) (Measurement, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return Measurement(st), err
 }
 
-func ReadRootSP3Entry(msg *capnp.Message) (SP3Entry, error) {
+func ReadRootMeasurement(msg *capnp.Message) (Measurement, error) {
 	root, err := msg.Root()
-	return SP3Entry(root.Struct()), err
+	return Measurement(root.Struct()), err
 }
 
-func (s SP3Entry) String() string {
-	str, _ := text.Marshal(0xdfc8474e015f357d, capnp.Struct(s))
+func (s Measurement) String() string {
+	str, _ := text.Marshal(0x945effe9a6c09d43, capnp.Struct(s))
 	return str
 }
 
-func (s SP3Entry) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s Measurement) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (SP3Entry) DecodeFromPtr(p capnp.Ptr) SP3Entry {
-	return SP3Entry(capnp.Struct{}.DecodeFromPtr(p))
+func (Measurement) DecodeFromPtr(p capnp.Ptr) Measurement {
+	return Measurement(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s SP3Entry) ToPtr() capnp.Ptr {
+func (s Measurement) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s SP3Entry) IsValid() bool {
+func (s Measurement) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s SP3Entry) Message() *capnp.Message {
+func (s Measurement) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s SP3Entry) Segment() *capnp.Segment {
+func (s Measurement) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s SP3Entry) SatelliteVehicleNumber() (string, error) {
+func (s Measurement) Code() (string, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.Text(), err
 }
 
-func (s SP3Entry) HasSatelliteVehicleNumber() bool {
+func (s Measurement) HasCode() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s SP3Entry) SatelliteVehicleNumberBytes() ([]byte, error) {
+func (s Measurement) CodeBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
 }
 
-func (s SP3Entry) SetSatelliteVehicleNumber(v string) error {
+func (s Measurement) SetCode(v string) error {
 	return capnp.Struct(s).SetText(0, v)
 }
 
-func (s SP3Entry) XPosition() float64 {
+func (s Measurement) Value() float64 {
 	return math.Float64frombits(capnp.Struct(s).Uint64(0))
 }
 
-func (s SP3Entry) SetXPosition(v float64) {
+func (s Measurement) SetValue(v float64) {
 	capnp.Struct(s).SetUint64(0, math.Float64bits(v))
 }
 
-func (s SP3Entry) YPosition() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(8))
+func (s Measurement) LossOfLockIndicator() uint8 {
+	return capnp.Struct(s).Uint8(8)
 }
 
-func (s SP3Entry) SetYPosition(v float64) {
-	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
+func (s Measurement) SetLossOfLockIndicator(v uint8) {
+	capnp.Struct(s).SetUint8(8, v)
 }
 
-func (s SP3Entry) ZPosition() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(16))
+func (s Measurement) SignalStrength() uint8 {
+	return capnp.Struct(s).Uint8(9)
 }
 
-func (s SP3Entry) SetZPosition(v float64) {
-	capnp.Struct(s).SetUint64(16, math.Float64bits(v))
+func (s Measurement) SetSignalStrength(v uint8) {
+	capnp.Struct(s).SetUint8(9, v)
 }
 
-func (s SP3Entry) ClockBias() float64 {
-	return math.Float64frombits(capnp.Struct(s).Uint64(24))
+func (s Measurement) HasValue() bool {
+	return capnp.Struct(s).Bit(80)
 }
 
-func (s SP3Entry) SetClockBias(v float64) {
-	capnp.Struct(s).SetUint64(24, math.Float64bits(v))
+func (s Measurement) SetHasValue(v bool) {
+	capnp.Struct(s).SetBit(80, v)
 }
 
-// SP3Entry_List is a list of SP3Entry.
-type SP3Entry_List = capnp.StructList[SP3Entry]
+// Measurement_List is a list of Measurement.
+type Measurement_List = capnp.StructList[Measurement]
 
-// NewSP3Entry creates a new list of SP3Entry.
-func NewSP3Entry_List(s *capnp.Segment, sz int32) (SP3Entry_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1}, sz)
-	return capnp.StructList[SP3Entry](l), err
+// NewMeasurement creates a new list of Measurement.
+func NewMeasurement_List(s *capnp.Segment, sz int32) (Measurement_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1}, sz)
+	return capnp.StructList[Measurement](l), err
 }
 
-// SP3Entry_Future is a wrapper for a SP3Entry promised by a client call.
-type SP3Entry_Future struct{ *capnp.Future }
+// Measurement_Future is a wrapper for a Measurement promised by a client call.
+type Measurement_Future struct{ *capnp.Future }
 
-func (f SP3Entry_Future) Struct() (SP3Entry, error) {
+func (f Measurement_Future) Struct() (Measurement, error) {
 	p, err := f.Future.Ptr()
-	return SP3Entry(p.Struct()), err
+	return Measurement(p.Struct()), err
 }
 
 type GPSTime capnp.Struct
@@ -2151,207 +5620,577 @@ func NewEphemerisType_List(s *capnp.Segment, sz int32) (EphemerisType_List, erro
 	return capnp.NewEnumList[EphemerisType](s, sz)
 }
 
-const schema_b3ca6d2462778bb1 = "x\xda\x8c\x98\x7f\x8cT\xd5\x15\xc7\xcfy?v\xf6'" +
-	";\xc3}\x8b\x0b\x82\x03\xb8(\x18h\xf9\x99Zb\xba" +
-	"\xb0.\xcaR\x84};\xab\x02!1og\xee\xec\xbc" +
-	"2\xef\xbd\xe1\xbd7\x0bc$kQS\xb4\xd0\x82\xc5" +
-	"\x06\x1aH\xd5h\x8b\x89M\x14\xb5\xb1\xc66\xdaHK" +
-	"+&h\xa4\xc1\x04#$\x98\x8a\x81\x14S\xb5\xd8@" +
-	"\xa69\xf7\xed\xbc\xf7\xb2\x0e\x94\xbff\xde\xe7\x9d{\xef" +
-	"y\xe7\xde{\xce\xf7\xde\xf9\x9f'\x96)\x0b\xda>Q" +
-	"A\xd2{\xd5\x86\xea\x95\x05\xbf\x7f\xf7\xbb\xc7\xba~\x02" +
-	"\xfa4\x94\xaa/\xfft\xcbP\x97\xf5\xf7W@I\x00" +
-	",:!\xdd\x86\xecS)\x01\xc0\xceH[\x00\xabG" +
-	"+\x9f\xcf\xeb<l\xec\x18g\xac6\x90\xf5ry\x15" +
-	"\xb2{e\xfa\xab\xcb\x7fE\xc0\xea\x9a\x0f~3\xf9g" +
-	"\xe7\xfe\xb4\x03R\xd3b\xd6\x80\x8b\x96\xa8\x1b\x90\xf5\xa9" +
-	"\xd4\xf1\x0au\x14\xb0j\x1d|\x8f=r*\xf5\x04u" +
-	"\x8c\xb1\x8e\x91z\xdb\xa5nG\xf6\x8c\xb0>\xa8\x92\x1b" +
-	"\x85U\xc7\x9e]v\xd8x\x12R\xd3\xe2\xc6\xe4\xe8\xa2" +
-	"\x8e\x86\x85\xc8f\x91ClF\x03\x19\xafR\x1e\xf8\xd7" +
-	"\xa67\xf7\xfc\x92\xba\x9e1\xdezW\xc3Rd\x07\x85" +
-	"\xfb\xfb\x1b\xbe\xd7\x04X}\xe9\xbf\x8f\xfeB-\xber" +
-	"\x82\xcc\xd5\xf1\x9f\xb8?\xb9\x14\xd9\x0bI\xfa\xfb|\xf2" +
-	"\xb7\x12`\xb5\xfc\xeb\x1d?\x1fZ\xb9\xf9\x1f\xe3\x1d\x17" +
-	"\xf1\xfb1\x1b@\xf6\x14\xa3\xbf{X\x9a\"\xb2m\xc9" +
-	"\x03\xb8\xe6\xee\xa3\x9f\x90\xb92\xfe;_\xd3\x16\";" +
-	"\xa2\xd1\xdf\xb75a\xfe\xee7?x\xfc\xde\x1f\xbe\xfc" +
-	"i\xbd\x0f=\xd7\xb1\x1d\xd9\x95\x0e\xfa\xd0o:\xe8C" +
-	"\xbf\x9e\xf8y\xe6\xc8\x9f\x9f=O}\xb7\x8f\xb7\xe6\x93" +
-	"\x86\x90U&\xd1\xdf\xf2\xa4\xff\xc8\x80\xd5\xe3\xdd\x89m" +
-	"\x7f\xd9y\xfa\xca\xf8\xb9\xa48/\xfah\xca\x06d\x17" +
-	"\xa6\x88q\xa6\x88\xb9\xfc\xfa\xdfG\xccG\x9a\xffY\xad" +
-	"\xb7N\xde\x9f:\x11\xd9\x99\xa9\xe4\xca\xc7S\xb7\xc0\xbc" +
-	"\xea\x8aR\x81[\xdc5e\xaf\xd7\xf0\x8d\x8c\xef\x96\xb3" +
-	"~\xd9\xe5\xdf\xc9\x1a%\xbb\xb4\xf4\xee\xfeL\xfb\xa0i" +
-	"\xf1~D\xbdQV\x00\x14\x04H\xcd\xb9\x0d@\xef\x92" +
-	"Q\x9f/a\x0aQC\x82\xf36\x00\xe8se\xd4o" +
-	"\x97\xb0}\x0b\xe7\x9bP\x01\x09\x15\xc0\xaaoZ|m" +
-	"\xfe~\x0e2\xdf\x84- a\x0b`8\xaeRw\xdc" +
-	"\x81\xbe5+\xd6\xad\xe4F\x8e\xbb\x004\xf8\xf4p\xf0" +
-	"\xf7{\x00\xf4c2\xea'%\xac\x8d}\x82\x1c:." +
-	"\xa3~J\xc2\x94\x84\x1aJ\x00\xa9\x8f\xb6\x03\xe8'e" +
-	"\xd4\xcfJ\x98\x92%\x0de\x80\xd4\x99!\x00\xfd\xb4\x8c" +
-	"\xfay\x09S\x8a\xac\xa1\x02\x90:\xb7\x14@?+\xa3" +
-	"~Q\xc2\x94\xaah\xa8\x02\xa4.P\x9f\x9f\xc9\xa8\x7f" +
-	")a\xaaA\xd5\xb0\x01 \xf5\xc5*\x00\xfd\xa2\x8c\x99" +
-	"V\x940\x95\x904Z\x0d\xac\x09\x87\x002\x8d(c" +
-	"FC\x09GG\xb8\xeb\x99\x8e]\xfb\xd6v\xbfR\xe2" +
-	"\xd8\x0a\x12\xb6\x02V=\xc3\xe7\xc5\xa2\xe9#\xcfT<" +
-	"\x9f[\x10\xbe)\xb9\xce\xb0kXk aX\xa1}" +
-	"\xb71\xcc\xedl\xa5\xf6\xd8\x9e3|\x8e\xc9h\x8e\x01" +
-	"1\x09X\xcd:\x96\xc5m\xdf\x03\x00\x9c\x00\xd8/\xa3" +
-	"h1\x01\xb0Z\xe4F)\xc3\xb3\x0e$\xec\x9c\x17N" +
-	"\xca\xb5\xe3_{\xeb\x0dV\xe4\x92\x98\xfd \xa6K6" +
-	"\xd0\x80\xa9\x05\xf4#\x89)G95g;\x00*\xa9" +
-	"Y\xab\x00\xaa\xb61b\x0e\x1b\xbe\x09\xb2cW\xf3\xa6" +
-	"m\x14\xd7\xbaC \x9b~\xd55Jf\xae\xf6P." +
-	"\xfa\xae1`\x94$\x81L\x1f6g\x1d\xab\xdf)V" +
-	"\x00B\xcf\xd4\xfa+\xd2u\xca%\x9e\xab\x19\xe5\xb8\x07" +
-	"\xe3\x16'\xcd\xf0l\x19\xf5\xc5\xd1\xfaX\xf0$\x80\xbe" +
-	"XF\xbd_\x8a&\x00\x12\xbc/\x17\xc6\xc3s\\\x9f" +
-	"\xba\xc5Z\xbf\xe8\xd5\x02\x99\x8c\xf6+\xa0\x08\xe9\xb57" +
-	"M\xa6\x7fQzE\xc9\xc9\x16\xaeg\xd7\xf4\x8cy\xdb" +
-	"+a;m\x94o\xcf\xed(\xb7}\xd7\xe41w\xc2" +
-	"\xd4t]\xee\xac(\x15\xba\x83\xc9$\x7f\x0e\xd5\xfca" +
-	"\x8f+\xb7\x01d\x1eUd\xcc\xecV\"\x97\xd8.\xc1" +
-	"w\x10\xdfK\\\x92\xc4\xdc\xb3=\xcaB\x80\xccN\xe2" +
-	"\xfb\x88\xcb\xb2\xd8R\xec)e&@f7\xf1\x03\xc4" +
-	"\x15E\xec*\xb6_\xf4\xb3\x97\xf8\xd3\xc4UUl," +
-	"vPY\x0a\x90\xd9G\xfc9\xe2\x0d\xb2\xd8[\xec\x19" +
-	"\xc1\x0f\x10?\xa4\xc4v\xd7\xf3\xa2\xff\xa7\x89\xbfH\xbc" +
-	"Q\xd6\xb0\x11\x80\xbd \xf8s\xc4_\"\xde\xa4h\xd8" +
-	"\x04\xc0~'\xf8!\xe2\xaf\x12oV5l\x06`/" +
-	"\x0b\x7f^$\xfe:\xf1\x96\x06\x0d[\x00\xd8k\xc2\xfe" +
-	"%\xe2o\x12oMh\xd8\x0a\xc0\xfe \xfcy\x95\xf8" +
-	"[\xc4\xdb\x1a5l\x03`\x7fT\xa6\x00d^'\xfe" +
-	"\x0e\xf1\x09M\x1aN\x00`o\x8b~\xde$~\x94x" +
-	"{\xb3\x86\xed\x00\xec\x88\xe0o\x11?F<\xd9\xa2a" +
-	"\x12\x80\xfdM\xf0w\x88\x1f'\x9ej\xd50\x05\xc0\xde" +
-	"S&\x02d\x8e\x12\xff\x90\xf8\xc46\x0d'\x02\xb0\xf7" +
-	"\x85\xfd1\xe2'\x89\xb3\x09\x1a2\x00vB\xf0\xe3\xc4" +
-	"O\x11\xd7\xda5\xd4\x00\xd8G\xc2\xff\x0f\x89\x9f&\xde" +
-	"\x91\xd4\xb0\x83\xf2\xbe\xb0?I\xfc,\xf1I)\x0d'" +
-	"\x91n\x10\xdfu\x8a\xf8g\xc4o\x98\xa8\xe1\x0d\x00\xec" +
-	"Sa\x7f\x9a\xf8y\xe2\x9dL\xc3N\x00vN\xac\x87" +
-	"\xb3\xc4/\x12\x9f\xaci8\x19\x80]PV\x01d\xce" +
-	"\x13\xbfD|J\x87\x86S\x00\xd8W\"\xfe\x17\x89_" +
-	"&~\xe3$\x0do\xa4\x92\xa8\xf4\x00d\xbeTd\x1c" +
-	"P%LM\xbdA\xc3\xa9\x00\xec\x8ap\xe7\x12\x99+" +
-	"\xc4\xa7uj8\x0d\x80\xa1J\xc3^&\xdeH\xfc\xa6" +
-	"\xc9\x1a\xde\x04\xc0T\x95\x86UT\x193I\xe2\xe9)" +
-	"\x1a\xa6\x01X\x9bJ\xee7\x12\xd7\x88O\xbfQ\xc3\xe9" +
-	"\x00,\xa5\x92;\xad\xc4;\x89\xcf\x98\xaa\xe1\x0c\x00\xd6" +
-	"\xa1>\x01\x90\xe9$\xdeE|\xe64\x0dg\x92DQ" +
-	")\xc9O'>\x97\xf8\xcd7ix3\x00\x9b#\xfa" +
-	"\xef\">_\x95pA\xd7[\x09\x0d\xbb\x00\xd8<u" +
-	";@f.\xbd\xb8]\x95\x10gi8\x0b\x80-Q" +
-	"\x07\x002\x8b\x09\xf7S?\xb7\xa0\x86\xb7\x00\xb0{\x84" +
-	"\xff\xab\x89\x17\x88\xdf\xda\xad\xe1\xad\x00\x8c\x0b\x9e#^" +
-	"\">{\x9b\x86\xb3\x01\x98\xa5R\xd8\x0a\xc4}\xe2s" +
-	"\x1e\xd6p\x0e\x00\xdb,x\x91\xf8VU\xc2vo\xa4" +
-	"/\x87\x09\x900\x01\xd8^\xe1\x86[{H[\x8e\xed" +
-	"\x17jO\x89\x9cQ\x09\xcd\x0aN94\xeb\xb6L\xbb" +
-	"\xec\xf3\xf0\xd1\xe3Y\xc7\xcea3H\xd8\x0c\x980\xf2" +
-	"\xf3ku.a\xe4\x17\xc4\xfe/\x0c\xeb\x9f\xe9\xe4x" +
-	"\xf8\"\xebz\xb5\xff\xdd9^\xf4\x8d5\xb5G\xd9\x8a" +
-	"\xba\xca\x96\xb3\xe1\x7f\x9e\xcd\xc6x\xd8\x1a\x8d\x90\xfaN" +
-	"\xac\x7f3\xb4\xeev,>l\xc4:5\xc3\xc6\xb2\x19" +
-	"\xc3n\xd8\"-Z\x84\"E<\xf5:>U\xd5\xf0" +
-	"cz\x1d\xbf\xf60\x9aur\xdc[\x1d~\xa9\\\x0c" +
-	"\xff\xa6\xbd\x91\xe5\x91\xdfUod%7\x8a~!\xd6" +
-	"S\xc2\x1f\xce\xc5C\x14\x19\xfb\xaea{\x96\xe9!\xa9" +
-	"\x08\x92_Q\xa3j\xde\xf4\xfbl\x9f\xbb\x90\x181\x8a" +
-	"\xb1\x00D\xadM\xc7v\xeetx\x1e\xf3\xde}F\xd1" +
-	"\xcc\x01\"H\x88co\x96\x17K\x05@\xa3VLZ" +
-	"\xc6D\x02\xbd\xea\xe1\xbe\x11\xd3\x0f\xb5W\xbe\xb3\xe5N" +
-	"\xa7l\x8b 4\x82\x84\x8d\x80\xa3\xbe\xc3\xef'\x897" +
-	"\xb6(F}'\x1b\x7f\xfe\xff\x05\xb2;\x10wT\x91" +
-	"\xe6\x87\x15i9\xd2\xd2\xbd\x83t\xd4J\x8cU\xa4\x15" +
-	"H[~\x19\xf1\xd5(!\x8e\x15\xa4>|\x10 \xb3" +
-	"\x92\xf0 F\x1a\x8f\xe9H;\xa6\x9f\xf8F\x8cd\x1e" +
-	"[\x8f\xb4\xb37\x12/`\xa4\xf4\x18G\xda\x919\xe2" +
-	"%\x8c\xc4\x1e\xb3\x90\x12h\x81\xb8/\xe4\x1e\x06\x05i" +
-	"\xb3p\xb3H|+\xf1F\x0c\x0aR\x19]\x80\x8cO" +
-	"\xfca\xe2MRP\x90\xb6\x09\xfe\x10\xf1\x1d\xc4\x9b\x1b" +
-	"\x82\x82\xf4\x18>I\x85\x96\xf8^\xe2-JP\x90\xf6" +
-	"\x08\xfb\xdd\xc4\x0f\xc4\xe5\xe4\x98\x04L{\xbe\xe1\xfau" +
-	"4\xa0]\xb6\x86\xb8\xbb6\x0f\xddB}DZ/g" +
-	"\xf8\xc6\xbd\x1e\xcf\xd1\x0c\xd6\xc4f\xd6q\xdc\x9ci\x1b" +
-	"\xe8\xd7th\xf4\xce!I6X)\x01^M\x86\x8e" +
-	"\x0e\x97\xbc\xfb\xe3\x1a?\xc8\x0a\xdeZH\xe7\x05\xaf-" +
-	"FN\x9e\xf4\xd9>\xa4\xb9\x1b[\xb0U\xcb\xc9\x99y" +
-	"\x93\xe7pU\xb9h\x1av\xaf\x81\x95\xb0\xaf\xbckd" +
-	"}\xd3\xb1!m\x14{\x8d\xcau\x1e\x19\xee\xee\xcf\xd4" +
-	"\x0cP(\x1d-T^\xdb\\\x00\xfd!\x19\xf5\x1d1" +
-	"\xe5\xf5\x18\xc1Ge\xd4wG\x1a'\xb5k&\x80\xbe" +
-	"CF}o$pR{\x08\xee\x94Q\xdf'!\x06" +
-	"\xe2&\xf5\xd4\x07\x00\xfa\x01\x19\xf5C\x12V\x87\x0c\x8f" +
-	"\xd3\xd8\x90\x162\x0b\x93\xd1\x89mlj\xf8\x98g\x90" +
-	"\x16\xbec2:\xeb\x06\x16\"\x85%\xa3#~H\xb3" +
-	"\xdf\xa6Uos\xd9p\xf9\x80#9\xfe\xda|\x86[" +
-	"\xe6=\xc6\x8f\x1cw\xf9V\x13\xbdo\x05\xeb\x1a\x12\xd5" +
-	"\xf6\xdd\xca\xb8@\xbd\x01\xa0?,\xa3\xbe3\xd2\xce\x8f" +
-	"\x0f\xc4BR;[\xed!\xb8[F\xfd@\xecl\xb5" +
-	"\x9f\xe0>\x19\xf5\xe7bg\xabg\x08>-\xa3\xfe\xa2" +
-	"\x14?\xfb\xdc\xc7\x0bf\xb6\xc8\xbb\xd7\x885\x1b\xae\xbc" +
-	"\xad\xfd\x8eg\xfa\xa6\x03\x18\x9e\x9e\xaa\x95:\xec\xc1:" +
-	",[t\xb2\x9bzL\x03\xeaDA\xbdZ\x14\xeer" +
-	"\\\xcb\xf0\xc3\xe3\xce\xf8\xb3\xc4\xd2z\x92}i$\xd9" +
-	"\xbb\x0b\"\x8da2\xba\x8c\x08\xe6\xa8\x9b\x07[0\xd4" +
-	"\xec\xe1E\xc88\xcd~\x8d\xf3o`\x92\x18\x13\xee+" +
-	"\xc34yN\xe4\xa5\xb3\x94\x1f.b8Q\xec\x828" +
-	"\x85\x9e'|\x09\xa3\xb9b_\x89\xecy\x91\xf8e\x91" +
-	"&1H\x93\xdf\x88\xb4w\x89\xb8\"\xd1\x8cIA\x9a" +
-	"D\xe90\x09)\x89\x84\x14qU\x0e\xd2d\x9bD\xe9" +
-	"3I|*\xf1\x06%H\x93\x93%\xea\xa7\x93x\x17" +
-	"\xf1\x84\x1a\xa4\xc9\x19\x82O'>\x97xcC\x90&" +
-	"\xe7H\x94\xdef\x13_L\xbc)\x11\xa4\xc9\x05\xc2~" +
-	">\xf1;\x8877\x06i\xf2\xfb\x82\xdfN\xbc\x97x" +
-	"KS\x90&\x97\x8b~\x96\x11_M\xbc\xb59\xd0\xed" +
-	"}\xc2~%\xf1A\xe2m-\x81n\xd7\x05\xef'\xbe" +
-	"\x91\xf8\x84\xd6@\xb7\xaf\x17\xfd\xac#\x9e#\xde\xde\x16" +
-	"\xe8vC\xa20o$^ \x9e\xc4@\xb7s\xe9\x0d" +
-	"*\x0b\xc4}\xe2\xa9\x09\x81n\xdf,Q9*\x11\x7f" +
-	"H\x8a/\x8d\xf0*\xae\xb6}\xeb\x1d@\xd3b\xc1\xd4" +
-	";\xd9\xd7Y\xd6./\x1a\xbe9\xc2\xf1.\x97o." +
-	"s;\x9b\xae\xf4\x98F\xf4\xde\xe2\x9eg\x0c\xf3\xbb\xd0" +
-	"5,>N@\x94\x82\x9dc\x03\xae\x0b\xd9\x08/:" +
-	"Y\xd3\xaf\xc4\x99\x91\xcd\xf2\"w\x0dH\x93\xf5\xba:" +
-	"\xed\xd7\xd7i\xbf\xfe*\xed\xd7\xd7i\xbf\xa1N\xfb\x0d" +
-	"Wi\x1fr\x0ak\xd1/Dzh,\x02X\xb9\xb3" +
-	"`\xd86/v\xaf\xcd\xe7=\xee\x87\xd5\xc4\xb4\xf3b" +
-	"\x8fC\xb7\xe9\xd8\xcb\x87\xf9u\x96\x93\x9e\xb1\x9cnq" +
-	"W\x0e6`\xec\x0a\xeaW\x00\xfa\x872\xea\xa7ci" +
-	"\xe1\xe3\x85\xd1u\xd3\x98BI\x9dqc\xb7Mr2" +
-	"H\x93\xe7\x06b\x17K\x0a\x06i\xf2\x8b\xc3\x00\xfa\x97" +
-	"2\x0e\x08a\"\x05WPW\xc8\xf22\x1dt0v" +
-	"NV\x85\xbcQh\xdf&\x85,Q\x82\xfd\xd6\x86\x1b" +
-	"\xe8@C\xbc\x13%\xac\x96<^\xce9\x03\x06\xda9" +
-	"\xc7\xa2<+G\x896\\m\xe3KK\xacP\x0dV" +
-	"J\x1c\xdb\xa3\x9ba@l\xa7xzcJ\x16+\xa1" +
-	"\xb0\xb4\x8c\xad\xa6U\xb6\x06\xd1\xb4x\xaf\x99\xcf\xf3\xb4" +
-	"\xcb\xed,\x8f\x89\xd6\"'M\x02XoLz\xbb\xc6" +
-	"\xa0E\x1aI\x10b\x19\xa7\xec\x82\x9c\x8d\xae\xce\xae]" +
-	"\xd3\x06M\x0b\xc7_T\xf6\xc4\xf3\xb74\x96\xbf\x87\xa2" +
-	"\x8b\xca\xd11\xdd\x82*H\xa8\x92\x862l\xc7\x1b\x7f" +
-	"Y\xf6\xbf\x00\x00\x00\xff\xff\xe4\xf7\x06\xf4"
+type NavMessageType uint16
+
+// NavMessageType_TypeID is the unique identifier for the type NavMessageType.
+const NavMessageType_TypeID = 0x883915138362485c
+
+// Values of NavMessageType.
+const (
+	NavMessageType_unknown NavMessageType = 0
+	NavMessageType_lnav    NavMessageType = 1
+	NavMessageType_cnav    NavMessageType = 2
+	NavMessageType_cnv2    NavMessageType = 3
+	NavMessageType_fdma    NavMessageType = 4
+	NavMessageType_inav    NavMessageType = 5
+	NavMessageType_fnav    NavMessageType = 6
+	NavMessageType_d1      NavMessageType = 7
+	NavMessageType_d2      NavMessageType = 8
+	NavMessageType_sbas    NavMessageType = 9
+	NavMessageType_cnv1    NavMessageType = 10
+	NavMessageType_cnv3    NavMessageType = 11
+)
+
+// String returns the enum's constant name.
+func (c NavMessageType) String() string {
+	switch c {
+	case NavMessageType_unknown:
+		return "unknown"
+	case NavMessageType_lnav:
+		return "lnav"
+	case NavMessageType_cnav:
+		return "cnav"
+	case NavMessageType_cnv2:
+		return "cnv2"
+	case NavMessageType_fdma:
+		return "fdma"
+	case NavMessageType_inav:
+		return "inav"
+	case NavMessageType_fnav:
+		return "fnav"
+	case NavMessageType_d1:
+		return "d1"
+	case NavMessageType_d2:
+		return "d2"
+	case NavMessageType_sbas:
+		return "sbas"
+	case NavMessageType_cnv1:
+		return "cnv1"
+	case NavMessageType_cnv3:
+		return "cnv3"
+
+	default:
+		return ""
+	}
+}
+
+// NavMessageTypeFromString returns the enum value with a name,
+// or the zero value if there's no such value.
+func NavMessageTypeFromString(c string) NavMessageType {
+	switch c {
+	case "unknown":
+		return NavMessageType_unknown
+	case "lnav":
+		return NavMessageType_lnav
+	case "cnav":
+		return NavMessageType_cnav
+	case "cnv2":
+		return NavMessageType_cnv2
+	case "fdma":
+		return NavMessageType_fdma
+	case "inav":
+		return NavMessageType_inav
+	case "fnav":
+		return NavMessageType_fnav
+	case "d1":
+		return NavMessageType_d1
+	case "d2":
+		return NavMessageType_d2
+	case "sbas":
+		return NavMessageType_sbas
+	case "cnv1":
+		return NavMessageType_cnv1
+	case "cnv3":
+		return NavMessageType_cnv3
+
+	default:
+		return 0
+	}
+}
+
+type NavMessageType_List = capnp.EnumList[NavMessageType]
+
+func NewNavMessageType_List(s *capnp.Segment, sz int32) (NavMessageType_List, error) {
+	return capnp.NewEnumList[NavMessageType](s, sz)
+}
+
+const schema_b3ca6d2462778bb1 = "x\xda\xe4\x9c\x7fp\x1cU\x9e\xd8\xdf\xf7\xbdn}%" +
+	"K\xf2\xa8\xfd\xc6\xac\xed\xc5\xc8\xd2\x99[[\xc1\x1b\xcb" +
+	"\xc2{\xb6\x02\xc8\x92%\xaf\xad\x18[=O^\xb0\x0b" +
+	"\xb2\xb4fZ\xd2\xc0\xfc\x90{z\x84F\xc1g\xccB" +
+	"\x82\x09\xe4\xc0\xc1)\xbc\x0bu\x98\x82\x0d\xde\xc2u\xc0" +
+	"\xc1\x06\x08N`\x03\x176kW\x80ZRP\x81\x0b" +
+	"$\x90\xb5\xb7\x96\x0aT\xbcwK\x0ajR\xdf\xee\x99" +
+	"\x9e\xee\xb1d\x9b\xbdJ\xd5U\xed\x1f\xcf\xaa\xe9\xcf{" +
+	"\xfd\xba\xdf\x8f\xef\xcf\xd7^{|\xf1&\xad\xbb\xf5\xfb" +
+	"\xcd\x8c\x9bw\xea\x0d\xe5\xaf\xba\x7f\xfa\x8b\x7fxr\xe5" +
+	"?g\xe6r\xe0\xe5g\xfe\xc5\xadc+\xb3\xff\xf9/" +
+	"\x99\x86\x8c\xf5\x1c\xd6\xbb@>\xa1c\xa5\xdc\xca\x98\xbc" +
+	"\xbc\x01\xcb7l\x1d\xfb\x81\\\xbc\xf1nf,\x0f\xb5" +
+	"`\xd0c4\x8c\x01\xd5\xa0\xd2sy\xc3\xb7\x801i" +
+	" \x96\xdf(\xfdz\xcd\x92g\xad\xbb\xa9\x0fQk\xa1" +
+	"7Q'_5\x0c\x03\xd5\xa2\xd2c\xe07\x04c\xb2" +
+	"\xb5\x19\xcb;\xde\xfe\xf1\xd2\x7fy\xe6\xdf\x9f\xdb\xcb\x17" +
+	"\x0b\xf6\x00\xd5\xa8\x94\xfd\x8c\xc9\x03\xcdX\xce>rJ" +
+	"\xfe\xe0}\xe3\x1e\xea\x04B\x9d4P'\xd9\xe6;\x80" +
+	"jQ\xe99\xd0\xfcg\xf4d\xaf\xb6byr\xf8\xe4" +
+	"c\x9b\x9e\xb5\x0e1cy\xb8\x8d\xa06\xc7[\xd7\x81" +
+	"<\xd1\x8a\x95\xf2\x17\x8c\xc9\xa3\x0b\xb1\xbc\xf9\x91W~" +
+	"|\xa6\xfcO\x1e\xac\x1b0\x1d\xa8\xcd}\x0b\x87\x81j" +
+	"Q\xe99\xba\xb0\x9d\xfa\xf94\x86\xe5a\xed\xfb\xff\xfb" +
+	"\x96\x97\x1f\xf8\xd7\xd4\xa8#\xd4\x88S\xa3\xf7b\xbd " +
+	"\xcf\xc4\x90J\xcf\x99\xd8\x9f41&\x8f-\xc3\xf2-" +
+	"\xff\xb1O\xe9\x07/\xfd\x115j\xabotx\xd9\x1e" +
+	"\xa0ZTz\x8e-\xfb[\x1a\xb6R;\x96g\xde\xd8" +
+	"p\xea\xf4\xf6_\xff\xa8\xee\x8d\xbc\xf9\xb4\xdb\x7f\x08r" +
+	"_;V\xca\xaf\x18\x93GV`y\xc7I\xed?L" +
+	"\x7f\xfco\x1f\xaek\xe2us\xd7\x8a\x9b\x81*U\x0a" +
+	"-\x81\xf5\x1dX\xbe\xec\xcd\x8f:\xc5\x91\xbf\xfei]" +
+	"\x13o\x0c::\x12@\x95*\xa5\x8f1y\xb0\x03\xcb" +
+	"\xfb\xff\xd9\xa9_\xfc\xa3\xedK\xfe]\xfd\"\xb8\x84\xda" +
+	"\x14;f\x81jQ\xe99\xd8\xb1_gL\xde\xb7\x0a" +
+	"\xcb\xef-Kl\x9c9m\xbc6\xd7\xa3\x95V9@" +
+	"\x95*\x85\x1em\xe9j,\xdf\xf0\xab\xcb~\x94X\xf6" +
+	"\xbb\xbf\xa2~\x1a\xebG@_}3P-*=K" +
+	"W\xff\x1b\xce\x98\xdcu\x05\x96\xbf\xfc\xe4\xd4\x99-F" +
+	"\xf6T\xfd\xa4z\x0b\xa1\xff\x8aY\xa0ZTzv]" +
+	"\xe1M\xea\xd15X~\xc9\xfd_\xabv\xe0\x82\xb7\xa9" +
+	"\x91^\xdf\xd3}k\x1c\xa0ZTz\x8e\xae\xf9\x13\xea" +
+	"\xc9^\x8b\xe5\xa7\xff\xef\x9d\xffJ\xcf\xfc\xe5;\xd4\x08" +
+	"C\x8dZ\xa8\x91\xb9\xb6\x17\xa4\xb5\x16\xa9\xf4Xk\xff" +
+	"\\cL\xae\xf9\x0e\x96\x8b\x7f~\xf7\x9f\x8dm\xdd\xfb" +
+	"_\xa9\x91\x16j\xa4S\xa3\xa5\xdfI\x00\xd5\xa2\xd2\xb3" +
+	"\xe6;eomo\xc0\xf2\xbe\xf5\xdf\x87\x1d\xdf}\xe3" +
+	"\xbfS\xa3\x05\xf5\x8d\x8eo\xa0\xc5\xbd\x01\xa9\xf4\x9c\xd8" +
+	"0A=\xed\xba\x06\xcb\xf9\xdcO\xf7\x0e\xff\xe9\x17\x1f" +
+	"\xd5\xf7\xe4\x0f\xc45\x87@\xee\xbe\x06\xa9\xf4\xec\xbe\xe6" +
+	"?QO{7a\xf9\xc7\x1bw\x16\xff\xe9\xef\xce\xfe" +
+	"\xcf\xba\x81\xf0\xc6\xe1\xc6Mc@\x95\xa8\xf4\xec\xdd\xe4" +
+	"\x8d\xc3\xe2\xcdX\xfe\xc5\x17\xd7\x1c\xdc\xf5\x8f\x9f\xf9d" +
+	"\xae\x99\x85\xcdw\x00U\xaa\x14\x9a\xd9g6c\xf9\xd1" +
+	"oO\xfc\x97\xbd\xef\xac?]?I^?\x8flv" +
+	"\x80jQ\xe9yf\xf3u\xf4l\xa5!,\xff\xe4\xdb" +
+	"\xa20\xdb\xfa\xdb3s\xf5c\x0f\x1d\x02\xb9o\x08+" +
+	"\x85\xfaY\xbc\x05\xcb\x7f\xb3\xe8\xd7\xea\xf5\x9f=\xf6\x1b" +
+	"\xeaG\xd6\x8f\x01l\x19\x03\xaaE\xa5g\xf1\x96\x18\xad" +
+	"\xd4\xa1\xedXv\x8e\xfc\xc5\x7f\xfb\xd3\xff\xf1\xd7\x7f[" +
+	"\xd7O#\xb5\xe9\xde\xde\x05\xb2\x7f;R\xe9\xe9\xdf\xee" +
+	"\x8d\xdb\xa7;\xb0\xfcf\x1f\xee\xfb\xab{?\xfc\xaa\xfe" +
+	"\x85\xbc\x19zo\xc7\x1e\xa0ZTz>\xdd\xe15\xea" +
+	"0\xb1\xfc7\xff\xe7\xf5\xf4\x0f\x16\xfc\xaa<\x97\xc0n" +
+	"5\x17\x81\\nb\xa5\xd0\x0b=b\"\xfb\x07\xe5\xa1" +
+	"\xa9I;k;iQ\x18\xb4\\K\xb9N1\xe9\x16" +
+	"\x1d\xfb\xdbIk*7\xd5\xfb\xdd\x11\x15\x1bMg\xed" +
+	"\x11\x80\x11\xe0f\xa3\xd0\x18\xd3\x801cu\x97\xb1\x1a" +
+	"\xcdU\x02\xcc+9\x18\x00q\xa0\xab\xdd{\x8c\xf5h" +
+	"^)\xc0\xdc\xc4!v\xabm\xdf2\x02\x1c4F\x05" +
+	"\xcan:k\xef\x1c\xbf\xcef\xc2\xbf\xde\xcc\xa8\xc0&" +
+	"\x08\x1eB\x9b\xf3!vX\xd3\xd7\xda\x85\x825a\x8f" +
+	"bi\xaa\xfa,k\x813f\x1c\x1c0\x0e\"\x80q" +
+	"W\x97q\x17\x027\x0et\x19\x07\x10\x84\xb1\xaf\xcb\xd8" +
+	"\x87\xa0\x19\xa5.\xa3\x84\xa0\x1b\xc5.\xa3\x88\xd0`\xec" +
+	"\xed2\xf6\"\xa0\x91]fd\x11\x1a\x8d\xf42#\x8d" +
+	"\xd0d\xd8]\x86\x8d\xb0\xc0\xb0\xba\x0c\x0b\xa1\xd9\xb8\xb1" +
+	"\xcb\xb8\x11\xf7\x17s\xb7\xe4\xf2\xb7\xe6F\x80\xc729" +
+	"k\x9a\xfe&\x83\xbf\xd3\xeb\xe8\xefx*k\xd1\xdft" +
+	"\xe5\xfa\xb8\xffW\xa4\xba\xbd\x7f\xbd:\x851\xabPi" +
+	"\xd3]\xf9\xdb3\x02\xfc\x82/\x9e\xd8\xb6c\xe8\xfa\xad" +
+	"\xb6\x95\xb2\x1d\xc6*\xaf\xbd\xa1:\x05\xb2\x04\x03\xb2\x04" +
+	"\xa8f@\x80\xba\x138TfA\x1e\x80.y\x00P" +
+	"\xddN\xe0^\xe0`p\x88\x03'i\x0bw\xc8\xfb\x00" +
+	"\xd5\xbdD\x1e\"\"x\x1c\x04c\xf20\x8c\xc9#\x80" +
+	"\xea!\"\x8f\x13\xd1D\x1ch\xef\x1f\x85^y\x14P" +
+	"=J\xe4)\"\xba\x16\x07Z\xdc\xc7\xa0K\x1e\x03T" +
+	"O\x12y\x99H\x83\x1e\x87\x06\xc6\xe4\x8b0,O\x00" +
+	"\xaa\x97\x89\xbcK\x04y\x1c\x901\xf9\x0e\x8c\xc9\xf7\x00" +
+	"\xd5\xbbD>&\xd2(\xe2\xd0\xc8\x98\xfc\x08\x0e\xc93" +
+	"\x80\xea4\x91\xb3D\x9a\xb48\x90\xc6\xfb\x1c\xee\x90\xbf" +
+	"\x05TgA@\x82s0\x16\xe8qX\xc0\x98\xfc\x0a" +
+	"f%pLp\x01\xaa\x85@sC\x1c\x9a\x19\x93M" +
+	"\xfcYipTmD.%\xd2\x82qh!=\xc0" +
+	"_\x92\x1d\x1c\xd5\x0a\"\x83DZ\x1b\xe3\xd0\xca\x98\xec" +
+	"\xe7\xcf\xcam\x1c\xd5V\"\x19\xcea\xff\xb4\xed\x14\xd2" +
+	"\xf9\\h\xa9\xc6\\o\xfdqhaT\xa0\\\xb0\\" +
+	";\x93I\xbb`\xabR\xc1\xb5\xb3,\x0c\xa7\x9c\xfc\x84" +
+	"cew0\xb4\xb2\xe1V}\xd6\x84\x9dK\x96BW" +
+	"b)\xcb\xf5j\xb4\xd561c\x9b\xc0\x00\x1c\xe1\x00" +
+	"m\x0c\xca\xc9|6k\xe7\xdc\x02c^\x1f\x0b\x19\x8c" +
+	"\x08\xf0\xda/dP\xce\xd8\xd6\x94\xb2\x93y\x86\xb9T" +
+	"!\xbc\xe7\xc6\x8b\xb4\x9e\xb6\xdb\xe0\xf3\\\x0a\"\xb8\xda" +
+	"\x0er\xa9\xc2u\xb6}\x0b\x9b\x0b\xb2\xbe\\\xaa0h" +
+	"\x95\xe6kH\x02B\xb5{\xef\x1f~\xfdt>\x97/" +
+	"LM\xda\xe0\xa4\x93\x9b\xf3\x8ec\xf7%\xddt>W" +
+	"\x08=~[\xcd(\x09\xbd\xee\xc2\x8a\xac\xa0!\x05;" +
+	"\xeb\xb5M\xb6\x9f\xdb6\xd0H\xd1\xb6\x17\xdcVUZ" +
+	"\x18-\x89@\x9cx\x1b\xc4X\xbf\xc7\xd8\x88\x00\x95\xbf" +
+	"\xbc\xf2W\x18\xeb\xef\xa0\xbf\x9a\xb1~\xd8\xd8\x88\xe5\x9c" +
+	"5\x9d\x9e\xb0\xdc4\x13\xde\xea(\x8f\xa7sVf\xa7" +
+	"3\xc6D\xda\xa5\xdf\x8e5\x95N\x85~\x173\xaec" +
+	"%\xac)\xf0\xae\xa6]\x1a\xe5\xf2\xded>;\x92\xcf" +
+	"\x94\xfc\x09\x0d=\xb4>\xb7$v\xf2\xc5);U\xad" +
+	"\x94\xb2\x0bU\x89pi \x94\x9f\x1f3^D\xf3\x05" +
+	"\x01\xe6k\x8140^=d\xfc\x1c\xcd7\x04\x98\xbf" +
+	"\xa9I\x02\xe3L\xa7q\x06\xcd\xd3\x02\xcc\xb35)`" +
+	"|\xdei|\x8e\xe6g\x02T<,\x02\x0c\x18\x90\x06" +
+	"\xa0j\xa3\xadyeX\x04tC\xaf\xec\x06Tk\x89" +
+	"\x8c\x86E\x80\x09]\xd2\x04T#D\\\xe0\xb5\xbd\xc2" +
+	"\xd0\xde\x96\x0a\xaf\xa5B\xdeq\xe9\xcd\xa0\xfaj\x10\x9d" +
+	"\xe7@\xebF\xe7\x19\xa7\x9c\\h\xbd\xe1\xc4T\xb4Y" +
+	"`\x1aE\x9b\xed\x9f\xb02\xe9\x8c\x9d\x8f\xd4\x0d\x0c\xb6" +
+	"h\xdd\xbe1;=\x98/F\xaa\x06Vd\xb4jU" +
+	"\xc8\xd7*\x06F\xfa\xbc\xcbsn\x9d\xabFz\xda\x87" +
+	"\xa6\xf2\xc9\xc9\xca\xfc\xb6\x04\xf3;\xd4e\x0c\xa19(" +
+	"\xc0\xbc>\xa4tw\x0d\x18\xbb\xd0\x1c\x15`\xce\xd0\x04" +
+	"s\x7f\x82\x8b\x03F\x11MW\x80y7\x87\x98\x9b\xce" +
+	"\x9e_\xbe\xec\xb7s\xae\x93\xb6\xa3o\x10\xd8\x89\xf5#" +
+	"8U\x18\x0dn\x18\xb8\x89\xd1\x1b^p\x17^k[" +
+	"\x85\xa2c\x93P\x0b\x94[<xUO\x7f\x9b\xb7\xf9" +
+	"\x8f_}\xd3\xbb\xd6\x19w\xa1y\xa7\x00\xf3~z\xd3" +
+	"F\xffM\xef{\xcc8\x8c\xe6\x83\x02\xccGi)7" +
+	"\xf9K\xf9\x91Y\xe3(\x9a\x8f\x0a0\x9f\xa2\x95<\xe2" +
+	"\xadd\xe3\xd8\xb0q\x1c\xcd\xa7\x04\x98/p\x88%\xf3" +
+	"\xa9\xb0\\n\x9f\xb62E;$\xee\xcb\x99|\xa1\xb0" +
+	"s|{\x1e\x92\xb7l\xcb\xa5\xd2I\x0b\xdd\xbcC\x15" +
+	"\x1a\x18\x15(\x17\xd2\x139+\xa3\\\xd6\xe7\xd8\xb9\x09" +
+	"w2\xcc&\xad\xc2\xf7\xe8\x86\x15\x89\x0d\x8c\xca\x85\xa7" +
+	"\x7fhj\xb2\xcf\xc3\x85\xca\xa0<\x19h\xfc\x83Z\x97" +
+	"<\xa8\xa1\xba[\x13\xa0\x1e\xd4jk@>\xa0u\xc9" +
+	"\x074T\xf7\x13yX\xab\xad\x03yD['\x8fh" +
+	"\xa8\x1e\"\xf28\x11!|\x95\x7fT\xeb\x94G5T" +
+	"\x8f\x12y\x8a\x88\xa6\xf9\xfb\xfd\x98\xd6%\x8fi\xa8\x9e" +
+	"$\xf2\x1c\x11]\xf7\xf7\xfb3Z\xaf|FC\xf54" +
+	"\x91\x97\x894\x88\x8a\xca\xd7z\xe5\x8b\x1a\xaa\x17\x88\xbc" +
+	"\xa6\x85T\xfe\xabZ\xa7|UC\xf5\x0a\x91\x93ZH" +
+	"\xe5\xff\\\xeb\x94?\xd7P\xbdA\xe4\x97ZH\xe5\xbf" +
+	"\xa5u\xca\xb74To\x12y_\x0b\xe9\xfc\xf7\xb4." +
+	"\xf9\x9e\x86\xea]\"\x1fk!\xa5\xff\x91\xd6)?\xd2" +
+	"P}H\xe47ZH\xe9\x9f\xd1z\xe5\x19\x0d\xd5i" +
+	"\"g\xb5\x90\xd2\xff\\[&?\xd7P}F\xe4K" +
+	"\"\x0b\x9b\xe2\xb0\x901\xf9\x85\xd6)\xbf\xd0P\xfd\x8e" +
+	"\x88\xa6s0b\x0b\xe2\x10cL\x82\xde)A\xc7\x84" +
+	"NV\x07\x81\xb6\xe68\xb4\x91\xd5\xa1w\xca&\x1dU" +
+	"#\x918\x11\xa3%\x0e\x06c\xd2\xd0\x17ICG\xd5" +
+	"F\xe4R\"\x8bZ\xe3\xb0\x88\xec\x11\xbdS.\xd5Q" +
+	"-!\xb2\x92\x88\\\x18\x07I\x16\xbd\xde);tT" +
+	"+\x88\\A$\x1e\x8bC\x9c1\xb9Z\xef\x95\xabu" +
+	"T\xab\x88\\Idq[\x1c\x16\x938\xd6;e\xb7" +
+	"\x8ej-\x91\xab\x88\\b\xc4\xe1\x12\xc6\xe4F}\x99" +
+	"\xdc\xa8\xa3\xda@d\x90\xc87\x16\xc5\xe1\x1bd\xf7\xe8" +
+	"\x9d\xb2_G\xb5\x89\xc8v\"Kd\x1c\x960&\xb7" +
+	"\xe9\xeb\xe46\x1d\xd5V\"\xa3D\x96\xc6\xe3\xb0\x94\x84" +
+	"\xbb>,w\xe9\xa8F\x89\xdcDd\xd9\xe28,c" +
+	"L\xde\xa8w\xc9\x1buT7\x10\x99$\xf2\xcdK\xe2" +
+	"\xf0M\xc6\xa4\xad\x0fH[G\x95\"2E\xe4\xd2o" +
+	"\xc4\xe1R\xc6dV_&\xb3:\xaa\x0c\x91\x19\"\xcb" +
+	"\x97\xc4a9c\xb2\xa8\xaf\x93E\x1d\x95K\xe4v\"" +
+	"\x97-\x8d\xc3e\x8c\xc9}\xfa\xb0<\xa0\xa3\xba\x9d\xc8" +
+	"\xbdD\xda\x97\xc5\xa1\x9d6\x89\xde)\x0f\xea\xa8\xee&" +
+	"\xf2 \x91\x15\xdf\x8c\xc3\x0a\xda$z\x97|@Gu" +
+	"?\x91\x87\x89t\\\x1a\x87\x0e\xda$\xfa=\xf2\xa8\x8e" +
+	"\xeaQ\"O\x11\xe9\\\x1e\x87N\xda\x0a\xfa\x98<\xae" +
+	"\xa3z\x8a\xc8\x0bD\xfe\xe8\xb28\xfc\x11c\xf2y\xbd" +
+	"S>\xaf\xa3z\x8e\xc8+:\x87\xee\x95\xaf`\x1cV" +
+	"2&O\xe8w\xc8WuT\xaf\x10:\xa9s\x80\xcb" +
+	"\xe3p9-x=!O\xe9\xa8N\x128Mw\xfb" +
+	"c\x88\xc3\x1f3&?\xd1\x87\xe5\x19\x1d\xd5i\"Z" +
+	"\x03\x07\xe3[}q\xf8\x16c\x12\x1a\x86\xa5\xde\x80J" +
+	"k\x10\xa0\xda\x88\xac\xda\x17\x87U\x14\x0bk\x18\x90\xad" +
+	"\x0d\xa8Z\x88,!\xb2\xfa\xf68\xac&_\xb5a@" +
+	".n@\x15'\xb2\xa2\x81C\xac0\xed\xeb\\dT" +
+	" V\xb2-'\xf4\xbb=\x9b\xcf\xb9\x93\xa1\x0b\x98\xb2" +
+	"J\xa1\x9f\xb1\xc9|1\\\xbf/\x9b\xce\x15];|" +
+	"\xa5\xe0\x99\x97te\x01\xa3\x02h\x8d\xaf\x0d\x09S\xb4" +
+	"\xc6\xbb\xa3?\xd7\x85~\xc6\xd2\x15Y\\\xc5I\xa7\x10" +
+	"\xfa\xd9\x97\xb23\xae\xb5#tEd#7O\x16\x93" +
+	"\xe1\x9fv2\x19\xa5\xe1\x9b\x81\x15fn>\xdao:" +
+	"\xdc\xb2/\x9f\xb5'\xachO\xe9\xf0\xbdD:\x0a\x9d" +
+	"p\xebv\xafu\xe8B\xd9\xbb0\x98w+:!x" +
+	"\xf9\xc1\xbc\x1b\xfa\xbd\x9f\x14Sa{x|D&\xfc" +
+	"\xab\xbd0\xdd\x1fy\xc3raz\xabme\xdc\xc9\xe8" +
+	"\x8d\xd1\x9dH\xd5\x0dr\xa4\x95\xebX\xb9B6]\x00" +
+	"\xf2sH\xa5GZ\x97\xc7\xd3\xee\xb6\x9ck;\x0c\xa7" +
+	"\xadLt\xcc\"\xb7!K\x7fs\xde\x1e\x87q\xd2x" +
+	"\xe9TX\xdfy\xb0?35\xc9\xc0\x0a\x19\x17\xcd\x15" +
+	"\xc7\x85\xe8\x80\xedZQ\xb7\xa6J\xdd\xfc\xad\x9b\xf3\xc5" +
+	"\\u\xb8\x1a\x19\x15\xd8\xef\xe6\xed\xeb*\xb1\x85\xca\xea" +
+	"\xdb\xef\xe6\x93u\x97.h\x80\xa8\x81~U\xa9!\x02" +
+	"e\xbb5P\xb6g\xc0\x91\x9f\x02\xaa\xdfT\xbc\xce\xaa" +
+	"\xaa\xfd\x0a\xc6\xa2Ng\xd5\xb9n\xe2\x9d\xb2\x89\xa3j" +
+	"\x0c\x9cN\x01\xbe\xa6]\xca\x13r9Gu)\x91U" +
+	"D4\xeek\xda\xcb\xf9\xb3r\x0dGu\x05\x91\x0dD" +
+	"t\xe1k\xda\xf5\xfc\x1ey5Gu\x15\x91\xadD\x1a" +
+	"4_\xd3\x0e\xf1D\xcdQ\x1d%\x82\xba\xafiM\x9e" +
+	"\x90\xbb8\xaaQ\"7\x11il\xf05\xed\x8d\xdc\x91" +
+	"\x16GuS\xd5\xb95\x9a\xd0\xd7\xb4i\x9e\x90Y\x8e" +
+	"*Cd\xc6\xf3\xae\x1b}M[\xe4\x09Y\xe2\xa8f" +
+	"\x88\xdcI\xa4\xb9\xc9\xd7\xb4\x07\xb8#\xef\xe2\xa8\xee$" +
+	"r?\x91\x96\x05\xbe\xa6\xbd\x8f'\xe4\x03\x1c\xd5\xfdD" +
+	"\x1e\xf6\xdc\xebf_\xd3\x1e\xe1\x09\xf9\x08G\xf50\x91" +
+	"'\x89,l\xf15\xed\x13\xdc\x91\xc78\xaa'\x89<" +
+	"G$\xd6\xeak\xdagx\xaf|\x86\xa3z\x9a\xc8\xcb" +
+	"D\xda\x16\xfa\xaa\xf6E>,OpT/\x13y\x83" +
+	"\x88\x11\xf3U\xed\xeb\xbcK\xbe\xceQ\xbdF\xe4M\xce" +
+	"\xa1<f\x15l\x9ag\xd6^\xb1\xaa\xc8j\x0d\x02l" +
+	"un\xf6|NJu\xc9\xcfk\xef\x96\x93\x99|\xf2" +
+	"\x96\x81\xb4\xc5 ,\x1f\xca\x8e\x9d\xb1\xdc\xf4\xb4\x0d[" +
+	"\x1c{o\xd1\xce%\xdbK\x03i\xab\xf05v\xe1T" +
+	"\xbe\x90&\xe7\x97\xc1\xf5\xe1\xcb\xd3v&\x9fL\xbb\xa5" +
+	"\xba\xcbV2igl\xc7b\x9e\xc3|\xfd\xdc7\xda" +
+	"=\xf7\x8dv\xcf\x7f\xa3\xdds\xdfh\xcf\xdc7\xda3" +
+	"\xff\x8d\xc2\xa8o\xd2\x93Y\xe1\xcaE\xc7\xda\x96K\xd9" +
+	"3\xf5\x022\x9f\xca\xcd\x1d2\x9c\xdb[\xdeV\x89=" +
+	"TC\x0fI7\xed\xf9\xea\xde\x1eo\x0b\xbc\x0ck\xd6" +
+	"\xb0\xd1L\x090\xa7B\x0eUv\x8f\xb1\x17\xcd)\x01" +
+	"\xe6\xbd!\x87\xea\xe0\xb0q\x1f\x9a\xf7\x0a0\x1f\xaa\x19" +
+	"\xd1\xc6\xe11\xe3\x08\x9a\x0f\x090\x1f\xe7\x14\xa4\xf1\xfb" +
+	"b}\xf9\xdch]\xa8h\xcar\xac\xac\xed\xdaL8" +
+	"\x85\xb9D]:k_k9\xb7T^\xbc>\xc0\x14" +
+	"\xac\xc7\x0a\xb8\xa0t\xdb9V\xb0\x9di\x8b\x86<\xb6" +
+	"%\x9d\x99#\x82\xdb[\x8d\xe0^\x15z\xf7\x8d\xbd\xc6" +
+	"F47\x080o\xe0\xde\xfc\xa4l\xc7_\xf7A\x96" +
+	"&\xba\xee\xfblrU\xa3~c\x10\x8e\xff\x9a\x81\x99" +
+	"~'9\x99\x9e\xb6i\x01\xc0L\xe5\x81\xb5\xe0\x81[" +
+	"\x07\x8cV4[\x04\x98k\xf9\xdc\xfej\x90\xab\xf9\x9a" +
+	"\xfd\x86\xc6\xaaok\xe5\x95\xbd\xceS\x8168\xce\x07" +
+	"\xe4q\x8e\xea)\x12+/\x84\xd4\xc1\xf3\xbcK>\xcf" +
+	"Q=G\xe0\x95\xb0>8\xc1\xef\x90\xafrT\xaf\x10" +
+	"9\xc9C\xc1\xd6\x9f\xf31y\x8a\xa3:I\xe4]\x1e" +
+	"\x8a\xb4\xbc\xc3{\xe5;\x1c\xd5/\x89|\xc8C\x91\x96" +
+	"\x0fx\x97\xfc\x80\xa3z\x9f\xc8g<\x14i\xf9\x94\xef" +
+	"\x91\x9fsT\x9f\x11\xf9\x92\x086\xf8\xfa\xe0\x0b~\xb3" +
+	"\xfc\x8a\xa3\xfa\x92H\xa3 }\x80\xbe>\xd0\xc5\xb0l" +
+	"\x12\xa8\x1a\x05\xb9*D\x9a\x1a}}`\x88Y\xb9X" +
+	"\xa0\x8a\x13YAdA\x93\xaf\x0f\x96\x8bY\xd9!P" +
+	"\xad r\x05\x91\xe6\x05\xbe>X-n\x96k\x04\xaa" +
+	"+\x88l \xd2\xd2\xec\xeb\x83\xf5\xe2\x0e\xb9Q\xa0\xda" +
+	"@d\x90HkK%\xdc*\x1c9$P\x0d\x12\x19" +
+	"!\xb2\xb0\xd5\xd7\x07\xd7\x8a1i\x0aT#Dn " +
+	"\x12[\xe8\xeb\x83\xdd\xe21i\x09T7\x11\xb9\x8dH" +
+	"[\xcc\xd7\x07%q\xb3\xdc'P\xddF\xe4!\"F" +
+	"\x9b\xaf\x0f\x0e\x8b{\xe4#\x02\xd5\xc3D^!\xb2\xc8" +
+	"\xf0]\xaf\x13\xe2\x87\xf2u\x81\xea5\"o\x12\x91\xe0" +
+	"\xbb^\xa7\xc4\xb0|K\xa0z\x93\xc8\xfbD\xe2\x8b|" +
+	"\xd7\xeb=\xf1\x92\xfcH\xa0\xfa\x90\xc8Y\"\x8b\xa5\xef" +
+	"z}.\x9e\x95_\x08T\xbf#\xd2B\x9e\xe4%q" +
+	"\xdf\xf5j\xd2\xf6\xc8V\x0dU\x0by\x92K\x88|C" +
+	"\xf3]\xaf\xc5\xda\x98\\\xaa\xa1ZBd%\x91%\x8b" +
+	"}\xd7\xabC\x1b\x96\x97k\xa8V\x12\xd9\xa4\xfd}\x0b" +
+	"Fg-\xe7\x16\xdb\xd9a1\x11\xb9W\xf5z\x91\xc5" +
+	"\xb2c\xb6\x13&yo\x8b\xd9N\x9dx\x0b.\xf7\xf5" +
+	"\xd7?F\xd9\xb1\x93v\xdac;\x8a\xf5\xb7\x0bX\xac" +
+	"^\xccV\x09|\xcf\x1f\xb0HwV\xce\xb5s9k" +
+	"\x07k?\xe7\x8e\x154\xca\xb0\xee\x86\xd6\xd4\x94\x93\x9f" +
+	"Ig\x81F\x85\x14\x1fV\xa6\xa1N\x80Wn0\xc8" +
+	"b\xe4\xaa\xccQ!_\x912\x90\xf6\x95C!b\xf2" +
+	"\xb6\xd52\xdau\xa1\xf0j\x8c\x09\xfc\x10\xd3\xae\x9c\x1f" +
+	"U\xae\xc5\xd9\xc9D\x9f\xb62Q\x95Y\xc9\xb6mI" +
+	"\x83Sp=\x01\xd7\xe7K\xb8\xf3\xcf\xac\xdfj\xbb\x05" +
+	"\x95F\xd3\xed\x17\xd9\x88\x96\x1c\x13\xd1\x0c\xc0\xbcY\x89" +
+	"\xf3&3\xbe\x8e\xb8n\xf7\x06\xf2<\xba-\x92\x9d\\" +
+	"gt\xa3\xb9V\x80\xb9\x9dC_\xa1>a\xd1\xeey" +
+	"]\xbf\xc7\xf3\x0cxAb\xafN,\x14\xb7[\x1b(" +
+	"\x8f~p\xe4\x10\xa0\x1a\x04\x01\xeaz\xa8=\x92\xdc\x05" +
+	"\x8e\xdc\x0d\xa8\xae'\x92\x81P\xdc.\x0d\x9d2\x0d\xa8" +
+	"&\x89\xdc\x06\xa1\xb8]\x09:ky?J\xefA%" +
+	"lw\x10\xde\x96\x87\x01\xd5\x83\x04\x1e\x0d\x87\xe9\x1f\x81" +
+	"\xb1Z\x0e\xefi\"\x0d\xdcW\x1e\xc7\xa1K\x1e\x07T" +
+	"O\x11y\x81\x08\x0a_y<\x0f]\xf2y@\xf5\x1c" +
+	"\x91W\xbcL\x9d\xe6+\x8f\x13\xd0U\xcb\xee\xbd\xe1e" +
+	"\xeat_y\xbc\x0e]\xf2u@\xf5\x1a\x917\x89," +
+	"h\xf0\x95\xc7)\xe8\x92\xa7\x00\xd5\xc9 #\xd8\x8c\xbe" +
+	"\xf2x\x07\x12\xd1\x8c\xe0\xd73\xd8\xed\xca\xd4\xb0vo" +
+	"n\xfc\xca\xc1\xa1\x9ah\xe5\xaa\xaf?\xaf\x11\x7fa+" +
+	"\xbf\xb0\xb7h9v\"\xcf\xf3\xee\xceqeg\xd3\xd7" +
+	"Z7\xe7\x9d\xfe\x99t\xd4\xea\xcfV\xf2\xd5\x814\x89" +
+	"\xd5\xceF\xd1\x1d\x19\x83\x18\x83\x98\x15\x0dz\xd0\xef\xb0" +
+	"c\x1ds'R\xddu\xbf#A\x13+\x1a7(\xfb" +
+	"Q\x92\xc1<\x03\xf7\xeb$\xd8CvW\xdf\xccP\xce" +
+	"uJ\xe7\xc6\xe3;C\xf1x\xe3\xdc\x80\xfcC!K" +
+	"\xf9pg5 \xff$\x07\xa8\x18\xcaO\xf4\x1aO\xa0" +
+	"\xf9\xb8\x00\xf3i\xb2w\xc0\x8f\xc7\x1f\xef\x0a\xc5\xe3\xeb" +
+	"\x12:\xed\x05\xd7r\xdc\xf3\xcf\x97\x9dK\x9d\xb7B_" +
+	"~|\xbc`{7ibT VH\xcf\xda\xa1\xdf" +
+	"\x17\x1c\x9c\xef\xfa\xe9\"\xaa\xd4\x1e\x0e\xcb\xaf\x0a\xb6w" +
+	"\x138\xb2\x15P\xb5\xd0\x0a^\x11\xde\xde\xcb\xc1\x91\x1d" +
+	"\x80jE\x908\xabn\xefn\xe8\xac%\xce\x06\xc3\xdb" +
+	"\xbb\x1f:e?\xa0\xdaTM\xa9U\xb7\xb7\x09o\xcb" +
+	"\x1b\x01\xd5\x0d\x04&\xc3\xdb\xdb\x86\xb1\x9a\xac\x98\x09g" +
+	"\xe1\x8a0\x16=#\x10D\xe5\xcf=$P\x8d\xca\x1f" +
+	"\x84\xe1\xbaC\x02\xd5\xa8\xfca\x18\x8e\x1e\x12\xf8\xc3\xd9" +
+	"\xaa\xe5\x14-\x8d|\xd1a\x98\xb4\x0b\xa10S\xac\x90" +
+	".DB|c\x13\xa9\xa1\xf5\xd6Pw\x9d:\xf6." +
+	"\x8f\xd5_\xbe\x98d`\xd4%\x19\x0d\x96\xdd\xd5|\xa0" +
+	".BT]vC|\x9d\x1c\xe2\xa8\x06\x89\\\xcf9" +
+	"@e\xd5\xed\xe2\xb3r7Gu=\x81T\xd8%\xb1" +
+	"\xf8\xb0\xb49\xaa\x14\x91\xa9\xb0K\x92\xe5\xf7\xc8\"G" +
+	"\xe5\x12\xb9=\xec\x92\xec\xe3\x09y\x80\xa3\xba\x9d\xc8\xbd" +
+	"a\x97\xe4 \xef\x95\x079\xaa\xbb\x89<H\x04\xc1_" +
+	"v\x0f\xf0\x81\xba\x00Q#\xf8\xcb\xee\x08w\xea\x02D" +
+	"M\xdc_v\xe7\x06\x88\xaaZ\xe5\x19~H\xbe\xc8Q" +
+	"\xbd@\xe45\"\xcd\x9a\xafU^\xe5N4\x0cd\xb4" +
+	"4\xf8.\xc9)\xfe\x93:\x17\xab\x15}\x97\xe4\x03\xfe" +
+	"C\xf9\x09G\xf5q\xe0b-D\xdf%\x89\xb8X-" +
+	"\x9eK\xd2\xe8\xbb$M\xe2\x904\x04\xaa\xb6\xc0)j" +
+	"k\xf2]\x92\xd5b\xb8\xce)2\x16\xf8.\xc9z\xb1" +
+	"\xa7\xce)Z\xa4\xfb.I\xbf\xb8\xb9\xce)\x92\x0d\xbe" +
+	"Kr\xadH\xd49E\xf1f\xdf%\xd9-\x86\xe5\x8d" +
+	"\x02\xd5\x0dDfD\xd4U\x98C\x94\xceg\xc4\xe5<" +
+	"\x93x\xe78\xeb\x1b\x0a<\xfa\xaa\xbdF\x8b\x7fW\xc1" +
+	"N\xd5Y\xef\xc9|\xdeI\xa5s\x16\xb8U\x8f#\x82" +
+	"\xf3t\xd6a\xb44\xc5\xe0\xfc\x0e\x07\xe5\x93\xaf\xab;" +
+	"+\xe6g\x13\x0a;Y\xfbx\x15Uw\x92\x17p\xd8" +
+	"\x96sY\xbbg\xf6F\xf6r>\x95\x1eO\xdb)\x18" +
+	".f\xd2Vn\xd0\x82\xc8\xa1\x95q\xc7\xf2\x8e\xa0\xb0" +
+	"v+S9\xcfR\x1f\xd2\x82\xefU\x82X\xb1-\x19" +
+	"k\"\xfc6\xd5\xf1\x01Uq\xb3\x84\x1d\x19\xa3 R" +
+	"#\xe62\")\x0aVt\xacd\x09\x86f\xa6\xf29" +
+	";\xe7F\x0e9h\x95Z\xe3\xe9\x8cM&m\xdd@" +
+	"\xcfg`W\x9f\x9a\xc5\x06\xacB$s\xedG#\xad" +
+	"\x02\x83\xe8\xe5\xbf\x93\xe1\xfd\xdd\x91j\xc0\xdc\x93\xa1\x9e" +
+	"<Z\x19\x98\x08\x9f:\xd5\xc3#ZX\x07\x028R" +
+	"\x07T\x1a\x90\xfb\x1b\xd6\x81\x8b\xa1S.\x06Tq\"" +
+	"\xab\xc2:\xf0r\xe8\x94\x97\x03\xaa\x95D6\x84t\xe0" +
+	"zx\xbb\xa6\x1c\xb7\x87u\xe06\x18\x93\xd7\x02\xaa\xed" +
+	"Dn\x08\x9b\xb8\xbb\xa1\xabfc\xa7\xc2&\xae\x05\x09" +
+	"i\x03\xaa\x14\x91\xa9\xb0\x89\x9b\x85\x97d\x11P\xb9D" +
+	"\xee\xff\x033J/\xca\xa8\xf4\xddN\x95\x9e\x80\x9c\x95" +
+	"\x99\xe7xW\xf39\xeb\xea<'^Bf\xe7H\xa0" +
+	"\xe3:\xf8Kr5G\xb5\x8a\x04\xef\x95\xa1\xb0[7" +
+	"O\xc8\xf5\x1c\xd5\x95\x046\x85\xc3nW\xf3\x84\xec\xe7" +
+	"\xa86\x11\xd9\x1e\xd6q\xdbxB^\xcbQm\xaf\xaa" +
+	"\xc5@\xc7\xed\xe2\x89\xa8^\xec\xd0\xc1_V\x16\x1f\xab" +
+	"\xd3\x8bU\x1d\x97\xe5\x09\xb9\x97\xa3\x9a\"r[8\xec" +
+	"V\xe2\x09\xb9\x8f\xa3\xba\x8d\xc8\xdd<\x14v\xbb\x8b'" +
+	"\xea\xf4b5\xec\xf6\x00O\xc8\xc3\x1c\xd5\x83D\x1e\xf5" +
+	"t\x1c\xf8:\xee\x11\xfe3\xf9\x04G\xf5x\x10`\xac" +
+	"\xa6aN\xf0\xc7\xeau\x1c\xaf\xea\xb8\x9f\xd5t\x9c\xa7" +
+	"\xc9Z\x17\xf9:\xeeS\xfe3\xf9[\x8e\xea,\x17\x90" +
+	"\x10\x1c\xba\x17\x02\xf8:\xee+\xbeG\x82\xc0\x84\xa8\xa8" +
+	"\xb8\xee\x18\x87\xaa\x8e\x9b\x95\xad\x02U\x0b\xa1%\x84\xda" +
+	"\x04\xf8Jn\xb1\x18\x96K\x05\xaa%\x84V\x12\xf2L" +
+	"{\x83\xa6N\xcc\xca\xcb\x05\xaa\x95\x84\xd6zZN\xf8" +
+	"Zn\x8dxL\xae\x17\xa8\xae$\xb2\x95\x88\xd4|-" +
+	"7$\x1e\x93\xd7\x0aT\xdb\x89\xdc$x8\xa6\xf5=" +
+	"{2\x9d\xcc\xd8s\x04\x83fF|1\xc8 \x1c\x1e" +
+	"+\x97\xe6\xbe<;\xf7\xe5y\xd27t\x88\x874\x02" +
+	"\xc3\xb4[\x0a\xa75g*\x8a\x82AD\x8f\x94\xe6\xbe" +
+	"<;\xf7e\xaf\xd3\x84\xe5\xd6I\xe9\xaaX\xe7\xcaM" +
+	"\x0d\xda\xd3U\x8dQ`sh\x0c\xef\x16\xcaMA\xb5" +
+	"\"\xda97\xac\x97\xaaY\x99\x8b\xbeY\xc2r\xb9\x1d" +
+	"\xad\x1c9\x16\xeaU\x1a\x9a\xb6\x99\xc8\xb9\xe1\x11\xf1\xae" +
+	"\x8f86\xebK\xa5\x93\xae\x9d\x0a\xb3\xac\x95\xb3\x8b\xb5" +
+	"\xf0_\xf5\xb2g!\xcc\xd3$\xd0\xc8\x9ep\xc9XA" +
+	"\xdc\xad\xad\xf6UA\x9d\x1c\xac\xbe\xea\xc57\xb9`\"" +
+	"i\xb4\xa2y\xab\xc7P\xddt\x1e\xaay\xa4\x15\x81\xea" +
+	"{k\xd6x\x07\xcd_\x0a0?\xac\x9dV\xfb`\x99" +
+	"\xf1\x01\x9a\xef\x0b0O\x87\x0e^~\xb2\xcc\xf8\x04\xcd" +
+	"\x8f\x05\x98\x9f\x91h\xd2|\xef8\xd0\x9d\xe6\x97$\x96" +
+	"t\xdf;\xfe\xc21\xbeB\xf3K\x0a\xde{\xaa\xae\"" +
+	"\x93tpd\x13\xa0j\x04J4\x87U\xddR\xe8\x95" +
+	"K\x01\xd5\x12\"+!$\x93:\xc0\xa9i\xd4\xb5p" +
+	"\xfe\\\x95\x88\x9cx\x10Vw4\xa19n;v." +
+	"\xc9\xda\xed\xea!\xc0\xea\xca\x08\xa1z{.\x84\x06/" +
+	"\x14^\xee+\xe4\x8bN2\x12\x84-\xba\xc9m)Z" +
+	"\x88\xedd\xdf9\xa1;_8\xcd?\xd2\x13]\x0e\xe7" +
+	"x\xef\x03\xb5\xd1\x8c\x87-\x97\xc8\xb1\xd7K\xc3\x96\xcb" +
+	"R\x18\xa8\x1b\xe7\xaa\xe5\xd2\x01c\xd1q\x0e\x0e\xd5\xad" +
+	"\x01\xa7\xe6\xf1_\x05\xa1Cu\x1b\xc1\x91W\x03\xaa\xab" +
+	"\x88l\xf5\xe6\xb3\xa1\x92\xea\x87C5\xa3\xc6\x0b\x1c\"" +
+	"\xfa\xf3\x19\x09\x1czFMc\xa3\xafc,8T\x0b" +
+	"\x06\xb8\x9e\xfb\xde\xe4\xeb\x98\xbdp(\x1a\x0c\xd8?\xe3" +
+	"o\xf3\xd0p\xee/\x9d{i\xf6\x9cK\x81\xd4aX" +
+	"\x07fJ\xfe`\xb3Z\xdc8@\xb3\xf3\xa3\xcdt\xbf" +
+	"\xcdy\xf0\xe7\xc9\xdfc5\\\x9a\xbfe\xe9\xfc-g" +
+	"\xcf\x8b/\xb8\xfb\xd5H\xcf\x96\xbc\x93\xb5\xdcj\xb5\x02" +
+	";O\xac\xf9\xe2\xf2\xa8\xc1g^\x17\xce\xa3\x06\xdf " +
+	"\xfe\xfe\xf9\xcc\xc8I\xe2%\xc1C\x1fYWM[?" +
+	"\x15z\xe8c\x03\xc614\x9f\x14`\xbe\x1c\xc4\x0c\x8c" +
+	"\x17\x13\xc6\x094_\x16`\xbeQ;\xd2b\xbc\xfe\x98" +
+	"q\x0a\xcd\x93\x02\xccwk\xc7Y\x8cw\xf6\x18\xef\xa1" +
+	"\xf9nE\x8cU\x8e\xb2\x18_\xdc\\\x15cd\xfe\xb7" +
+	"\xdb\xfe\x13\x9d\xef0\xf2\xc5\x1c0.\xdb\xd3v\xce\xdd" +
+	"\x92\xb1\x18L\x84\x8f\xdd\x06\x09 o\xeaw\x8e\x8fc" +
+	"%\x00\x18\x1c\x95\x9a\xdbQk\xab}\x10V\x97\x80\xf1" +
+	":J\xd8I\x16\xcb;\xa9\xf3'\x08\xe6YF\x95\x1e" +
+	"\xed\xda\xc4@\xee\xdc\x85\xd49g\xd2\xe2\xe6\xea'U" +
+	"\xd7\x9f\x13!-g+g\xa8Y\x8c4z\xe4e\x82" +
+	"\x8fQ\xbf\xe6\xda\xf1\xbe9\xf2\xab`-\xd8yC " +
+	".\x8b\xbc\xb7\x16\x0e\xba;d\x91\xdf\xc5\xc7\xeal\xdb" +
+	"\xaaE\xfe\x00_W\x8b\xf9<\x1e>\x18u\x94'j" +
+	"\xb6\xed\xd3\xe1\x83Q\xc7\xf9\xb3ui\xf5\xea\xc1\xa8\x13" +
+	"\xfc\x9e:\xab\xb7z0\xea\x14O\xc8\xb78\xaa7\x89" +
+	"\xbc\x1f>\x18\xf5\x1eO\xd4\x92\xe7\xa7\xc3\x07\xa3>\xe1" +
+	"\x8e<\xc3Q\x9d&r6|0\xeas\x9e\x88X\xca" +
+	"\xc1\xb9\xa8\xafx\"b(\x07\xf6x\x93p\xa2vr" +
+	"p,j\xb1HD\xcd\xe4\xe0XT\x87H\xd4Y\xc9" +
+	"\xd5cQk\x84#\xbb\x05\xaa\xb5D\xae\x12\xa1cQ" +
+	"\x1bEo]\xfc\xa8\xadb\x8e\xf7\x8b\x97\xe46\x81j" +
+	"+\x91Q\"\xc6B\xdf\x1a7\xc5\xac\xdc%P\x8dV" +
+	"-kcQ\xcc\xb7\xc6o\x14c\xb5\xa4z\x86\x88l" +
+	"\xf3\xad\xf1\xb4\xf8\x89\xdc+PM\x05\xe9\xf6\xb8\xe1\xc7" +
+	"\x9cJ\xa2K\x96\x04\xaa\x19\"wzi\xf0E~\x1a" +
+	"\xfc\x80\x18\x93w\x09Tw\x12\xb9\x9f\xc8%\xdcO\x83" +
+	"\xdf'\x1c\xf9\x80@u?\x91\xc7E\x9dT\x0c>\x04" +
+	"\xbf\xc8\xf3X\x17!E\xfe\x0e'\xb2*\x9e\xf2\x16\xa0" +
+	"#;\x7f\xe0'\xb2\xc6+\xa3\x04\xa5\xcd\x93V.g" +
+	"g\xfav\x069\x95\xaa\x9aM\xe7\xc6=5\xc9\xfa\xd2" +
+	"\xf9\\\xffD\xc4\x99)\xb8\x96[,l\xc90\xb4&" +
+	"\"\x83<A_4\x0d\xda\x19\xb0J\x83\xe9\xf1q\xdb" +
+	"\x89\xd9\xb9d$\x1dVt\xact\xc4\x19\xf3\x1en\x8e" +
+	"[}\x9d\xe0\xcc\x05\xa3\x11;\xac\xe9\xf0\x89\xa9\x90\x95" +
+	"\xdfk\xbc\x85\xe6\x9b\x02\xcc\x8fC\x02\xfa\xa3N\xe3#" +
+	"4?\xac\x86\xbd\xaa&\"\xc0\x80\x04\xc0\xc4\x1c\x16\xe2" +
+	"@-'4\x18\xb6\x10\xfb\xa1\xb7\x16\xdcJ\x85-D" +
+	"\x0b\xba\xa4\x05\xa8n\x0a\xd28U\x0b\xf1\x00\xac\xab\xa5" +
+	"q\x1e\x0f[\x88G\xa1\xab\x96\xf3}\x0d.n\xbf]" +
+	"\xfcGT\x99|\xce*\\\xd4wZ\xff\xbf>\xb8\xda" +
+	";[\xb8\xa8gm\xcfY\xd3\xdb6_L\xcd\xdf\xe7" +
+	"\x1b\xaey\x8e\x04T\x16d\xe4lqh%\xfd\xb0j" +
+	"'EV\xd2\xba\xcaJ2\xcf\xd6\xcc\xaf\xcf\x1d\xe3\xb7" +
+	"h\x9e\xad./\xd1\xe6\xaf\"\x80D-\xaa\xda\x06\xb5" +
+	"\x94\xaal\x85gkQU/\x1b\xa9s\x7f\x15-\x87" +
+	"D]6\xb2\xfa\xf1N7\x0c\xcb\xf5\x80\xeaJ\"\x9b" +
+	"\x88\xa0\xe6\xaf\xa2\xabaO4\xe0Z\x9e*\xd8\xc5T" +
+	">aA.\x95\xcfR0FD\xa21a\xc1<\xaf" +
+	"\xe5V\x0b\x8f\x8e\x06\xa1\xc8\xe0\x7f\xf5\x08%\xdd\xd2\x85" +
+	"\xca\xa9v(E\xa3\x093\xe9l1;\x0a\xe9\xac\xed" +
+	"\x89\x8ev\xa7Ntx\x81t2}\x19\\\xe0Y\xa8" +
+	"\xe2\x0e+[\x1fq\xa7\xcb^\xbaOD\x1c\xd1\x0b\x0a" +
+	"\x8f\xd1t\x16\xe68k9\x101\xedx\xc5\xb4\x1b\x0b" +
+	"}-\xbf\xbf\x92\xf1\xa0\xcetF\x05\xca9+\x97/" +
+	"\xccq~f\x13\xfc\xbf\x01\x00\x13\xd1\x90V"
 
 func RegisterSchema(reg *schemas.Registry) {
 	reg.Register(&schemas.Schema{
 		String: schema_b3ca6d2462778bb1,
 		Nodes: []uint64{
 			0x8724cc2fcbb631fd,
+			0x883915138362485c,
 			0x8861b2182dea79c8,
 			0x88bee98e19a6d24e,
 			0x8a11dc8313cd9d6d,
 			0x9261b240a2cc4a68,
+			0x945effe9a6c09d43,
 			0x9691bc6bef5f044a,
+			0x9b1c8905533fc36b,
+			0x9bea4ce8cd38c878,
+			0x9cb7e476bf04cc4e,
+			0xb6de990322e1d01e,
+			0xbb184c3bcbcd867f,
+			0xc411e87839521ad9,
+			0xc6f81a529b1ee75c,
+			0xcd6d1146e9cde5fc,
+			0xd20a074e28e674ba,
 			0xd5b36c059384fab0,
 			0xd67148628f889f75,
 			0xdfc8474e015f357d,
+			0xe1f97e4a71b66e6f,
+			0xe3f4f87b754f39a6,
 			0xe5b14b55893ef9cb,
+			0xe835d571cf672ea0,
+			0xe9f50d7a73032eaa,
 			0xeca2c2c553ea12f6,
+			0xf7dee27edbaf9972,
 			0xfde08cc67d073fd0,
 			0xffe70a8369c5f3f6,
 		},
@@ -0,0 +1,210 @@
+package gnss
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"capnproto.org/go/capnp/v3"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  CAPNP PERSISTENCE
+// Parsed files are saved as a single capnp message, either as a plain stream
+// (segment table followed by the segments, readable in place, see Archive)
+// or packed (runs of zero bytes compressed, several times smaller). The root
+// of the message is:
+//   navigation    NavFile
+//   SP3           SP3FormatEphemeris
+//   observation   ObservationFile
+// Loading returns the types of the text parsers, backed by the decoded
+// message.
+
+// maxMessageSize bounds a loaded message, a day of 1 Hz multi-GNSS
+// observations stays well below it.
+const maxMessageSize = 1 << 30
+
+// SaveMessage writes msg to w, packed or not.
+func SaveMessage(w io.Writer, msg *capnp.Message, packed bool) error {
+	encoder := capnp.NewEncoder(w)
+	if packed {
+		encoder = capnp.NewPackedEncoder(w)
+	}
+	if err := encoder.Encode(msg); err != nil {
+		return fmt.Errorf("failed to write message: %v", err)
+	}
+	return nil
+}
+
+// LoadMessage reads the next message of r, packed or not.
+func LoadMessage(r io.Reader, packed bool) (*capnp.Message, error) {
+	decoder := capnp.NewDecoder(r)
+	if packed {
+		decoder = capnp.NewPackedDecoder(r)
+	}
+	decoder.MaxMessageSize = maxMessageSize
+	msg, err := decoder.Decode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message: %v", err)
+	}
+	liftReadLimit(msg)
+	return msg, nil
+}
+
+// liftReadLimit removes the traversal limit of msg. Loaded files are read
+// over and over by the ephemeris accessors and would exhaust the default.
+func liftReadLimit(msg *capnp.Message) {
+	msg.ResetReadLimit(math.MaxUint64)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// SaveNav writes nav as one NavFile message. Navigation data that was not
+// parsed from a file (see MergeNavigation) is copied into a new message.
+func SaveNav(w io.Writer, nav *NavigationData, packed bool) error {
+	file, err := nav.navFile()
+	if err != nil {
+		return err
+	}
+	return SaveMessage(w, file.Message(), packed)
+}
+
+// LoadNav reads a NavFile message written by SaveNav.
+func LoadNav(r io.Reader, packed bool) (*NavigationData, error) {
+	msg, err := LoadMessage(r, packed)
+	if err != nil {
+		return nil, err
+	}
+	file, err := ReadRootNavFile(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NavFile: %v", err)
+	}
+	return navigationDataFromFile(file)
+}
+
+// navFile returns the message of a parsed file, or a copy of the records
+// in a new one.
+func (nav *NavigationData) navFile() (NavFile, error) {
+	if nav.File.IsValid() {
+		return nav.File, nil
+	}
+	_, seg, err := capnp.NewMessage(capnp.MultiSegment(nil))
+	if err != nil {
+		return NavFile{}, fmt.Errorf("failed to create new message: %v", err)
+	}
+	file, err := NewRootNavFile(seg)
+	if err != nil {
+		return NavFile{}, fmt.Errorf("failed to create new NavFile: %v", err)
+	}
+	if err := file.SetHeader(nav.Header); err != nil {
+		return NavFile{}, fmt.Errorf("failed to set header: %v", err)
+	}
+	for _, err := range []error{
+		copyEphemerides(nav.GPS, file.NewGps),
+		copyEphemerides(nav.GLONASS, file.NewGlonass),
+		copyEphemerides(nav.Galileo, file.NewGalileo),
+		copyEphemerides(nav.BeiDou, file.NewBeiDou),
+		copyEphemerides(nav.QZSS, file.NewQzss),
+		copyEphemerides(nav.NavIC, file.NewNavIC),
+		copyEphemerides(nav.SBAS, file.NewSbas),
+	} {
+		if err != nil {
+			return NavFile{}, err
+		}
+	}
+
+	// the copies carry the header of their source file, point them at the
+	// header of this one as ParseNav does
+	header, err := file.Header()
+	if err != nil {
+		return NavFile{}, fmt.Errorf("failed to get header: %v", err)
+	}
+	glonass, err := file.Glonass()
+	if err != nil {
+		return NavFile{}, fmt.Errorf("failed to get GLONASS records: %v", err)
+	}
+	for i := 0; i < glonass.Len(); i++ {
+		if err := glonass.At(i).SetHeader(header); err != nil {
+			return NavFile{}, fmt.Errorf("failed to set header: %v", err)
+		}
+	}
+	return file, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// SaveSP3 writes the message of a parsed SP3 file.
+func SaveSP3(w io.Writer, sp3 *SP3FormatEphemeris, packed bool) error {
+	return SaveMessage(w, sp3.Message(), packed)
+}
+
+// LoadSP3 reads an SP3FormatEphemeris message written by SaveSP3.
+func LoadSP3(r io.Reader, packed bool) (*SP3FormatEphemeris, error) {
+	msg, err := LoadMessage(r, packed)
+	if err != nil {
+		return nil, err
+	}
+	sp3, err := ReadRootSP3FormatEphemeris(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SP3FormatEphemeris: %v", err)
+	}
+	return &sp3, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// SaveObservation copies the header and epochs of an observation file into
+// one ObservationFile message and writes it.
+func SaveObservation(w io.Writer, header *ObservationHeader, epochs []ObservationEpoch, packed bool) error {
+	_, seg, err := capnp.NewMessage(capnp.MultiSegment(nil))
+	if err != nil {
+		return fmt.Errorf("failed to create new message: %v", err)
+	}
+	file, err := NewRootObservationFile(seg)
+	if err != nil {
+		return fmt.Errorf("failed to create new ObservationFile: %v", err)
+	}
+	if err := file.SetHeader(*header); err != nil {
+		return fmt.Errorf("failed to set header: %v", err)
+	}
+	list, err := file.NewEpochs(int32(len(epochs)))
+	if err != nil {
+		return fmt.Errorf("failed to create new epochs: %v", err)
+	}
+	for i, epoch := range epochs {
+		if err := list.Set(i, epoch); err != nil {
+			return fmt.Errorf("failed to copy epoch: %v", err)
+		}
+	}
+	return SaveMessage(w, file.Message(), packed)
+}
+
+// LoadObservation reads an ObservationFile message written by
+// SaveObservation.
+func LoadObservation(r io.Reader, packed bool) (*ObservationHeader, []ObservationEpoch, error) {
+	msg, err := LoadMessage(r, packed)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := ReadRootObservationFile(msg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read ObservationFile: %v", err)
+	}
+	header, err := file.Header()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get header: %v", err)
+	}
+	epochs, err := listElements(file.Epochs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &header, epochs, nil
+}
@@ -0,0 +1,116 @@
+package gnss
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// =========================================================================
+
+// =========================================================================
+
+func TestSaveLoadNav(t *testing.T) {
+	parsed, err := ParseNavFile(testFile(t, "brdc2050.nav"), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mixed, err := ParseNav(strings.NewReader(mixedNavV3), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// merged data has no message of its own and is copied
+	merged, err := MergeNavigation(parsed, mixed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name string
+		nav  *NavigationData
+	}{
+		{"parsed", parsed},
+		{"merged", merged.Navigation},
+	} {
+		for _, packed := range []bool{false, true} {
+			var buf bytes.Buffer
+			if err := SaveNav(&buf, c.nav, packed); err != nil {
+				t.Fatalf("%s packed %v: %v", c.name, packed, err)
+			}
+			loaded, err := LoadNav(&buf, packed)
+			if err != nil {
+				t.Fatalf("%s packed %v: %v", c.name, packed, err)
+			}
+			compareNavRecords(t, c.name, navRecords(t, loaded), navRecords(t, c.nav))
+			if loaded.Header.Version() != c.nav.Header.Version() {
+				t.Errorf("%s: version %v, want %v", c.name, loaded.Header.Version(), c.nav.Header.Version())
+			}
+			for i, eph := range loaded.GLONASS {
+				if header, err := eph.Header(); err != nil || header.Message() != loaded.File.Message() {
+					t.Fatalf("%s: GLONASS record %d header %v", c.name, i, err)
+				}
+			}
+		}
+	}
+
+	if _, err := LoadNav(strings.NewReader("not a message"), false); err == nil {
+		t.Error("text loaded as a message")
+	}
+}
+
+func TestSaveLoadSP3(t *testing.T) {
+	sp3, err := ParseSP3File("testdata/whu-short.sp3", ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := sp3.Message().Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, packed := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := SaveSP3(&buf, sp3, packed); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := LoadSP3(&buf, packed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := loaded.Message().Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("packed %v: loaded message differs", packed)
+		}
+	}
+}
+
+func TestSaveLoadObservation(t *testing.T) {
+	header, epochs, err := ParseRINEXObservationFile("testdata/obs-304.24o")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, packed := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := SaveObservation(&buf, header, epochs, packed); err != nil {
+			t.Fatal(err)
+		}
+		loadedHeader, loaded, err := LoadObservation(&buf, packed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if loadedHeader.Version() != header.Version() {
+			t.Errorf("version %v, want %v", loadedHeader.Version(), header.Version())
+		}
+		if len(loaded) != len(epochs) {
+			t.Fatalf("%d epochs, want %d", len(loaded), len(epochs))
+		}
+		for i := range loaded {
+			if !reflect.DeepEqual(epochSummary(loaded[i]), epochSummary(epochs[i])) {
+				t.Errorf("packed %v: epoch %d differs", packed, i)
+			}
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package gnss
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"sync"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/schemas"
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  CAPNP JSON
+// Writes any struct of EphemerisDataStructure.capnp as JSON, driven by the
+// schema compiled into the generated code, so the names and nesting always
+// match the schema:
+//   struct, group     object, fields in declaration order
+//   List(T)           array
+//   enum              enumerant name ("lnav", "finalOrbit")
+//   Text / Data       string / base64 string
+// Unset struct pointers are null, so are NaN and infinities which JSON
+// cannot represent.
+
+var (
+	schemaNodesOnce sync.Once
+	schemaNodes     map[uint64]schema.Node
+	schemaNodesErr  error
+)
+
+func schemaNode(id uint64) (schema.Node, error) {
+	schemaNodesOnce.Do(func() {
+		reg := new(schemas.Registry)
+		RegisterSchema(reg)
+		data, err := reg.Find(NavFile_TypeID)
+		if err != nil {
+			schemaNodesErr = fmt.Errorf("failed to find schema: %v", err)
+			return
+		}
+		msg, err := capnp.Unmarshal(data)
+		if err != nil {
+			schemaNodesErr = fmt.Errorf("failed to read schema: %v", err)
+			return
+		}
+		liftReadLimit(msg)
+		request, err := schema.ReadRootCodeGeneratorRequest(msg)
+		if err != nil {
+			schemaNodesErr = fmt.Errorf("failed to read schema: %v", err)
+			return
+		}
+		nodes, err := request.Nodes()
+		if err != nil {
+			schemaNodesErr = fmt.Errorf("failed to read schema nodes: %v", err)
+			return
+		}
+		schemaNodes = make(map[uint64]schema.Node, nodes.Len())
+		for i := 0; i < nodes.Len(); i++ {
+			schemaNodes[nodes.At(i).Id()] = nodes.At(i)
+		}
+	})
+	if schemaNodesErr != nil {
+		return schema.Node{}, schemaNodesErr
+	}
+	node, ok := schemaNodes[id]
+	if !ok {
+		return schema.Node{}, fmt.Errorf("unknown schema type %#x", id)
+	}
+	return node, nil
+}
+
+// EncodeJSON writes s, a struct of type typeID (GPSEphemeris_TypeID, ...),
+// as a single JSON object.
+func EncodeJSON(w io.Writer, typeID uint64, s capnp.Struct) error {
+	e := &jsonEncoder{w: bufio.NewWriter(w)}
+	if err := e.encodeStruct(typeID, s); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+// =========================================================================
+
+// =========================================================================
+
+type jsonField struct {
+	typeID uint64
+	name   string
+}
+
+type jsonEncoder struct {
+	w   *bufio.Writer
+	tmp []byte
+	// omit lists fields left out of the output
+	omit map[jsonField]bool
+}
+
+func (e *jsonEncoder) encodeStruct(typeID uint64, s capnp.Struct) error {
+	if !s.IsValid() {
+		e.w.WriteString("null")
+		return nil
+	}
+	node, err := schemaNode(typeID)
+	if err != nil {
+		return err
+	}
+	if node.Which() != schema.Node_Which_structNode {
+		return fmt.Errorf("schema type %#x is not a struct", typeID)
+	}
+	structNode := node.StructNode()
+	var discriminant uint16
+	if structNode.DiscriminantCount() > 0 {
+		discriminant = s.Uint16(capnp.DataOffset(structNode.DiscriminantOffset() * 2))
+	}
+	fields, err := structNode.Fields()
+	if err != nil {
+		return fmt.Errorf("failed to get schema fields: %v", err)
+	}
+	ordered := make([]schema.Field, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		ordered[fields.At(i).CodeOrder()] = fields.At(i)
+	}
+
+	e.w.WriteByte('{')
+	first := true
+	for _, field := range ordered {
+		if dv := field.DiscriminantValue(); dv != schema.Field_noDiscriminant && dv != discriminant {
+			continue
+		}
+		name, err := field.Name()
+		if err != nil {
+			return fmt.Errorf("failed to get field name: %v", err)
+		}
+		if e.omit[jsonField{typeID, name}] {
+			continue
+		}
+		if !first {
+			e.w.WriteByte(',')
+		}
+		first = false
+		e.encodeString(name)
+		e.w.WriteByte(':')
+
+		switch field.Which() {
+		case schema.Field_Which_slot:
+			err = e.encodeSlot(s, field.Slot())
+		case schema.Field_Which_group:
+			err = e.encodeStruct(field.Group().TypeId(), s)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	e.w.WriteByte('}')
+	return nil
+}
+
+func (e *jsonEncoder) encodeSlot(s capnp.Struct, slot schema.Field_slot) error {
+	typ, err := slot.Type()
+	if err != nil {
+		return fmt.Errorf("failed to get field type: %v", err)
+	}
+	dv, err := slot.DefaultValue()
+	if err != nil {
+		return fmt.Errorf("failed to get default value: %v", err)
+	}
+	offset := slot.Offset()
+
+	// data fields are stored XOR their default
+	switch typ.Which() {
+	case schema.Type_Which_void:
+		e.w.WriteString("null")
+	case schema.Type_Which_bool:
+		e.w.WriteString(strconv.FormatBool(s.Bit(capnp.BitOffset(offset)) != dv.Bool()))
+	case schema.Type_Which_int8:
+		e.encodeInt(int64(int8(s.Uint8(capnp.DataOffset(offset)) ^ uint8(dv.Int8()))))
+	case schema.Type_Which_int16:
+		e.encodeInt(int64(int16(s.Uint16(capnp.DataOffset(offset*2)) ^ uint16(dv.Int16()))))
+	case schema.Type_Which_int32:
+		e.encodeInt(int64(int32(s.Uint32(capnp.DataOffset(offset*4)) ^ uint32(dv.Int32()))))
+	case schema.Type_Which_int64:
+		e.encodeInt(int64(s.Uint64(capnp.DataOffset(offset*8)) ^ uint64(dv.Int64())))
+	case schema.Type_Which_uint8:
+		e.encodeUint(uint64(s.Uint8(capnp.DataOffset(offset)) ^ dv.Uint8()))
+	case schema.Type_Which_uint16:
+		e.encodeUint(uint64(s.Uint16(capnp.DataOffset(offset*2)) ^ dv.Uint16()))
+	case schema.Type_Which_uint32:
+		e.encodeUint(uint64(s.Uint32(capnp.DataOffset(offset*4)) ^ dv.Uint32()))
+	case schema.Type_Which_uint64:
+		e.encodeUint(s.Uint64(capnp.DataOffset(offset*8)) ^ dv.Uint64())
+	case schema.Type_Which_float32:
+		bits := s.Uint32(capnp.DataOffset(offset*4)) ^ math.Float32bits(dv.Float32())
+		e.encodeFloat(float64(math.Float32frombits(bits)), 32)
+	case schema.Type_Which_float64:
+		bits := s.Uint64(capnp.DataOffset(offset*8)) ^ math.Float64bits(dv.Float64())
+		e.encodeFloat(math.Float64frombits(bits), 64)
+	case schema.Type_Which_enum:
+		return e.encodeEnum(typ.Enum().TypeId(), s.Uint16(capnp.DataOffset(offset*2))^dv.Enum())
+	case schema.Type_Which_text:
+		p, err := s.Ptr(uint16(offset))
+		if err != nil {
+			return err
+		}
+		e.encodeString(p.Text())
+	case schema.Type_Which_data:
+		p, err := s.Ptr(uint16(offset))
+		if err != nil {
+			return err
+		}
+		e.encodeString(base64.StdEncoding.EncodeToString(p.Data()))
+	case schema.Type_Which_structType:
+		p, err := s.Ptr(uint16(offset))
+		if err != nil {
+			return err
+		}
+		return e.encodeStruct(typ.StructType().TypeId(), p.Struct())
+	case schema.Type_Which_list:
+		elem, err := typ.List().ElementType()
+		if err != nil {
+			return fmt.Errorf("failed to get list type: %v", err)
+		}
+		p, err := s.Ptr(uint16(offset))
+		if err != nil {
+			return err
+		}
+		return e.encodeList(elem, p.List())
+	default:
+		// interfaces and AnyPointer carry nothing to export
+		e.w.WriteString("null")
+	}
+	return nil
+}
+
+func (e *jsonEncoder) encodeList(elem schema.Type, l capnp.List) error {
+	e.w.WriteByte('[')
+	for i := 0; i < l.Len(); i++ {
+		if i > 0 {
+			e.w.WriteByte(',')
+		}
+		switch elem.Which() {
+		case schema.Type_Which_bool:
+			e.w.WriteString(strconv.FormatBool(capnp.BitList(l).At(i)))
+		case schema.Type_Which_int8:
+			e.encodeInt(int64(capnp.Int8List(l).At(i)))
+		case schema.Type_Which_int16:
+			e.encodeInt(int64(capnp.Int16List(l).At(i)))
+		case schema.Type_Which_int32:
+			e.encodeInt(int64(capnp.Int32List(l).At(i)))
+		case schema.Type_Which_int64:
+			e.encodeInt(capnp.Int64List(l).At(i))
+		case schema.Type_Which_uint8:
+			e.encodeUint(uint64(capnp.UInt8List(l).At(i)))
+		case schema.Type_Which_uint16:
+			e.encodeUint(uint64(capnp.UInt16List(l).At(i)))
+		case schema.Type_Which_uint32:
+			e.encodeUint(uint64(capnp.UInt32List(l).At(i)))
+		case schema.Type_Which_uint64:
+			e.encodeUint(capnp.UInt64List(l).At(i))
+		case schema.Type_Which_float32:
+			e.encodeFloat(float64(capnp.Float32List(l).At(i)), 32)
+		case schema.Type_Which_float64:
+			e.encodeFloat(capnp.Float64List(l).At(i), 64)
+		case schema.Type_Which_enum:
+			if err := e.encodeEnum(elem.Enum().TypeId(), capnp.UInt16List(l).At(i)); err != nil {
+				return err
+			}
+		case schema.Type_Which_text:
+			v, err := capnp.TextList(l).At(i)
+			if err != nil {
+				return err
+			}
+			e.encodeString(v)
+		case schema.Type_Which_data:
+			v, err := capnp.DataList(l).At(i)
+			if err != nil {
+				return err
+			}
+			e.encodeString(base64.StdEncoding.EncodeToString(v))
+		case schema.Type_Which_structType:
+			if err := e.encodeStruct(elem.StructType().TypeId(), l.Struct(i)); err != nil {
+				return err
+			}
+		case schema.Type_Which_list:
+			inner, err := elem.List().ElementType()
+			if err != nil {
+				return fmt.Errorf("failed to get list type: %v", err)
+			}
+			p, err := capnp.PointerList(l).At(i)
+			if err != nil {
+				return err
+			}
+			if err := e.encodeList(inner, p.List()); err != nil {
+				return err
+			}
+		default:
+			e.w.WriteString("null")
+		}
+	}
+	e.w.WriteByte(']')
+	return nil
+}
+
+func (e *jsonEncoder) encodeEnum(typeID uint64, v uint16) error {
+	node, err := schemaNode(typeID)
+	if err != nil {
+		return err
+	}
+	enumerants, err := node.Enum().Enumerants()
+	if err != nil {
+		return fmt.Errorf("failed to get enumerants: %v", err)
+	}
+	if int(v) >= enumerants.Len() {
+		// written by a newer schema
+		e.encodeUint(uint64(v))
+		return nil
+	}
+	name, err := enumerants.At(int(v)).Name()
+	if err != nil {
+		return fmt.Errorf("failed to get enumerant name: %v", err)
+	}
+	e.encodeString(name)
+	return nil
+}
+
+func (e *jsonEncoder) encodeInt(v int64) {
+	e.tmp = strconv.AppendInt(e.tmp[:0], v, 10)
+	e.w.Write(e.tmp)
+}
+
+func (e *jsonEncoder) encodeUint(v uint64) {
+	e.tmp = strconv.AppendUint(e.tmp[:0], v, 10)
+	e.w.Write(e.tmp)
+}
+
+func (e *jsonEncoder) encodeFloat(v float64, bits int) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		e.w.WriteString("null")
+		return
+	}
+	e.tmp = strconv.AppendFloat(e.tmp[:0], v, 'g', -1, bits)
+	e.w.Write(e.tmp)
+}
+
+func (e *jsonEncoder) encodeString(v string) {
+	b, _ := json.Marshal(v)
+	e.w.Write(b)
+}
@@ -31,12 +31,37 @@ const (
 	GLONASS_L3       = 1.201e9
 	GLONASS_L3_DELTA = 0.4375e6
 
+	// PZ-90 parameters used by the GLONASS orbit equations (ICD 5.1, 3.1.1)
+	GLONASS_GM                  = 3.986004418e14 // m^3/s^2
+	GLONASS_EARTH_RADIUS        = 6.378136e6     // m
+	GLONASS_J2                  = 1.0826257e-3
+	GLONASS_EARTH_ROTATION_RATE = 7.292115e-5 // rad/s
+
 	// Galileo system parameters:  Has additional frequencies on E6
 	// Source RINEX 2.11 document
+	GALILEO_E1   = 1.57542e9  // Hz
+	GALILEO_E5A  = 1.17645e9  // Hz
 	GALILEO_E5B  = 1.207140e9 // Hz
 	GALILEO_E5AB = 1.191795e9 // Hz
 	GALILEO_E6   = 1.27875e9  // Hz
 
+	// BeiDou system parameters
+	BEIDOU_B1I = 1.561098e9 // Hz
+	BEIDOU_B1C = 1.57542e9  // Hz
+	BEIDOU_B2A = 1.17645e9  // Hz
+	BEIDOU_B2B = 1.20714e9  // Hz
+	BEIDOU_B3I = 1.26852e9  // Hz
+
+	// Galileo (GTRF) and BeiDou (CGCS2000) orbit constants
+	GALILEO_GM                  = 3.986004418e14  // m^3/s^2
+	GALILEO_EARTH_ROTATION_RATE = 7.2921151467e-5 // rad/s
+	BEIDOU_GM                   = 3.986004418e14  // m^3/s^2
+	BEIDOU_EARTH_ROTATION_RATE  = 7.2921150e-5    // rad/s
+
+	// BeiDou time (BDT) starts 2006-01-01 00:00:00 UTC and runs 14 s behind GPS time
+	BDT_GPS_WEEK_OFFSET    = 1356
+	BDT_GPS_SECONDS_OFFSET = 14
+
 	// Time constants
 	SECS_IN_MIN  = 60
 	SECS_IN_HR   = 60 * SECS_IN_MIN
@@ -0,0 +1,267 @@
+package gnss
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  COMPRESSED INPUT
+// IGS archives distribute brdc*.gz, *.Z and Hatanaka compressed observation
+// files (*.crx, *d.Z, *.crx.gz). The format is told from the content, not
+// the file name:
+//   1F 8B                                  gzip
+//   1F 9D                                  Unix compress (LZW)
+//   "CRINEX VERS   / TYPE" in columns 61-80 Hatanaka (see hatanaka.go)
+// The layers are removed in that order, a .crx.Z file is inflated and then
+// expanded to RINEX.
+
+// Decompress returns a reader of the plain text behind r. Input that is not
+// compressed is returned as is (buffered).
+func Decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read input: %v", err)
+	}
+
+	var plain io.Reader = br
+	switch {
+	case bytes.Equal(magic, []byte{0x1f, 0x8b}):
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gzip stream: %v", err)
+		}
+		plain = gz
+	case bytes.Equal(magic, []byte{0x1f, 0x9d}):
+		z, err := newLZWReader(br)
+		if err != nil {
+			return nil, err
+		}
+		plain = z
+	default:
+		return crinexDecompress(br)
+	}
+	return crinexDecompress(bufio.NewReader(plain))
+}
+
+// crinexDecompress expands br when its first line is a CRINEX header.
+func crinexDecompress(br *bufio.Reader) (io.Reader, error) {
+	first, err := br.Peek(80)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read input: %v", err)
+	}
+	line, _, _ := strings.Cut(string(first), "\n")
+	if strings.TrimRight(column(line, 60, 80), " \r") == crinexVersionLabel {
+		return newCRINEXReader(br)
+	}
+	return br, nil
+}
+
+// openFile opens filename and removes any compression layer.
+func openFile(filename string) (io.ReadCloser, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+	r, err := Decompress(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error decompressing %s: %v", filename, err)
+	}
+	return decompressedFile{Reader: r, file: file}, nil
+}
+
+type decompressedFile struct {
+	io.Reader
+	file *os.File
+}
+
+func (f decompressedFile) Close() error {
+	return f.file.Close()
+}
+
+// =========================================================================
+
+// =========================================================================
+//  UNIX COMPRESS (.Z)
+// compress/lzw implements the GIF/TIFF variant, .Z files need their own
+// decoder. Header: 1F 9D, then a byte holding the maximum code width (bits
+// 0-4) and the block mode flag (0x80, code 256 clears the table).
+// Codes are packed LSB first in groups of eight, a group of n bit codes
+// takes n bytes. When the code width grows or the table is cleared the rest
+// of the current group is padding.
+
+const (
+	lzwInitBits = 9
+	lzwClear    = 256
+)
+
+type lzwReader struct {
+	r         *bufio.Reader
+	maxBits   uint
+	blockMode bool
+
+	bits    uint
+	maxCode int
+	next    int
+	prefix  []int
+	suffix  []byte
+	oldCode int
+	last    byte
+
+	group    []byte
+	groupLen int // codes in group
+	groupPos int
+
+	stack   []byte
+	pending []byte
+	err     error
+}
+
+func newLZWReader(r *bufio.Reader) (*lzwReader, error) {
+	header := make([]byte, 3)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("failed to read compress header: %v", err)
+	}
+	maxBits := uint(header[2] & 0x1f)
+	if maxBits < lzwInitBits || maxBits > 16 {
+		return nil, fmt.Errorf("unsupported compress code width %d", maxBits)
+	}
+	z := &lzwReader{
+		r:         r,
+		maxBits:   maxBits,
+		blockMode: header[2]&0x80 != 0,
+		prefix:    make([]int, 1<<maxBits),
+		suffix:    make([]byte, 1<<maxBits),
+		oldCode:   -1,
+	}
+	for i := 0; i < 256; i++ {
+		z.suffix[i] = byte(i)
+	}
+	z.reset()
+	return z, nil
+}
+
+func (z *lzwReader) reset() {
+	z.bits = lzwInitBits
+	z.maxCode = 1<<z.bits - 1
+	z.next = 256
+	if z.blockMode {
+		z.next = 257
+	}
+}
+
+// readCode returns the next code, io.EOF once the input is exhausted.
+func (z *lzwReader) readCode() (int, error) {
+	if z.groupPos == z.groupLen {
+		if z.group == nil || len(z.group) != int(z.bits) {
+			z.group = make([]byte, z.bits)
+		}
+		n, err := io.ReadFull(z.r, z.group)
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return 0, err
+		}
+		z.groupLen = n * 8 / int(z.bits)
+		z.groupPos = 0
+		if z.groupLen == 0 {
+			return 0, io.EOF
+		}
+	}
+	bit := uint(z.groupPos) * z.bits
+	code := 0
+	for i := uint(0); i < z.bits; i++ {
+		b := bit + i
+		if z.group[b/8]&(1<<(b%8)) != 0 {
+			code |= 1 << i
+		}
+	}
+	z.groupPos++
+	return code, nil
+}
+
+// skipGroup drops the padding after a code width change or a clear code.
+func (z *lzwReader) skipGroup() {
+	z.groupPos = z.groupLen
+}
+
+func (z *lzwReader) decode() error {
+	if z.next > z.maxCode && z.bits < z.maxBits {
+		z.skipGroup()
+		z.bits++
+		z.maxCode = 1<<z.bits - 1
+	}
+
+	code, err := z.readCode()
+	if err != nil {
+		return err
+	}
+
+	if z.oldCode == -1 {
+		if code >= 256 {
+			return fmt.Errorf("invalid first compress code %d", code)
+		}
+		z.oldCode = code
+		z.last = byte(code)
+		z.pending = append(z.pending, z.last)
+		return nil
+	}
+
+	if code == lzwClear && z.blockMode {
+		z.skipGroup()
+		z.reset()
+		// the entry following a clear is never referenced, 256 is taken
+		z.next = 256
+		return nil
+	}
+
+	in := code
+	z.stack = z.stack[:0]
+	if code >= z.next {
+		if code > z.next {
+			return fmt.Errorf("invalid compress code %d", code)
+		}
+		z.stack = append(z.stack, z.last)
+		code = z.oldCode
+	}
+	for code >= 256 {
+		z.stack = append(z.stack, z.suffix[code])
+		code = z.prefix[code]
+	}
+	z.last = z.suffix[code]
+	z.stack = append(z.stack, z.last)
+	for i := len(z.stack) - 1; i >= 0; i-- {
+		z.pending = append(z.pending, z.stack[i])
+	}
+
+	if z.next < 1<<z.maxBits {
+		z.prefix[z.next] = z.oldCode
+		z.suffix[z.next] = z.last
+		z.next++
+	}
+	z.oldCode = in
+	return nil
+}
+
+func (z *lzwReader) Read(p []byte) (int, error) {
+	for len(z.pending) == 0 && z.err == nil {
+		z.err = z.decode()
+	}
+	if len(z.pending) > 0 {
+		n := copy(p, z.pending)
+		z.pending = z.pending[n:]
+		return n, nil
+	}
+	if errors.Is(z.err, io.EOF) {
+		return 0, io.EOF
+	}
+	return 0, fmt.Errorf("failed to decompress: %v", z.err)
+}
@@ -0,0 +1,140 @@
+package gnss
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// The .Z fixtures were written with a separate LZW encoder and checked with
+// gzip -d, the .crx ones with a separate CRINEX encoder.
+
+func decompressFile(tb testing.TB, path string) []byte {
+	tb.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer file.Close()
+	r, err := Decompress(file)
+	if err != nil {
+		tb.Fatalf("%s: %v", path, err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		tb.Fatalf("%s: %v", path, err)
+	}
+	return data
+}
+
+func readTestFile(tb testing.TB, path string) []byte {
+	tb.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return data
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func TestDecompress(t *testing.T) {
+	for _, c := range []struct{ compressed, plain string }{
+		{"testdata/obs-211.24o", "testdata/obs-211.24o"},
+		{"testdata/obs-211.24o.Z", "testdata/obs-211.24o"},
+		{"testdata/obs-211.crx", "testdata/obs-211.24o"},
+		{"testdata/obs-304.crx", "testdata/obs-304.24o"},
+		{"testdata/obs-304.crx.Z", "testdata/obs-304.24o"},
+	} {
+		if got, want := decompressFile(t, c.compressed), readTestFile(t, c.plain); !bytes.Equal(got, want) {
+			t.Errorf("%s: %d bytes differ from %s (%d bytes)", c.compressed, len(got), c.plain, len(want))
+		}
+	}
+}
+
+func TestDecompressGzip(t *testing.T) {
+	for _, c := range []struct{ compressed, plain string }{
+		{"testdata/obs-304.24o", "testdata/obs-304.24o"},
+		{"testdata/obs-211.crx", "testdata/obs-211.24o"},
+	} {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		gz.Write(readTestFile(t, c.compressed))
+		gz.Close()
+
+		r, err := Decompress(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := readTestFile(t, c.plain); !bytes.Equal(got, want) {
+			t.Errorf("%s.gz: %d bytes differ from %s (%d bytes)", c.compressed, len(got), c.plain, len(want))
+		}
+	}
+}
+
+// The file based parsers read compressed files as the plain ones.
+func TestParseCompressedObservation(t *testing.T) {
+	_, want, err := ParseRINEXObservationFile("testdata/obs-211.24o")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"testdata/obs-211.24o.Z", "testdata/obs-211.crx"} {
+		_, got, err := ParseRINEXObservationFile(path)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%s: %d epochs, want %d", path, len(got), len(want))
+		}
+		for i := range got {
+			if !reflect.DeepEqual(epochSummary(got[i]), epochSummary(want[i])) {
+				t.Errorf("%s: epoch %d differs", path, i)
+			}
+		}
+	}
+}
+
+func TestDecompressCorrupt(t *testing.T) {
+	if _, err := Decompress(bytes.NewReader([]byte{0x1f, 0x8b, 0})); err == nil {
+		t.Error("truncated gzip header accepted")
+	}
+	if _, err := Decompress(bytes.NewReader([]byte{0x1f, 0x9d, 0x91})); err == nil {
+		t.Error("17 bit compress stream accepted")
+	}
+	// a first code that is not a literal byte
+	r, err := Decompress(bytes.NewReader([]byte{0x1f, 0x9d, 0x90, 0x2c, 0x01}))
+	if err == nil {
+		_, err = io.ReadAll(r)
+	}
+	if err == nil {
+		t.Error("invalid compress code read without error")
+	}
+}
+
+// epochSummary lists the satellites and measurements of an epoch.
+func epochSummary(e ObservationEpoch) []string {
+	var s []string
+	satellites, _ := e.Satellites()
+	for i := 0; i < satellites.Len(); i++ {
+		sat := satellites.At(i)
+		prn, _ := sat.Prn()
+		measurements, _ := sat.Measurements()
+		for j := 0; j < measurements.Len(); j++ {
+			m := measurements.At(j)
+			code, _ := m.Code()
+			s = append(s, fmt.Sprintf("%s %s %v %v %d %d", prn, code, m.HasValue(), m.Value(),
+				m.LossOfLockIndicator(), m.SignalStrength()))
+		}
+	}
+	return s
+}
@@ -1,18 +1,13 @@
 package gnss
 
 import (
-	"bufio"
 	math "math"
 
 	"fmt"
 
 	"errors"
-	"os"
 	"strconv"
 	"strings"
-	"time"
-
-	"capnproto.org/go/capnp/v3"
 )
 
 // type EphemerisType int
@@ -52,6 +47,12 @@ func (g *GPSEphemeris) DebugString() string {
 
 		fileName, err := BaseEphemeris.FileName()
 		result += fmt.Sprintf("   FileName:%s (Error: %v)\n", fileName, err)
+
+		fileEpoch, err := BaseEphemeris.FileEpoch()
+		result += fmt.Sprintf("   FileEpoch:%v (Error: %v)\n", fileEpoch, err)
+
+		fileSource, err := BaseEphemeris.FileSource()
+		result += fmt.Sprintf("   FileSource:%s (Error: %v)\n", fileSource, err)
 	} else {
 		result += fmt.Sprintf("BaseEphemeris: Error: %v\n", err)
 	}
@@ -63,34 +64,13 @@ func (g *GPSEphemeris) DebugString() string {
 
 // =========================================================================
 
-// func (e *BaseEphemeris) Valid(time GPSTime) bool {
-// 	return math.Abs(time.Sub(e.Epoch)) <= e.MaxTimeDiff
-// }
-
-// =========================================================================
-
-// =========================================================================
-
-// func NewGPSEphemeris(eph Ephemeris, fileName string) *GPSEphemeris {
-// 	toe := NewGPSTime(int(eph.ToeWeek()), float64(eph.Toe()))
-// 	toc := NewGPSTime(int(eph.TimeOfClockWeek()), float64(eph.TimeOfClock()))
-// 	epoch := toc
-
-// 	return &GPSEphemeris{
-// 		BaseEphemeris: BaseEphemeris{
-// 			PRN:         fmt.Sprintf("G%02d", eph.SatelliteId()),
-// 			Epoch:       epoch,
-// 			EphType:     NAV,
-// 			Healthy:     eph.SatelliteHealth() == 0,
-// 			MaxTimeDiff: 2 * SECS_IN_HR,
-// 			FileName:    fileName,
-// 		},
-// 		Ephemeris: eph,
-// 		TOE:       toe,
-// 		TOC:       toc,
-// 		SqrtA:     math.Sqrt(eph.SemiMajorAxis()),
-// 	}
-// }
+func (e BaseEphemeris) Valid(time GPSTime) bool {
+	epoch, err := e.Epoch()
+	if err != nil {
+		return false
+	}
+	return math.Abs(time.Sub(epoch)) <= e.MaximumTimeDifference()
+}
 
 // =========================================================================
 
@@ -99,8 +79,6 @@ func (g *GPSEphemeris) DebugString() string {
 // # http://gauss.gge.unb.ca/GLONASS.ICD.pdf
 
 func (e GPSEphemeris) GetSatInfo(time GPSTime) ([]float64, []float64, float64, float64, error) {
-	fmt.Println("GetSatInfo==========")
-
 	ephData, err := e.EphemerisData()
 	if err != nil {
 		return nil, nil, 0, 0, fmt.Errorf("failed to get ephemeris data: %v", err)
@@ -125,72 +103,14 @@ func (e GPSEphemeris) GetSatInfo(time GPSTime) ([]float64, []float64, float64, f
 		return nil, nil, 0, 0, fmt.Errorf("failed to get time of ephemeris: %v", err)
 	}
 
-	tdiff := time.Sub(toc)
-	clockErr := ephData.Af0() + tdiff*(ephData.Af1()+tdiff*ephData.Af2())
-	clockRateErr := ephData.Af1() + 2*tdiff*ephData.Af2()
-
-	tdiff = time.Sub(toe)
-
-	sqrtA := e.SquareRootOfSemiMajorAxis()
-	a := sqrtA * sqrtA
-	maDot := math.Sqrt(EARTH_GM/(a*a*a)) + ephData.DeltaN()
-	ma := ephData.M0() + maDot*tdiff
-
-	ea := ma
-	eaOld := 2222.0
-	for math.Abs(ea-eaOld) > 1.0e-14 {
-		eaOld = ea
-		ea = ea + (ma-eaOld+ephData.Ecc()*math.Sin(eaOld))/(1.0-ephData.Ecc()*math.Cos(eaOld))
+	orbit := keplerOrbit{
+		sqrtA:     e.SquareRootOfSemiMajorAxis(),
+		aDot:      e.ADot(),
+		deltaNDot: e.DeltaNDot(),
+		gm:        EARTH_GM,
+		omegaE:    EARTH_ROTATION_RATE,
 	}
-
-	eaDot := maDot / (1.0 - ephData.Ecc()*math.Cos(ea))
-
-	// Relativistic correction term
-	einstein := -4.442807633e-10 * ephData.Ecc() * sqrtA * math.Sin(ea)
-
-	// Begin calc for True Anomaly and Argument of Latitude
-	tempd2 := math.Sqrt(1.0 - ephData.Ecc()*ephData.Ecc())
-	al := math.Atan2(tempd2*math.Sin(ea), math.Cos(ea)-ephData.Ecc()) + ephData.Omega()
-	alDot := tempd2 * eaDot / (1.0 - ephData.Ecc()*math.Cos(ea))
-
-	// Calculate corrected argument of latitude based on position
-	cal := al + ephData.Cus()*math.Sin(2.0*al) + ephData.Cuc()*math.Cos(2.0*al)
-	calDot := alDot * (1.0 + 2.0*(ephData.Cus()*math.Cos(2.0*al)-ephData.Cuc()*math.Sin(2.0*al)))
-
-	// Calculate corrected radius based on argument of latitude
-	r := a*(1.0-ephData.Ecc()*math.Cos(ea)) + ephData.Crc()*math.Cos(2.0*al) + ephData.Crs()*math.Sin(2.0*al)
-	rDot := a*ephData.Ecc()*math.Sin(ea)*eaDot + 2.0*alDot*(ephData.Crs()*math.Cos(2.0*al)-ephData.Crc()*math.Sin(2.0*al))
-
-	// Calculate inclination based on argument of latitude
-	inc := ephData.I0() + ephData.IDot()*tdiff + ephData.Cic()*math.Cos(2.0*al) + ephData.Cis()*math.Sin(2.0*al)
-	incDot := ephData.IDot() + 2.0*alDot*(ephData.Cis()*math.Cos(2.0*al)-ephData.Cic()*math.Sin(2.0*al))
-
-	// Calculate position and velocity in orbital plane
-	x := r * math.Cos(cal)
-	y := r * math.Sin(cal)
-	xDot := rDot*math.Cos(cal) - y*calDot
-	yDot := rDot*math.Sin(cal) + x*calDot
-
-	// Corrected longitude of ascending node
-	omDot := ephData.OmegaDot() - EARTH_ROTATION_RATE
-	om := ephData.Omega0() + tdiff*omDot - EARTH_ROTATION_RATE*toe.TimeOfWeek()
-
-	// Compute the satellite's position in Earth-Centered Earth-Fixed coordinates
-	pos := make([]float64, 3)
-	pos[0] = x*math.Cos(om) - y*math.Cos(inc)*math.Sin(om)
-	pos[1] = x*math.Sin(om) + y*math.Cos(inc)*math.Cos(om)
-	pos[2] = y * math.Sin(inc)
-
-	tempd3 := yDot*math.Cos(inc) - y*math.Sin(inc)*incDot
-
-	// Compute the satellite's velocity in Earth-Centered Earth-Fixed coordinates
-	vel := make([]float64, 3)
-	vel[0] = -omDot*pos[1] + xDot*math.Cos(om) - tempd3*math.Sin(om)
-	vel[1] = omDot*pos[0] + xDot*math.Sin(om) + tempd3*math.Cos(om)
-	vel[2] = y*math.Cos(inc)*incDot + yDot*math.Sin(inc)
-
-	clockErr += einstein
-
+	pos, vel, clockErr, clockRateErr := orbit.satInfo(ephData, toc, toe, time)
 	return pos, vel, clockErr, clockRateErr, nil
 }
 
@@ -198,93 +118,45 @@ func (e GPSEphemeris) GetSatInfo(time GPSTime) ([]float64, []float64, float64, f
 
 // =========================================================================
 
-// func SortEphemerisBySatelliteID(ephs []*RINEXEphemeris) []GroupedEphemerides {
-// 	ephemerisMap := make(map[int][]*RINEXEphemeris)
-
-// 	for _, eph := range ephs {
-// 		singleEph := RINEXEphemeris{
-// 			SatelliteID:           eph.SatelliteID,
-// 			Epoch:                 eph.Epoch,
-// 			ClockBias:             eph.ClockBias,
-// 			RelativeFreqBias:      eph.RelativeFreqBias,
-// 			MessageFrameTime:      eph.MessageFrameTime,
-// 			PositionX:             eph.PositionX,
-// 			VelocityX:             eph.VelocityX,
-// 			AccelerationX:         eph.AccelerationX,
-// 			PositionY:             eph.PositionY,
-// 			VelocityY:             eph.VelocityY,
-// 			AccelerationY:         eph.AccelerationY,
-// 			PositionZ:             eph.PositionZ,
-// 			VelocityZ:             eph.VelocityZ,
-// 			AccelerationZ:         eph.AccelerationZ,
-// 			Health:                eph.Health,
-// 			FrequencyChannelOfSet: eph.FrequencyChannelOfSet,
-// 			InformationAge:        eph.InformationAge,
-// 		}
-
-// 		ephemerisMap[eph.SatelliteID] = append(ephemerisMap[eph.SatelliteID], &singleEph)
-
-// 	}
-// 	var groupedEphemerides []GroupedEphemerides
-
-// 	var satelliteIDs []int
-// 	for id := range ephemerisMap {
-// 		satelliteIDs = append(satelliteIDs, id)
-// 	}
-// 	sort.Ints(satelliteIDs)
-
-// 	for _, id := range satelliteIDs {
-// 		group := ephemerisMap[id]
-
-// 		sort.Slice(group, func(i, j int) bool {
-// 			return group[i].Epoch.Before(group[j].Epoch)
-// 		})
-
-// 		groupedEphemerides = append(groupedEphemerides, GroupedEphemerides{
-// 			SatelliteID:       id,
-// 			SortedEphemerides: group,
-// 		})
-// 	}
-
-// 	return groupedEphemerides
-// }
-
-// =========================================================================
-
-// =========================================================================
-func parseRINEXHeader(scanner *bufio.Scanner) (RINEXHeader, error) {
-	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
-	if err != nil {
-		return RINEXHeader{}, fmt.Errorf("failed to create new message: %v", err)
-	}
-	fmt.Println(msg)
-	header, err := NewRINEXHeader(seg)
-	if err != nil {
-		return RINEXHeader{}, fmt.Errorf("failed to create new RINEXHeader: %v", err)
-	}
-
+// parseRINEXHeader fills header, allocated by the caller in the message of
+// the file.
+func parseRINEXHeader(scanner *lineScanner, header RINEXHeader) error {
 	var comments []string
+	var corrections navHeaderCorrections
 
 	for scanner.Scan() {
 		line := scanner.Text()
 		if len(line) < 60 {
 			continue
 		}
+		record := scanner.newRecord()
+		record.add(line)
 		label := strings.TrimRight(line[60:], " ")
+		if corrections.parseLine(header, label, record) {
+			if record.err != nil {
+				return record.err
+			}
+			continue
+		}
 		switch label {
 		case "RINEX VERSION / TYPE":
-			version, _ := strconv.ParseFloat(strings.TrimSpace(line[:20]), 64)
-			header.SetVersion(version)
+			header.SetVersion(record.float(0, 0, 20))
+			if record.err != nil {
+				return record.err
+			}
 			header.SetType(strings.TrimSpace(line[20:40]))
 			header.SetSatelliteSystem(strings.TrimSpace(line[40:60]))
 		case "PGM / RUN BY / DATE":
 			header.SetProgramName(strings.TrimSpace(line[:20]))
 			header.SetAgency(strings.TrimSpace(line[20:40]))
-			parsedDate, _ := time.Parse("02-Jan-06 15:04", strings.TrimSpace(line[40:60]))
+			parsedDate, ok := parseHeaderDate(strings.TrimSpace(line[40:60]))
+			if !ok {
+				continue
+			}
 
-			newDate, err := NewTime(seg)
+			newDate, err := header.NewDate()
 			if err != nil {
-				return RINEXHeader{}, fmt.Errorf("failed to create new Time struct: %v", err)
+				return fmt.Errorf("failed to create new Time struct: %v", err)
 			}
 
 			unixTime := parsedDate.Unix()
@@ -292,102 +164,91 @@ func parseRINEXHeader(scanner *bufio.Scanner) (RINEXHeader, error) {
 
 			newDate.SetSeconds(unixTime)
 			newDate.SetNanoseconds(int32(nanos))
-
-			if err := header.SetDate(newDate); err != nil {
-				return RINEXHeader{}, fmt.Errorf("failed to set Date: %v", err)
-			}
 		case "COMMENT":
 			comments = append(comments, strings.TrimSpace(line[:60]))
 		case "END OF HEADER":
 			commentList, err := header.NewComments(int32(len(comments)))
 			if err != nil {
-				return RINEXHeader{}, fmt.Errorf("failed to create new Comments: %v", err)
+				return fmt.Errorf("failed to create new Comments: %v", err)
 			}
 			for i, comment := range comments {
 				commentList.Set(i, comment)
 			}
 			header.SetComments(commentList)
-			return header, nil
+			if err := corrections.set(header); err != nil {
+				return err
+			}
+			return nil
 		}
 	}
-	return RINEXHeader{}, fmt.Errorf("end of header not found")
+	return fmt.Errorf("end of header not found")
 }
 
 // =========================================================================
 
 // =========================================================================
 
+// ParseRINEXFileV201 reads a RINEX 2 GLONASS navigation file, see ParseNav
+// for the reader based, configurable variant.
 func ParseRINEXFileV201(filename string) (*RINEXHeader, []RINEXEphemeris, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, nil, fmt.Errorf("error opening file: %v", err)
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	header, err := parseRINEXHeader(scanner)
-	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing header: %v", err)
-	}
-
-	ephemerides, err := parseRINEXEphemeris(scanner)
+	nav, err := ParseNavFile(filename, ParseOptions{})
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing ephemerides: %v", err)
+		return nil, nil, err
 	}
-
-	return &header, ephemerides, nil
+	return &nav.Header, nav.GLONASS, nil
 }
 
 // =========================================================================
 
 // =========================================================================
-func parseRINEXEphemeris(scanner *bufio.Scanner) ([]RINEXEphemeris, error) {
-	var ephemerides []RINEXEphemeris
-	var currentLines []string
-	lineCount := 0
+func parseRINEXEphemeris(scanner *lineScanner) ([]navRecord, error) {
+	var ephemerides []navRecord
+	record := scanner.newRecord()
+
+	flush := func() error {
+		if record.len() == 0 {
+			return nil
+		}
+		current := record
+		record = scanner.newRecord()
+		eph, err := processEphemerisLines(current)
+		if err != nil {
+			return scanner.recordError(current, err)
+		}
+		ephemerides = append(ephemerides, eph)
+		return nil
+	}
 
 	for scanner.Scan() {
 		line := scanner.Text()
-		lineCount++
 
 		if len(line) == 0 {
-			fmt.Printf("Debug: Skipping empty line %d\n", lineCount)
+			scanner.debug("skipping empty line", "line", scanner.line)
 			continue
 		}
 
-		if len(line) > 0 && line[0] >= '1' && line[0] <= '9' {
-			if len(currentLines) > 0 {
-				eph, err := processEphemerisLines(currentLines)
-				if err != nil {
-					return nil, fmt.Errorf("error processing ephemeris lines: %v", err)
-				}
-				ephemerides = append(ephemerides, eph)
-				fmt.Printf("Debug: Processed ephemeris for satellite %d\n", eph.SatelliteId())
+		if line[0] >= '1' && line[0] <= '9' {
+			if err := flush(); err != nil {
+				return nil, err
 			}
-			currentLines = []string{line}
-		} else {
-			currentLines = append(currentLines, line)
 		}
+		record.add(line)
 
-		if len(currentLines) == 4 {
-			eph, err := processEphemerisLines(currentLines)
-			if err != nil {
-				return nil, fmt.Errorf("error processing ephemeris lines: %v", err)
+		if record.len() == 4 {
+			if err := flush(); err != nil {
+				return nil, err
 			}
-			ephemerides = append(ephemerides, eph)
-			currentLines = nil
 		}
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 
-	if len(currentLines) > 0 {
-		eph, err := processEphemerisLines(currentLines)
-		if err != nil {
-			return nil, fmt.Errorf("error processing ephemeris lines: %v", err)
-		}
-		ephemerides = append(ephemerides, eph)
+	if err := flush(); err != nil {
+		return nil, err
 	}
 
-	fmt.Printf("Debug: Total ephemerides parsed: %d\n", len(ephemerides))
+	scanner.debug("parsed GLONASS ephemerides", "count", len(ephemerides))
 	return ephemerides, nil
 }
 
@@ -427,164 +288,45 @@ func parseRINEXEphemeris(scanner *bufio.Scanner) ([]RINEXEphemeris, error) {
 // - Age of operation: 0 days
 
 // lines are a maximum of 80 characters long , NEED TO BE KEPT AT 79 CHARACTERS
-func processEphemerisLines(lines []string) (RINEXEphemeris, error) {
+func processEphemerisLines(record *lineRecord) (navRecord, error) {
+	lines := record.lines
 	if len(lines) != 4 {
-		return RINEXEphemeris{}, fmt.Errorf("invalid number of lines for ephemeris record: %d", len(lines))
-	}
-
-	// Create a new RINEXEphemeris
-	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
-	if err != nil {
-		return RINEXEphemeris{}, fmt.Errorf("failed to create new message: %v", err)
-	}
-	fmt.Println(msg)
-	eph, err := NewRootRINEXEphemeris(seg)
-	if err != nil {
-		return RINEXEphemeris{}, fmt.Errorf("failed to create new RINEXEphemeris: %v", err)
+		return navRecord{}, fmt.Errorf("invalid number of lines for ephemeris record: %d", len(lines))
 	}
 
 	if len(lines[0]) < 79 {
-		return RINEXEphemeris{}, fmt.Errorf("line 1 is too short: %d characters", len(lines[0]))
-	}
-
-	eph.SetSatelliteId(int32(parseInt(lines[0][0:2])))
-	year := parseInt(lines[0][3:5])
-
-	if year < 100 {
-		if year < 80 {
-			year += 2000
-		} else {
-			year += 1900
-		}
-	}
-
-	month := parseInt(lines[0][6:8])
-	day := parseInt(lines[0][9:11])
-	hour := parseInt(lines[0][12:14])
-	min := parseInt(lines[0][16:17])
-	sec := parseFloat(lines[0][19:22])
-
-	epochTime := time.Date(year, time.Month(month), day, hour, min, int(sec), int((sec-float64(int(sec)))*1e9), time.UTC)
-	epochCapnp, err := NewTime(seg)
-	if err != nil {
-		return RINEXEphemeris{}, fmt.Errorf("failed to create new Time: %v", err)
+		return navRecord{}, fmt.Errorf("line 1 is too short: %d characters", len(lines[0]))
 	}
-	epochCapnp.SetSeconds(epochTime.Unix())
-	epochCapnp.SetNanoseconds(int32(epochTime.Nanosecond()))
-	eph.SetEpoch(epochCapnp)
 
-	eph.SetClockBias(parseFloat(lines[0][23:41]))
-	eph.SetRelativeFrequencyBias(parseFloat(lines[0][42:61]))
-	eph.SetMessageFrameTime(parseFloat(lines[0][61:79]))
+	var svId int
+	epoch := epochFields{shortYear: true}
+	record.decode(0, navEpochV2, append([]any{&svId}, epoch.dst()...)...)
+	epochTime := epoch.time()
 
-	for i := 1; i < 4; i++ {
-		if len(lines[i]) < 79 {
-			fmt.Printf("Debug: Line %d is short: %d characters\n", i+1, len(lines[i]))
-			continue
-		}
-		switch i {
-		case 1:
-			eph.SetPositionX(parseFloat(lines[i][3:22]))
-			eph.SetVelocityX(parseFloat(lines[i][22:41]))
-			eph.SetAccelerationX(parseFloat(lines[i][41:59]))
-			eph.SetHealth(parseFloat(lines[i][61:79]))
-		case 2:
-			eph.SetPositionY(parseFloat(lines[i][3:22]))
-			eph.SetVelocityY(parseFloat(lines[i][22:41]))
-			eph.SetAccelerationY(parseFloat(lines[i][41:59]))
-			freqNum := parseFloat(lines[i][60:79])
-			eph.SetFrequencyChannelOffset(int32(freqNum))
-		case 3:
-			eph.SetPositionZ(parseFloat(lines[i][3:22]))
-			eph.SetVelocityZ(parseFloat(lines[i][22:41]))
-			eph.SetAccelerationZ(parseFloat(lines[i][41:59]))
-			eph.SetInformationAge(parseFloat(lines[i][61:79]))
-		}
+	// Values are D19.12 fields, the sign sits in the first column of each
+	values := record.orbitValues(3)
+	if record.err != nil {
+		return navRecord{}, record.err
 	}
-
-	return eph, nil
+	return navRecord{system: 'R', svId: svId, epoch: epochTime, messageType: NavMessageType_fdma, values: values}, nil
 }
 
-// =========================================================================
-
-// =========================================================================
-
-// TODO CREATE PARSER  FOR RINEX SP3 FILE
-// https://files.igs.org/pub/data/format/sp3c.txt#:~:text=The%20basic%20format%20of%20an,correction%20rate%2Dof%2Dchange.
-// https://files.igs.org/pub/data/format/sp3c.txt
-// https://files.igs.org/pub/data/format/sp3d.pdf
-
-//  AFTER THE HEADER THERE ARE SATELITE ID  LINES  PG01, PJ02 , PC01, PE01,
-//  THOSE CORESPOND WITH
-// G: GPS (US)
-// R: GLONASS (Russia)
-// E: Galileo (EU)
-// C: BeiDou (China)
-// J: QZSS (Japan)
-
-// func ParseSP3FormatEphemeris(filename string) (*SP3FormatEphemeris, error) {
-// 	file, err := os.Open(filename)
-// 	if err != nil {
-// 		return nil, fmt.Errorf("error opening file: %v", err)
-// 	}
-// 	defer file.Close()
-
-// 	scanner := bufio.NewScanner(file)
-// 	SP3FormatEphemeris := &SP3FormatEphemeris{
-// 		Header: SP3Header{},
-// 		Epochs: []SP3Epoch{},
-// 	}
-// 	// var currentEpoch *SP3Epoch
-// 	lineCount := 0
-
-// 	for scanner.Scan() {
-// 		line := scanner.Text()
-// 		lineCount++
-
-// 		if lineCount <= 27 {
-// 			if err := parseHeaderLine(line, &SP3FormatEphemeris.Header, lineCount); err != nil {
-// 				return nil, fmt.Errorf("error parsing header line: %v", err)
-// 			}
-// 		}
-// 	}
-
-// 	return SP3FormatEphemeris, nil
-// }
-
-// func parseHeaderLine(line string, header *SP3Header, lineNumber int) error {
-// 	switch {
-// 	case lineNumber == 1:
-// 		return parseFirstHeaderLine(line, header)
-// 	case lineNumber == 2:
-// 		return parseSecondLine(line, header)
-// 	case strings.HasPrefix(line, "++"):
-
-// 	}
-// 	return nil
-// }
-
-// func parseFirstHeaderLine(line string, header *SP3Header) error {
-// 	fmt.Println(line)
-// 	// starTimeStr := strings.Join(fields[2:8], " ")
-// 	// fmt.Println(starTimeStr)
-
-// 	return nil
-// }
-
-// func parseSecondLine(line string, header *SP3Header) error {
-// 	fmt.Println(line)
-// 	return nil
-// }
-
 // =========================================================================
 //  HELPERS
 // =========================================================================
 
+// parseFloat and parseInt read malformed fields as zero, parsers that report
+// errors use parseFloatField and parseIntField through lineRecord.
 func parseFloat(s string) float64 {
+	f, _ := parseFloatField(s)
+	return f
+}
+
+func parseFloatField(s string) (float64, error) {
 	s = strings.TrimSpace(s)
 	s = strings.ReplaceAll(s, "D", "E")
 	if s == "" {
-		return 0
+		return 0, nil
 	}
 
 	if strings.HasPrefix(s, "-") {
@@ -595,10 +337,9 @@ func parseFloat(s string) float64 {
 
 	f, err := strconv.ParseFloat(s, 64)
 	if err != nil {
-		fmt.Printf("Debug: Error parsing float: %s, error: %v\n", s, err)
-		return 0
+		return 0, fmt.Errorf("invalid float %q", s)
 	}
-	return f
+	return f, nil
 }
 
 // =========================================================================
@@ -606,43 +347,69 @@ func parseFloat(s string) float64 {
 // =========================================================================
 
 func parseInt(s string) int {
+	i, _ := parseIntField(s)
+	return i
+}
+
+func parseIntField(s string) (int, error) {
 	s = strings.TrimSpace(s)
 	if s == "" {
-		return 0
+		return 0, nil
 	}
 	i, err := strconv.Atoi(s)
 	if err != nil {
-		fmt.Printf("Debug: Error parsing integer: %s, error: %v\n", s, err)
-		return 0
+		return 0, fmt.Errorf("invalid integer %q", s)
 	}
-	return i
+	return i, nil
 }
 
 // =========================================================================
 
 // =========================================================================
 
-// func PrintSortedEphemerides(sorted []GroupedEphemerides) {
-// 	fmt.Println("Grouped Ephemerides Summary:")
-// 	for _, group := range sorted {
-// 		fmt.Printf("Satellite ID: %d\n", group.SatelliteID)
-// 		fmt.Printf("  Number of Ephemerides: %d\n", len(group.SortedEphemerides))
-
-// 		if len(group.SortedEphemerides) > 0 {
-// 			firstEph := group.SortedEphemerides[0]
-// 			lastEph := group.SortedEphemerides[len(group.SortedEphemerides)-1]
-
-// 			fmt.Printf("  Time Range: %s to %s\n",
-// 				formatTime(firstEph.Epoch),
-// 				formatTime(lastEph.Epoch))
-
-// 			fmt.Printf("  First Ephemeris Data:\n")
-// 			printData(firstEph)
-// 		}
+// PrintSortedEphemerides prints a summary of the groups of
+// SortEphemerisBySatelliteID or GroupEphemerides.
+func PrintSortedEphemerides(sorted []GroupedEphemerides) {
+	fmt.Println("Grouped Ephemerides Summary:")
+	for _, group := range sorted {
+		prn, _ := group.Prn()
+		fmt.Printf("Satellite: %s (ID %d)\n", prn, group.SatelliteId())
+
+		ephs, err := group.SortedEphemerides()
+		if err == nil && ephs.Len() > 0 {
+			fmt.Printf("  Number of Ephemerides: %d\n", ephs.Len())
+
+			firstEph := ephs.At(0)
+			lastEph := ephs.At(ephs.Len() - 1)
+			firstEpoch, _ := firstEph.Epoch()
+			lastEpoch, _ := lastEph.Epoch()
+
+			fmt.Printf("  Time Range: %s to %s\n",
+				formatTime(firstEpoch),
+				formatTime(lastEpoch))
+
+			fmt.Printf("  First Ephemeris Data:\n")
+			PrintRINEXEphemeris(firstEph)
+		} else {
+			count := 0
+			if list, err := group.Gps(); err == nil {
+				count += list.Len()
+			}
+			if list, err := group.Galileo(); err == nil {
+				count += list.Len()
+			}
+			if list, err := group.BeiDou(); err == nil {
+				count += list.Len()
+			}
+			if list, err := group.Sbas(); err == nil {
+				count += list.Len()
+			}
+			fmt.Printf("  Number of Ephemerides: %d\n", count)
+		}
 
-// 		fmt.Println(strings.Repeat("-", 60))
-// 	}
-// }
+		fmt.Println(strings.Repeat("-", 60))
+	}
+}
 
 // =========================================================================
 
@@ -683,7 +450,30 @@ func PrintRINEXHeader(header RINEXHeader) {
 		fmt.Printf("    Error retrieving comments: %v\n", err)
 	}
 
-	fmt.Printf("  Leap Seconds: %d\n", header.LeapSeconds())
+	fmt.Printf("  Leap Seconds: %d (future %d, week %d, day %d)\n", header.LeapSeconds(),
+		header.FutureLeapSeconds(), header.LeapSecondsWeek(), header.LeapSecondsDay())
+
+	if corrections, err := header.IonosphericCorrections(); err == nil {
+		for i := 0; i < corrections.Len(); i++ {
+			corr := corrections.At(i)
+			typ, _ := corr.CorrectionType()
+			fmt.Printf("  Ionospheric Correction %s:", typ)
+			if parameters, err := corr.Parameters(); err == nil {
+				for j := 0; j < parameters.Len(); j++ {
+					fmt.Printf(" %e", parameters.At(j))
+				}
+			}
+			fmt.Println()
+		}
+	}
+	if corrections, err := header.TimeSystemCorrections(); err == nil {
+		for i := 0; i < corrections.Len(); i++ {
+			corr := corrections.At(i)
+			typ, _ := corr.CorrectionType()
+			fmt.Printf("  Time System Correction %s: A0 %e A1 %e T %d W %d\n", typ,
+				corr.A0(), corr.A1(), corr.ReferenceTime(), corr.ReferenceWeek())
+		}
+	}
 	fmt.Println(strings.Repeat("-", 60))
 }
 
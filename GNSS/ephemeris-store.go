@@ -0,0 +1,254 @@
+package gnss
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  EPHEMERIS STORE
+// Collects broadcast and precise ephemerides of any number of files, keyed by
+// PRN and sorted by epoch. Broadcasts received from several stations or
+// files are kept once per PRN, IODE and toe (GLONASS: tb).
+//
+// Selection at a given time only considers valid and healthy ephemerides, the
+// best ranked product wins and ties go to the epoch closest to the time:
+//   FINAL_ORBIT > RAPID_ORBIT > ULTRA_RAPID_ORBIT > NAV
+
+type EphemerisStore struct {
+	ephemerides map[string][]SatelliteEphemeris
+	keys        map[string]struct{}
+}
+
+func NewEphemerisStore() *EphemerisStore {
+	return &EphemerisStore{
+		ephemerides: make(map[string][]SatelliteEphemeris),
+		keys:        make(map[string]struct{}),
+	}
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// Add stores ephs and returns how many of them were not already known.
+func (s *EphemerisStore) Add(ephs ...SatelliteEphemeris) int {
+	added := 0
+	touched := make(map[string]bool)
+	for _, eph := range ephs {
+		key := ephemerisKey(eph)
+		if _, ok := s.keys[key]; ok {
+			continue
+		}
+		s.keys[key] = struct{}{}
+
+		prn := eph.PRN()
+		s.ephemerides[prn] = append(s.ephemerides[prn], eph)
+		touched[prn] = true
+		added++
+	}
+
+	for prn := range touched {
+		group := s.ephemerides[prn]
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].Epoch().Sub(group[j].Epoch()) < 0
+		})
+	}
+	return added
+}
+
+// AddGPS stores the records returned by ParseRINEXGPSFileV211.
+func (s *EphemerisStore) AddGPS(ephs []GPSEphemeris) int {
+	list := make([]SatelliteEphemeris, len(ephs))
+	for i, eph := range ephs {
+		list[i] = eph
+	}
+	return s.Add(list...)
+}
+
+// AddGLONASS stores the records returned by ParseRINEXFileV201.
+func (s *EphemerisStore) AddGLONASS(ephs []RINEXEphemeris) int {
+	list := make([]SatelliteEphemeris, len(ephs))
+	for i, eph := range ephs {
+		list[i] = GLONASSEphemeris{eph}
+	}
+	return s.Add(list...)
+}
+
+// AddNavigationData stores every constellation of a RINEX 3/4 navigation file.
+// SBAS records carry no orbit model usable by SatelliteEphemeris and are
+// skipped.
+func (s *EphemerisStore) AddNavigationData(nav *NavigationData) int {
+	return s.Add(navigationEphemerides(nav)...)
+}
+
+func navigationEphemerides(nav *NavigationData) []SatelliteEphemeris {
+	var list []SatelliteEphemeris
+	for _, group := range [][]GPSEphemeris{nav.GPS, nav.QZSS, nav.NavIC} {
+		for _, eph := range group {
+			list = append(list, eph)
+		}
+	}
+	for _, eph := range nav.Galileo {
+		list = append(list, eph)
+	}
+	for _, eph := range nav.BeiDou {
+		list = append(list, eph)
+	}
+	for _, eph := range nav.GLONASS {
+		list = append(list, GLONASSEphemeris{eph})
+	}
+	return list
+}
+
+// AddSP3 builds an interpolator for every satellite of the SP3 header and
+// stores it as orbitType, fileName is kept for EphemerisFile. Satellites
+// with too few positions for the default window are skipped.
+func (s *EphemerisStore) AddSP3(sp3 SP3FormatEphemeris, fileName string, orbitType EphemerisType) (int, error) {
+	header, err := sp3.Header()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get SP3 header: %v", err)
+	}
+	satellites, err := header.Satellites()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get SP3 satellites: %v", err)
+	}
+
+	var list []SatelliteEphemeris
+	for i := 0; i < satellites.Len(); i++ {
+		prn, err := satellites.At(i)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get SP3 satellite: %v", err)
+		}
+		interpolator, err := NewSP3Interpolator(sp3, prn, 0)
+		if err != nil {
+			continue
+		}
+		interpolator.OrbitType = orbitType
+		interpolator.FileName = fileName
+		list = append(list, interpolator)
+	}
+	return s.Add(list...), nil
+}
+
+// SP3OrbitType guesses the product type from an SP3 file name, either the
+// long form (IGS0OPSFIN_..., COD0MGXRAP_..., WUM0MGXULT_...) or the short one
+// (igs, igr, igu). Unknown names are taken as FINAL_ORBIT.
+func SP3OrbitType(filename string) EphemerisType {
+	name := strings.ToUpper(filepath.Base(filename))
+	if len(name) > 10 && name[10] == '_' {
+		switch name[7:10] {
+		case "RAP":
+			return RAPID_ORBIT
+		case "ULT", "PRD", "NRT":
+			return ULTRA_RAPID_ORBIT
+		}
+		return FINAL_ORBIT
+	}
+	switch {
+	case strings.HasPrefix(name, "IGR"):
+		return RAPID_ORBIT
+	case strings.HasPrefix(name, "IGU"):
+		return ULTRA_RAPID_ORBIT
+	}
+	return FINAL_ORBIT
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// Get returns the best ephemeris for prn at time, see the ranking above.
+func (s *EphemerisStore) Get(prn string, time GPSTime) (SatelliteEphemeris, error) {
+	group, ok := s.ephemerides[prn]
+	if !ok {
+		return nil, fmt.Errorf("no ephemeris for %s", prn)
+	}
+
+	var best SatelliteEphemeris
+	bestDiff := 0.0
+	for _, eph := range group {
+		if !eph.Healthy() || !eph.Valid(time) {
+			continue
+		}
+		diff := math.Abs(time.Sub(eph.Epoch()))
+		if best == nil {
+			best, bestDiff = eph, diff
+			continue
+		}
+		rank, bestRank := ephemerisRank(eph.Type()), ephemerisRank(best.Type())
+		if rank > bestRank || rank == bestRank && diff < bestDiff {
+			best, bestDiff = eph, diff
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("no valid healthy ephemeris for %s at week %d tow %.0f", prn, time.Week(), time.TimeOfWeek())
+	}
+	return best, nil
+}
+
+// PRNs returns the stored satellites in ascending order.
+func (s *EphemerisStore) PRNs() []string {
+	prns := make([]string, 0, len(s.ephemerides))
+	for prn := range s.ephemerides {
+		prns = append(prns, prn)
+	}
+	sort.Strings(prns)
+	return prns
+}
+
+// Ephemerides returns the stored ephemerides of prn sorted by epoch.
+func (s *EphemerisStore) Ephemerides(prn string) []SatelliteEphemeris {
+	return s.ephemerides[prn]
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func ephemerisRank(t EphemerisType) int {
+	switch t {
+	case FINAL_ORBIT:
+		return 4
+	case RAPID_ORBIT:
+		return 3
+	case ULTRA_RAPID_ORBIT:
+		return 2
+	case NAV:
+		return 1
+	}
+	return 0
+}
+
+// ephemerisKey identifies a record for deduplication: the issue of data and
+// toe for Kepler broadcasts, tb for GLONASS and the first epoch, agency and
+// file for SP3, so same-rank products of two centers are both kept.
+func ephemerisKey(eph SatelliteEphemeris) string {
+	epoch := eph.Epoch()
+	key := fmt.Sprintf("%s %d %d %.3f", eph.PRN(), eph.Type(), epoch.Week(), epoch.TimeOfWeek())
+
+	switch e := eph.(type) {
+	case GPSEphemeris:
+		if data, err := e.EphemerisData(); err == nil {
+			key += fmt.Sprintf(" %.0f %.3f", data.Iode(), data.Toe())
+		}
+	case GalileoEphemeris:
+		// I/NAV and F/NAV share IODnav but carry different clocks
+		if data, err := e.EphemerisData(); err == nil {
+			key += fmt.Sprintf(" %.0f %.3f %d", data.Iode(), data.Toe(), e.MessageType())
+		}
+	case BeiDouEphemeris:
+		if data, err := e.EphemerisData(); err == nil {
+			key += fmt.Sprintf(" %.0f %.3f", e.Aode(), data.Toe())
+		}
+	case *SP3Interpolator:
+		key += fmt.Sprintf(" %d %q %q", len(e.positionTimes), e.agency, e.FileName)
+	}
+	return key
+}
@@ -0,0 +1,159 @@
+package gnss
+
+import (
+	"strings"
+	"testing"
+)
+
+// =========================================================================
+
+// =========================================================================
+
+func TestEphemerisStoreDeduplication(t *testing.T) {
+	path := testFile(t, "abpo2120.24n")
+	_, ephs, err := ParseRINEXGPSFileV211(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewEphemerisStore()
+	added := store.AddGPS(ephs)
+	if added == 0 || added > len(ephs) {
+		t.Fatalf("%d of %d records added", added, len(ephs))
+	}
+
+	// the same broadcasts read again, as from a second station
+	_, again, err := ParseRINEXGPSFileV211(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := store.AddGPS(again); n != 0 {
+		t.Errorf("%d duplicates added", n)
+	}
+
+	stored := 0
+	for _, prn := range store.PRNs() {
+		group := store.Ephemerides(prn)
+		stored += len(group)
+		for i := 1; i < len(group); i++ {
+			if group[i].Epoch().Sub(group[i-1].Epoch()) < 0 {
+				t.Errorf("%s: ephemerides not sorted by epoch", prn)
+			}
+		}
+	}
+	if stored != added {
+		t.Errorf("%d ephemerides stored, %d added", stored, added)
+	}
+}
+
+func TestEphemerisStoreSelection(t *testing.T) {
+	_, ephs, err := ParseRINEXGPSFileV211(testFile(t, "abpo2120.24n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewEphemerisStore()
+	store.AddGPS(ephs)
+
+	if _, err := store.Get("G99", ephs[0].Epoch()); err == nil {
+		t.Error("ephemeris of an unknown PRN")
+	}
+
+	// the closest healthy broadcast wins
+	group := store.Ephemerides("G05")
+	if len(group) < 2 {
+		t.Fatalf("%d G05 ephemerides", len(group))
+	}
+	for _, want := range group {
+		if !want.Healthy() {
+			continue
+		}
+		got, err := store.Get("G05", want.Epoch())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Epoch().Sub(want.Epoch()) != 0 {
+			t.Errorf("at %v: ephemeris of %v", want.Epoch().ToDateTime(), got.Epoch().ToDateTime())
+		}
+	}
+	last := group[len(group)-1].Epoch()
+	if _, err := store.Get("G05", last.Add(2*SECS_IN_DAY)); err == nil {
+		t.Error("ephemeris two days after the last one")
+	}
+}
+
+// Precise orbits are preferred inside their span, the broadcasts remain
+// outside of it.
+func TestEphemerisStoreRanking(t *testing.T) {
+	_, ephs, err := ParseRINEXGPSFileV211(testFile(t, "abpo2120.24n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	eph := ephs[0]
+	toe, _ := eph.Toe()
+	start := toe.Add(-3600)
+	sp3, err := ParseSP3(strings.NewReader(sp3FromEphemeris(t, eph, start, 300, 25)), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewEphemerisStore()
+	store.AddGPS(ephs)
+	for _, c := range []struct {
+		file      string
+		orbitType EphemerisType
+	}{
+		{"igu23251_00.sp3", ULTRA_RAPID_ORBIT},
+		{"IGS0OPSRAP_20242110000_01D_15M_ORB.SP3", RAPID_ORBIT},
+	} {
+		if n, err := store.AddSP3(*sp3, c.file, c.orbitType); err != nil || n != 1 {
+			t.Fatalf("%s: %d added, %v", c.file, n, err)
+		}
+	}
+	// the same product read twice
+	if n, _ := store.AddSP3(*sp3, "igu23251_00.sp3", ULTRA_RAPID_ORBIT); n != 0 {
+		t.Errorf("%d duplicate SP3 orbits added", n)
+	}
+
+	inside := start.Add(1800)
+	got, err := store.Get("G05", inside)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Type() != RAPID_ORBIT {
+		t.Errorf("type %v inside the SP3 span, want RAPID_ORBIT", got.Type())
+	}
+
+	final, err := NewSP3Interpolator(*sp3, "G05", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	final.FileName = "IGS0OPSFIN_20242110000_01D_15M_ORB.SP3"
+	store.Add(final)
+	if got, _ := store.Get("G05", inside); got != SatelliteEphemeris(final) {
+		t.Errorf("type %v inside the SP3 span, want the final orbit", got.Type())
+	}
+
+	outside := start.Add(7200 + 600)
+	got, err = store.Get("G05", outside)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Type() != NAV {
+		t.Errorf("type %v outside the SP3 span, want NAV", got.Type())
+	}
+}
+
+func TestSP3OrbitType(t *testing.T) {
+	for name, want := range map[string]EphemerisType{
+		"IGS0OPSFIN_20242110000_01D_15M_ORB.SP3":       FINAL_ORBIT,
+		"COD0MGXRAP_20242110000_01D_05M_ORB.SP3.gz":    RAPID_ORBIT,
+		"/data/WUM0MGXULT_20242110000_01D_05M_ORB.SP3": ULTRA_RAPID_ORBIT,
+		"igr23251.sp3":    RAPID_ORBIT,
+		"igu23251_00.sp3": ULTRA_RAPID_ORBIT,
+		"igs23251.sp3":    FINAL_ORBIT,
+		"orbit.sp3":       FINAL_ORBIT,
+	} {
+		if got := SP3OrbitType(name); got != want {
+			t.Errorf("%s: %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package gnss
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  FIXED WIDTH RECORDS
+// RINEX and SP3 lines are FORTRAN formatted records. A recordLayout lists the
+// fields of a line as (start column, width, kind) and decodes them into the
+// destinations given in the same order. Columns are 0-based here, the format
+// tables of the standards count from 1. Blank fields, and fields past the
+// end of a line whose trailing blanks were stripped, read as zero.
+
+type fieldKind uint8
+
+const (
+	FIELD_INT fieldKind = iota
+	// F, E and D edit descriptors, the FORTRAN D exponent is accepted
+	FIELD_FLOAT
+	// A descriptor, read with the surrounding blanks trimmed
+	FIELD_TEXT
+)
+
+var fieldKindNames = []string{
+	"integer",
+	"float",
+	"text",
+}
+
+func (k fieldKind) String() string {
+	if int(k) < len(fieldKindNames) {
+		return fieldKindNames[k]
+	}
+	return "unknown"
+}
+
+type fieldSpec struct {
+	name  string
+	start int
+	width int
+	kind  fieldKind
+}
+
+func intField(name string, start, width int) fieldSpec {
+	return fieldSpec{name, start, width, FIELD_INT}
+}
+
+func floatField(name string, start, width int) fieldSpec {
+	return fieldSpec{name, start, width, FIELD_FLOAT}
+}
+
+func textField(name string, start, width int) fieldSpec {
+	return fieldSpec{name, start, width, FIELD_TEXT}
+}
+
+func (f fieldSpec) end() int {
+	return f.start + f.width
+}
+
+// decode reads the field of line into dst: *int, *int32 or *float64 for
+// numbers, *string for text. dst is zero when the field is malformed.
+func (f fieldSpec) decode(line string, dst any) error {
+	text := column(line, f.start, f.end())
+	var err error
+	switch f.kind {
+	case FIELD_INT:
+		var n int
+		n, err = parseIntField(text)
+		switch p := dst.(type) {
+		case *int:
+			*p = n
+		case *int32:
+			*p = int32(n)
+		default:
+			return fmt.Errorf("field %s: cannot decode %s into %T", f.name, f.kind, dst)
+		}
+	case FIELD_FLOAT:
+		p, ok := dst.(*float64)
+		if !ok {
+			return fmt.Errorf("field %s: cannot decode %s into %T", f.name, f.kind, dst)
+		}
+		*p, err = parseFloatField(text)
+	case FIELD_TEXT:
+		p, ok := dst.(*string)
+		if !ok {
+			return fmt.Errorf("field %s: cannot decode %s into %T", f.name, f.kind, dst)
+		}
+		*p = strings.TrimSpace(text)
+	}
+	if err != nil {
+		return &fieldError{field: f, err: err}
+	}
+	return nil
+}
+
+// fieldError is a malformed field, it carries the spec so that callers with
+// line numbers can locate it.
+type fieldError struct {
+	field fieldSpec
+	err   error
+}
+
+func (e *fieldError) Error() string {
+	return fmt.Sprintf("%s (columns %d-%d): %v", e.field.name, e.field.start+1, e.field.end(), e.err)
+}
+
+func (e *fieldError) Unwrap() error {
+	return e.err
+}
+
+// =========================================================================
+
+// =========================================================================
+
+type recordLayout []fieldSpec
+
+// decode reads every field of the layout into dst, one destination per
+// field. All fields are decoded, the first malformed one is returned.
+func (l recordLayout) decode(line string, dst ...any) error {
+	if len(dst) != len(l) {
+		return fmt.Errorf("layout has %d fields, got %d destinations", len(l), len(dst))
+	}
+	var first error
+	for i, f := range l {
+		if err := f.decode(line, dst[i]); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// repeated returns count fields of the same kind and width, step columns
+// apart, as the 4D19.12 of a BROADCAST ORBIT line.
+func repeated(name string, start, width, step, count int, kind fieldKind) recordLayout {
+	layout := make(recordLayout, count)
+	for i := range layout {
+		layout[i] = fieldSpec{fmt.Sprintf("%s %d", name, i+1), start + i*step, width, kind}
+	}
+	return layout
+}
+
+// decode reads the fields of line i of the record. Malformed fields are
+// reported as ParseError with their columns and read as zero.
+func (r *lineRecord) decode(i int, layout recordLayout, dst ...any) {
+	if len(dst) != len(layout) {
+		panic(fmt.Sprintf("layout has %d fields, got %d destinations", len(layout), len(dst)))
+	}
+	for j, f := range layout {
+		r.decodeField(i, f, dst[j])
+	}
+}
+
+// decodeField reads the single field f of line i, see decode.
+func (r *lineRecord) decodeField(i int, f fieldSpec, dst any) {
+	if err := f.decode(r.lines[i], dst); err != nil {
+		if fe, ok := err.(*fieldError); ok {
+			err = fe.err
+		}
+		r.fieldError(i, f.start, f.end(), err)
+	}
+}
+
+// =========================================================================
+
+// =========================================================================
+//  EPOCHS
+
+// epochFields is the date of an epoch line, seconds may carry a fraction.
+// The two digit years of RINEX 2 (shortYear) are 1980-2079.
+type epochFields struct {
+	year, month, day, hour, minute int
+	second                         float64
+	shortYear                      bool
+}
+
+// dst returns the destinations of the six date fields of a layout.
+func (e *epochFields) dst() []any {
+	return []any{&e.year, &e.month, &e.day, &e.hour, &e.minute, &e.second}
+}
+
+func (e epochFields) time() time.Time {
+	year := e.year
+	if e.shortYear && year < 80 {
+		year += 2000
+	} else if e.shortYear && year < 100 {
+		year += 1900
+	}
+	// rounded, F5.1 and F11.7 fractions are not exact in binary
+	ns := time.Duration(math.Round(e.second * float64(time.Second)))
+	return time.Date(year, time.Month(e.month), e.day, e.hour, e.minute, 0, 0, time.UTC).Add(ns)
+}
+
+// dateLayout lists the year, month, day, hour, minute and second fields of
+// an epoch: year and seconds have their own width, the other fields are I2
+// one column apart.
+func dateLayout(start, yearWidth, secondsWidth int) recordLayout {
+	layout := recordLayout{intField("year", start, yearWidth)}
+	column := start + yearWidth + 1
+	for _, name := range []string{"month", "day", "hour", "minute"} {
+		layout = append(layout, intField(name, column, 2))
+		column += 3
+	}
+	return append(layout, floatField("second", column-1, secondsWidth))
+}
+
+var (
+	// RINEX 2 navigation SV / EPOCH / SV CLK, GPS and GLONASS alike:
+	// I2,1X,I2.2,4(1X,I2),F5.1, the clock or orbit values follow as 3D19.12
+	navEpochV2 = append(recordLayout{intField("satellite", 0, 2)}, dateLayout(3, 2, 5)...)
+	// RINEX 3 / 4: A1,I2.2,1X,I4,5(1X,I2.2), values as 3D19.12. The seconds
+	// are read with their leading blank as F3.0
+	navEpochV3 = append(recordLayout{intField("satellite", 1, 2)}, dateLayout(4, 4, 3)...)
+
+	// observation EPOCH / SAT of RINEX 2: 1X,I2.2,4(1X,I2),F11.7,2X,I1,I3 and
+	// the receiver clock offset F12.9 in columns 69-80
+	obsEpochV2 = append(dateLayout(1, 2, 11),
+		intField("epoch flag", 28, 1), intField("satellites", 29, 3), floatField("clock offset", 68, 12))
+	// RINEX 3: A1,1X,I4,4(1X,I2.2),F11.7,2X,I1,I3,6X,F15.12
+	obsEpochV3 = append(dateLayout(2, 4, 11),
+		intField("epoch flag", 31, 1), intField("satellites", 32, 3), floatField("clock offset", 41, 15))
+	// TIME OF FIRST OBS / TIME OF LAST OBS: 5I6,F13.7,5X,A3
+	obsHeaderEpoch = recordLayout{intField("year", 0, 6), intField("month", 6, 6), intField("day", 12, 6),
+		intField("hour", 18, 6), intField("minute", 24, 6), floatField("second", 30, 13),
+		textField("time system", 48, 3)}
+	// observation header records. RINEX VERSION / TYPE is F9.2,11X,A1,19X,A1
+	// but the type and system are kept with their description
+	obsVersionLine        = recordLayout{floatField("version", 0, 9), textField("type", 20, 20), textField("satellite system", 40, 20)}
+	obsProgramLine        = recordLayout{textField("program", 0, 20), textField("run by", 20, 20), textField("date", 40, 20)}
+	obsTextLine           = recordLayout{textField("text", 0, 60)}
+	obsMarkerNumberLine   = recordLayout{textField("marker number", 0, 20)}
+	obsObserverLine       = recordLayout{textField("observer", 0, 20), textField("agency", 20, 40)}
+	obsReceiverLine       = recordLayout{textField("receiver number", 0, 20), textField("receiver type", 20, 20), textField("receiver version", 40, 20)}
+	obsAntennaLine        = recordLayout{textField("antenna number", 0, 20), textField("antenna type", 20, 20)}
+	obsSignalStrengthLine = recordLayout{textField("signal strength unit", 0, 20)}
+	obsIntervalLine       = recordLayout{floatField("interval", 0, 10)}
+	obsLeapSecondsLine    = recordLayout{intField("leap seconds", 0, 6)}
+	// APPROX POSITION XYZ, ANTENNA: DELTA H/E/N: 3F14.4
+	obsVectorLine = repeated("value", 0, 14, 14, 3, FIELD_FLOAT)
+	// # / TYPES OF OBSERV: I6,9(4X,A2), SYS / # / OBS TYPES: A1,2X,I3,13(1X,A3).
+	// The count is read as text, blank on continuation lines
+	obsTypesV2 = append(recordLayout{textField("count", 0, 6)}, repeated("type", 10, 2, 6, 9, FIELD_TEXT)...)
+	obsTypesV3 = append(recordLayout{textField("system", 0, 1)}, repeated("type", 7, 3, 4, 13, FIELD_TEXT)...)
+
+	// SP3 first header line and epoch lines: I4,4(1X,I2),1X,F11.8 in columns 4-31
+	sp3Date = dateLayout(3, 4, 12)
+	// rest of the first line: I7,1X,A5,1X,A5,1X,A3,1X,A4
+	sp3FirstLine = recordLayout{intField("epochs", 32, 7), textField("data used", 40, 5),
+		textField("coordinate system", 46, 5), textField("orbit type", 52, 3), textField("agency", 56, 4)}
+	// ## line: I4,1X,F15.8,1X,F14.8,1X,I5,1X,F15.13
+	sp3TimeLine = recordLayout{intField("gps week", 3, 4), floatField("seconds of week", 8, 15),
+		floatField("epoch interval", 24, 14), intField("modified julian day", 39, 5), floatField("fractional day", 45, 15)}
+	// ++ line: 9X,17I3
+	sp3AccuracyLine = repeated("accuracy", 9, 3, 3, 17, FIELD_INT)
+	// %f line: F10.7,1X,F12.9
+	sp3BaseLine = recordLayout{floatField("position base", 3, 10), floatField("clock base", 14, 12)}
+	// P / V record: A1,A3,4F14.6,3(1X,I2),1X,I3,1X,2A1,2X,2A1
+	sp3StateLine = recordLayout{textField("satellite", 1, 3),
+		floatField("x", 4, 14), floatField("y", 18, 14), floatField("z", 32, 14), floatField("clock", 46, 14),
+		intField("x exponent", 61, 2), intField("y exponent", 64, 2), intField("z exponent", 67, 2),
+		intField("clock exponent", 70, 3),
+		textField("clock event", 74, 1), textField("clock prediction", 75, 1),
+		textField("maneuver", 78, 1), textField("orbit prediction", 79, 1)}
+	// EP / EV record: A2,2X,3(I4,1X),I7,6(1X,I8)
+	sp3CorrelationLine = append(repeated("std dev", 4, 4, 5, 3, FIELD_INT),
+		append(recordLayout{intField("clock std dev", 19, 7)}, repeated("correlation", 27, 8, 9, 6, FIELD_INT)...)...)
+
+	// IONEX EPOCH OF FIRST MAP / LAST MAP / CURRENT MAP: 6I6
+	ionexEpoch = recordLayout{intField("year", 0, 6), intField("month", 6, 6), intField("day", 12, 6),
+		intField("hour", 18, 6), intField("minute", 24, 6), floatField("second", 30, 6)}
+	// HGT1 / HGT2 / DHGT, LAT1 / LAT2 / DLAT, LON1 / LON2 / DLON: 2X,3F6.1
+	ionexAxis = recordLayout{floatField("first", 2, 6), floatField("last", 8, 6), floatField("step", 14, 6)}
+	// LAT/LON1/LON2/DLON/H: 2X,5F6.1
+	ionexBand = recordLayout{floatField("latitude", 2, 6), floatField("first longitude", 8, 6),
+		floatField("last longitude", 14, 6), floatField("longitude step", 20, 6), floatField("height", 26, 6)}
+	// map values: 16I5
+	ionexValues = repeated("value", 0, 5, 5, 16, FIELD_INT)
+	// PRN / BIAS / RMS: 3X,A1,I2,2F10.3 (1.0: 3X,I3,2F10.3)
+	ionexSatelliteBias = recordLayout{textField("satellite", 3, 3), floatField("bias", 6, 10), floatField("rms", 16, 10)}
+	// STATION / BIAS / RMS: 3X,A1,2X,A4,1X,A9,6X,2F10.3 (1.0 without the system)
+	ionexStationBias = recordLayout{textField("system", 3, 1), textField("station", 6, 4), textField("domes", 11, 9),
+		floatField("bias", 26, 10), floatField("rms", 36, 10)}
+)
+
+// navValue returns field j of the D19.12 values from column start.
+func navValue(start, j int) fieldSpec {
+	return floatField("value", start+19*j, 19)
+}
@@ -0,0 +1,114 @@
+package gnss
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+	"time"
+)
+
+// TestNavEpochs writes a record at every second of a day, and in RINEX 2 at
+// every tenth of a second, then checks that the GPS and GLONASS RINEX 2 and
+// the RINEX 3 parsers read the same epoch back. The two digit years are
+// checked from 1980 to 2079. -short samples the day every 37.3 s.
+func TestNavEpochs(t *testing.T) {
+	step := 1
+	if testing.Short() {
+		step = 373
+	}
+	day := time.Date(2024, 7, 30, 0, 0, 0, 0, time.UTC)
+	for tenth := 0; tenth < 864000; tenth += step {
+		verifyNavEpoch(t, day.Add(time.Duration(tenth)*100*time.Millisecond), tenth%10 == 0)
+	}
+	for year := 1980; year < 2080; year++ {
+		verifyNavEpoch(t, time.Date(year, 12, 31, 23, 59, 59, 0, time.UTC), true)
+	}
+}
+
+// verifyNavEpoch round trips epoch through writeNavRecord and the record
+// decoders. RINEX 3 has whole seconds only.
+func verifyNavEpoch(t *testing.T, epoch time.Time, rinex3 bool) {
+	t.Helper()
+	records := []struct {
+		record  navRecord
+		version float64
+		decode  func(*lineRecord) (navRecord, error)
+	}{
+		{navRecord{system: 'G', svId: 5, epoch: epoch, values: make([]float64, 31)}, 2.11, processGPSEphemerisLines},
+		{navRecord{system: 'R', svId: 12, epoch: epoch, values: make([]float64, 15)}, 2.11, processEphemerisLines},
+		{navRecord{system: 'E', svId: 11, epoch: epoch, values: make([]float64, 31)}, 3.04, func(r *lineRecord) (navRecord, error) {
+			return decodeNavRecord(r, 3.04, NavMessageType_unknown)
+		}},
+	}
+	if !rinex3 {
+		records = records[:2]
+	}
+
+	for _, r := range records {
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		writeNavRecord(w, r.record, r.version)
+		w.Flush()
+
+		scanner := newLineScanner(&buf, ParseOptions{Strict: true})
+		record := scanner.newRecord()
+		for scanner.Scan() {
+			record.add(scanner.Text())
+		}
+		got, err := r.decode(record)
+		if err != nil {
+			t.Fatalf("failed to parse %q: %v", record.lines[0], err)
+		}
+		if !got.epoch.Equal(epoch) || got.svId != r.record.svId {
+			t.Fatalf("%q read as satellite %d at %s, expected %d at %s", record.lines[0],
+				got.svId, got.epoch.Format(time.RFC3339Nano), r.record.svId, epoch.Format(time.RFC3339Nano))
+		}
+	}
+}
+
+func TestRecordLayout(t *testing.T) {
+	var satellite int
+	var e epochFields
+	e.shortYear = true
+	if err := navEpochV2.decode("12 24  7 30 10 45 59.9", append([]any{&satellite}, e.dst()...)...); err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2024, 7, 30, 10, 45, 59, 9e8, time.UTC); satellite != 12 || !e.time().Equal(want) {
+		t.Errorf("satellite %d epoch %v, want 12 %v", satellite, e.time(), want)
+	}
+
+	layout := recordLayout{floatField("value", 0, 19), textField("text", 19, 6), intField("count", 25, 3), floatField("blank", 28, 10)}
+	var value, blank float64
+	var text string
+	var count int32
+	// the line ends before the last field
+	if err := layout.decode("-0.186264514923D-08  ab    7", &value, &text, &count, &blank); err != nil {
+		t.Fatal(err)
+	}
+	if value != -0.186264514923e-08 || text != "ab" || count != 7 || blank != 0 {
+		t.Errorf("decoded %v %q %d %v", value, text, count, blank)
+	}
+
+	// every field is read, the first malformed one is returned
+	value, count = 1, 1
+	err := layout.decode("  0.1X             ab    x.2", &value, &text, &count, &blank)
+	fe, ok := err.(*fieldError)
+	if !ok || fe.field.name != "value" || err.Error() != `value (columns 1-19): invalid float "0.1X"` {
+		t.Errorf("error %v", err)
+	}
+	if value != 0 || count != 0 || text != "ab" {
+		t.Errorf("decoded %v %q %d after the error", value, text, count)
+	}
+
+	if err := layout.decode("", &value, &text); err == nil {
+		t.Error("decoded into too few destinations")
+	}
+	if err := layout.decode("", &value, &text, &value, &blank); err == nil {
+		t.Error("integer decoded into a *float64")
+	}
+
+	values := repeated("orbit", 3, 19, 19, 4, FIELD_FLOAT)
+	if last := values[3]; last.name != "orbit 4" || last.start != 60 || last.end() != 79 {
+		t.Errorf("last repeated field %+v", last)
+	}
+}
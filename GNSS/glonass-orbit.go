@@ -0,0 +1,148 @@
+package gnss
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  GLONASS ORBIT PROPAGATION
+// http://gauss.gge.unb.ca/GLONASS.ICD.pdf (Appendix A.3.1.2)
+// The broadcast state vector at tb is integrated with a 4th order
+// Runge-Kutta scheme in PZ-90:
+//   dvx/dt = -GM/r³ x - 3/2 J2 GM ae²/r⁵ x (1 - 5z²/r²) + ω² x + 2ω vy + ax
+//   dvy/dt = -GM/r³ y - 3/2 J2 GM ae²/r⁵ y (1 - 5z²/r²) + ω² y - 2ω vx + ay
+//   dvz/dt = -GM/r³ z - 3/2 J2 GM ae²/r⁵ z (3 - 5z²/r²)                + az
+// The luni-solar accelerations ax, ay, az are held constant over the
+// interval. RINEX stores -TauN as clock bias and +GammaN as relative frequency
+// bias, so the clock error is clockBias + relativeFrequencyBias * (t - tb).
+
+const (
+	glonassIntegrationStep = 60.0   // s
+	glonassMaxTimeDiff     = 1800.0 // s, a full 30 min tb interval on either side of tb
+)
+
+// =========================================================================
+
+// =========================================================================
+
+// GetSatInfo returns ECEF (PZ-90) position (m), velocity (m/s), clock error (s)
+// and clock rate (s/s) at time, matching GPSEphemeris.GetSatInfo.
+func (e RINEXEphemeris) GetSatInfo(time GPSTime) ([]float64, []float64, float64, float64, error) {
+	if e.Health() != 0 {
+		return nil, nil, 0, 0, errors.New("unhealthy ephemeris")
+	}
+
+	tb, err := e.glonassEpoch()
+	if err != nil {
+		return nil, nil, 0, 0, err
+	}
+	tdiff := time.Sub(tb)
+	// a NaN would never bring the integration loop below to an end
+	if math.IsNaN(tdiff) || math.IsInf(tdiff, 0) {
+		return nil, nil, 0, 0, fmt.Errorf("invalid time difference %v to the ephemeris epoch", tdiff)
+	}
+	if math.Abs(tdiff) > glonassMaxTimeDiff {
+		return nil, nil, 0, 0, fmt.Errorf("time is %.0f s from the ephemeris epoch, maximum %.0f s", tdiff, glonassMaxTimeDiff)
+	}
+
+	state := [6]float64{
+		e.PositionX() * 1e3, e.PositionY() * 1e3, e.PositionZ() * 1e3,
+		e.VelocityX() * 1e3, e.VelocityY() * 1e3, e.VelocityZ() * 1e3,
+	}
+	acc := [3]float64{e.AccelerationX() * 1e3, e.AccelerationY() * 1e3, e.AccelerationZ() * 1e3}
+
+	step := glonassIntegrationStep
+	if tdiff < 0 {
+		step = -step
+	}
+	for remaining := tdiff; remaining != 0; {
+		h := step
+		if math.Abs(remaining) < math.Abs(step) {
+			h = remaining
+		}
+		state = glonassRungeKutta(state, acc, h)
+		remaining -= h
+	}
+
+	pos := []float64{state[0], state[1], state[2]}
+	vel := []float64{state[3], state[4], state[5]}
+	clockErr := e.ClockBias() + e.RelativeFrequencyBias()*tdiff
+	clockRateErr := e.RelativeFrequencyBias()
+
+	return pos, vel, clockErr, clockRateErr, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// glonassEpoch converts the UTC epoch of the record (tb) to GPS time through
+// the GLONASS day count: N4 is the four year interval since 1992 (the ICD
+// counts from 1996 with N4 = 1), NT the day within it and GLONASS time runs
+// 3 hours ahead of UTC.
+func (e RINEXEphemeris) glonassEpoch() (GPSTime, error) {
+	epoch, err := e.Epoch()
+	if err != nil {
+		return GPSTime{}, fmt.Errorf("failed to get epoch: %v", err)
+	}
+	glonassTime := time.Unix(epoch.Seconds(), int64(epoch.Nanoseconds())).UTC().Add(3 * time.Hour)
+
+	cycle := (glonassTime.Year() - 1992) / 4
+	cycleStart := time.Date(1992+4*cycle, 1, 1, 0, 0, 0, 0, time.UTC)
+	elapsed := glonassTime.Sub(cycleStart)
+	days := int(elapsed/(24*time.Hour)) + 1
+	tod := (elapsed % (24 * time.Hour)).Seconds()
+
+	return GPSTimeFromGLONASS(cycle, days, tod), nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func glonassRungeKutta(state [6]float64, acc [3]float64, h float64) [6]float64 {
+	k1 := glonassDerivatives(state, acc)
+	k2 := glonassDerivatives(addScaled(state, k1, h/2), acc)
+	k3 := glonassDerivatives(addScaled(state, k2, h/2), acc)
+	k4 := glonassDerivatives(addScaled(state, k3, h), acc)
+
+	var next [6]float64
+	for i := range next {
+		next[i] = state[i] + h/6*(k1[i]+2*k2[i]+2*k3[i]+k4[i])
+	}
+	return next
+}
+
+func addScaled(state, derivative [6]float64, h float64) [6]float64 {
+	var r [6]float64
+	for i := range r {
+		r[i] = state[i] + derivative[i]*h
+	}
+	return r
+}
+
+func glonassDerivatives(state [6]float64, acc [3]float64) [6]float64 {
+	x, y, z := state[0], state[1], state[2]
+	vx, vy := state[3], state[4]
+
+	r2 := x*x + y*y + z*z
+	r := math.Sqrt(r2)
+	gmr3 := GLONASS_GM / (r2 * r)
+	j2 := 1.5 * GLONASS_J2 * GLONASS_GM * GLONASS_EARTH_RADIUS * GLONASS_EARTH_RADIUS / (r2 * r2 * r)
+	z2r2 := 5 * z * z / r2
+	w2 := GLONASS_EARTH_ROTATION_RATE * GLONASS_EARTH_ROTATION_RATE
+
+	return [6]float64{
+		vx,
+		vy,
+		state[5],
+		-gmr3*x - j2*x*(1-z2r2) + w2*x + 2*GLONASS_EARTH_ROTATION_RATE*vy + acc[0],
+		-gmr3*y - j2*y*(1-z2r2) + w2*y - 2*GLONASS_EARTH_ROTATION_RATE*vx + acc[1],
+		-gmr3*z - j2*z*(3-z2r2) + acc[2],
+	}
+}
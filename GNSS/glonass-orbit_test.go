@@ -0,0 +1,92 @@
+package gnss
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+
+// At tb the propagated state is the broadcast one.
+func TestGLONASSPositionAtEpoch(t *testing.T) {
+	nav, err := ParseNavFile(testFile(t, "brdc2050.24g"), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nav.GLONASS) == 0 {
+		t.Fatal("no GLONASS records")
+	}
+
+	//  1 24  7 23  0 15  0.0 0.922027975321D-04 0.909494701773D-12 0.000000000000D+00
+	eph := nav.GLONASS[0]
+	tb, err := eph.glonassEpoch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// UTC + 18 leap seconds
+	if got, want := tb.ToDateTime(), time.Date(2024, 7, 23, 0, 15, 18, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("tb %v, want %v", got, want)
+	}
+	pos, vel, clock, clockRate, err := eph.GetSatInfo(tb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPos := []float64{eph.PositionX() * 1e3, eph.PositionY() * 1e3, eph.PositionZ() * 1e3}
+	wantVel := []float64{eph.VelocityX() * 1e3, eph.VelocityY() * 1e3, eph.VelocityZ() * 1e3}
+	if distance(pos, wantPos) != 0 || distance(vel, wantVel) != 0 {
+		t.Errorf("state at tb %v %v, want %v %v", pos, vel, wantPos, wantVel)
+	}
+	if clock != 0.922027975321e-04 || clockRate != 0.909494701773e-12 {
+		t.Errorf("clock %v rate %v", clock, clockRate)
+	}
+
+	if _, _, _, _, err := eph.GetSatInfo(tb.Add(1801)); err == nil {
+		t.Error("no error beyond the 30 minute interval")
+	}
+}
+
+// Integrating a record over 30 minutes must bring it to the state of the
+// next record of the satellite.
+func TestGLONASSPropagation(t *testing.T) {
+	nav, err := ParseNavFile(testFile(t, "brdc2050.24g"), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := make(map[int32]RINEXEphemeris)
+	pairs := 0
+	worst := 0.0
+	for _, eph := range nav.GLONASS {
+		slot := eph.SatelliteId()
+		prev, ok := last[slot]
+		last[slot] = eph
+		if !ok || prev.Health() != 0 || eph.Health() != 0 {
+			continue
+		}
+		tb, _ := eph.glonassEpoch()
+		prevTb, _ := prev.glonassEpoch()
+		if tb.Sub(prevTb) != 1800 {
+			continue
+		}
+		pos, vel, _, _, err := prev.GetSatInfo(tb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, wantVel, _, _, _ := eph.GetSatInfo(tb)
+		pairs++
+		d := distance(pos, want)
+		worst = math.Max(worst, d)
+		if d > 20 {
+			t.Errorf("R%02d at %v: propagated position %.2f m from the next record", slot, tb.ToDateTime(), d)
+		}
+		if dv := distance(vel, wantVel); dv > 0.05 {
+			t.Errorf("R%02d at %v: propagated velocity %.4f m/s from the next record", slot, tb.ToDateTime(), dv)
+		}
+	}
+	if pairs == 0 {
+		t.Fatal("no consecutive records")
+	}
+	t.Logf("%d pairs, largest difference %.2f m", pairs, worst)
+}
@@ -1 +1,318 @@
 package gnss
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  FORMAT DETECTION AND REGISTRY
+// Files are routed by their content, never by their name. Compression layers
+// are removed first (see decompress.go), then the start of the stream decides:
+//   D3 + 6 reserved zero bits                RTCM 3 frame
+//   B5 62                                    u-blox UBX frame
+//   "#a".."#d" + P/V                         SP3
+//   "RINEX VERSION / TYPE" in columns 61-80  RINEX, column 21 is the type:
+//                                            O obs, N/G/H/L/E nav, C clock,
+//                                            M meteo
+//   "RINEX VERSION / TYPE" in columns 66-85  RINEX 3.04 clock
+//   "IONEX VERSION / TYPE"                   IONEX
+//   "ANTEX VERSION / SYST"                   ANTEX
+//
+// Parse dispatches the detected format to the parser registered for it and
+// returns a ParsedFile tagged with the detection. Formats without a parser are
+// detected but cannot be parsed, RegisterFormat adds or replaces one.
+
+type FileFormat int
+
+const (
+	FORMAT_UNKNOWN FileFormat = iota
+	FORMAT_RINEX_OBS
+	FORMAT_RINEX_NAV
+	FORMAT_RINEX_CLOCK
+	FORMAT_RINEX_MET
+	FORMAT_SP3
+	FORMAT_IONEX
+	FORMAT_ANTEX
+	FORMAT_RTCM3
+	FORMAT_UBX
+)
+
+var fileFormatNames = []string{
+	"Unknown",
+	"RINEX observation",
+	"RINEX navigation",
+	"RINEX clock",
+	"RINEX meteorological",
+	"SP3",
+	"IONEX",
+	"ANTEX",
+	"RTCM 3",
+	"UBX",
+}
+
+func (f FileFormat) String() string {
+	if int(f) < len(fileFormatNames) {
+		return fileFormatNames[f]
+	}
+	return "Unknown"
+}
+
+// Detection describes the start of a file. Version and Type are those of the
+// header line (RINEX file type letter, SP3 P/V flag), System is the satellite
+// system column of RINEX and ANTEX headers.
+type Detection struct {
+	Format  FileFormat
+	Version string
+	Type    string
+	System  string
+}
+
+// ParsedFile is the result of Parse, the field matching Format is set.
+// Formats registered by the caller leave their result in Data. Warnings lists
+// the malformed fields and records of a lenient parse.
+type ParsedFile struct {
+	Detection
+	Navigation  *NavigationData
+	Observation *ObservationHeader
+	Epochs      []ObservationEpoch
+	SP3         *SP3FormatEphemeris
+	IONEX       *IONEX
+	Data        any
+	Warnings    []*ParseError
+}
+
+// FormatParser parses r, positioned at the start of the file, into result.
+type FormatParser func(r io.Reader, opts ParseOptions, result *ParsedFile) error
+
+var (
+	formatParsersMu sync.RWMutex
+	formatParsers   = map[FileFormat]FormatParser{
+		FORMAT_RINEX_NAV: parseNavFormat,
+		FORMAT_RINEX_OBS: parseObservationFormat,
+		FORMAT_SP3:       parseSP3Format,
+		FORMAT_IONEX:     parseIONEXFormat,
+	}
+)
+
+// RegisterFormat sets the parser of format, nil removes it.
+func RegisterFormat(format FileFormat, parser FormatParser) {
+	formatParsersMu.Lock()
+	defer formatParsersMu.Unlock()
+	if parser == nil {
+		delete(formatParsers, format)
+		return
+	}
+	formatParsers[format] = parser
+}
+
+func formatParser(format FileFormat) (FormatParser, bool) {
+	formatParsersMu.RLock()
+	defer formatParsersMu.RUnlock()
+	parser, ok := formatParsers[format]
+	return parser, ok
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// Parse detects the format of r and parses it with the registered parser.
+func Parse(r io.Reader, opts ParseOptions) (*ParsedFile, error) {
+	detection, plain, err := DetectFormat(r)
+	if err != nil {
+		return nil, err
+	}
+	if detection.Format == FORMAT_UNKNOWN {
+		return nil, fmt.Errorf("unknown file format")
+	}
+	parser, ok := formatParser(detection.Format)
+	if !ok {
+		return nil, fmt.Errorf("no parser registered for %s files", detection.Format)
+	}
+
+	result := &ParsedFile{Detection: detection}
+	if err := parser(plain, opts, result); err != nil {
+		return nil, fmt.Errorf("failed to parse %s file: %w", detection.Format, err)
+	}
+	return result, nil
+}
+
+// ParseFile opens filename and parses it with Parse, opts.FileName defaults
+// to filename.
+func ParseFile(filename string, opts ParseOptions) (*ParsedFile, error) {
+	file, err := openFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if opts.FileName == "" {
+		opts.FileName = filename
+	}
+	return Parse(file, opts)
+}
+
+func parseNavFormat(r io.Reader, opts ParseOptions, result *ParsedFile) error {
+	// RINEX 2 GEO (H) and Galileo (L, E) files are detected but ParseNav
+	// only reads the GPS and GLONASS ones
+	if version, _ := strconv.ParseFloat(result.Version, 64); version < 3 && result.Type != "N" && result.Type != "G" {
+		return fmt.Errorf("RINEX %s navigation files of type %s (system %s) are detected but not parsed: %w",
+			result.Version, result.Type, result.System, errors.ErrUnsupported)
+	}
+	nav, err := ParseNav(r, opts)
+	if err != nil {
+		return err
+	}
+	result.Navigation = nav
+	result.Warnings = nav.Warnings
+	return nil
+}
+
+func parseObservationFormat(r io.Reader, opts ParseOptions, result *ParsedFile) error {
+	header, epochs, warnings, err := parseObservation(r, opts)
+	if err != nil {
+		return err
+	}
+	result.Observation = header
+	result.Epochs = epochs
+	result.Warnings = warnings
+	return nil
+}
+
+func parseSP3Format(r io.Reader, opts ParseOptions, result *ParsedFile) error {
+	sp3, warnings, err := parseSP3(r, opts)
+	if err != nil {
+		return err
+	}
+	result.SP3 = sp3
+	result.Warnings = warnings
+	return nil
+}
+
+func parseIONEXFormat(r io.Reader, opts ParseOptions, result *ParsedFile) error {
+	ionex, err := ParseIONEX(r, opts)
+	if err != nil {
+		return err
+	}
+	result.IONEX = ionex
+	result.Warnings = ionex.Warnings
+	return nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// detectionSize covers the first line of any supported header.
+const detectionSize = 256
+
+// DetectFormat removes the compression layers of r and identifies the file
+// from its first bytes. The returned reader yields the whole decompressed
+// input, including the inspected bytes.
+func DetectFormat(r io.Reader) (Detection, io.Reader, error) {
+	plain, err := Decompress(r)
+	if err != nil {
+		return Detection{}, nil, err
+	}
+	br := bufio.NewReader(plain)
+	head, err := br.Peek(detectionSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return Detection{}, nil, fmt.Errorf("failed to read input: %v", err)
+	}
+	return DetectFormatBytes(head), br, nil
+}
+
+// DetectFormatBytes identifies a file from its first bytes.
+func DetectFormatBytes(head []byte) Detection {
+	switch {
+	case len(head) >= 2 && head[0] == 0xd3 && head[1]&0xfc == 0:
+		return Detection{Format: FORMAT_RTCM3}
+	case len(head) >= 2 && head[0] == 0xb5 && head[1] == 0x62:
+		return Detection{Format: FORMAT_UBX}
+	}
+
+	line, _, _ := bytes.Cut(head, []byte("\n"))
+	first := strings.TrimRight(string(line), "\r")
+
+	if len(first) >= 3 && first[0] == '#' && strings.ContainsRune("abcd", rune(first[1])) && strings.ContainsRune("PV", rune(first[2])) {
+		return Detection{Format: FORMAT_SP3, Version: first[1:2], Type: first[2:3]}
+	}
+
+	switch strings.TrimSpace(column(first, 60, 80)) {
+	case "RINEX VERSION / TYPE":
+		return detectRINEX(first)
+	case "IONEX VERSION / TYPE":
+		return Detection{
+			Format:  FORMAT_IONEX,
+			Version: strings.TrimSpace(column(first, 0, 9)),
+			Type:    strings.TrimSpace(column(first, 20, 21)),
+			System:  strings.TrimSpace(column(first, 40, 43)),
+		}
+	case "ANTEX VERSION / SYST":
+		return Detection{
+			Format:  FORMAT_ANTEX,
+			Version: strings.TrimSpace(column(first, 0, 8)),
+			System:  strings.TrimSpace(column(first, 20, 21)),
+		}
+	}
+
+	// RINEX 3.04 clock files moved the labels to column 66
+	if strings.TrimSpace(column(first, 65, 85)) == "RINEX VERSION / TYPE" {
+		fields := strings.Fields(column(first, 0, 65))
+		d := Detection{Format: FORMAT_RINEX_CLOCK, Type: "C"}
+		if len(fields) > 0 {
+			d.Version = fields[0]
+		}
+		if len(fields) > 2 {
+			d.System = fields[2]
+		}
+		return d
+	}
+	return Detection{Format: FORMAT_UNKNOWN}
+}
+
+func detectRINEX(line string) Detection {
+	d := Detection{
+		Version: strings.TrimSpace(column(line, 0, 9)),
+		Type:    strings.TrimSpace(column(line, 20, 21)),
+		System:  strings.TrimSpace(column(line, 40, 41)),
+	}
+	version, err := strconv.ParseFloat(d.Version, 64)
+	if err != nil {
+		return Detection{Format: FORMAT_UNKNOWN}
+	}
+
+	switch d.Type {
+	case "O":
+		d.Format = FORMAT_RINEX_OBS
+	case "N", "G", "H", "L", "E":
+		d.Format = FORMAT_RINEX_NAV
+	case "C":
+		d.Format = FORMAT_RINEX_CLOCK
+	case "M":
+		d.Format = FORMAT_RINEX_MET
+	}
+	// RINEX 2 GLONASS, GEO and Galileo nav files name the system by type
+	if version < 3 && d.Format == FORMAT_RINEX_NAV && d.System == "" {
+		switch d.Type {
+		case "N":
+			d.System = "G"
+		case "G":
+			d.System = "R"
+		case "H":
+			d.System = "S"
+		case "L", "E":
+			d.System = "E"
+		}
+	}
+	return d
+}
@@ -0,0 +1,135 @@
+package gnss
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// =========================================================================
+
+// =========================================================================
+
+func TestDetectFormatBytes(t *testing.T) {
+	for _, c := range []struct {
+		head string
+		want Detection
+	}{
+		{"     2.11           N: GPS NAV DATA                         RINEX VERSION / TYPE\n",
+			Detection{FORMAT_RINEX_NAV, "2.11", "N", "G"}},
+		{"     2.01           GLONASS NAV DATA                        RINEX VERSION / TYPE\r\n",
+			Detection{FORMAT_RINEX_NAV, "2.01", "G", "R"}},
+		{"     2.10           H: GEO NAV MSG DATA                     RINEX VERSION / TYPE\n",
+			Detection{FORMAT_RINEX_NAV, "2.10", "H", "S"}},
+		{"     3.04           N: GNSS NAV DATA    M: MIXED            RINEX VERSION / TYPE\n",
+			Detection{FORMAT_RINEX_NAV, "3.04", "N", "M"}},
+		{"     3.04           OBSERVATION DATA    M                   RINEX VERSION / TYPE\n",
+			Detection{FORMAT_RINEX_OBS, "3.04", "O", "M"}},
+		{"     3.00           C                   G                   RINEX VERSION / TYPE\n",
+			Detection{FORMAT_RINEX_CLOCK, "3.00", "C", "G"}},
+		{"3.04           C                   M                             RINEX VERSION / TYPE\n",
+			Detection{FORMAT_RINEX_CLOCK, "3.04", "C", "M"}},
+		{"     3.05           METEOROLOGICAL DATA                     RINEX VERSION / TYPE\n",
+			Detection{FORMAT_RINEX_MET, "3.05", "M", ""}},
+		{"#dP2024  4 13 23  0  0.00000000     289 ORBIT IGb20 FIT  WHU\n",
+			Detection{FORMAT_SP3, "d", "P", ""}},
+		{"#cV2024  4 13 23  0  0.00000000     289 ORBIT IGb20 FIT  WHU\n",
+			Detection{FORMAT_SP3, "c", "V", ""}},
+		{"     1.0            IONOSPHERE MAPS     GPS                 IONEX VERSION / TYPE\n",
+			Detection{FORMAT_IONEX, "1.0", "I", "GPS"}},
+		{"     1.4            M                                       ANTEX VERSION / SYST\n",
+			Detection{FORMAT_ANTEX, "1.4", "", "M"}},
+		{"\xd3\x00\x13\x3e\xd0", Detection{Format: FORMAT_RTCM3}},
+		{"\xb5\x62\x01\x07", Detection{Format: FORMAT_UBX}},
+		{"\xd3\xff\x13", Detection{Format: FORMAT_UNKNOWN}},
+		{"#eP2024  4 13", Detection{Format: FORMAT_UNKNOWN}},
+		{"     x.yz           OBSERVATION DATA    M                   RINEX VERSION / TYPE\n",
+			Detection{Format: FORMAT_UNKNOWN}},
+		{"", Detection{Format: FORMAT_UNKNOWN}},
+	} {
+		if got := DetectFormatBytes([]byte(c.head)); got != c.want {
+			t.Errorf("%q: %+v, want %+v", c.head, got, c.want)
+		}
+	}
+}
+
+// DetectFormat looks through the compression layers and hands back the
+// whole input.
+func TestDetectFormat(t *testing.T) {
+	detection, r, err := DetectFormat(bytes.NewReader(readTestFile(t, "testdata/obs-304.crx.Z")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detection.Format != FORMAT_RINEX_OBS || detection.Version != "3.04" {
+		t.Errorf("detection %+v", detection)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, readTestFile(t, "testdata/obs-304.24o")) {
+		t.Errorf("%d bytes returned", len(data))
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	for _, c := range []struct {
+		path   string
+		format FileFormat
+		check  func(*ParsedFile) bool
+	}{
+		{"testdata/obs-211.24o.Z", FORMAT_RINEX_OBS, func(p *ParsedFile) bool { return p.Observation != nil && len(p.Epochs) == 3 }},
+		{"testdata/whu-short.sp3", FORMAT_SP3, func(p *ParsedFile) bool { return p.SP3 != nil }},
+		{"../brdc2050.24g", FORMAT_RINEX_NAV, func(p *ParsedFile) bool { return p.Navigation != nil && len(p.Navigation.GLONASS) > 0 }},
+	} {
+		if _, err := os.Stat(c.path); err != nil {
+			t.Logf("%s: %v", c.path, err)
+			continue
+		}
+		parsed, err := ParseFile(c.path, ParseOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", c.path, err)
+		}
+		if parsed.Format != c.format || !c.check(parsed) {
+			t.Errorf("%s: parsed as %v", c.path, parsed.Format)
+		}
+	}
+
+	const geo = "     2.10           H: GEO NAV MSG DATA                     RINEX VERSION / TYPE\n"
+	if _, err := Parse(strings.NewReader(geo), ParseOptions{}); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("RINEX 2 GEO file: %v, want ErrUnsupported", err)
+	}
+	if _, err := Parse(strings.NewReader("hello\n"), ParseOptions{}); err == nil {
+		t.Error("unknown format parsed")
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	const clock = "     3.00           C                   G                   RINEX VERSION / TYPE\n"
+	if _, err := Parse(strings.NewReader(clock), ParseOptions{}); err == nil {
+		t.Fatal("clock file parsed without a registered parser")
+	}
+
+	RegisterFormat(FORMAT_RINEX_CLOCK, func(r io.Reader, opts ParseOptions, result *ParsedFile) error {
+		data, err := io.ReadAll(r)
+		result.Data = string(data)
+		return err
+	})
+	t.Cleanup(func() { RegisterFormat(FORMAT_RINEX_CLOCK, nil) })
+
+	parsed, err := Parse(strings.NewReader(clock), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Format != FORMAT_RINEX_CLOCK || parsed.Data != clock {
+		t.Errorf("parsed %v %q", parsed.Format, parsed.Data)
+	}
+
+	RegisterFormat(FORMAT_RINEX_CLOCK, nil)
+	if _, err := Parse(strings.NewReader(clock), ParseOptions{}); err == nil {
+		t.Error("parser still registered after removal")
+	}
+}
@@ -0,0 +1,266 @@
+package gnss
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  GPS EPHEMERIS FORMAT (RINEX 2.11)
+// https://files.igs.org/pub/data/format/rinex211.txt
+// https://www.gps.gov/technical/icwg/IS-GPS-200N.pdf
+//  5 24  7 29 23 59 44.0-1.814444549382D-04-1.250555214938D-12 0.000000000000D+00
+//     1.600000000000D+01-1.039375000000D+02 4.031239345871D-09-2.266875691854D+00
+//    -5.450099706650D-06 5.963351577520D-03 7.448717951775D-06 5.153655038834D+03
+//     1.727840000000D+05 7.264316082001D-08 1.893942802914D+00 3.166496753693D-08
+//     9.721468054966D-01 2.340000000000D+02 1.298159075228D+00-7.757823144473D-09
+//     2.596536727606D-10 1.000000000000D+00 2.325000000000D+03 0.000000000000D+00
+//     2.000000000000D+00 0.000000000000D+00-1.071020960808D-08 1.600000000000D+01
+//     1.656180000000D+05 4.000000000000D+00
+// Line 1: PRN, epoch (toc), SV clock bias af0 (s), drift af1 (s/s), drift rate af2 (s/s²)
+// Line 2: IODE, Crs (m), Delta n (rad/s), M0 (rad)
+// Line 3: Cuc (rad), e, Cus (rad), sqrt(A) (sqrt(m))
+// Line 4: Toe (sec of GPS week), Cic (rad), OMEGA0 (rad), Cis (rad)
+// Line 5: i0 (rad), Crc (m), omega (rad), OMEGA DOT (rad/s)
+// Line 6: IDOT (rad/s), codes on L2, GPS week (of toe), L2 P data flag
+// Line 7: SV accuracy (m), SV health, TGD (s), IODC
+// Line 8: transmission time of message (sec of GPS week), fit interval (h)
+
+const gpsNavRecordLines = 8
+
+// ParseRINEXGPSFileV211 reads a RINEX 2 GPS navigation file, see ParseNav
+// for the reader based, configurable variant.
+func ParseRINEXGPSFileV211(filename string) (*RINEXHeader, []GPSEphemeris, error) {
+	nav, err := ParseNavFile(filename, ParseOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &nav.Header, nav.GPS, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// A record starts on the line carrying the PRN in columns 1-2, every
+// BROADCAST ORBIT line starts with three blanks.
+func parseGPSEphemeris(scanner *lineScanner) ([]navRecord, error) {
+	var ephemerides []navRecord
+	record := scanner.newRecord()
+
+	flush := func() error {
+		if record.len() == 0 {
+			return nil
+		}
+		current := record
+		record = scanner.newRecord()
+		eph, err := processGPSEphemerisLines(current)
+		if err != nil {
+			return scanner.recordError(current, err)
+		}
+		ephemerides = append(ephemerides, eph)
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if len(line) >= 2 && strings.TrimSpace(line[:2]) != "" {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		record.add(line)
+
+		if record.len() == gpsNavRecordLines {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	scanner.debug("parsed GPS ephemerides", "count", len(ephemerides))
+	return ephemerides, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func processGPSEphemerisLines(record *lineRecord) (navRecord, error) {
+	lines := record.lines
+	if len(lines) != gpsNavRecordLines {
+		return navRecord{}, fmt.Errorf("invalid number of lines for GPS ephemeris record: %d", len(lines))
+	}
+	if len(lines[0]) < 22 {
+		return navRecord{}, fmt.Errorf("line 1 is too short: %d characters", len(lines[0]))
+	}
+
+	var svId int
+	epoch := epochFields{shortYear: true}
+	record.decode(0, navEpochV2, append([]any{&svId}, epoch.dst()...)...)
+	epochTime := epoch.time()
+
+	values := record.orbitValues(3)
+	if record.err != nil {
+		return navRecord{}, record.err
+	}
+	return navRecord{system: 'G', svId: svId, epoch: epochTime, messageType: NavMessageType_lnav, values: values}, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// fillKeplerEphemeris writes the clock terms and the Keplerian orbit of a
+// broadcast record (values in file order: 3 clock terms then BROADCAST ORBIT
+// 1-4 and IDOT) into data. These columns are shared by GPS, QZSS, Galileo,
+// BeiDou and NavIC, the remaining columns are system specific.
+func fillKeplerEphemeris(data Ephemeris, svId int, epochTime time.Time, v []float64) {
+	data.SetSvId(uint16(svId))
+	data.SetYear(uint16(epochTime.Year()))
+	data.SetMonth(uint16(epochTime.Month()))
+	data.SetDay(uint16(epochTime.Day()))
+	data.SetHour(uint16(epochTime.Hour()))
+	data.SetMinute(uint16(epochTime.Minute()))
+	data.SetSecond(float32(epochTime.Second()) + float32(epochTime.Nanosecond())/1e9)
+
+	data.SetAf0(v[0])
+	data.SetAf1(v[1])
+	data.SetAf2(v[2])
+
+	data.SetIode(v[3])
+	data.SetCrs(v[4])
+	data.SetDeltaN(v[5])
+	data.SetM0(v[6])
+
+	data.SetCuc(v[7])
+	data.SetEcc(v[8])
+	data.SetCus(v[9])
+	data.SetA(v[10] * v[10])
+
+	data.SetToe(v[11])
+	data.SetCic(v[12])
+	data.SetOmega0(v[13])
+	data.SetCis(v[14])
+
+	data.SetI0(v[15])
+	data.SetCrc(v[16])
+	data.SetOmega(v[17])
+	data.SetOmegaDot(v[18])
+
+	data.SetIDot(v[19])
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// navMaxTimeDifference is the default validity (maximumTimeDifference, s)
+// of a broadcast record around its epoch per constellation: half the
+// update interval plus margin. QZSS and NavIC use the GPS fit interval.
+var navMaxTimeDifference = map[byte]float64{
+	'G': 2 * SECS_IN_HR,
+	'J': 2 * SECS_IN_HR,
+	'I': 2 * SECS_IN_HR,
+	'E': 3 * SECS_IN_HR,
+	'C': SECS_IN_HR,
+	'R': glonassMaxTimeDiff,
+	'S': 360,
+}
+
+func setBaseEphemeris(base BaseEphemeris, prn string, epoch GPSTime, healthy bool) error {
+	if err := base.SetPseudoRandomNumber(prn); err != nil {
+		return fmt.Errorf("failed to set PRN: %v", err)
+	}
+	newEpoch, err := base.NewEpoch()
+	if err != nil {
+		return fmt.Errorf("failed to create new epoch: %v", err)
+	}
+	setGPSTime(newEpoch, epoch)
+	base.SetEphemerisType(NAV)
+	base.SetIsHealthy(healthy)
+	base.SetMaximumTimeDifference(navMaxTimeDifference[prn[0]])
+	return nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func setGPSTime(dst GPSTime, src GPSTime) {
+	dst.SetWeek(src.Week())
+	dst.SetTimeOfWeek(src.TimeOfWeek())
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// fillGPSEphemeris writes the 29 values of a GPS LNAV record (3 clock terms
+// followed by the BROADCAST ORBIT 1-7 fields, in file order) into eph.
+func fillGPSEphemeris(eph GPSEphemeris, prn string, svId int, epochTime time.Time, v []float64) error {
+	if len(v) < 29 {
+		return fmt.Errorf("GPS ephemeris record for %s has %d values, need 29", prn, len(v))
+	}
+
+	data, err := eph.NewEphemerisData()
+	if err != nil {
+		return fmt.Errorf("failed to create new Ephemeris: %v", err)
+	}
+	fillKeplerEphemeris(data, svId, epochTime, v)
+
+	data.SetCodesL2(v[20])
+	week := int(v[21])
+	data.SetL2(v[22])
+
+	data.SetSvAcc(v[23])
+	data.SetSvHealth(v[24])
+	data.SetTgd(v[25])
+	data.SetIodc(v[26])
+
+	data.SetTransmissionTime(v[27])
+	data.SetFitInterval(v[28])
+	data.SetTowCount(uint32(v[27]))
+
+	// The epoch of a GPS navigation record is toc in GPS time
+	tocTime := GPSTimeFromDateTime(epochTime)
+	data.SetToc(tocTime.TimeOfWeek())
+	data.SetTocWeek(uint16(tocTime.Week()))
+	data.SetToeWeek(uint16(week))
+
+	toe, err := eph.NewToe()
+	if err != nil {
+		return fmt.Errorf("failed to create new toe: %v", err)
+	}
+	toe.SetWeek(int32(week))
+	toe.SetTimeOfWeek(v[11])
+
+	toc, err := eph.NewToc()
+	if err != nil {
+		return fmt.Errorf("failed to create new toc: %v", err)
+	}
+	setGPSTime(toc, tocTime)
+
+	eph.SetSquareRootOfSemiMajorAxis(v[10])
+	eph.SetMessageType(NavMessageType_lnav)
+
+	base, err := eph.NewBaseEphemeris()
+	if err != nil {
+		return fmt.Errorf("failed to create new BaseEphemeris: %v", err)
+	}
+	return setBaseEphemeris(base, prn, tocTime, v[24] == 0)
+}
@@ -0,0 +1,181 @@
+package gnss
+
+import (
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testFile returns the path of a navigation file bundled at the root of the
+// repository, the test is skipped when it is missing.
+func testFile(tb testing.TB, name string) string {
+	tb.Helper()
+	path := "../" + name
+	if _, err := os.Stat(path); err != nil {
+		tb.Skip(err)
+	}
+	return path
+}
+
+// navRecordCount counts the records of a RINEX 2 navigation file, the lines
+// after the header with a PRN in columns 1-2.
+func navRecordCount(tb testing.TB, path string) int {
+	tb.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	_, body, ok := strings.Cut(string(data), "END OF HEADER")
+	if !ok {
+		tb.Fatalf("%s: no END OF HEADER", path)
+	}
+	count := 0
+	for _, line := range strings.Split(body, "\n")[1:] {
+		if len(line) >= 2 && strings.TrimSpace(line[:2]) != "" {
+			count++
+		}
+	}
+	return count
+}
+
+func distance(a, b []float64) float64 {
+	return math.Sqrt((a[0]-b[0])*(a[0]-b[0]) + (a[1]-b[1])*(a[1]-b[1]) + (a[2]-b[2])*(a[2]-b[2]))
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func TestParseRINEXGPSFileV211(t *testing.T) {
+	path := testFile(t, "abpo2120.24n")
+	header, ephs, err := ParseRINEXGPSFileV211(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Version() != 2.11 {
+		t.Errorf("version %v, want 2.11", header.Version())
+	}
+	if want := navRecordCount(t, path); len(ephs) != want {
+		t.Fatalf("%d ephemerides, want %d", len(ephs), want)
+	}
+
+	// first record of the file
+	//  5 24  7 29 23 59 44.0-1.814444549382D-04-1.250555214938D-12 0.000000000000D+00
+	eph := ephs[0]
+	if prn := eph.PRN(); prn != "G05" {
+		t.Errorf("PRN %q, want G05", prn)
+	}
+	data, err := eph.EphemerisData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"af0", data.Af0(), -1.814444549382e-04},
+		{"af1", data.Af1(), -1.250555214938e-12},
+		{"iode", data.Iode(), 16},
+		{"crs", data.Crs(), -1.039375000000e+02},
+		{"m0", data.M0(), -2.266875691854},
+		{"e", data.Ecc(), 5.963351577520e-03},
+		{"sqrtA", eph.SquareRootOfSemiMajorAxis(), 5.153655038834e+03},
+		{"toe", data.Toe(), 1.727840000000e+05},
+		{"i0", data.I0(), 9.721468054966e-01},
+		{"omegaDot", data.OmegaDot(), -7.757823144473e-09},
+		{"tgd", data.Tgd(), -1.071020960808e-08},
+		{"iodc", data.Iodc(), 16},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	toe, err := eph.Toe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if toe.Week() != 2325 || toe.TimeOfWeek() != 172784 {
+		t.Errorf("toe week %d tow %v, want 2325 172784", toe.Week(), toe.TimeOfWeek())
+	}
+	toc, err := eph.Toc()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := toc.ToDateTime(), time.Date(2024, 7, 29, 23, 59, 44, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("toc %v, want %v", got, want)
+	}
+}
+
+// Consecutive ephemerides of a satellite must agree where their fit
+// intervals overlap: at the toe of the later one the earlier is two hours
+// from its own toe.
+func TestGPSEphemerisContinuity(t *testing.T) {
+	_, ephs, err := ParseRINEXGPSFileV211(testFile(t, "abpo2120.24n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := make(map[string]GPSEphemeris)
+	pairs := 0
+	worst := 0.0
+	for _, eph := range ephs {
+		prev, ok := last[eph.PRN()]
+		last[eph.PRN()] = eph
+		if !ok || !prev.Healthy() || !eph.Healthy() {
+			continue
+		}
+		toe, _ := eph.Toe()
+		prevToe, _ := prev.Toe()
+		if dt := toe.Sub(prevToe); dt <= 0 || dt > 7200 {
+			continue
+		}
+		p1, _, clock1, _, err := prev.GetSatInfo(toe)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p2, _, clock2, _, err := eph.GetSatInfo(toe)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pairs++
+		d := distance(p1, p2)
+		worst = math.Max(worst, d)
+		if d > 5 {
+			t.Errorf("%s at %v: positions of consecutive ephemerides %.2f m apart", eph.PRN(), toe.ToDateTime(), d)
+		}
+		if dc := math.Abs(clock1-clock2) * SPEED_OF_LIGHT; dc > 5 {
+			t.Errorf("%s at %v: clocks of consecutive ephemerides %.2f m apart", eph.PRN(), toe.ToDateTime(), dc)
+		}
+	}
+	if pairs == 0 {
+		t.Fatal("no consecutive ephemerides")
+	}
+	t.Logf("%d pairs, largest difference %.2f m", pairs, worst)
+}
+
+func TestGPSVelocity(t *testing.T) {
+	_, ephs, err := ParseRINEXGPSFileV211(testFile(t, "abpo2120.24n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	eph := ephs[0]
+	toe, _ := eph.Toe()
+	for _, dt := range []float64{-3600, 0, 3600} {
+		at := toe.Add(dt)
+		pos, vel, _, _, err := eph.GetSatInfo(at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r := math.Sqrt(pos[0]*pos[0] + pos[1]*pos[1] + pos[2]*pos[2]); r < 26.0e6 || r > 27.2e6 {
+			t.Errorf("%+v s: orbit radius %.0f m", dt, r)
+		}
+		before, _, _, _, _ := eph.GetSatInfo(toe.Add(dt - 0.5))
+		after, _, _, _, _ := eph.GetSatInfo(toe.Add(dt + 0.5))
+		for i := range vel {
+			if d := math.Abs(after[i] - before[i] - vel[i]); d > 1e-3 {
+				t.Errorf("%+v s: velocity %d %.4f m/s, difference quotient %.4f m/s", dt, i, vel[i], after[i]-before[i])
+			}
+		}
+	}
+}
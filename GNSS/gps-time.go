@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"capnproto.org/go/capnp/v3"
 )
 
 const SecondsInWeek = 604800
@@ -73,9 +75,21 @@ func newTimeSync(monoTime time.Duration, gpsTime GPSTime) *TimeSync {
 
 // ========================================
 
-// func NewGPSTime(week int, tow float64) GPSTime {
-// 	return GPSTime{week, tow}
-// }
+// GPSTime is a capnp struct, so a zero value cannot be written to. Every
+// standalone GPSTime gets its own single segment message.
+func GPSTimeFromWeek(week int, tow float64) GPSTime {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return GPSTime{}
+	}
+	gpsTime, err := NewRootGPSTime(seg)
+	if err != nil {
+		return GPSTime{}
+	}
+	gpsTime.SetWeek(int32(week))
+	gpsTime.SetTimeOfWeek(tow)
+	return gpsTime
+}
 
 // =======================================
 
@@ -131,10 +145,7 @@ func GPSTimeFromDateTime(t time.Time) GPSTime {
 	wk := int(t.Sub(wkRef).Hours()/24/7) + refWk
 	tow := float64(t.Sub(wkRef).Seconds()) - float64((wk-refWk)*SecondsInWeek)
 
-	var gpsTime GPSTime
-	gpsTime.SetWeek(int32(wk))
-	gpsTime.SetTimeOfWeek(tow)
-	return gpsTime
+	return GPSTimeFromWeek(wk, tow)
 }
 
 // =======================================
@@ -167,10 +178,7 @@ func (t GPSTime) Add(seconds float64) GPSTime {
 		newWeek++
 	}
 
-	var newGPSTime GPSTime
-	newGPSTime.SetWeek(newWeek)
-	newGPSTime.SetTimeOfWeek(newTow)
-	return newGPSTime
+	return GPSTimeFromWeek(int(newWeek), newTow)
 }
 
 // =======================================
@@ -0,0 +1,428 @@
+package gnss
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  HATANAKA COMPRESSED RINEX (CRINEX 1.0 / 3.0)
+// https://terras.gsi.go.jp/ja/crx2rnx.html
+// Y. Hatanaka, A Compression Format and Tools for GNSS Observation Data,
+// Bulletin of the GSI, 55, 2008
+//
+// Two CRINEX header lines precede the RINEX header, which is copied as is.
+// Every epoch then consists of:
+//
+//   epoch line   text differenced against the previous epoch line: a blank
+//                keeps the old character, '&' stands for a blank. A leading
+//                '&' (CRINEX 1) or '>' (CRINEX 3) starts over. The satellite
+//                list follows without continuation lines from column 33
+//                (CRINEX 1) or 42 (CRINEX 3), the receiver clock is moved to
+//                the next line.
+//   clock line   receiver clock offset, empty when there is none
+//   data lines   one per satellite of the list, one field per observation
+//                type separated by single blanks, then the LLI / signal
+//                strength flags text differenced against the previous epoch
+//
+// Numeric fields hold integers (observations in 0.001, clocks in units of the
+// last RINEX decimal). "n&v" starts an arc of difference order n with value
+// v, any other value is the next n-th order difference. An empty field is a
+// blank observation, a satellite missing from an epoch starts over.
+// Event records (flags 2-5) are copied without clock line.
+
+const (
+	crinexVersionLabel = "CRINEX VERS   / TYPE"
+	crinexMaxArcOrder  = 9
+)
+
+type crinexArc struct {
+	valid bool
+	order int
+	arc   int
+	diffs [crinexMaxArcOrder + 1]int64
+}
+
+// update decodes field, it reports whether the field held a value.
+func (a *crinexArc) update(field string) (bool, error) {
+	if field == "" {
+		a.valid = false
+		return false, nil
+	}
+	if len(field) > 1 && field[1] == '&' {
+		arc := int(field[0] - '0')
+		if arc < 0 || arc > crinexMaxArcOrder {
+			return false, fmt.Errorf("invalid arc order in %q", field)
+		}
+		v, err := strconv.ParseInt(field[2:], 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("invalid value %q", field)
+		}
+		*a = crinexArc{valid: true, arc: arc}
+		a.diffs[0] = v
+		return true, nil
+	}
+	if !a.valid {
+		return false, fmt.Errorf("difference %q without initialized arc", field)
+	}
+	v, err := strconv.ParseInt(field, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q", field)
+	}
+	if a.order < a.arc {
+		a.order++
+	}
+	a.diffs[a.order] = v
+	for k := a.order; k > 0; k-- {
+		a.diffs[k-1] += a.diffs[k]
+	}
+	return true, nil
+}
+
+func (a *crinexArc) value() int64 {
+	return a.diffs[0]
+}
+
+type crinexSatellite struct {
+	arcs  []crinexArc
+	flags string
+}
+
+// =========================================================================
+
+// =========================================================================
+
+type crinexReader struct {
+	scanner      *bufio.Scanner
+	version      int
+	observations map[string]int
+	header       bool
+	line         int
+	epoch        string
+	clock        crinexArc
+	satellites   map[string]*crinexSatellite
+	out          bytes.Buffer
+	err          error
+}
+
+func newCRINEXReader(r io.Reader) (*crinexReader, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	c := &crinexReader{
+		scanner:      scanner,
+		observations: make(map[string]int),
+		satellites:   make(map[string]*crinexSatellite),
+	}
+
+	lines, err := c.readLines(2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CRINEX header: %v", err)
+	}
+	version := strings.TrimSpace(column(lines[0], 0, 20))
+	switch {
+	case strings.HasPrefix(version, "1."):
+		c.version = 1
+	case strings.HasPrefix(version, "3."):
+		c.version = 3
+	default:
+		return nil, fmt.Errorf("unsupported CRINEX version %q", version)
+	}
+	return c, nil
+}
+
+func (c *crinexReader) Read(p []byte) (int, error) {
+	for c.out.Len() == 0 && c.err == nil {
+		if !c.header {
+			c.err = c.copyHeader()
+		} else {
+			c.err = c.decodeEpoch()
+		}
+	}
+	if c.out.Len() > 0 {
+		return c.out.Read(p)
+	}
+	return 0, c.err
+}
+
+func (c *crinexReader) readLines(count int) ([]string, error) {
+	lines := make([]string, 0, count)
+	for len(lines) < count {
+		if !c.scanner.Scan() {
+			if err := c.scanner.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.ErrUnexpectedEOF
+		}
+		c.line++
+		lines = append(lines, strings.TrimRight(c.scanner.Text(), "\r"))
+	}
+	return lines, nil
+}
+
+func (c *crinexReader) writeLine(line string) {
+	c.out.WriteString(strings.TrimRight(line, " "))
+	c.out.WriteByte('\n')
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// copyHeader copies the RINEX header and keeps the number of observation
+// types per system ("" for RINEX 2).
+func (c *crinexReader) copyHeader() error {
+	for {
+		lines, err := c.readLines(1)
+		if err != nil {
+			return fmt.Errorf("failed to read RINEX header: %v", err)
+		}
+		line := lines[0]
+		c.out.WriteString(line)
+		c.out.WriteByte('\n')
+
+		if strings.TrimSpace(column(line, 60, 80)) == "END OF HEADER" {
+			c.header = true
+			return nil
+		}
+		c.observationTypes(line)
+	}
+}
+
+// observationTypes keeps the number of observation types of a header line,
+// event records (flag 4) may redefine them.
+func (c *crinexReader) observationTypes(line string) {
+	switch strings.TrimSpace(column(line, 60, 80)) {
+	case "# / TYPES OF OBSERV":
+		if n := strings.TrimSpace(column(line, 0, 6)); n != "" {
+			c.observations[""] = parseInt(n)
+		}
+	case "SYS / # / OBS TYPES":
+		if system := strings.TrimSpace(column(line, 0, 1)); system != "" {
+			c.observations[system] = parseInt(column(line, 3, 6))
+		}
+	}
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func (c *crinexReader) decodeEpoch() error {
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			return err
+		}
+		return io.EOF
+	}
+	c.line++
+	diff := strings.TrimRight(c.scanner.Text(), "\r")
+
+	if (c.version == 1 && strings.HasPrefix(diff, "&")) || (c.version == 3 && strings.HasPrefix(diff, ">")) {
+		c.epoch = ""
+	}
+	c.epoch = repairText(c.epoch, diff)
+
+	flagColumn, countColumn, satelliteColumn := 28, 29, 32
+	if c.version == 3 {
+		flagColumn, countColumn, satelliteColumn = 31, 32, 41
+	}
+	flag := parseInt(column(c.epoch, flagColumn, flagColumn+1))
+	count, err := strconv.Atoi(strings.TrimSpace(column(c.epoch, countColumn, countColumn+3)))
+	if err != nil {
+		return fmt.Errorf("CRINEX line %d: invalid epoch line %q", c.line, c.epoch)
+	}
+
+	if flag >= 2 && flag <= 5 {
+		c.writeLine(column(c.epoch, 0, satelliteColumn))
+		lines, err := c.readLines(count)
+		if err != nil {
+			return fmt.Errorf("CRINEX line %d: failed to read event records: %v", c.line, err)
+		}
+		for _, line := range lines {
+			c.writeLine(line)
+			c.observationTypes(line)
+		}
+		c.epoch = ""
+		return nil
+	}
+
+	prns := make([]string, count)
+	for i := range prns {
+		prns[i] = column(c.epoch, satelliteColumn+3*i, satelliteColumn+3*i+3)
+	}
+
+	lines, err := c.readLines(1 + count)
+	if err != nil {
+		return fmt.Errorf("CRINEX line %d: failed to read epoch data: %v", c.line, err)
+	}
+
+	clock := ""
+	hasClock, err := c.clock.update(strings.TrimSpace(lines[0]))
+	if err != nil {
+		return fmt.Errorf("CRINEX line %d: receiver clock: %v", c.line-count, err)
+	}
+	if hasClock {
+		if c.version == 1 {
+			clock = formatScaled(c.clock.value(), 9, 12)
+		} else {
+			clock = formatScaled(c.clock.value(), 12, 15)
+		}
+	}
+
+	c.writeEpochLine(prns, clock)
+
+	satellites := make(map[string]*crinexSatellite, count)
+	for i, prn := range prns {
+		sat, ok := c.satellites[prn]
+		if !ok {
+			sat = &crinexSatellite{}
+		}
+		fields, err := c.decodeSatellite(sat, prn, lines[1+i])
+		if err != nil {
+			return fmt.Errorf("CRINEX line %d: %s: %v", c.line-count+1+i, prn, err)
+		}
+		satellites[prn] = sat
+		c.writeSatellite(prn, fields)
+	}
+	c.satellites = satellites
+	return nil
+}
+
+func (c *crinexReader) decodeSatellite(sat *crinexSatellite, prn, line string) ([]string, error) {
+	system := ""
+	if c.version == 3 {
+		system = prn[:1]
+	}
+	count, ok := c.observations[system]
+	if !ok {
+		return nil, fmt.Errorf("no observation types for system %q", system)
+	}
+	if len(sat.arcs) != count {
+		sat.arcs = make([]crinexArc, count)
+	}
+
+	values := make([]string, count)
+	pos := 0
+	for j := 0; j < count; j++ {
+		field := ""
+		if pos < len(line) {
+			end := strings.IndexByte(line[pos:], ' ')
+			if end < 0 {
+				end = len(line) - pos
+			}
+			field = line[pos : pos+end]
+			pos += end + 1
+		} else {
+			pos = len(line) + 1
+		}
+		ok, err := sat.arcs[j].update(field)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			values[j] = formatScaled(sat.arcs[j].value(), 3, 14)
+		}
+	}
+	if pos < len(line) {
+		sat.flags = repairText(sat.flags, line[pos:])
+	}
+
+	fields := make([]string, count)
+	for j := range fields {
+		value := values[j]
+		if value == "" {
+			value = strings.Repeat(" ", 14)
+		}
+		fields[j] = value + column(sat.flags, 2*j, 2*j+1) + column(sat.flags, 2*j+1, 2*j+2)
+		fields[j] += strings.Repeat(" ", 16-len(fields[j]))
+	}
+	return fields, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func (c *crinexReader) writeEpochLine(prns []string, clock string) {
+	if c.version == 3 {
+		line := column(c.epoch, 0, 35)
+		if clock != "" {
+			line += strings.Repeat(" ", 41-len(line)) + clock
+		}
+		c.writeLine(line)
+		return
+	}
+
+	line := column(c.epoch, 0, 32)
+	for i := 0; i < len(prns) || i == 0; i += 12 {
+		end := i + 12
+		if end > len(prns) {
+			end = len(prns)
+		}
+		if i > 0 {
+			line = strings.Repeat(" ", 32)
+		}
+		line += strings.Join(prns[i:end], "")
+		if i == 0 && clock != "" {
+			line += strings.Repeat(" ", 68-len(line)) + clock
+		}
+		c.writeLine(line)
+	}
+}
+
+func (c *crinexReader) writeSatellite(prn string, fields []string) {
+	if c.version == 3 {
+		c.writeLine(prn + strings.Join(fields, ""))
+		return
+	}
+	for i := 0; i < len(fields) || i == 0; i += 5 {
+		end := i + 5
+		if end > len(fields) {
+			end = len(fields)
+		}
+		c.writeLine(strings.Join(fields[i:end], ""))
+	}
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// repairText applies a CRINEX text difference to old.
+func repairText(old, diff string) string {
+	b := []byte(old)
+	for i := 0; i < len(diff); i++ {
+		if i >= len(b) {
+			b = append(b, ' ')
+		}
+		switch diff[i] {
+		case ' ':
+		case '&':
+			b[i] = ' '
+		default:
+			b[i] = diff[i]
+		}
+	}
+	return string(b)
+}
+
+// formatScaled writes v / 10^decimals right aligned in width columns.
+func formatScaled(v int64, decimals, width int) string {
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	scale := int64(1)
+	for i := 0; i < decimals; i++ {
+		scale *= 10
+	}
+	s := fmt.Sprintf("%s%d.%0*d", sign, v/scale, decimals, v%scale)
+	return fmt.Sprintf("%*s", width, s)
+}
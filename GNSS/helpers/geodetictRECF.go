@@ -249,6 +249,31 @@ func (lc *LocalCoordinates) NEDToGeodetic(ned []float64) []float64 {
 
 // ======================================
 
+// AzimuthElevation returns the direction of satellite seen from receiver,
+// both ECEF in meters: azimuth clockwise from north in [0, 2π), elevation
+// above the local horizon, in radians, and the range in meters.
+func AzimuthElevation(receiver, satellite []float64) (float64, float64, float64) {
+	geodetic := ECEFToGeodetic([][]float64{receiver}, true)[0]
+	sinLat, cosLat := math.Sincos(geodetic[0])
+	sinLon, cosLon := math.Sincos(geodetic[1])
+
+	d := Subtract(satellite, receiver)
+	east := -sinLon*d[0] + cosLon*d[1]
+	north := -sinLat*cosLon*d[0] - sinLat*sinLon*d[1] + cosLat*d[2]
+	up := cosLat*cosLon*d[0] + cosLat*sinLon*d[1] + sinLat*d[2]
+
+	distance := math.Sqrt(east*east + north*north + up*up)
+	azimuth := math.Atan2(east, north)
+	if azimuth < 0 {
+		azimuth += 2 * math.Pi
+	}
+	return azimuth, math.Asin(up / distance), distance
+}
+
+// ======================================
+
+// ======================================
+
 func Transpose(matrix [][]float64) [][]float64 {
 	rows := len(matrix)
 	cols := len(matrix[0])
@@ -0,0 +1,401 @@
+package gnss
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  IONEX 1.0 / 1.1
+// https://files.igs.org/pub/data/format/ionex1.pdf
+// Global or regional maps of the vertical TEC, its RMS and optionally the
+// height of the layer, on a latitude / longitude (/ height) grid:
+//
+//      1.1            IONOSPHERE MAPS     GPS                 IONEX VERSION / TYPE
+//     24                                                      # OF MAPS IN FILE
+//   6371.0                                                    BASE RADIUS
+//      2                                                      MAP DIMENSION
+//    450.0 450.0   0.0                                        HGT1 / HGT2 / DHGT
+//     87.5 -87.5  -2.5                                        LAT1 / LAT2 / DLAT
+//   -180.0 180.0   5.0                                        LON1 / LON2 / DLON
+//     -1                                                      EXPONENT
+// DIFFERENTIAL CODE BIASES                                    START OF AUX DATA
+//    G01    -7.993     0.008                                  PRN / BIAS / RMS
+//    G  ALBH 40129M003          -2.063     0.012              STATION / BIAS / RMS
+// DIFFERENTIAL CODE BIASES                                    END OF AUX DATA
+//                                                             END OF HEADER
+//      1                                                      START OF TEC MAP
+//   2024     7    30     0     0     0                        EPOCH OF CURRENT MAP
+//     87.5-180.0 180.0   5.0 450.0                            LAT/LON1/LON2/DLON/H
+//    92   92   93   93   94   94   95   95   96   96   97   97   98   98   99   99
+//
+// Values are integers in units of 10^EXPONENT TECU (default 0.1 TECU), an
+// EXPONENT record within a map changes the unit for the rest of the map.
+// 9999 marks missing values. Epochs are UT. IONEX 1.0 satellite biases carry
+// the PRN only, 1.1 adds the system letter and a system per station. Biases
+// are in ns, heights in km, angles in degrees as in the file. HEIGHT maps
+// are skipped.
+
+// IONEX is a parsed IONEX file, TEC and RMS hold the maps in epoch order.
+type IONEX struct {
+	Version float64
+	// I for ionosphere maps
+	Type string
+	// satellite system or model: GPS, GLO, GAL, GNS, MIX, ENV, NNS...
+	System      string
+	Program     string
+	Agency      string
+	Date        string
+	Description []string
+	Comments    []string
+	FirstEpoch  time.Time
+	LastEpoch   time.Time
+	// s
+	Interval int
+	// NONE, COSZ, QFAC
+	MappingFunction string
+	// degrees
+	ElevationCutoff float64
+	Observables     string
+	Maps            int
+	Stations        int
+	Satellites      int
+	// km
+	BaseRadius float64
+	// 2 or 3
+	Dimension int
+	// km, degrees, degrees
+	Height    IONEXAxis
+	Latitude  IONEXAxis
+	Longitude IONEXAxis
+	TEC       []IONEXMap
+	RMS       []IONEXMap
+	// differential code biases of the auxiliary data block
+	SatelliteBiases []IONEXBias
+	StationBiases   []IONEXBias
+	Warnings        []*ParseError
+}
+
+// IONEXAxis is one dimension of the grid, End is included.
+type IONEXAxis struct {
+	Start, End, Step float64
+}
+
+// Count returns the number of grid points along the axis.
+func (a IONEXAxis) Count() int {
+	if a.Step == 0 {
+		return 1
+	}
+	return int(math.Round((a.End-a.Start)/a.Step)) + 1
+}
+
+// index returns the fractional grid index of x.
+func (a IONEXAxis) index(x float64) float64 {
+	if a.Step == 0 {
+		return 0
+	}
+	return (x - a.Start) / a.Step
+}
+
+// IONEXMap is one TEC or RMS map.
+type IONEXMap struct {
+	Epoch time.Time
+	// TECU, NaN where missing: heights, latitudes then longitudes, the
+	// longitude index runs fastest
+	Values []float64
+}
+
+// IONEXBias is a PRN / BIAS / RMS or STATION / BIAS / RMS record.
+type IONEXBias struct {
+	// system letter, empty for IONEX 1.0 stations
+	System string
+	// satellite (G01) or station (ALBH)
+	ID string
+	// DOMES number of stations
+	Domes string
+	// ns
+	Bias float64
+	RMS  float64
+}
+
+const ionexMissing = 9999
+
+// =========================================================================
+
+// =========================================================================
+
+// ParseIONEXFile opens filename and parses it with ParseIONEX,
+// opts.FileName defaults to filename.
+func ParseIONEXFile(filename string, opts ParseOptions) (*IONEX, error) {
+	file, err := openFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if opts.FileName == "" {
+		opts.FileName = filename
+	}
+	return ParseIONEX(file, opts)
+}
+
+// ParseIONEX reads an IONEX 1.0 / 1.1 file from r.
+func ParseIONEX(r io.Reader, opts ParseOptions) (*IONEX, error) {
+	scanner := newLineScanner(r, opts)
+	x := &IONEX{Dimension: 2}
+	exponent, err := parseIONEXHeader(scanner, x)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing header: %w", err)
+	}
+	if x.Latitude.Step == 0 || x.Longitude.Step == 0 {
+		return nil, fmt.Errorf("missing LAT1 / LAT2 / DLAT or LON1 / LON2 / DLON")
+	}
+	if x.Dimension == 2 && x.Height.Step != 0 {
+		return nil, fmt.Errorf("2D maps with %d heights", x.Height.Count())
+	}
+	if err := parseIONEXMaps(scanner, x, exponent); err != nil {
+		return nil, fmt.Errorf("error parsing maps: %w", err)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read input: %v", err)
+	}
+	for _, maps := range [][]IONEXMap{x.TEC, x.RMS} {
+		sort.SliceStable(maps, func(i, j int) bool { return maps[i].Epoch.Before(maps[j].Epoch) })
+	}
+	x.Warnings = scanner.warnings
+	return x, nil
+}
+
+// parseIONEXHeader reads the header up to END OF HEADER and returns the
+// exponent of the values.
+func parseIONEXHeader(scanner *lineScanner, x *IONEX) (int, error) {
+	exponent := -1
+	aux := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) < 60 {
+			continue
+		}
+		record := scanner.newRecord()
+		record.add(line)
+		label := strings.TrimRight(line[60:], " ")
+		text := strings.TrimSpace(line[:60])
+
+		switch label {
+		case "IONEX VERSION / TYPE":
+			x.Version = record.float(0, 0, 8)
+			x.Type = strings.TrimSpace(column(line, 20, 21))
+			x.System = strings.TrimSpace(column(line, 40, 43))
+		case "PGM / RUN BY / DATE":
+			x.Program = strings.TrimSpace(line[:20])
+			x.Agency = strings.TrimSpace(line[20:40])
+			x.Date = strings.TrimSpace(line[40:60])
+		case "DESCRIPTION":
+			x.Description = append(x.Description, text)
+		case "COMMENT":
+			x.Comments = append(x.Comments, text)
+		case "EPOCH OF FIRST MAP", "EPOCH OF LAST MAP":
+			var e epochFields
+			record.decode(0, ionexEpoch, e.dst()...)
+			if label == "EPOCH OF FIRST MAP" {
+				x.FirstEpoch = e.time()
+			} else {
+				x.LastEpoch = e.time()
+			}
+		case "INTERVAL":
+			x.Interval = record.integer(0, 0, 6)
+		case "MAPPING FUNCTION":
+			x.MappingFunction = strings.TrimSpace(column(line, 2, 6))
+		case "ELEVATION CUTOFF":
+			x.ElevationCutoff = record.float(0, 0, 8)
+		case "OBSERVABLES USED":
+			x.Observables = text
+		case "# OF MAPS IN FILE":
+			x.Maps = record.integer(0, 0, 6)
+		case "# OF STATIONS":
+			x.Stations = record.integer(0, 0, 6)
+		case "# OF SATELLITES":
+			x.Satellites = record.integer(0, 0, 6)
+		case "BASE RADIUS":
+			x.BaseRadius = record.float(0, 0, 8)
+		case "MAP DIMENSION":
+			x.Dimension = record.integer(0, 0, 6)
+		case "HGT1 / HGT2 / DHGT":
+			record.decode(0, ionexAxis, &x.Height.Start, &x.Height.End, &x.Height.Step)
+		case "LAT1 / LAT2 / DLAT":
+			record.decode(0, ionexAxis, &x.Latitude.Start, &x.Latitude.End, &x.Latitude.Step)
+		case "LON1 / LON2 / DLON":
+			record.decode(0, ionexAxis, &x.Longitude.Start, &x.Longitude.End, &x.Longitude.Step)
+		case "EXPONENT":
+			exponent = record.integer(0, 0, 6)
+		case "START OF AUX DATA":
+			aux = text == "DIFFERENTIAL CODE BIASES"
+		case "END OF AUX DATA":
+			aux = false
+		case "PRN / BIAS / RMS":
+			if aux {
+				x.SatelliteBiases = append(x.SatelliteBiases, parseIONEXSatelliteBias(record, x.System))
+			}
+		case "STATION / BIAS / RMS":
+			if aux {
+				var b IONEXBias
+				record.decode(0, ionexStationBias, &b.System, &b.ID, &b.Domes, &b.Bias, &b.RMS)
+				x.StationBiases = append(x.StationBiases, b)
+			}
+		case "END OF HEADER":
+			return exponent, record.err
+		}
+		if record.err != nil {
+			return 0, record.err
+		}
+	}
+	return 0, fmt.Errorf("missing END OF HEADER")
+}
+
+// parseIONEXSatelliteBias reads a PRN / BIAS / RMS record, IONEX 1.0 PRNs
+// take the system of the file.
+func parseIONEXSatelliteBias(record *lineRecord, system string) IONEXBias {
+	var b IONEXBias
+	record.decode(0, ionexSatelliteBias, &b.ID, &b.Bias, &b.RMS)
+	if prn, err := strconv.Atoi(b.ID); err == nil {
+		letter := "G"
+		switch system {
+		case "GLO":
+			letter = "R"
+		case "GAL":
+			letter = "E"
+		}
+		b.ID = fmt.Sprintf("%s%02d", letter, prn)
+	}
+	if b.ID != "" {
+		b.System = b.ID[:1]
+	}
+	return b
+}
+
+// parseIONEXMaps reads the TEC, RMS and HEIGHT maps up to END OF FILE.
+func parseIONEXMaps(scanner *lineScanner, x *IONEX, headerExponent int) error {
+	size := x.Height.Count() * x.Latitude.Count() * x.Longitude.Count()
+
+	var current *IONEXMap
+	var kind string
+	exponent := headerExponent
+	// values still to read of the current latitude band, where they go and
+	// whether the band is off the grid
+	var band *lineRecord
+	pending, next, drop := 0, 0, false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if pending > 0 {
+			band.add(line)
+			count := min(pending, 16)
+			values := make([]int, count)
+			dst := make([]any, count)
+			for i := range values {
+				dst[i] = &values[i]
+			}
+			band.decode(band.len()-1, ionexValues[:count], dst...)
+			if band.err != nil {
+				return band.err
+			}
+			for _, v := range values {
+				if current != nil && !drop && v != ionexMissing {
+					current.Values[next] = float64(v) * math.Pow10(exponent)
+				}
+				next++
+			}
+			pending -= count
+			continue
+		}
+		if len(line) < 60 {
+			continue
+		}
+		record := scanner.newRecord()
+		record.add(line)
+		label := strings.TrimRight(line[60:], " ")
+
+		switch label {
+		case "START OF TEC MAP", "START OF RMS MAP", "START OF HEIGHT MAP":
+			kind = strings.Fields(label)[2]
+			exponent = headerExponent
+			current = nil
+			if kind != "HEIGHT" {
+				current = &IONEXMap{Values: make([]float64, size)}
+				for i := range current.Values {
+					current.Values[i] = math.NaN()
+				}
+			}
+		case "EPOCH OF CURRENT MAP":
+			var e epochFields
+			record.decode(0, ionexEpoch, e.dst()...)
+			if current != nil {
+				current.Epoch = e.time()
+			}
+		case "EXPONENT":
+			exponent = record.integer(0, 0, 6)
+		case "LAT/LON1/LON2/DLON/H":
+			var latitude, lon1, lon2, dlon, height float64
+			record.decode(0, ionexBand, &latitude, &lon1, &lon2, &dlon, &height)
+			if record.err != nil {
+				return record.err
+			}
+			first, count, err := x.band(latitude, lon1, lon2, dlon, height)
+			drop = err != nil
+			if drop {
+				if err := scanner.recordError(record, err); err != nil {
+					return err
+				}
+			}
+			band, pending, next = record, count, first
+		case "END OF TEC MAP", "END OF RMS MAP", "END OF HEIGHT MAP":
+			if current != nil && kind == "TEC" {
+				x.TEC = append(x.TEC, *current)
+			} else if current != nil {
+				x.RMS = append(x.RMS, *current)
+			}
+			current = nil
+		case "END OF FILE":
+			return nil
+		}
+		if record.err != nil {
+			return record.err
+		}
+	}
+	return nil
+}
+
+// band returns the index of the first value of a latitude band and the
+// number of values in it, the count is also returned with an error for the
+// values to be skipped.
+func (x *IONEX) band(latitude, lon1, lon2, dlon, height float64) (int, int, error) {
+	count := 0
+	if dlon != 0 {
+		count = int(math.Round((lon2-lon1)/dlon)) + 1
+	}
+	if count <= 0 || math.Abs(dlon-x.Longitude.Step) > 1e-6 {
+		return 0, max(count, 0), fmt.Errorf("DLON %g differs from the header %g", dlon, x.Longitude.Step)
+	}
+	h := x.Height.index(height)
+	j := x.Latitude.index(latitude)
+	k := x.Longitude.index(lon1)
+	for _, index := range [][2]float64{
+		{h, float64(x.Height.Count())},
+		{j, float64(x.Latitude.Count())},
+		{k, float64(x.Longitude.Count())},
+		{k + float64(count) - 1, float64(x.Longitude.Count())},
+	} {
+		if math.Abs(index[0]-math.Round(index[0])) > 1e-6 || index[0] < -1e-6 || math.Round(index[0]) >= index[1] {
+			return 0, count, fmt.Errorf("band %g %g-%g at %g km is off the grid", latitude, lon1, lon2, height)
+		}
+	}
+	first := (int(math.Round(h))*x.Latitude.Count()+int(math.Round(j)))*x.Longitude.Count() + int(math.Round(k))
+	return first, count, nil
+}
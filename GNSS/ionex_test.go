@@ -0,0 +1,171 @@
+package gnss
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// two 2D TEC maps 2 h apart, the second with an EXPONENT of its own and a
+// missing value, and an RMS map, on a global 10° x 90° grid
+const ionexMaps = `     1.1            I                   GPS                 IONEX VERSION / TYPE
+TESTGEN             TEST                30-JUL-24 03:00     PGM / RUN BY / DATE
+two maps 2 h apart on a 10 x 90 degree grid                 DESCRIPTION
+  2024     7    30     0     0     0                        EPOCH OF FIRST MAP
+  2024     7    30     2     0     0                        EPOCH OF LAST MAP
+  7200                                                      INTERVAL
+     2                                                      # OF MAPS IN FILE
+  COSZ                                                      MAPPING FUNCTION
+    10.0                                                    ELEVATION CUTOFF
+  6371.0                                                    BASE RADIUS
+     2                                                      MAP DIMENSION
+   450.0 450.0   0.0                                        HGT1 / HGT2 / DHGT
+    10.0 -10.0 -10.0                                        LAT1 / LAT2 / DLAT
+  -180.0 180.0  90.0                                        LON1 / LON2 / DLON
+    -1                                                      EXPONENT
+DIFFERENTIAL CODE BIASES                                    START OF AUX DATA
+   G01    -7.993     0.008                                  PRN / BIAS / RMS
+   G  ALBH 40129M003          -2.063     0.012              STATION / BIAS / RMS
+DIFFERENTIAL CODE BIASES                                    END OF AUX DATA
+                                                            END OF HEADER
+     1                                                      START OF TEC MAP
+  2024     7    30     0     0     0                        EPOCH OF CURRENT MAP
+    10.0-180.0 180.0  90.0 450.0                            LAT/LON1/LON2/DLON/H
+  100  100  100  100  100
+     0.0-180.0 180.0  90.0 450.0                            LAT/LON1/LON2/DLON/H
+  100  100  100  100  100
+   -10.0-180.0 180.0  90.0 450.0                            LAT/LON1/LON2/DLON/H
+  100  100  100  100  100
+     1                                                      END OF TEC MAP
+     2                                                      START OF TEC MAP
+  2024     7    30     2     0     0                        EPOCH OF CURRENT MAP
+    -2                                                      EXPONENT
+    10.0-180.0 180.0  90.0 450.0                            LAT/LON1/LON2/DLON/H
+ 2000 2000 2000 2000 2000
+     0.0-180.0 180.0  90.0 450.0                            LAT/LON1/LON2/DLON/H
+ 2000 3000 4000 3000 2000
+   -10.0-180.0 180.0  90.0 450.0                            LAT/LON1/LON2/DLON/H
+ 2000 2000 9999 2000 2000
+     2                                                      END OF TEC MAP
+     1                                                      START OF RMS MAP
+  2024     7    30     0     0     0                        EPOCH OF CURRENT MAP
+    10.0-180.0 180.0  90.0 450.0                            LAT/LON1/LON2/DLON/H
+   10   10   10   10   10
+     0.0-180.0 180.0  90.0 450.0                            LAT/LON1/LON2/DLON/H
+   10   10   10   10   10
+   -10.0-180.0 180.0  90.0 450.0                            LAT/LON1/LON2/DLON/H
+   10   10   10   10   10
+     1                                                      END OF RMS MAP
+                                                            END OF FILE
+`
+
+func parseIONEXText(tb testing.TB, text string) *IONEX {
+	tb.Helper()
+	x, err := ParseIONEX(strings.NewReader(text), ParseOptions{Strict: true})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return x
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func TestParseIONEX(t *testing.T) {
+	x := parseIONEXText(t, ionexMaps)
+	if x.Version != 1.1 || x.Type != "I" || x.System != "GPS" || x.Program != "TESTGEN" || x.Agency != "TEST" {
+		t.Errorf("header %v %q %q %q %q", x.Version, x.Type, x.System, x.Program, x.Agency)
+	}
+	first, last := time.Date(2024, 7, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 30, 2, 0, 0, 0, time.UTC)
+	if !x.FirstEpoch.Equal(first) || !x.LastEpoch.Equal(last) || x.Interval != 7200 || x.Maps != 2 {
+		t.Errorf("epochs %v - %v every %d s, %d maps", x.FirstEpoch, x.LastEpoch, x.Interval, x.Maps)
+	}
+	if x.MappingFunction != "COSZ" || x.ElevationCutoff != 10 || x.BaseRadius != 6371 || x.Dimension != 2 {
+		t.Errorf("%q %v %v %d", x.MappingFunction, x.ElevationCutoff, x.BaseRadius, x.Dimension)
+	}
+	if x.Height != (IONEXAxis{450, 450, 0}) || x.Latitude != (IONEXAxis{10, -10, -10}) || x.Longitude != (IONEXAxis{-180, 180, 90}) {
+		t.Errorf("axes %v %v %v", x.Height, x.Latitude, x.Longitude)
+	}
+	if x.Height.Count() != 1 || x.Latitude.Count() != 3 || x.Longitude.Count() != 5 {
+		t.Errorf("%d x %d x %d points", x.Height.Count(), x.Latitude.Count(), x.Longitude.Count())
+	}
+	if want := []IONEXBias{{System: "G", ID: "G01", Bias: -7.993, RMS: 0.008}}; !reflect.DeepEqual(x.SatelliteBiases, want) {
+		t.Errorf("satellite biases %+v", x.SatelliteBiases)
+	}
+	if want := []IONEXBias{{System: "G", ID: "ALBH", Domes: "40129M003", Bias: -2.063, RMS: 0.012}}; !reflect.DeepEqual(x.StationBiases, want) {
+		t.Errorf("station biases %+v", x.StationBiases)
+	}
+
+	if len(x.TEC) != 2 || len(x.RMS) != 1 {
+		t.Fatalf("%d TEC and %d RMS maps", len(x.TEC), len(x.RMS))
+	}
+	for i, c := range []struct {
+		m     IONEXMap
+		epoch time.Time
+		want  []float64
+	}{
+		{x.TEC[0], first, []float64{10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10}},
+		{x.TEC[1], last, []float64{20, 20, 20, 20, 20, 20, 30, 40, 30, 20, 20, 20, math.NaN(), 20, 20}},
+		{x.RMS[0], first, []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+	} {
+		if !c.m.Epoch.Equal(c.epoch) {
+			t.Errorf("map %d at %v, want %v", i, c.m.Epoch, c.epoch)
+		}
+		for j, v := range c.m.Values {
+			if w := c.want[j]; math.IsNaN(w) != math.IsNaN(v) || !math.IsNaN(w) && math.Abs(v-w) > 1e-12 {
+				t.Errorf("map %d value %d: %v, want %v", i, j, v, w)
+			}
+		}
+	}
+
+	parsed, err := Parse(strings.NewReader(ionexMaps), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Format != FORMAT_IONEX || parsed.IONEX == nil || len(parsed.IONEX.TEC) != 2 {
+		t.Errorf("parsed as %v", parsed.Format)
+	}
+}
+
+// IONEX 1.0 satellite biases carry the number only, the system comes from
+// the file.
+func TestParseIONEXSatelliteBias(t *testing.T) {
+	for system, want := range map[string]string{"GPS": "G05", "GLO": "R05", "GAL": "E05", "MIX": "G05"} {
+		text := strings.Replace(ionexMaps, "   G01    -7.993", "    05    -7.993", 1)
+		text = strings.Replace(text, "GPS                 IONEX", system+"                 IONEX", 1)
+		x := parseIONEXText(t, text)
+		if b := x.SatelliteBiases[0]; b.ID != want || b.System != want[:1] {
+			t.Errorf("%s: %+v, want %s", system, b, want)
+		}
+	}
+}
+
+func TestParseIONEXErrors(t *testing.T) {
+	for _, c := range []struct{ name, old, new string }{
+		{"no latitudes", "LAT1 / LAT2 / DLAT", "COMMENT"},
+		{"heights of 2D maps", "   450.0 450.0   0.0", "   450.0 500.0  50.0"},
+		{"no END OF HEADER", "END OF HEADER", "COMMENT"},
+		{"bad value", " 2000 3000 4000", " 2000 3x00 4000"},
+	} {
+		text := strings.Replace(ionexMaps, c.old, c.new, 1)
+		if _, err := ParseIONEX(strings.NewReader(text), ParseOptions{Strict: true}); err == nil {
+			t.Errorf("%s: parsed", c.name)
+		}
+	}
+
+	// a band off the grid is skipped with a warning
+	text := strings.Replace(ionexMaps, "     0.0-180.0 180.0  90.0 450.0", "     5.0-180.0 180.0  90.0 450.0", 1)
+	if _, err := ParseIONEX(strings.NewReader(text), ParseOptions{Strict: true}); err == nil {
+		t.Error("band off the grid parsed in strict mode")
+	}
+	x, err := ParseIONEX(strings.NewReader(text), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(x.Warnings) != 1 || !math.IsNaN(x.TEC[0].Values[5]) || x.TEC[0].Values[10] != 10 {
+		t.Errorf("%d warnings, values %v", len(x.Warnings), x.TEC[0].Values)
+	}
+}
@@ -0,0 +1,348 @@
+package gnss
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"strconv"
+	"sync"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  BEIDOU IONOSPHERE
+// BDS-2 broadcasts 8 Klobuchar parameters in the D1 / D2 messages
+// (BDS-SIS-ICD-B1I 5.2.4.7). Unlike GPS they are applied in BDT with the
+// pierce point at 375 km on a spherical Earth, powers of its geographic
+// (not geomagnetic) latitude, the exact cosine of the day term and a period
+// bounded to 72000 - 172800 s. The delay refers to B1I.
+//
+// BDS-3 B-CNAV messages broadcast the 9 coefficients of BDGIM
+// (BDS-SIS-ICD-B1C 7.11), a spherical harmonic expansion of the vertical TEC
+// to degree and order 2 in a sun-fixed geomagnetic frame, the pierce point
+// at 400 km. The broadcast terms correct a non-broadcast background A0 built
+// from coefficient tables of the ICD, see BDGIMBackground.
+// RINEX 4 ION records of message CNVX hold the 9 coefficients, they are kept
+// as IONOSPHERIC CORR records of type BDGM.
+
+const (
+	beidouEarthRadius     = 6378e3 // m
+	bdsKlobucharHeight    = 375e3  // m
+	bdgimHeight           = 400e3  // m
+	bdgimPoleLatitude     = 80.27 * math.Pi / 180
+	bdgimPoleLongitude    = -72.58 * math.Pi / 180
+	bdgimTECUDelayScale   = 40.28e16 // m Hz² per TECU
+	bdgimCoefficientCount = 9
+)
+
+// BDSKlobucharModel is the IonosphereModel of the BDS-2 Klobuchar
+// coefficients (BDSA / BDSB header records, D1D2 ION records).
+type BDSKlobucharModel struct {
+	Coefficients KlobucharCoefficients
+}
+
+func (k BDSKlobucharModel) Delay(t GPSTime, receiver, satellite []float64, frequency float64) (float64, error) {
+	azimuth, elevation, err := ionosphereLookAngles(receiver, satellite)
+	if err != nil {
+		return 0, err
+	}
+	r := BEIDOU_B1I / frequency
+	return k.Coefficients.BDSDelay(t, receiver, azimuth, elevation) * r * r, nil
+}
+
+// BDSDelay returns the B1I slant delay in meters of the BDS-2 Klobuchar
+// model, arguments as for Delay.
+func (k KlobucharCoefficients) BDSDelay(t GPSTime, receiver []float64, azimuth, elevation float64) float64 {
+	latitude, longitude := receiver[0], receiver[1]
+
+	// pierce point
+	ratio := beidouEarthRadius / (beidouEarthRadius + bdsKlobucharHeight) * math.Cos(elevation)
+	psi := math.Pi/2 - elevation - math.Asin(ratio)
+	phi := math.Asin(math.Sin(latitude)*math.Cos(psi) + math.Cos(latitude)*math.Sin(psi)*math.Cos(azimuth))
+	lambda := longitude + math.Asin(math.Sin(psi)*math.Sin(azimuth)/math.Cos(phi))
+
+	// local time at the pierce point from BDT seconds of day
+	localTime := math.Mod(bdtSecondsOfDay(t)+lambda*43200/math.Pi, SECS_IN_DAY)
+	if localTime < 0 {
+		localTime += SECS_IN_DAY
+	}
+
+	x := math.Abs(phi / math.Pi)
+	amplitude := k.Alpha[0] + x*(k.Alpha[1]+x*(k.Alpha[2]+x*k.Alpha[3]))
+	amplitude = math.Max(amplitude, 0)
+	period := k.Beta[0] + x*(k.Beta[1]+x*(k.Beta[2]+x*k.Beta[3]))
+	period = math.Max(72000, math.Min(period, 172800))
+
+	delay := 5e-9
+	if math.Abs(localTime-50400) < period/4 {
+		delay += amplitude * math.Cos(2*math.Pi*(localTime-50400)/period)
+	}
+	return SPEED_OF_LIGHT * delay / math.Sqrt(1-ratio*ratio)
+}
+
+// bdtSecondsOfDay returns the BDT seconds of the day of t.
+func bdtSecondsOfDay(t GPSTime) float64 {
+	s := math.Mod(t.TimeOfWeek()-BDT_GPS_SECONDS_OFFSET, SECS_IN_DAY)
+	if s < 0 {
+		s += SECS_IN_DAY
+	}
+	return s
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// BDGIMCoefficients are the broadcast coefficients alpha1 ... alpha9 of
+// BDGIM, TECU.
+type BDGIMCoefficients [bdgimCoefficientCount]float64
+
+// BDGIMFromHeader returns the coefficients of the BDGM IONOSPHERIC CORR
+// record of header.
+func BDGIMFromHeader(header RINEXHeader) (BDGIMCoefficients, bool) {
+	parameters, ok := header.IonosphericCorrection("BDGM")
+	if !ok || len(parameters) < bdgimCoefficientCount {
+		return BDGIMCoefficients{}, false
+	}
+	var b BDGIMCoefficients
+	copy(b[:], parameters)
+	return b, true
+}
+
+// BDGIM is the IonosphereModel of the BDS-3 broadcast coefficients.
+type BDGIM struct {
+	Coefficients BDGIMCoefficients
+	// Background returns the non-broadcast vertical TEC A0 (TECU) at t and
+	// the sun-fixed geomagnetic latitude / longitude (radians) of the pierce
+	// point, nil for the A0 of DefaultBDGIMBackground.
+	Background func(t GPSTime, latitude, longitude float64) float64
+}
+
+// degree and order of the broadcast terms, negative orders are sine terms
+var bdgimTerms = [bdgimCoefficientCount][2]int{
+	{0, 0}, {1, 0}, {1, 1}, {1, -1}, {2, 0}, {2, 1}, {2, -1}, {2, 2}, {2, -2},
+}
+
+func (b BDGIM) Delay(t GPSTime, receiver, satellite []float64, frequency float64) (float64, error) {
+	background := b.Background
+	if background == nil {
+		d, err := DefaultBDGIMBackground()
+		if err != nil {
+			return 0, fmt.Errorf("BDGIM background A0 not available: %v", err)
+		}
+		background = d.A0
+	}
+	azimuth, elevation, err := ionosphereLookAngles(receiver, satellite)
+	if err != nil {
+		return 0, err
+	}
+	latitude, longitude := receiver[0], receiver[1]
+
+	// pierce point
+	ratio := beidouEarthRadius / (beidouEarthRadius + bdgimHeight) * math.Cos(elevation)
+	psi := math.Pi/2 - elevation - math.Asin(ratio)
+	phi := math.Asin(math.Sin(latitude)*math.Cos(psi) + math.Cos(latitude)*math.Sin(psi)*math.Cos(azimuth))
+	lambda := longitude + math.Atan2(math.Sin(psi)*math.Sin(azimuth)*math.Cos(latitude),
+		math.Cos(psi)-math.Sin(latitude)*math.Sin(phi))
+
+	// geomagnetic coordinates of the pierce point and of the subsolar point
+	// (on the equator), the longitude then counts from the sun
+	phiM, lambdaM := bdgimGeomagnetic(phi, lambda)
+	sunLongitude := math.Pi * (1 - bdtSecondsOfDay(t)/43200)
+	_, sunM := bdgimGeomagnetic(0, sunLongitude)
+	lambdaS := lambdaM - sunM
+
+	vtec := background(t, phiM, lambdaS)
+	for i, term := range bdgimTerms {
+		vtec += b.Coefficients[i] * bdgimHarmonic(term[0], term[1], phiM, lambdaS)
+	}
+	vtec = math.Max(vtec, 0)
+
+	stec := vtec / math.Sqrt(1-ratio*ratio)
+	return bdgimTECUDelayScale * stec / (frequency * frequency), nil
+}
+
+// bdgimGeomagnetic converts geographic latitude and longitude to the
+// geomagnetic frame of BDGIM.
+func bdgimGeomagnetic(latitude, longitude float64) (float64, float64) {
+	sinPole, cosPole := math.Sin(bdgimPoleLatitude), math.Cos(bdgimPoleLatitude)
+	dl := longitude - bdgimPoleLongitude
+	phiM := math.Asin(sinPole*math.Sin(latitude) + cosPole*math.Cos(latitude)*math.Cos(dl))
+	lambdaM := math.Atan2(math.Cos(latitude)*math.Sin(dl)*cosPole, sinPole*math.Sin(phiM)-math.Sin(latitude))
+	return phiM, lambdaM
+}
+
+// bdgimHarmonic is the normalized term of degree n and order m at latitude
+// and longitude, negative orders are sine terms.
+func bdgimHarmonic(n, m int, latitude, longitude float64) float64 {
+	if m < 0 {
+		return bdgimLegendre(n, -m, math.Sin(latitude)) * math.Sin(float64(-m)*longitude)
+	}
+	return bdgimLegendre(n, m, math.Sin(latitude)) * math.Cos(float64(m)*longitude)
+}
+
+// bdgimLegendre is the fully normalized associated Legendre function of
+// degree n and order m at x, by the recursion in degree from Pmm.
+func bdgimLegendre(n, m int, x float64) float64 {
+	c := math.Sqrt(1 - x*x)
+	p := 1.0
+	for i := 1; i <= m; i++ {
+		p *= float64(2*i-1) * c
+	}
+	previous := 0.0
+	for l := m + 1; l <= n; l++ {
+		p, previous = (float64(2*l-1)*x*p-float64(l+m-1)*previous)/float64(l-m), p
+	}
+	norm := float64(2*n+1) * factorial(n-m) / factorial(n+m)
+	if m != 0 {
+		norm *= 2
+	}
+	return math.Sqrt(norm) * p
+}
+
+func factorial(n int) float64 {
+	f := 1.0
+	for i := 2; i <= n; i++ {
+		f *= float64(i)
+	}
+	return f
+}
+
+// =========================================================================
+
+// =========================================================================
+//  BDGIM BACKGROUND
+// The non-broadcast A0 of BDGIM is an expansion of 17 further terms of
+// degree n and order m whose coefficients follow the periodic series
+//   βj = a0,j + Σk ak,j cos(ωk tp) + bk,j sin(ωk tp)   (ωk = 2π / Tk)
+// of the ICD tables, tp the Modified Julian Date of the epoch. The 12
+// periods Tk (days) and the terms with their a / b coefficients are read
+// from bdgim-background.txt. The copy in bdgim-data is embedded in the
+// package.
+
+const (
+	bdgimBackgroundTermCount = 17
+	bdgimPeriodCount         = 12
+	bdgimBackgroundFileName  = "bdgim-background.txt"
+)
+
+// bdgimBackgroundTerm is one term, negative orders are sine terms. The
+// series holds a0 and the ak, bk pairs of every period.
+type bdgimBackgroundTerm struct {
+	n, m   int
+	series [1 + 2*bdgimPeriodCount]float64
+}
+
+// BDGIMBackground are the non-broadcast terms of BDGIM.
+type BDGIMBackground struct {
+	periods [bdgimPeriodCount]float64
+	terms   [bdgimBackgroundTermCount]bdgimBackgroundTerm
+}
+
+//go:embed bdgim-data
+var bdgimFiles embed.FS
+
+var (
+	defaultBDGIMOnce       sync.Once
+	defaultBDGIMBackground *BDGIMBackground
+	defaultBDGIMErr        error
+)
+
+// DefaultBDGIMBackground returns the tables embedded from bdgim-data, read
+// once.
+func DefaultBDGIMBackground() (*BDGIMBackground, error) {
+	defaultBDGIMOnce.Do(func() {
+		fsys, err := fs.Sub(bdgimFiles, "bdgim-data")
+		if err != nil {
+			defaultBDGIMErr = fmt.Errorf("failed to open embedded BDGIM background: %v", err)
+			return
+		}
+		if defaultBDGIMBackground, err = LoadBDGIMBackground(fsys); err != nil {
+			defaultBDGIMErr = fmt.Errorf("failed to load embedded BDGIM background: %v", err)
+		}
+	})
+	return defaultBDGIMBackground, defaultBDGIMErr
+}
+
+// LoadBDGIMBackground reads bdgim-background.txt from fsys: the periods,
+// then per term n, m and its series.
+func LoadBDGIMBackground(fsys fs.FS) (*BDGIMBackground, error) {
+	termSize := 2 + 1 + 2*bdgimPeriodCount
+	values, err := readDataValues(fsys, bdgimBackgroundFileName, bdgimPeriodCount+bdgimBackgroundTermCount*termSize)
+	if err != nil {
+		return nil, err
+	}
+	b := &BDGIMBackground{}
+	copy(b.periods[:], values)
+	for i, period := range b.periods {
+		if !(period > 0) {
+			return nil, fmt.Errorf("%s: period %d of %g days", bdgimBackgroundFileName, i+1, period)
+		}
+	}
+	values = values[bdgimPeriodCount:]
+	for i := range b.terms {
+		term := &b.terms[i]
+		term.n, term.m = int(values[0]), int(values[1])
+		if float64(term.n) != values[0] || float64(term.m) != values[1] || term.n < 0 || term.m > term.n || -term.m > term.n {
+			return nil, fmt.Errorf("%s: term %d of degree %g and order %g", bdgimBackgroundFileName, i+1, values[0], values[1])
+		}
+		copy(term.series[:], values[2:termSize])
+		values = values[termSize:]
+	}
+	return b, nil
+}
+
+// A0 returns the background vertical TEC (TECU) at t and the sun-fixed
+// geomagnetic latitude / longitude (radians) of the pierce point, it is the
+// Background of BDGIM.
+func (b *BDGIMBackground) A0(t GPSTime, latitude, longitude float64) float64 {
+	mjd := modifiedJulianDate(t)
+	var cosines, sines [bdgimPeriodCount]float64
+	for k, period := range b.periods {
+		sines[k], cosines[k] = math.Sincos(2 * math.Pi / period * mjd)
+	}
+	a0 := 0.0
+	for _, term := range b.terms {
+		beta := term.series[0]
+		for k := range b.periods {
+			beta += term.series[1+2*k]*cosines[k] + term.series[2+2*k]*sines[k]
+		}
+		a0 += beta * bdgimHarmonic(term.n, term.m, latitude, longitude)
+	}
+	return a0
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// BeiDouIonosphere is the IonosphereModel of both BeiDou generations: BDS2
+// for C01 ... C18, BDS3 (BDGIM) for C19 and above. Ionosphere.Delay picks by
+// PRN, Delay alone uses BDS3 when it is set.
+type BeiDouIonosphere struct {
+	BDS2, BDS3 IonosphereModel
+}
+
+func (b BeiDouIonosphere) Delay(t GPSTime, receiver, satellite []float64, frequency float64) (float64, error) {
+	model := b.model("")
+	if model == nil {
+		return 0, errors.New("no BeiDou ionosphere model")
+	}
+	return model.Delay(t, receiver, satellite, frequency)
+}
+
+// model returns the model of prn, the other one when it is not set.
+func (b BeiDouIonosphere) model(prn string) IonosphereModel {
+	bds2 := len(prn) > 1
+	if bds2 {
+		number, err := strconv.Atoi(prn[1:])
+		bds2 = err == nil && number < 19
+	}
+	if b.BDS3 == nil || bds2 && b.BDS2 != nil {
+		return b.BDS2
+	}
+	return b.BDS3
+}
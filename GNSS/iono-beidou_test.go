@@ -0,0 +1,306 @@
+package gnss
+
+import (
+	"fmt"
+	"io/fs"
+	"math"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mothergoose31/GNNS-GO/GNSS/helpers"
+)
+
+// D1D2 and CNVX ION records of a BeiDou satellite in RINEX 4
+const navBeiDouIonV4 = `     4.00           N: GNSS NAV DATA    M: MIXED            RINEX VERSION / TYPE
+                                                            END OF HEADER
+> ION C19 D1D2
+    2024 07 29 23 50 00 1.000000000000E-08 2.000000000000E-08-6.000000000000E-08
+    -1.000000000000E-07 9.000000000000E+04 1.000000000000E+05-6.000000000000E+04
+    -5.000000000000E+05 0.000000000000E+00
+> ION C19 CNVX
+    2024 07 29 23 50 00 1.000000000000E+00 2.000000000000E+00 3.000000000000E+00
+     4.000000000000E+00 5.000000000000E+00 6.000000000000E+00 7.000000000000E+00
+     8.000000000000E+00 9.000000000000E+00
+`
+
+// zenithSatellite returns a satellite position straight above receiver.
+func zenithSatellite(receiver []float64) []float64 {
+	return helpers.GeodeticToECEF([][]float64{{receiver[0], receiver[1], 20000e3}}, true)[0]
+}
+
+// bdgimBackgroundFile returns bdgim-background.txt with periods of 1 ... 12
+// days and zero terms of degree 3 except the constant term of degree 0 with
+// a0 and the cosine amplitude a1 of the daily period.
+func bdgimBackgroundFile(a0, a1 float64) fstest.MapFS {
+	var b strings.Builder
+	for k := 1; k <= bdgimPeriodCount; k++ {
+		fmt.Fprintf(&b, "%d ", k)
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "0 0 %g %g%s\n", a0, a1, strings.Repeat(" 0", 2*bdgimPeriodCount-1))
+	for i := 1; i < bdgimBackgroundTermCount; i++ {
+		fmt.Fprintf(&b, "3 %d 0%s\n", i%7-3, strings.Repeat(" 0", 2*bdgimPeriodCount))
+	}
+	return fstest.MapFS{bdgimBackgroundFileName: {Data: []byte(b.String())}}
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// With a constant amplitude the vertical delay is known: 5 ns at night, 5 ns
+// plus the amplitude at 14:00 BDT, the cosine at an eighth of the period
+// bounded to 72000 or 172800 s. The obliquity factor is that of the pierce
+// point at 375 km.
+func TestBDSKlobucharDelay(t *testing.T) {
+	const amplitude = 2e-8
+	receiver := []float64{0, 0, 0}
+	zenith := math.Pi / 2
+	// local time of the pierce point is BDT at longitude 0
+	day := GPSTimeFromWeek(2325, 2*SECS_IN_DAY).Add(BDT_GPS_SECONDS_OFFSET)
+
+	for _, c := range []struct {
+		name      string
+		beta0     float64
+		localTime float64
+		want      float64
+	}{
+		{"night", 0, 2 * SECS_IN_HR, 5e-9},
+		{"14:00", 0, 14 * SECS_IN_HR, 5e-9 + amplitude},
+		{"minimum period", 0, 14*SECS_IN_HR + 9000, 5e-9 + amplitude*math.Cos(math.Pi/4)},
+		{"minimum period night", 0, 14*SECS_IN_HR + 21600, 5e-9},
+		{"maximum period", 1e6, 14*SECS_IN_HR + 21600, 5e-9 + amplitude*math.Cos(math.Pi/4)},
+	} {
+		k := KlobucharCoefficients{Alpha: [4]float64{amplitude}, Beta: [4]float64{c.beta0}}
+		got := k.BDSDelay(day.Add(c.localTime), receiver, 0, zenith)
+		if want := SPEED_OF_LIGHT * c.want; math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s: %.6f m, want %.6f m", c.name, got, want)
+		}
+	}
+
+	k := KlobucharCoefficients{Alpha: [4]float64{amplitude}}
+	for _, elevation := range []float64{5, 15, 30, 60} {
+		e := elevation * math.Pi / 180
+		ratio := beidouEarthRadius / (beidouEarthRadius + bdsKlobucharHeight) * math.Cos(e)
+		got := k.BDSDelay(day.Add(2*SECS_IN_HR), receiver, 1, e)
+		if want := SPEED_OF_LIGHT * 5e-9 / math.Sqrt(1-ratio*ratio); math.Abs(got-want) > 1e-9 {
+			t.Errorf("elevation %v°: %.6f m, want %.6f m", elevation, got, want)
+		}
+	}
+
+	// the model scales the B1I delay to the frequency
+	model := BDSKlobucharModel{Coefficients: k}
+	receiver = []float64{0.5, 2, 100}
+	at := day.Add(14 * SECS_IN_HR)
+	b1i := k.BDSDelay(at, receiver, 0, zenith)
+	got, err := model.Delay(at, receiver, zenithSatellite(receiver), BEIDOU_B2A)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := BEIDOU_B1I / BEIDOU_B2A; math.Abs(got-b1i*r*r) > 1e-6 {
+		t.Errorf("B2a delay %.6f m, want %.6f m", got, b1i*r*r)
+	}
+	below := helpers.GeodeticToECEF([][]float64{{-0.5, 2 + math.Pi, 20000e3}}, true)[0]
+	if _, err := model.Delay(at, receiver, below, BEIDOU_B1I); err == nil {
+		t.Error("delay of a satellite below the horizon")
+	}
+}
+
+func TestBDTSecondsOfDay(t *testing.T) {
+	for _, c := range []struct{ tow, want float64 }{
+		{BDT_GPS_SECONDS_OFFSET, 0},
+		{0, SECS_IN_DAY - BDT_GPS_SECONDS_OFFSET},
+		{3*SECS_IN_DAY + 3600, 3600 - BDT_GPS_SECONDS_OFFSET},
+	} {
+		if got := bdtSecondsOfDay(GPSTimeFromWeek(2325, c.tow)); got != c.want {
+			t.Errorf("TOW %v: %v s, want %v s", c.tow, got, c.want)
+		}
+	}
+}
+
+// The normalized functions have ∫P̄² = 2 over [-1, 1] for order 0, 4
+// otherwise, and are orthogonal between degrees of one order.
+func TestBDGIMLegendre(t *testing.T) {
+	integral := func(f func(float64) float64) float64 {
+		return gaussKronrod(f, -1, 1, 1e-12, 0)
+	}
+	for n := 0; n <= 5; n++ {
+		for m := 0; m <= n; m++ {
+			want := 4.0
+			if m == 0 {
+				want = 2
+			}
+			got := integral(func(x float64) float64 { p := bdgimLegendre(n, m, x); return p * p })
+			if math.Abs(got-want) > 1e-10 {
+				t.Errorf("P̄(%d,%d): ∫P̄² = %v, want %v", n, m, got, want)
+			}
+		}
+	}
+	for _, c := range [][3]int{{0, 1, 0}, {0, 2, 0}, {1, 2, 0}, {1, 2, 1}, {2, 4, 0}, {3, 5, 2}} {
+		got := integral(func(x float64) float64 { return bdgimLegendre(c[0], c[2], x) * bdgimLegendre(c[1], c[2], x) })
+		if math.Abs(got) > 1e-10 {
+			t.Errorf("P̄(%d,%d) P̄(%d,%d): ∫ = %v, want 0", c[0], c[2], c[1], c[2], got)
+		}
+	}
+	if got, want := bdgimLegendre(2, 1, 0.6), math.Sqrt(5.0/3)*3*0.6*0.8; math.Abs(got-want) > 1e-12 {
+		t.Errorf("P̄(2,1)(0.6) = %v, want %v", got, want)
+	}
+}
+
+func TestBDGIMGeomagnetic(t *testing.T) {
+	if phi, _ := bdgimGeomagnetic(bdgimPoleLatitude, bdgimPoleLongitude); math.Abs(phi-math.Pi/2) > 1e-7 {
+		t.Errorf("pole at %v rad", phi)
+	}
+	if phi, _ := bdgimGeomagnetic(-bdgimPoleLatitude, bdgimPoleLongitude+math.Pi); math.Abs(phi+math.Pi/2) > 1e-7 {
+		t.Errorf("antipode at %v rad", phi)
+	}
+	if phi, _ := bdgimGeomagnetic(0, bdgimPoleLongitude); math.Abs(phi-(math.Pi/2-bdgimPoleLatitude)) > 1e-12 {
+		t.Errorf("equator below the pole at %v rad", phi)
+	}
+}
+
+// At the geomagnetic pole only the zonal terms remain, P̄(n,0)(1) = √(2n+1).
+func TestBDGIMDelay(t *testing.T) {
+	at := GPSTimeFromWeek(2325, 2*SECS_IN_DAY)
+	pole := []float64{bdgimPoleLatitude, bdgimPoleLongitude, 0}
+	scale := bdgimTECUDelayScale / (BEIDOU_B1I * BEIDOU_B1I)
+
+	if _, err := DefaultBDGIMBackground(); err != nil {
+		if _, err := (BDGIM{}).Delay(at, pole, zenithSatellite(pole), BEIDOU_B1I); err == nil {
+			t.Error("delay without the background")
+		}
+	}
+
+	var latitude float64
+	model := BDGIM{
+		Coefficients: BDGIMCoefficients{1, 1, 7, 7, 1, 7, 7, 7, 7},
+		Background: func(t GPSTime, phi, lambda float64) float64 {
+			latitude = phi
+			return 10
+		},
+	}
+	got, err := model.Delay(at, pole, zenithSatellite(pole), BEIDOU_B1I)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(latitude-math.Pi/2) > 1e-6 {
+		t.Errorf("background at latitude %v rad, want π/2", latitude)
+	}
+	if want := scale * (10 + 1 + math.Sqrt(3) + math.Sqrt(5)); math.Abs(got-want) > 1e-4 {
+		t.Errorf("pole: %.6f m, want %.6f m", got, want)
+	}
+
+	// the constant term of degree 0 of the background tables
+	background, err := LoadBDGIMBackground(bdgimBackgroundFile(10, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	model.Background = background.A0
+	if again, err := model.Delay(at, pole, zenithSatellite(pole), BEIDOU_B1I); err != nil || math.Abs(again-got) > 1e-9 {
+		t.Errorf("tables: %v m %v, want %v m", again, err, got)
+	}
+
+	// a negative TEC is clamped
+	model = BDGIM{Background: func(GPSTime, float64, float64) float64 { return -5 }}
+	if got, err := model.Delay(at, pole, zenithSatellite(pole), BEIDOU_B1I); err != nil || got != 0 {
+		t.Errorf("negative TEC: %v m %v", got, err)
+	}
+}
+
+// The series of a term are evaluated at the Modified Julian Date.
+func TestLoadBDGIMBackground(t *testing.T) {
+	background, err := LoadBDGIMBackground(bdgimBackgroundFile(5, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, at := range []GPSTime{GPSTimeFromWeek(2325, 0), GPSTimeFromWeek(2325, 3*SECS_IN_HR), GPSTimeFromWeek(2325, 7*SECS_IN_HR+600)} {
+		want := 5 + 2*math.Cos(2*math.Pi*modifiedJulianDate(at))
+		if got := background.A0(at, 0.4, 1.2); math.Abs(got-want) > 1e-9 {
+			t.Errorf("%v: A0 %v TECU, want %v", at, got, want)
+		}
+	}
+
+	for name, text := range map[string]string{
+		"short":  "1 2 3",
+		"period": strings.Replace(string(bdgimBackgroundFile(5, 2)[bdgimBackgroundFileName].Data), "1 2 3", "1 0 3", 1),
+		"order":  strings.Replace(string(bdgimBackgroundFile(5, 2)[bdgimBackgroundFileName].Data), "\n3 -2 ", "\n3 -4 ", 1),
+	} {
+		fsys := fstest.MapFS{bdgimBackgroundFileName: {Data: []byte(text)}}
+		if _, err := LoadBDGIMBackground(fsys); err == nil {
+			t.Errorf("%s: background loaded", name)
+		}
+	}
+}
+
+// DefaultBDGIMBackground loads the embedded ICD tables and fails while
+// bdgim-data lacks them.
+func TestDefaultBDGIMBackground(t *testing.T) {
+	background, err := DefaultBDGIMBackground()
+	if _, statErr := fs.Stat(bdgimFiles, "bdgim-data/"+bdgimBackgroundFileName); statErr != nil {
+		if err == nil || background != nil {
+			t.Errorf("BDGIM background loaded without the ICD tables: %v", err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// BDS-2 satellites take the Klobuchar, BDS-3 ones BDGIM, and either model
+// serves all satellites alone.
+func TestBeiDouIonosphere(t *testing.T) {
+	background, err := LoadBDGIMBackground(bdgimBackgroundFile(20, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	klobuchar := BDSKlobucharModel{Coefficients: KlobucharCoefficients{Alpha: [4]float64{2e-8}, Beta: [4]float64{9e4}}}
+	bdgim := BDGIM{Background: background.A0}
+	both := BeiDouIonosphere{BDS2: klobuchar, BDS3: bdgim}
+	for _, c := range []struct {
+		models BeiDouIonosphere
+		prn    string
+		want   IonosphereModel
+	}{
+		{both, "C05", klobuchar},
+		{both, "C18", klobuchar},
+		{both, "C19", bdgim},
+		{both, "C45", bdgim},
+		{both, "", bdgim},
+		{BeiDouIonosphere{BDS2: klobuchar}, "C30", klobuchar},
+		{BeiDouIonosphere{BDS3: bdgim}, "C05", bdgim},
+	} {
+		if got := c.models.model(c.prn); fmt.Sprintf("%T", got) != fmt.Sprintf("%T", c.want) {
+			t.Errorf("%q: %T, want %T", c.prn, got, c.want)
+		}
+	}
+	if _, err := (BeiDouIonosphere{}).Delay(GPSTimeFromWeek(2325, 0), []float64{0.5, 2, 0}, zenithSatellite([]float64{0.5, 2, 0}), BEIDOU_B1I); err == nil {
+		t.Error("delay without a model")
+	}
+}
+
+func TestBeiDouIonosphericRecords(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(navBeiDouIonV4), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, ok := BDGIMFromHeader(nav.Header)
+	if want := (BDGIMCoefficients{1, 2, 3, 4, 5, 6, 7, 8, 9}); !ok || b != want {
+		t.Errorf("BDGM %v %v, want %v", b, ok, want)
+	}
+	k, ok := KlobucharFromHeader(nav.Header, 'C')
+	want := KlobucharCoefficients{Alpha: [4]float64{1e-8, 2e-8, -6e-8, -1e-7}, Beta: [4]float64{9e4, 1e5, -6e4, -5e5}}
+	if !ok || k != want {
+		t.Errorf("BDSA / BDSB %+v %v, want %+v", k, ok, want)
+	}
+
+	// the Galileo ION record only
+	other, err := ParseNav(strings.NewReader(navV4), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := BDGIMFromHeader(other.Header); ok {
+		t.Error("BDGM found without a CNVX record")
+	}
+}
@@ -0,0 +1,191 @@
+package gnss
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  IONEX SLANT DELAY
+// IONEX 1.0 section 2.2: the ionosphere is a single thin layer at HGT1 above
+// a sphere of BASE RADIUS. The line of sight crosses it at the pierce point
+// with zenith angle z' where sin z' = R / (R + H) sin z, the slant TEC is the
+// vertical TEC there times the mapping function 1 / cos z'. The vertical TEC
+// between two maps is interpolated on maps rotated with the sun (section
+// 2.3, formula 3): each map is read at the longitude the point had, relative
+// to the sun, at the epoch of the map, then bilinearly in latitude and
+// longitude. 3D maps are not supported.
+
+// PiercePoint returns the latitude and longitude (radians) of the pierce
+// point of the line of sight from receiver (geodetic latitude, longitude in
+// radians, height in meters) to satellite (ECEF m) and the single layer
+// mapping function there.
+func (x *IONEX) PiercePoint(receiver, satellite []float64) (float64, float64, float64, error) {
+	azimuth, elevation, err := ionosphereLookAngles(receiver, satellite)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	radius := x.BaseRadius
+	if radius == 0 {
+		radius = 6371
+	}
+	latitude := receiver[0]
+
+	z := math.Pi/2 - elevation
+	sinZ := radius / (radius + x.Height.Start) * math.Sin(z)
+	psi := z - math.Asin(sinZ)
+	phi := math.Asin(math.Sin(latitude)*math.Cos(psi) + math.Cos(latitude)*math.Sin(psi)*math.Cos(azimuth))
+	lambda := receiver[1] + math.Atan2(math.Sin(psi)*math.Sin(azimuth)*math.Cos(latitude),
+		math.Cos(psi)-math.Sin(latitude)*math.Sin(phi))
+	return phi, lambda, 1 / math.Sqrt(1-sinZ*sinZ), nil
+}
+
+// Delay returns the slant delay in meters at frequency (Hz), IONEX is an
+// IonosphereModel.
+func (x *IONEX) Delay(t GPSTime, receiver, satellite []float64, frequency float64) (float64, error) {
+	tec, err := x.SlantTEC(t, receiver, satellite)
+	if err != nil {
+		return 0, err
+	}
+	return 40.3e16 * tec / (frequency * frequency), nil
+}
+
+// SlantTEC returns the TEC (TECU) along the line of sight.
+func (x *IONEX) SlantTEC(t GPSTime, receiver, satellite []float64) (float64, error) {
+	latitude, longitude, mapping, err := x.PiercePoint(receiver, satellite)
+	if err != nil {
+		return 0, err
+	}
+	vtec, err := x.VerticalTEC(t, latitude, longitude)
+	if err != nil {
+		return 0, err
+	}
+	return mapping * vtec, nil
+}
+
+// VerticalTEC interpolates the TEC maps (TECU) at t and latitude /
+// longitude (radians).
+func (x *IONEX) VerticalTEC(t GPSTime, latitude, longitude float64) (float64, error) {
+	return x.interpolate(x.TEC, t, latitude, longitude)
+}
+
+// VerticalRMS interpolates the RMS maps (TECU) at t and latitude /
+// longitude (radians).
+func (x *IONEX) VerticalRMS(t GPSTime, latitude, longitude float64) (float64, error) {
+	return x.interpolate(x.RMS, t, latitude, longitude)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// interpolate reads maps between the two epochs around t, rotated with the
+// sun.
+func (x *IONEX) interpolate(maps []IONEXMap, t GPSTime, latitude, longitude float64) (float64, error) {
+	if x.Dimension != 2 {
+		return 0, fmt.Errorf("%dD maps are not supported", x.Dimension)
+	}
+	if len(maps) == 0 {
+		return 0, errors.New("no maps")
+	}
+	utc := t.ToUTC()
+	latitude *= 180 / math.Pi
+	longitude *= 180 / math.Pi
+
+	// last map at or before t
+	i := sort.Search(len(maps), func(i int) bool { return maps[i].Epoch.After(utc) }) - 1
+	if i < 0 || (i == len(maps)-1 && !maps[i].Epoch.Equal(utc)) {
+		return 0, fmt.Errorf("%s is outside the maps (%s - %s)", utc.Format("2006-01-02 15:04:05"),
+			maps[0].Epoch.Format("2006-01-02 15:04:05"), maps[len(maps)-1].Epoch.Format("2006-01-02 15:04:05"))
+	}
+	if len(maps) == 1 {
+		return x.mapValue(maps[0], latitude, longitude)
+	}
+	if i == len(maps)-1 {
+		i--
+	}
+
+	// the sun moves 360° west per day
+	dt0 := utc.Sub(maps[i].Epoch).Seconds()
+	dt1 := utc.Sub(maps[i+1].Epoch).Seconds()
+	e0, err := x.mapValue(maps[i], latitude, longitude+dt0*360/SECS_IN_DAY)
+	if err != nil {
+		return 0, err
+	}
+	e1, err := x.mapValue(maps[i+1], latitude, longitude+dt1*360/SECS_IN_DAY)
+	if err != nil {
+		return 0, err
+	}
+	w := dt0 / (dt0 - dt1)
+	return (1-w)*e0 + w*e1, nil
+}
+
+// mapValue interpolates m bilinearly at latitude and longitude (degrees).
+// Latitudes up to one step past the grid take the edge values, global maps
+// wrap in longitude.
+func (x *IONEX) mapValue(m IONEXMap, latitude, longitude float64) (float64, error) {
+	rows, cols := x.Latitude.Count(), x.Longitude.Count()
+
+	q := x.Latitude.index(latitude)
+	if q < -1 || q > float64(rows) {
+		return 0, fmt.Errorf("latitude %.2f° is off the maps", latitude)
+	}
+	q = math.Max(0, math.Min(q, float64(rows-1)))
+	j := min(int(math.Floor(q)), max(rows-2, 0))
+	fq := q - float64(j)
+
+	// columns of a full circle
+	circle := int(math.Round(360 / math.Abs(x.Longitude.Step)))
+	global := cols >= circle
+	p := x.Longitude.index(longitude)
+	if global {
+		p = math.Mod(p, float64(circle))
+		if p < 0 {
+			p += float64(circle)
+		}
+	} else if p < 0 || p > float64(cols-1) {
+		// regional maps may be given over 0 - 360°
+		p = x.Longitude.index(longitude + 360)
+		if p < 0 || p > float64(cols-1) {
+			p = x.Longitude.index(longitude - 360)
+		}
+		if p < 0 || p > float64(cols-1) {
+			return 0, fmt.Errorf("longitude %.2f° is off the maps", longitude)
+		}
+	}
+	k := int(math.Floor(p))
+	if !global {
+		k = min(k, max(cols-2, 0))
+	}
+	fp := p - float64(k)
+
+	value := func(row, col int) float64 {
+		row = min(row, rows-1)
+		if global {
+			col %= circle
+		} else {
+			col = min(col, cols-1)
+		}
+		return m.Values[row*cols+col]
+	}
+	// nodes without weight are left out, they may be missing
+	var v float64
+	add := func(w float64, row, col int) {
+		if w != 0 {
+			v += w * value(row, col)
+		}
+	}
+	add((1-fp)*(1-fq), j, k)
+	add(fp*(1-fq), j, k+1)
+	add((1-fp)*fq, j+1, k)
+	add(fp*fq, j+1, k+1)
+	if math.IsNaN(v) {
+		return 0, fmt.Errorf("no value at %.2f° %.2f° of the map of %s", latitude, longitude,
+			m.Epoch.Format("2006-01-02 15:04:05"))
+	}
+	return v, nil
+}
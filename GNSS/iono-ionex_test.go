@@ -0,0 +1,152 @@
+package gnss
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mothergoose31/GNNS-GO/GNSS/helpers"
+)
+
+// ionexTime returns the GPS time of minutes past midnight of the maps.
+func ionexTime(minutes float64) GPSTime {
+	return UTCToGPST(time.Date(2024, 7, 30, 0, 0, 0, 0, time.UTC)).Add(minutes * 60)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// The first map is 10 TECU everywhere, the second 20 TECU but 30 and 40 TECU
+// on the equator at ±90° and 0°.
+func TestIONEXVerticalTEC(t *testing.T) {
+	x := parseIONEXText(t, ionexMaps)
+	deg := math.Pi / 180
+	for _, c := range []struct {
+		name                string
+		minutes             float64
+		latitude, longitude float64
+		want                float64
+	}{
+		{"first map", 0, 3, 17, 10},
+		{"last map", 120, 10, 0, 20},
+		{"node", 120, 0, 90, 30},
+		{"bilinear", 120, 5, 45, 27.5},
+		{"antimeridian", 120, 0, 180, 20},
+		{"wrapped", 120, 0, 200, 20 + 10*20.0/90},
+		{"edge latitude", 120, 15, 0, 20},
+		{"latitude between maps", 30, 10, 0, 12.5},
+		// the maps are read 15° east and west of 15°, at 0° on the second
+		{"rotated", 60, 0, 15, 25},
+	} {
+		got, err := x.VerticalTEC(ionexTime(c.minutes), c.latitude*deg, c.longitude*deg)
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+		} else if math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("%s: %v TECU, want %v", c.name, got, c.want)
+		}
+	}
+
+	for _, c := range []struct {
+		name                string
+		minutes             float64
+		latitude, longitude float64
+	}{
+		{"before the maps", -1, 0, 0},
+		{"after the maps", 121, 0, 0},
+		{"off the latitudes", 0, 25, 0},
+		{"missing value", 120, -5, 10},
+	} {
+		if v, err := x.VerticalTEC(ionexTime(c.minutes), c.latitude*deg, c.longitude*deg); err == nil {
+			t.Errorf("%s: %v TECU", c.name, v)
+		}
+	}
+
+	// a single map holds at its epoch only
+	if v, err := x.VerticalRMS(ionexTime(0), 0, 0); err != nil || v != 1 {
+		t.Errorf("RMS %v %v", v, err)
+	}
+	if _, err := x.VerticalRMS(ionexTime(60), 0, 0); err == nil {
+		t.Error("RMS after its map")
+	}
+
+	x.Dimension = 3
+	if _, err := x.VerticalTEC(ionexTime(0), 0, 0); err == nil {
+		t.Error("3D maps interpolated")
+	}
+}
+
+// Regional maps do not wrap and take longitudes given over 0 - 360°.
+func TestIONEXRegionalMap(t *testing.T) {
+	x := &IONEX{
+		Dimension: 2,
+		Latitude:  IONEXAxis{10, 0, -10},
+		Longitude: IONEXAxis{-20, 20, 20},
+		TEC:       []IONEXMap{{Values: []float64{1, 2, 3, 4, 5, 6}}},
+	}
+	deg := math.Pi / 180
+	if v, err := x.VerticalTEC(GPSTimeFromDateTime(time.Time{}), 0, 350*deg); err != nil || math.Abs(v-4.5) > 1e-12 {
+		t.Errorf("350°: %v %v, want 4.5", v, err)
+	}
+	if v, err := x.VerticalTEC(GPSTimeFromDateTime(time.Time{}), 5*deg, 20*deg); err != nil || math.Abs(v-4.5) > 1e-12 {
+		t.Errorf("east edge: %v %v, want 4.5", v, err)
+	}
+	if _, err := x.VerticalTEC(GPSTimeFromDateTime(time.Time{}), 0, 30*deg); err == nil {
+		t.Error("30° east of a map ending at 20°")
+	}
+}
+
+// The mapping function follows sin z' = R / (R + H) sin z, the delay goes
+// with 40.3 / f².
+func TestIONEXDelay(t *testing.T) {
+	x := parseIONEXText(t, ionexMaps)
+	at := ionexTime(0)
+	receiver := []float64{0.1, 0.2, 0}
+	position := helpers.GeodeticToECEF([][]float64{receiver}, true)[0]
+
+	latitude, longitude, mapping, err := x.PiercePoint(receiver, zenithSatellite(receiver))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(latitude-0.1) > 1e-7 || math.Abs(longitude-0.2) > 1e-7 || math.Abs(mapping-1) > 1e-12 {
+		t.Errorf("zenith pierce point %v %v, mapping %v", latitude, longitude, mapping)
+	}
+
+	// a satellite low in the east
+	satellite := helpers.GeodeticToECEF([][]float64{{0.1, 0.9, 20000e3}}, true)[0]
+	_, elevation, _ := helpers.AzimuthElevation(position, satellite)
+	sinZ := 6371 / (6371 + 450.0) * math.Cos(elevation)
+	latitude, longitude, mapping, err = x.PiercePoint(receiver, satellite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 1 / math.Sqrt(1-sinZ*sinZ); math.Abs(mapping-want) > 1e-12 {
+		t.Errorf("mapping %v, want %v", mapping, want)
+	}
+	if longitude <= 0.2 || longitude >= 0.9 {
+		t.Errorf("pierce point longitude %v", longitude)
+	}
+	// the zenith angle seen from the Earth center
+	psi := math.Acos(math.Sin(0.1)*math.Sin(latitude) + math.Cos(0.1)*math.Cos(latitude)*math.Cos(longitude-0.2))
+	if want := math.Pi/2 - elevation - math.Asin(sinZ); math.Abs(psi-want) > 1e-9 {
+		t.Errorf("pierce point %v rad from the receiver, want %v", psi, want)
+	}
+
+	got, err := x.Delay(at, receiver, satellite, GPS_L1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 40.3e16 * 10 * mapping / (GPS_L1 * GPS_L1); math.Abs(got-want) > 1e-9 {
+		t.Errorf("delay %v m, want %v m", got, want)
+	}
+
+	below := helpers.GeodeticToECEF([][]float64{{-0.1, 0.2 + math.Pi, 20000e3}}, true)[0]
+	if _, err := x.Delay(at, receiver, below, GPS_L1); err == nil {
+		t.Error("delay of a satellite below the horizon")
+	}
+	// IONEX fills the ionosphere of any system
+	models := Ionosphere{'R': x}
+	if v, err := models.Delay("R01", at, receiver, satellite, GPS_L1); err != nil || v != got {
+		t.Errorf("GLONASS delay %v %v, want %v", v, err, got)
+	}
+}
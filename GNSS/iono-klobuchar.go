@@ -0,0 +1,159 @@
+package gnss
+
+import (
+	"fmt"
+	"math"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  KLOBUCHAR IONOSPHERE
+// GPS broadcast ionosphere model, IS-GPS-200 20.3.3.5.2.5. The vertical
+// delay is a half cosine over the day (peak at 14:00 local time) with
+// amplitude and period given as cubic polynomials of the geomagnetic latitude
+// of the pierce point at 350 km, mapped to the line of sight by the obliquity
+// factor F. Angles of the ICD are in semicircles.
+// https://gssc.esa.int/navipedia/index.php/Klobuchar_Ionospheric_Model
+//
+// The coefficients come from the nav message (Ephemeris ionoAlpha / ionoBeta,
+// filled from the file header when parsing) or from the IONOSPHERIC CORR
+// records of a RINEX header: GPSA / GPSB, RINEX 2 ION ALPHA / ION BETA.
+
+type KlobucharCoefficients struct {
+	// s, s/semicircle, s/semicircle², s/semicircle³
+	Alpha [4]float64
+	// s, s/semicircle, s/semicircle², s/semicircle³
+	Beta [4]float64
+}
+
+// klobucharCorrections are the IONOSPHERIC CORR types holding alpha and
+// beta for the systems broadcasting Klobuchar coefficients.
+var klobucharCorrections = map[byte][2]string{
+	'G': {"GPSA", "GPSB"},
+	'J': {"QZSA", "QZSB"},
+	'C': {"BDSA", "BDSB"},
+	'I': {"IRNA", "IRNB"},
+}
+
+// KlobucharFromHeader returns the coefficients of system (G, J, C or I)
+// from the IONOSPHERIC CORR records of header.
+func KlobucharFromHeader(header RINEXHeader, system byte) (KlobucharCoefficients, bool) {
+	types, ok := klobucharCorrections[system]
+	if !ok {
+		return KlobucharCoefficients{}, false
+	}
+	alpha, ok := header.IonosphericCorrection(types[0])
+	if !ok || len(alpha) < 4 {
+		return KlobucharCoefficients{}, false
+	}
+	beta, ok := header.IonosphericCorrection(types[1])
+	if !ok || len(beta) < 4 {
+		return KlobucharCoefficients{}, false
+	}
+	var k KlobucharCoefficients
+	copy(k.Alpha[:], alpha)
+	copy(k.Beta[:], beta)
+	return k, true
+}
+
+// KlobucharFromEphemeris returns the coefficients carried by data, ok is
+// false unless ionoCoeffsValid is set.
+func KlobucharFromEphemeris(data Ephemeris) (KlobucharCoefficients, bool) {
+	if !data.IonoCoeffsValid() {
+		return KlobucharCoefficients{}, false
+	}
+	alpha, err := data.IonoAlpha()
+	if err != nil || alpha.Len() < 4 {
+		return KlobucharCoefficients{}, false
+	}
+	beta, err := data.IonoBeta()
+	if err != nil || beta.Len() < 4 {
+		return KlobucharCoefficients{}, false
+	}
+	var k KlobucharCoefficients
+	for i := 0; i < 4; i++ {
+		k.Alpha[i] = alpha.At(i)
+		k.Beta[i] = beta.At(i)
+	}
+	return k, true
+}
+
+// setEphemeris writes the coefficients into data and marks them valid.
+func (k KlobucharCoefficients) setEphemeris(data Ephemeris) error {
+	alpha, err := data.NewIonoAlpha(4)
+	if err != nil {
+		return fmt.Errorf("failed to create new iono alpha: %v", err)
+	}
+	beta, err := data.NewIonoBeta(4)
+	if err != nil {
+		return fmt.Errorf("failed to create new iono beta: %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		alpha.Set(i, k.Alpha[i])
+		beta.Set(i, k.Beta[i])
+	}
+	data.SetIonoCoeffsValid(true)
+	return nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// Delay returns the L1 slant delay in meters at t of a signal arriving at
+// receiver (geodetic latitude, longitude in radians, height, as returned by
+// helpers.ECEFToGeodetic with radians set) from azimuth and elevation in
+// radians (helpers.AzimuthElevation). Use IonosphericScale for other
+// frequencies.
+func (k KlobucharCoefficients) Delay(t GPSTime, receiver []float64, azimuth, elevation float64) float64 {
+	latitude := receiver[0] / math.Pi
+	longitude := receiver[1] / math.Pi
+	e := elevation / math.Pi
+
+	// Earth centred angle to the pierce point and its geodetic coordinates
+	psi := 0.0137/(e+0.11) - 0.022
+	phi := latitude + psi*math.Cos(azimuth)
+	phi = math.Max(-0.416, math.Min(0.416, phi))
+	lambda := longitude + psi*math.Sin(azimuth)/math.Cos(phi*math.Pi)
+
+	// geomagnetic latitude and local time of the pierce point
+	phiM := phi + 0.064*math.Cos((lambda-1.617)*math.Pi)
+	localTime := math.Mod(43200*lambda+t.TimeOfWeek(), SECS_IN_DAY)
+	if localTime < 0 {
+		localTime += SECS_IN_DAY
+	}
+
+	f := 1 + 16*math.Pow(0.53-e, 3)
+	amplitude := k.Alpha[0] + phiM*(k.Alpha[1]+phiM*(k.Alpha[2]+phiM*k.Alpha[3]))
+	amplitude = math.Max(amplitude, 0)
+	period := k.Beta[0] + phiM*(k.Beta[1]+phiM*(k.Beta[2]+phiM*k.Beta[3]))
+	period = math.Max(period, 72000)
+
+	delay := 5e-9
+	if x := 2 * math.Pi * (localTime - 50400) / period; math.Abs(x) < 1.57 {
+		x2 := x * x
+		delay += amplitude * (1 - x2/2 + x2*x2/24)
+	}
+	return SPEED_OF_LIGHT * f * delay
+}
+
+// IonosphericScale converts a GPS L1 ionospheric delay to frequency (Hz):
+// the first order delay goes with 1/f².
+func IonosphericScale(frequency float64) float64 {
+	r := GPS_L1 / frequency
+	return r * r
+}
+
+// GLONASSFrequency returns the FDMA carrier (Hz) of band 1, 2 or 3 for the
+// frequency channel number k (-7 to +6, RINEXEphemeris frequencyChannelOffset).
+func GLONASSFrequency(band, k int) float64 {
+	switch band {
+	case 1:
+		return GLONASS_L1 + float64(k)*GLONASS_L1_DELTA
+	case 2:
+		return GLONASS_L2 + float64(k)*GLONASS_L2_DELTA
+	default:
+		return GLONASS_L3 + float64(k)*GLONASS_L3_DELTA
+	}
+}
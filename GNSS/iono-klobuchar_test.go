@@ -0,0 +1,104 @@
+package gnss
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// =========================================================================
+
+// =========================================================================
+
+// With a constant amplitude the vertical delay is known at the extremes of
+// the day: 5 ns at night, 5 ns plus the amplitude at 14:00 local time, and
+// the cosine of x = 1 rad with the 20 h minimum period.
+func TestKlobucharDelay(t *testing.T) {
+	const amplitude = 2e-8
+	k := KlobucharCoefficients{Alpha: [4]float64{amplitude}}
+	receiver := []float64{0, 0, 0}
+	zenith := math.Pi / 2
+	// obliquity factor at the zenith, e = 0.5 semicircles
+	f := 1 + 16*math.Pow(0.03, 3)
+	day := GPSTimeFromWeek(2325, 2*SECS_IN_DAY)
+
+	for _, c := range []struct {
+		name      string
+		localTime float64
+		want      float64
+	}{
+		{"night", 2 * SECS_IN_HR, 5e-9},
+		{"14:00", 14 * SECS_IN_HR, 5e-9 + amplitude},
+		{"x = 1", 14*SECS_IN_HR + 72000/(2*math.Pi), 5e-9 + amplitude*(1-0.5+1.0/24)},
+	} {
+		got := k.Delay(day.Add(c.localTime), receiver, 0, zenith)
+		if want := SPEED_OF_LIGHT * f * c.want; math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s: %.6f m, want %.6f m", c.name, got, want)
+		}
+	}
+
+	// a negative amplitude leaves the night value
+	negative := KlobucharCoefficients{Alpha: [4]float64{-amplitude}}
+	if got, want := negative.Delay(day.Add(14*SECS_IN_HR), receiver, 0, zenith), SPEED_OF_LIGHT*f*5e-9; math.Abs(got-want) > 1e-9 {
+		t.Errorf("negative amplitude: %.6f m, want %.6f m", got, want)
+	}
+
+	// at night the delay follows the obliquity factor alone
+	for _, elevation := range []float64{5, 15, 30, 60} {
+		e := elevation / 180
+		got := k.Delay(day.Add(2*SECS_IN_HR), receiver, 0, elevation*math.Pi/180)
+		if want := SPEED_OF_LIGHT * (1 + 16*math.Pow(0.53-e, 3)) * 5e-9; math.Abs(got-want) > 1e-9 {
+			t.Errorf("elevation %v°: %.6f m, want %.6f m", elevation, got, want)
+		}
+	}
+}
+
+func TestIonosphericScale(t *testing.T) {
+	if s := IonosphericScale(GPS_L1); s != 1 {
+		t.Errorf("L1 scale %v", s)
+	}
+	// L1 and L2 are 154 and 120 times 10.23 MHz
+	if s := IonosphericScale(GPS_L2); math.Abs(s-154.0*154/(120*120)) > 1e-12 {
+		t.Errorf("L2 scale %v", s)
+	}
+	if f := GLONASSFrequency(1, -7); f != 1598.0625e6 {
+		t.Errorf("G1 channel -7 %v Hz", f)
+	}
+	if f := GLONASSFrequency(2, 6); f != 1248.625e6 {
+		t.Errorf("G2 channel 6 %v Hz", f)
+	}
+}
+
+// The GPSA / GPSB header records reach the GPS records of the file.
+func TestKlobucharCoefficients(t *testing.T) {
+	// the records of mixedNavV3 under the header of navHeaderV3, as 3.04:
+	// RINEX 3.05 GLONASS records have a fifth line
+	_, body, _ := strings.Cut(mixedNavV3, "END OF HEADER\n")
+	header := strings.Replace(navHeaderV3, "3.05", "3.04", 1)
+	nav, err := ParseNav(strings.NewReader(header+body), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := KlobucharCoefficients{
+		Alpha: [4]float64{1.1176e-08, 2.2352e-08, -5.9605e-08, -1.1921e-07},
+		Beta:  [4]float64{9.6256e+04, 1.3107e+05, -6.5536e+04, -5.2429e+05},
+	}
+	if k, ok := KlobucharFromHeader(nav.Header, 'G'); !ok || k != want {
+		t.Errorf("header %+v %v", k, ok)
+	}
+	data, _ := nav.GPS[0].EphemerisData()
+	if k, ok := KlobucharFromEphemeris(data); !ok || k != want {
+		t.Errorf("G05 record %+v %v", k, ok)
+	}
+	// BDSA without BDSB
+	if _, ok := KlobucharFromHeader(nav.Header, 'C'); ok {
+		t.Error("BeiDou coefficients from half the records")
+	}
+	data, _ = nav.BeiDou[0].EphemerisData()
+	if _, ok := KlobucharFromEphemeris(data); ok {
+		t.Error("BeiDou record with coefficients")
+	}
+	if _, ok := KlobucharFromHeader(nav.Header, 'E'); ok {
+		t.Error("Klobuchar coefficients for Galileo")
+	}
+}
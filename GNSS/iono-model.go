@@ -0,0 +1,125 @@
+package gnss
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mothergoose31/GNNS-GO/GNSS/helpers"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  IONOSPHERE MODELS
+// Every broadcast or map based model gives the slant delay in meters of one
+// signal at its carrier frequency, the first order delay going with 1/f²:
+//   GPS, QZSS, NavIC   KlobucharModel     (IS-GPS-200, L1 reference)
+//   BeiDou-2 D1/D2     BDSKlobucharModel  (BDS-SIS-ICD-B1I, B1I reference)
+//   BeiDou-3 B-CNAV    BDGIM              (BDS-SIS-ICD-B1C)
+//   Galileo            NeQuickG           (Galileo Ionospheric Model ICD)
+//   any (IGS maps)     *IONEX             (IONEX 1.0 / 1.1 files)
+// Ionosphere picks the model per satellite system, IonosphereFromHeader
+// fills it from the coefficients of a navigation file.
+
+// IonosphereModel returns the slant delay of the signal from satellite (ECEF
+// m) to receiver (geodetic latitude, longitude in radians, height in meters,
+// as returned by helpers.ECEFToGeodetic with radians set) at frequency (Hz).
+type IonosphereModel interface {
+	Delay(t GPSTime, receiver, satellite []float64, frequency float64) (float64, error)
+}
+
+// Ionosphere maps a satellite system (RINEX letter) to its model.
+type Ionosphere map[byte]IonosphereModel
+
+// Delay returns the delay of the signal of prn, see IonosphereModel.
+func (i Ionosphere) Delay(prn string, t GPSTime, receiver, satellite []float64, frequency float64) (float64, error) {
+	if prn == "" {
+		return 0, errors.New("empty PRN")
+	}
+	model, ok := i[prn[0]]
+	if b, isBeiDou := model.(BeiDouIonosphere); isBeiDou {
+		model = b.model(prn)
+		ok = model != nil
+	}
+	if !ok {
+		return 0, fmt.Errorf("no ionosphere model for system %c", prn[0])
+	}
+	return model.Delay(t, receiver, satellite, frequency)
+}
+
+// IonosphereFromHeader returns the broadcast models of the coefficients in
+// header: Klobuchar for GPS, QZSS and NavIC (falling back to the GPS
+// coefficients), the BDS Klobuchar and, while DefaultBDGIMBackground loads,
+// BDGIM for BeiDou (a BeiDouIonosphere when both are present) and NeQuick-G
+// for Galileo with data, nil for DefaultNeQuickData. GLONASS and SBAS, which
+// broadcast no model, use the GPS Klobuchar.
+func IonosphereFromHeader(header RINEXHeader, data *NeQuickData) Ionosphere {
+	models := make(Ionosphere)
+	gps, hasGPS := KlobucharFromHeader(header, 'G')
+	if hasGPS {
+		models['G'] = KlobucharModel{Coefficients: gps}
+		models['R'] = KlobucharModel{Coefficients: gps}
+		models['S'] = KlobucharModel{Coefficients: gps}
+	}
+	for _, system := range []byte{'J', 'I'} {
+		if k, ok := KlobucharFromHeader(header, system); ok {
+			models[system] = KlobucharModel{Coefficients: k}
+		} else if hasGPS {
+			models[system] = KlobucharModel{Coefficients: gps}
+		}
+	}
+	var beidou BeiDouIonosphere
+	if k, ok := KlobucharFromHeader(header, 'C'); ok {
+		beidou.BDS2 = BDSKlobucharModel{Coefficients: k}
+	}
+	if b, ok := BDGIMFromHeader(header); ok {
+		if _, err := DefaultBDGIMBackground(); err == nil {
+			beidou.BDS3 = BDGIM{Coefficients: b}
+		}
+	}
+	switch {
+	case beidou.BDS2 != nil && beidou.BDS3 != nil:
+		models['C'] = beidou
+	case beidou.BDS2 != nil:
+		models['C'] = beidou.BDS2
+	case beidou.BDS3 != nil:
+		models['C'] = beidou.BDS3
+	}
+	if n, ok := NeQuickFromHeader(header); ok {
+		if data == nil {
+			data, _ = DefaultNeQuickData()
+		}
+		if data != nil {
+			models['E'] = NeQuickG{Data: data, Coefficients: n}
+		}
+	}
+	return models
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// KlobucharModel is the IonosphereModel of GPS style Klobuchar coefficients.
+type KlobucharModel struct {
+	Coefficients KlobucharCoefficients
+}
+
+func (k KlobucharModel) Delay(t GPSTime, receiver, satellite []float64, frequency float64) (float64, error) {
+	azimuth, elevation, err := ionosphereLookAngles(receiver, satellite)
+	if err != nil {
+		return 0, err
+	}
+	return k.Coefficients.Delay(t, receiver, azimuth, elevation) * IonosphericScale(frequency), nil
+}
+
+// ionosphereLookAngles returns the azimuth and elevation (radians) of
+// satellite from receiver, an error when it is below the horizon.
+func ionosphereLookAngles(receiver, satellite []float64) (float64, float64, error) {
+	position := helpers.GeodeticToECEF([][]float64{receiver}, true)[0]
+	azimuth, elevation, _ := helpers.AzimuthElevation(position, satellite)
+	if elevation < 0 {
+		return 0, 0, errors.New("satellite below the horizon")
+	}
+	return azimuth, elevation, nil
+}
@@ -0,0 +1,104 @@
+package gnss
+
+import (
+	"strings"
+	"testing"
+)
+
+// =========================================================================
+
+// =========================================================================
+
+func TestIonosphereFromHeader(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(navHeaderV3), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := LoadNeQuickData(neQuickTestFiles())
+	if err != nil {
+		t.Fatal(err)
+	}
+	models := IonosphereFromHeader(nav.Header, data)
+
+	gps, _ := KlobucharFromHeader(nav.Header, 'G')
+	for _, system := range []byte{'G', 'R', 'S', 'J', 'I'} {
+		if m, ok := models[system].(KlobucharModel); !ok || m.Coefficients != gps {
+			t.Errorf("%c: %#v, want the GPS Klobuchar", system, models[system])
+		}
+	}
+	if m, ok := models['E'].(NeQuickG); !ok || m.Data != data || m.Coefficients != (NeQuickCoefficients{Ai0: 29.25, Ai1: 3.9062e-02, Ai2: 1.8768e-02}) {
+		t.Errorf("E: %#v", models['E'])
+	}
+	// BDSA without BDSB
+	if m, ok := models['C']; ok {
+		t.Errorf("C: %#v without BDSB", m)
+	}
+
+	bdsb := "BDSB   1.2288e+05  1.3107e+05 -6.5536e+04 -3.9322e+05       IONOSPHERIC CORR\n"
+	header := strings.Replace(navHeaderV3, "GAUT", bdsb+"GAUT", 1)
+	nav, err = ParseNav(strings.NewReader(header), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	models = IonosphereFromHeader(nav.Header, data)
+	bds, _ := KlobucharFromHeader(nav.Header, 'C')
+	if m, ok := models['C'].(BDSKlobucharModel); !ok || m.Coefficients != bds || bds.Beta[0] != 1.2288e+05 {
+		t.Errorf("C: %#v, want the BDS Klobuchar", models['C'])
+	}
+
+	// BDGIM joins with the background tables
+	nav, err = ParseNav(strings.NewReader(navBeiDouIonV4), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	models = IonosphereFromHeader(nav.Header, data)
+	if _, err := DefaultBDGIMBackground(); err != nil {
+		if _, ok := models['C'].(BDSKlobucharModel); !ok {
+			t.Errorf("C: %#v without the BDGIM background", models['C'])
+		}
+	} else if m, ok := models['C'].(BeiDouIonosphere); !ok {
+		t.Errorf("C: %#v, want the BDS Klobuchar and BDGIM", models['C'])
+	} else if b, ok := m.BDS3.(BDGIM); !ok || b.Coefficients != (BDGIMCoefficients{1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("BDS-3: %#v", m.BDS3)
+	}
+}
+
+func TestIonosphereDelay(t *testing.T) {
+	k := KlobucharCoefficients{Alpha: [4]float64{2e-8}, Beta: [4]float64{9e4}}
+	models := Ionosphere{'G': KlobucharModel{Coefficients: k}, 'C': BDSKlobucharModel{Coefficients: k}}
+	at := GPSTimeFromWeek(2325, 2*SECS_IN_DAY+14*SECS_IN_HR)
+	receiver := []float64{0.5, 2, 100}
+	satellite := zenithSatellite(receiver)
+
+	for prn, model := range map[string]IonosphereModel{"G05": models['G'], "C19": models['C']} {
+		got, err := models.Delay(prn, at, receiver, satellite, GPS_L2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, _ := model.Delay(at, receiver, satellite, GPS_L2); got != want || got <= 0 {
+			t.Errorf("%s: %v m, want %v m", prn, got, want)
+		}
+	}
+	// BDS-3 satellites take BDGIM
+	background, err := LoadBDGIMBackground(bdgimBackgroundFile(20, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bdgim := BDGIM{Background: background.A0}
+	models['C'] = BeiDouIonosphere{BDS2: models['C'], BDS3: bdgim}
+	for prn, model := range map[string]IonosphereModel{"C05": BDSKlobucharModel{Coefficients: k}, "C25": bdgim} {
+		got, err := models.Delay(prn, at, receiver, satellite, BEIDOU_B1I)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, _ := model.Delay(at, receiver, satellite, BEIDOU_B1I); got != want || got <= 0 {
+			t.Errorf("%s: %v m, want %v m", prn, got, want)
+		}
+	}
+
+	for _, prn := range []string{"", "E05"} {
+		if _, err := models.Delay(prn, at, receiver, satellite, GPS_L1); err == nil {
+			t.Errorf("delay of %q without a model", prn)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package gnss
+
+import (
+	"math"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  NEQUICK-G ELECTRON DENSITY PROFILE
+// Galileo Ionospheric Model ICD 2.5.5 - 2.5.7. The vertical profile at a
+// point is a sum of semi-Epstein layers for E, F1 and F2 below the F2 peak
+// and a single layer with height dependent thickness above it. The peaks
+// come from the CCIR maps (foF2, M(3000)F2) and from the solar zenith angle
+// (foE). Heights are in km, angles in degrees and densities in 1e11 m⁻³
+// as in the ICD.
+
+// neQuickEpoch holds what depends only on the time and the solar activity:
+// the CCIR coefficients interpolated to the effective sunspot number and
+// evaluated at the universal time, and the solar declination.
+type neQuickEpoch struct {
+	data   *NeQuickData
+	month  int
+	ut     float64
+	az     float64
+	azr    float64
+	sinDec float64
+	cosDec float64
+	cf2    [76]float64
+	cm3    [49]float64
+}
+
+// number of latitude terms per longitude harmonic of foF2 and M(3000)F2
+var (
+	neQuickF2Terms  = []int{12, 12, 9, 5, 2, 1, 1, 1, 1}
+	neQuickFm3Terms = []int{7, 8, 6, 3, 2, 1, 1}
+)
+
+func newNeQuickEpoch(data *NeQuickData, month int, ut, az float64) *neQuickEpoch {
+	e := &neQuickEpoch{data: data, month: month, ut: ut, az: az}
+	e.azr = math.Sqrt(167273+(az-63.7)*1123.6) - 408.99
+
+	// solar declination at the middle of the month
+	t := 30.5*float64(month) - 15 + (18-ut)/24
+	am := (0.9856*t - 3.289) * math.Pi / 180
+	al := am + (1.916*math.Sin(am)+0.020*math.Sin(2*am)+282.634)*math.Pi/180
+	e.sinDec = 0.39782 * math.Sin(al)
+	e.cosDec = math.Sqrt(1 - e.sinDec*e.sinDec)
+
+	// CCIR coefficients at Azr, then their Fourier series in time
+	f2 := &data.f2[month-1]
+	fm3 := &data.fm3[month-1]
+	hour := (15*ut - 180) * math.Pi / 180
+	for i := range e.cf2 {
+		a := func(k int) float64 { return f2[0][i][k]*(1-e.azr/100) + f2[1][i][k]*e.azr/100 }
+		e.cf2[i] = a(0)
+		for k := 1; k <= 6; k++ {
+			e.cf2[i] += a(2*k-1)*math.Sin(float64(k)*hour) + a(2*k)*math.Cos(float64(k)*hour)
+		}
+	}
+	for i := range e.cm3 {
+		a := func(k int) float64 { return fm3[0][i][k]*(1-e.azr/100) + fm3[1][i][k]*e.azr/100 }
+		e.cm3[i] = a(0)
+		for k := 1; k <= 4; k++ {
+			e.cm3[i] += a(2*k-1)*math.Sin(float64(k)*hour) + a(2*k)*math.Cos(float64(k)*hour)
+		}
+	}
+	return e
+}
+
+// ccirMap evaluates a CCIR map at latitude / longitude (radians) and modip
+// (degrees): powers of sin(modip) for the first terms, then cos and sin
+// longitude harmonics weighted by powers of cos(latitude).
+func ccirMap(coefficients []float64, terms []int, latitude, longitude, modip float64) float64 {
+	var m [13]float64
+	m[0] = 1
+	sinModip := math.Sin(modip * math.Pi / 180)
+	for k := 1; k < len(m); k++ {
+		m[k] = m[k-1] * sinModip
+	}
+
+	value := 0.0
+	for k := 0; k < terms[0]; k++ {
+		value += coefficients[k] * m[k]
+	}
+	index := terms[0]
+	p := 1.0
+	for n := 1; n < len(terms); n++ {
+		p *= math.Cos(latitude)
+		c, s := math.Cos(float64(n)*longitude), math.Sin(float64(n)*longitude)
+		for k := 0; k < terms[n]; k++ {
+			value += (coefficients[index]*c + coefficients[index+1]*s) * m[k] * p
+			index += 2
+		}
+	}
+	return value
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// neQuickProfile are the layer parameters of a vertical profile.
+type neQuickProfile struct {
+	hmE, hmF1, hmF2                      float64
+	nmF2                                 float64
+	a1, a2, a3                           float64
+	b2Bottom, b1Top, b1Bottom, bETop, h0 float64
+}
+
+const (
+	neQuickHmE      = 120.0
+	neQuickBEBottom = 5.0
+)
+
+// profile returns the layers at latitude and longitude (degrees).
+func (e *neQuickEpoch) profile(latitude, longitude float64) neQuickProfile {
+	modip := e.data.modipAt(latitude, longitude)
+	lat := latitude * math.Pi / 180
+	lon := longitude * math.Pi / 180
+
+	// effective solar zenith angle, joined to a night value past 86.23°
+	localTime := e.ut + longitude/15
+	cosChi := math.Sin(lat)*e.sinDec + math.Cos(lat)*e.cosDec*math.Cos(math.Pi/12*(12-localTime))
+	chi := math.Atan2(math.Sqrt(math.Max(1-cosChi*cosChi, 0)), cosChi) * 180 / math.Pi
+	chiEff := neqJoin(90-0.24*neqClipExp(20-0.2*chi), chi, 12, chi-86.23292796211615)
+
+	// E layer critical frequency, MHz
+	season := 0.0
+	switch e.month {
+	case 1, 2, 11, 12:
+		season = -1
+	case 5, 6, 7, 8:
+		season = 1
+	}
+	if latitude < 0 {
+		season = -season
+	}
+	ee := neqClipExp(0.3 * latitude)
+	seasp := season * (ee - 1) / (ee + 1)
+	cosChiEff := math.Max(math.Cos(chiEff*math.Pi/180), 0)
+	factor := 1.112 - 0.019*seasp
+	foE := math.Sqrt(factor*factor*math.Sqrt(e.az)*math.Pow(cosChiEff, 0.6) + 0.49)
+
+	// F2 from the CCIR maps, F1 from E
+	foF2 := ccirMap(e.cf2[:], neQuickF2Terms, lat, lon, modip)
+	m3000 := ccirMap(e.cm3[:], neQuickFm3Terms, lat, lon, modip)
+	foF1 := neqJoin(1.4*foE, 0, 1000, foE-2)
+	foF1 = neqJoin(0, foF1, 1000, foE-foF1)
+	foF1 = neqJoin(foF1, 0.85*1.4*foE, 60, 0.85*foF2-foF1)
+	if foF1 < 1e-6 {
+		foF1 = 0
+	}
+
+	nmE := 0.124 * foE * foE
+	nmF1 := 0.124 * foF1 * foF1
+	if foF1 <= 0 && foE > 2 {
+		nmF1 = 0.124 * (foE + 0.5) * (foE + 0.5)
+	}
+	nmF2 := 0.124 * foF2 * foF2
+
+	// peak heights, km
+	deltaM := -0.012
+	if foE >= 1e-30 {
+		ratio := foF2 / foE
+		ratio = neqJoin(ratio, 1.75, 20, ratio-1.75)
+		deltaM = 0.253/(ratio-1.215) - 0.012
+	}
+	p := neQuickProfile{hmE: neQuickHmE, nmF2: nmF2}
+	p.hmF2 = 1490*m3000*math.Sqrt((0.0196*m3000*m3000+1)/(1.2967*m3000*m3000-1))/(m3000+deltaM) - 176
+	p.hmF1 = (p.hmF2 + p.hmE) / 2
+
+	// thicknesses, km
+	gradient := 0.01 * math.Exp(-3.467+0.857*math.Log(foF2*foF2)+2.02*math.Log(m3000))
+	p.b2Bottom = 0.385 * nmF2 / gradient
+	p.b1Top = 0.3 * (p.hmF2 - p.hmF1)
+	p.b1Bottom = 0.5 * (p.hmF1 - p.hmE)
+	p.bETop = math.Max(p.b1Bottom, 7)
+
+	// layer amplitudes, F1 and E are solved together
+	p.a1 = 4 * nmF2
+	if foF1 < 0.5 {
+		p.a3 = 4 * (nmE - epstein(p.a1, p.hmF2, p.b2Bottom, p.hmE))
+	} else {
+		a3 := 4 * nmE
+		a2 := 0.0
+		for i := 0; i < 5; i++ {
+			a2 = 4 * (nmF1 - epstein(p.a1, p.hmF2, p.b2Bottom, p.hmF1) - epstein(a3, p.hmE, p.bETop, p.hmF1))
+			a2 = neqJoin(a2, 0.8*nmF1, 1, a2-0.8*nmF1)
+			a3 = 4 * (nmE - epstein(a2, p.hmF1, p.b1Bottom, p.hmE) - epstein(p.a1, p.hmF2, p.b2Bottom, p.hmE))
+		}
+		p.a2 = a2
+		p.a3 = neqJoin(a3, 0.05, 60, a3-0.005)
+	}
+
+	// topside thickness
+	var k float64
+	if e.month >= 4 && e.month <= 9 {
+		k = 6.705 - 0.014*e.azr - 0.008*p.hmF2
+	} else {
+		ratio := p.hmF2 / p.b2Bottom
+		k = -7.77 + 0.097*ratio*ratio + 0.153*nmF2
+	}
+	k = neqJoin(k, 2, 1, k-2)
+	k = neqJoin(8, k, 1, k-8)
+	p.h0 = k * p.b2Bottom
+	return p
+}
+
+// density returns the electron density at height (km), 1e11 m⁻³.
+func (p neQuickProfile) density(height float64) float64 {
+	if height > p.hmF2 {
+		return p.topside(height)
+	}
+	if height >= 100 {
+		n, _ := p.bottomside(height)
+		return n
+	}
+	// below 100 km a Chapman layer continuing the profile and its gradient
+	n, gradient := p.bottomside(100)
+	if n <= 0 {
+		return 0
+	}
+	bf := 1 - 10*gradient/n
+	z := (height - 100) / 10
+	return n * neqClipExp(1-bf*z-neqClipExp(-z))
+}
+
+// bottomside returns the density and its height derivative below the F2
+// peak.
+func (p neQuickProfile) bottomside(height float64) (float64, float64) {
+	bE := neQuickBEBottom
+	if height > p.hmE {
+		bE = p.bETop
+	}
+	bF1 := p.b1Bottom
+	if height > p.hmF1 {
+		bF1 = p.b1Top
+	}
+	// the F1 and E layers fade out near the F2 peak
+	fade := neqClipExp(10 / (1 + math.Abs(height-p.hmF2)))
+
+	n, gradient := 0.0, 0.0
+	for _, layer := range [3]struct{ amplitude, s, ds float64 }{
+		{p.a1, (height - p.hmF2) / p.b2Bottom, 1 / p.b2Bottom},
+		{p.a2, (height - p.hmF1) / bF1 * fade, fade / bF1},
+		{p.a3, (height - p.hmE) / bE * fade, fade / bE},
+	} {
+		if math.Abs(layer.s) > 25 {
+			continue
+		}
+		es := math.Exp(layer.s)
+		value := layer.amplitude * es / ((1 + es) * (1 + es))
+		n += value
+		gradient += value * layer.ds * (1 - es) / (1 + es)
+	}
+	return n, gradient
+}
+
+// topside returns the density above the F2 peak.
+func (p neQuickProfile) topside(height float64) float64 {
+	const g, r = 0.125, 100.0
+	dh := height - p.hmF2
+	z := dh / (p.h0 * (1 + r*g*dh/(r*p.h0+g*dh)))
+	ea := neqClipExp(z)
+	if ea > 1e11 {
+		return 4 * p.nmF2 / ea
+	}
+	return 4 * p.nmF2 * ea / ((1 + ea) * (1 + ea))
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// neqClipExp is exp bounded to the float range of the ICD.
+func neqClipExp(x float64) float64 {
+	if x > 80 {
+		return 5.5406e34
+	}
+	if x < -80 {
+		return 1.8049e-35
+	}
+	return math.Exp(x)
+}
+
+// neqJoin joins f1 (x > 0) and f2 (x < 0) smoothly with steepness alpha.
+func neqJoin(f1, f2, alpha, x float64) float64 {
+	ee := neqClipExp(alpha * x)
+	return (f1*ee + f2) / (ee + 1)
+}
+
+// epstein is the Epstein layer of amplitude peak, peak height and thickness
+// at height h.
+func epstein(peak, height, thickness, h float64) float64 {
+	ee := neqClipExp((h - height) / thickness)
+	return peak * ee / ((1 + ee) * (1 + ee))
+}
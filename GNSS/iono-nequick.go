@@ -0,0 +1,398 @@
+package gnss
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"sync"
+
+	"github.com/mothergoose31/GNNS-GO/GNSS/helpers"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  NEQUICK-G IONOSPHERE
+// Galileo broadcast ionosphere model, European GNSS (Galileo) Open Service
+// Ionospheric Correction Algorithm for Galileo Single Frequency Users 1.2.
+// The broadcast coefficients give the effective ionisation level
+//   Az = ai0 + ai1 μ + ai2 μ²   (μ the modified dip latitude of the receiver)
+// which drives the CCIR maps of the NeQuick electron density profile, see
+// iono-nequick-profile.go. The slant TEC is the density integrated along the
+// straight receiver - satellite ray by adaptive Gauss-Kronrod quadrature.
+// https://www.gsc-europa.eu/sites/default/files/sites/all/files/Galileo_Ionospheric_Model.pdf
+//
+// The model needs the CCIR maps and the modip grid distributed with the ICD
+// (ccir11.asc ... ccir22.asc for January ... December, modipNeQG_wrapped.asc).
+// The files are not part of this tree: nequick-data is embedded in the
+// package and DefaultNeQuickData loads them from it once they are added, it
+// fails until then. LoadNeQuickData reads copies from a directory (os.DirFS)
+// or an embed.FS, the files may be compressed.
+
+type NeQuickCoefficients struct {
+	// sfu, sfu/degree, sfu/degree²
+	Ai0, Ai1, Ai2 float64
+}
+
+// NeQuickFromHeader returns the coefficients of the GAL IONOSPHERIC CORR
+// record of header (RINEX 3) or of the first Galileo ION record (RINEX 4).
+func NeQuickFromHeader(header RINEXHeader) (NeQuickCoefficients, bool) {
+	parameters, ok := header.IonosphericCorrection("GAL")
+	if !ok || len(parameters) < 3 {
+		return NeQuickCoefficients{}, false
+	}
+	return NeQuickCoefficients{Ai0: parameters[0], Ai1: parameters[1], Ai2: parameters[2]}, true
+}
+
+// NeQuickData are the CCIR maps and modip grid of NeQuick-G.
+type NeQuickData struct {
+	// foF2 and M(3000)F2 coefficients per month for a sunspot number of 0
+	// and 100, latitude terms, time terms
+	f2  [12][2][76][13]float64
+	fm3 [12][2][49][9]float64
+	// modified dip latitude (degrees) on a 5° x 10° grid from -95° latitude
+	// and -190° longitude, one row and column past every edge
+	modip [39][39]float64
+}
+
+// LoadNeQuickData reads ccir11.asc ... ccir22.asc and modipNeQG_wrapped.asc
+// from fsys.
+func LoadNeQuickData(fsys fs.FS) (*NeQuickData, error) {
+	data := &NeQuickData{}
+	for month := range data.f2 {
+		name := fmt.Sprintf("ccir%d.asc", month+11)
+		values, err := readDataValues(fsys, name, 2*76*13+2*49*9)
+		if err != nil {
+			return nil, err
+		}
+		// Fortran order of F2(13,76,2) then FM3(9,49,2)
+		for i := range data.f2[month] {
+			for j := range data.f2[month][i] {
+				copy(data.f2[month][i][j][:], values)
+				values = values[13:]
+			}
+		}
+		for i := range data.fm3[month] {
+			for j := range data.fm3[month][i] {
+				copy(data.fm3[month][i][j][:], values)
+				values = values[9:]
+			}
+		}
+	}
+
+	values, err := readDataValues(fsys, "modipNeQG_wrapped.asc", 39*39)
+	if err != nil {
+		return nil, err
+	}
+	for i := range data.modip {
+		copy(data.modip[i][:], values[39*i:])
+	}
+	return data, nil
+}
+
+//go:embed nequick-data
+var neQuickFiles embed.FS
+
+var (
+	defaultNeQuickOnce sync.Once
+	defaultNeQuickData *NeQuickData
+	defaultNeQuickErr  error
+)
+
+// DefaultNeQuickData returns the maps embedded from nequick-data, read once.
+func DefaultNeQuickData() (*NeQuickData, error) {
+	defaultNeQuickOnce.Do(func() {
+		fsys, err := fs.Sub(neQuickFiles, "nequick-data")
+		if err != nil {
+			defaultNeQuickErr = fmt.Errorf("failed to open embedded NeQuick data: %v", err)
+			return
+		}
+		if defaultNeQuickData, err = LoadNeQuickData(fsys); err != nil {
+			defaultNeQuickErr = fmt.Errorf("failed to load embedded NeQuick data: %v", err)
+		}
+	})
+	return defaultNeQuickData, defaultNeQuickErr
+}
+
+// modipAt interpolates the modip grid at latitude and longitude (degrees)
+// with third order polynomials, first along latitude then longitude.
+func (d *NeQuickData) modipAt(latitude, longitude float64) float64 {
+	if latitude >= 90 {
+		return 90
+	}
+	if latitude <= -90 {
+		return -90
+	}
+	longitude = math.Mod(longitude+180, 360)
+	if longitude < 0 {
+		longitude += 360
+	}
+
+	// grid coordinates, the point lies between the second and third of the
+	// four rows and columns used
+	y := (latitude + 95) / 5
+	x := (longitude + 10) / 10
+	row := min(int(y), 37) - 1
+	col := min(int(x), 37) - 1
+
+	var z [4]float64
+	for k := range z {
+		var column [4]float64
+		for j := range column {
+			column[j] = d.modip[row+j][col+k]
+		}
+		z[k] = cubicInterpolation(column, y-float64(row+1))
+	}
+	return cubicInterpolation(z, x-float64(col+1))
+}
+
+// cubicInterpolation evaluates the cubic through z at -1, 0, 1 and 2 at x.
+func cubicInterpolation(z [4]float64, x float64) float64 {
+	if math.Abs(2*x) < 1e-10 {
+		return z[1]
+	}
+	delta := 2*x - 1
+	g1, g2 := z[2]+z[1], z[2]-z[1]
+	g3, g4 := z[3]+z[0], (z[3]-z[0])/3
+	a0, a1 := 9*g1-g3, 9*g2-g4
+	a2, a3 := g3-g1, g4-g2
+	return (a0 + delta*(a1+delta*(a2+delta*a3))) / 16
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// NeQuickG is the Galileo model of Data driven by the broadcast
+// Coefficients, nil Data uses DefaultNeQuickData.
+type NeQuickG struct {
+	Data         *NeQuickData
+	Coefficients NeQuickCoefficients
+}
+
+const (
+	// mean Earth radius of the ICD, km
+	neQuickEarthRadius = 6371.2
+	// integration breakpoints (km) and relative tolerances below and above
+	neQuickHeightA       = 1000.0
+	neQuickHeightB       = 2000.0
+	neQuickToleranceLow  = 0.001
+	neQuickToleranceHigh = 0.01
+	neQuickMaxLevel      = 50
+)
+
+// Delay returns the slant delay in meters at frequency (Hz, GALILEO_E1,
+// GALILEO_E5A...) of the signal from satellite (ECEF m) to receiver
+// (geodetic latitude, longitude in radians, height in meters).
+func (n NeQuickG) Delay(t GPSTime, receiver, satellite []float64, frequency float64) (float64, error) {
+	tec, err := n.SlantTEC(t, receiver, satellite)
+	if err != nil {
+		return 0, err
+	}
+	return 40.3e16 * tec / (frequency * frequency), nil
+}
+
+// withData returns n with Data set to the embedded maps when nil.
+func (n NeQuickG) withData() (NeQuickG, error) {
+	if n.Data != nil {
+		return n, nil
+	}
+	data, err := DefaultNeQuickData()
+	if err != nil {
+		return n, err
+	}
+	n.Data = data
+	return n, nil
+}
+
+// EffectiveIonisation returns Az (sfu) at receiver, NaN without NeQuick data.
+func (n NeQuickG) EffectiveIonisation(receiver []float64) float64 {
+	c := n.Coefficients
+	if c.Ai0 == 0 && c.Ai1 == 0 && c.Ai2 == 0 {
+		return 63.7
+	}
+	n, err := n.withData()
+	if err != nil {
+		return math.NaN()
+	}
+	modip := n.Data.modipAt(receiver[0]*180/math.Pi, receiver[1]*180/math.Pi)
+	az := c.Ai0 + c.Ai1*modip + c.Ai2*modip*modip
+	return math.Max(0, math.Min(400, az))
+}
+
+// SlantTEC returns the total electron content (TECU) between receiver and
+// satellite, see Delay.
+func (n NeQuickG) SlantTEC(t GPSTime, receiver, satellite []float64) (float64, error) {
+	n, err := n.withData()
+	if err != nil {
+		return 0, err
+	}
+	sat := helpers.ECEFToGeodetic([][]float64{satellite}, true)[0]
+	ray, err := newNeQuickRay(receiver, sat)
+	if err != nil {
+		return 0, err
+	}
+
+	utc := t.ToUTC()
+	ut := float64(utc.Hour()) + float64(utc.Minute())/60 + (float64(utc.Second())+float64(utc.Nanosecond())/1e9)/3600
+	epoch := newNeQuickEpoch(n.Data, int(utc.Month()), ut, n.EffectiveIonisation(receiver))
+	density := func(s float64) float64 {
+		latitude, longitude, height := ray.point(s)
+		return epoch.profile(latitude, longitude).density(height)
+	}
+
+	// tighter tolerance below 1000 km where most of the electrons are
+	h1, h2 := receiver[2]/1000, sat[2]/1000
+	tec := 0.0
+	from := ray.distance(h1)
+	for _, segment := range []struct{ top, tolerance float64 }{
+		{neQuickHeightA, neQuickToleranceLow},
+		{neQuickHeightB, neQuickToleranceHigh},
+		{math.Inf(1), neQuickToleranceHigh},
+	} {
+		if h1 >= segment.top {
+			continue
+		}
+		to := ray.distance(math.Min(h2, segment.top))
+		tec += gaussKronrod(density, from, to, segment.tolerance, 0)
+		if h2 <= segment.top {
+			break
+		}
+		from = to
+	}
+	// 1e11 m⁻³ km to TECU
+	return tec * 1e-2, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+//  RAY GEOMETRY
+// The ray is parametrized by the distance s (km) from its perigee, the
+// point closest to the Earth center, on a spherical Earth: at s the radius
+// is √(s² + rp²) and the great circle angle from the perigee atan(s / rp)
+// along the ray azimuth at the perigee. Vertical rays use the radius.
+
+type neQuickRay struct {
+	vertical  bool
+	perigee   float64 // km
+	latitude  float64 // perigee, rad
+	longitude float64 // perigee, rad
+	sinAz     float64 // azimuth at the perigee towards the satellite
+	cosAz     float64
+}
+
+func newNeQuickRay(receiver, satellite []float64) (*neQuickRay, error) {
+	lat1, lon1 := receiver[0], receiver[1]
+	lat2, lon2 := satellite[0], satellite[1]
+	r1 := neQuickEarthRadius + receiver[2]/1000
+	r2 := neQuickEarthRadius + satellite[2]/1000
+
+	if math.Abs(lat2-lat1) < 1e-5*math.Pi/180 && math.Abs(lon2-lon1) < 1e-5*math.Pi/180 {
+		return &neQuickRay{vertical: true, latitude: lat1, longitude: lon1}, nil
+	}
+
+	// great circle angle and azimuth from the receiver to the satellite
+	cosDelta := math.Sin(lat1)*math.Sin(lat2) + math.Cos(lat1)*math.Cos(lat2)*math.Cos(lon2-lon1)
+	sinDelta := math.Sqrt(math.Max(1-cosDelta*cosDelta, 0))
+	zenith := math.Atan2(sinDelta, cosDelta-r1/r2)
+	sinAz, cosAz := azimuthOnSphere(lat1, lat2, lon2-lon1, cosDelta, sinDelta)
+
+	if zenith > math.Pi/2 {
+		return nil, errors.New("satellite below the horizon")
+	}
+	ray := &neQuickRay{perigee: r1 * math.Sin(zenith)}
+
+	// the perigee lies behind the receiver by 90° - zenith
+	dp := math.Pi/2 - zenith
+	sinLat := math.Sin(lat1)*math.Cos(dp) - math.Cos(lat1)*math.Sin(dp)*cosAz
+	ray.latitude = math.Asin(sinLat)
+	cosLat := math.Cos(ray.latitude)
+	ray.longitude = lon1 + math.Atan2(-sinAz*math.Sin(dp)*math.Cos(lat1), math.Cos(dp)-math.Sin(lat1)*sinLat)
+
+	cosPsi := sinLat*math.Sin(lat2) + cosLat*math.Cos(lat2)*math.Cos(lon2-ray.longitude)
+	sinPsi := math.Sqrt(math.Max(1-cosPsi*cosPsi, 0))
+	ray.sinAz, ray.cosAz = azimuthOnSphere(ray.latitude, lat2, lon2-ray.longitude, cosPsi, sinPsi)
+	return ray, nil
+}
+
+// azimuthOnSphere returns the sine and cosine of the azimuth from lat1 to
+// lat2, dLon away at a great circle angle with cosine and sine given.
+func azimuthOnSphere(lat1, lat2, dLon, cosDelta, sinDelta float64) (float64, float64) {
+	if math.Abs(math.Cos(lat1)) < 1e-10 || sinDelta < 1e-10 {
+		if lat1 > 0 {
+			return 0, -1
+		}
+		return 0, 1
+	}
+	sinAz := math.Sin(dLon) * math.Cos(lat2) / sinDelta
+	cosAz := (math.Sin(lat2) - cosDelta*math.Sin(lat1)) / (sinDelta * math.Cos(lat1))
+	return sinAz, cosAz
+}
+
+// distance returns s at height (km), the receiver is past the perigee as
+// the satellite is above its horizon.
+func (r *neQuickRay) distance(height float64) float64 {
+	radius := neQuickEarthRadius + height
+	if r.vertical {
+		return radius
+	}
+	return math.Sqrt(math.Max(radius*radius-r.perigee*r.perigee, 0))
+}
+
+// point returns the latitude, longitude (degrees) and height (km) at s.
+func (r *neQuickRay) point(s float64) (float64, float64, float64) {
+	if r.vertical {
+		return r.latitude * 180 / math.Pi, r.longitude * 180 / math.Pi, s - neQuickEarthRadius
+	}
+	height := math.Sqrt(s*s+r.perigee*r.perigee) - neQuickEarthRadius
+	delta := math.Atan2(s, r.perigee)
+	sinLat := math.Sin(r.latitude)*math.Cos(delta) + math.Cos(r.latitude)*math.Sin(delta)*r.cosAz
+	latitude := math.Asin(sinLat)
+	longitude := r.longitude + math.Atan2(math.Sin(delta)*r.sinAz*math.Cos(r.latitude), math.Cos(delta)-math.Sin(r.latitude)*sinLat)
+	return latitude * 180 / math.Pi, longitude * 180 / math.Pi, height
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// Gauss-Kronrod 7-15 nodes and weights, the Gauss nodes are the odd ones
+var (
+	kronrodNodes = [8]float64{
+		0.991455371120812639, 0.949107912342758525, 0.864864423359769073, 0.741531185599394440,
+		0.586087235467691130, 0.405845151377397167, 0.207784955007898468, 0,
+	}
+	kronrodWeights = [8]float64{
+		0.022935322010529225, 0.063092092629978553, 0.104790010322250184, 0.140653259715525919,
+		0.169004726639267903, 0.190350578064785410, 0.204432940075298892, 0.209482141084727828,
+	}
+	gaussWeights = [4]float64{
+		0.129484966168869693, 0.279705391489276668, 0.381830050505118945, 0.417959183673469388,
+	}
+)
+
+// gaussKronrod integrates f from a to b, halving the interval until the
+// Gauss and Kronrod estimates agree within the relative tolerance.
+func gaussKronrod(f func(float64) float64, a, b, tolerance float64, level int) float64 {
+	middle, half := (a+b)/2, (b-a)/2
+	center := f(middle)
+	kronrod := kronrodWeights[7] * center
+	gauss := gaussWeights[3] * center
+	for i := 0; i < 7; i++ {
+		sum := f(middle-half*kronrodNodes[i]) + f(middle+half*kronrodNodes[i])
+		kronrod += kronrodWeights[i] * sum
+		if i%2 == 1 {
+			gauss += gaussWeights[i/2] * sum
+		}
+	}
+	kronrod *= half
+	gauss *= half
+
+	if math.Abs(kronrod-gauss) <= tolerance*math.Abs(kronrod) || level == neQuickMaxLevel {
+		return kronrod
+	}
+	return gaussKronrod(f, a, middle, tolerance, level+1) + gaussKronrod(f, middle, b, tolerance, level+1)
+}
@@ -0,0 +1,174 @@
+package gnss
+
+import (
+	"fmt"
+	"io/fs"
+	"math"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// neQuickTestFiles returns CCIR maps of zeros and a modip grid equal to the
+// latitude of its rows.
+func neQuickTestFiles() fstest.MapFS {
+	files := fstest.MapFS{}
+	zeros := strings.Repeat("0.00000000E+00 ", 2*76*13+2*49*9)
+	for month := 11; month <= 22; month++ {
+		files[fmt.Sprintf("ccir%d.asc", month)] = &fstest.MapFile{Data: []byte(zeros)}
+	}
+	var modip strings.Builder
+	for row := 0; row < 39; row++ {
+		for col := 0; col < 39; col++ {
+			fmt.Fprintf(&modip, "%g ", float64(5*row-95))
+		}
+		modip.WriteString("\n")
+	}
+	files["modipNeQG_wrapped.asc"] = &fstest.MapFile{Data: []byte(modip.String())}
+	return files
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func TestLoadNeQuickData(t *testing.T) {
+	data, err := LoadNeQuickData(neQuickTestFiles())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a linear grid is interpolated exactly
+	for _, p := range [][2]float64{{0, 0}, {37.3, -122.1}, {-61.8, 179.9}, {88, 45}} {
+		if got := data.modipAt(p[0], p[1]); math.Abs(got-p[0]) > 1e-9 {
+			t.Errorf("modip at %v: %v", p, got)
+		}
+	}
+	if data.modipAt(90, 0) != 90 || data.modipAt(-90, 0) != -90 {
+		t.Error("modip at the poles")
+	}
+
+	short := neQuickTestFiles()
+	short["ccir15.asc"] = &fstest.MapFile{Data: []byte("1 2 3")}
+	if _, err := LoadNeQuickData(short); err == nil {
+		t.Error("short CCIR map loaded")
+	}
+	missing := neQuickTestFiles()
+	delete(missing, "modipNeQG_wrapped.asc")
+	if _, err := LoadNeQuickData(missing); err == nil {
+		t.Error("data without the modip grid loaded")
+	}
+}
+
+// DefaultNeQuickData loads the embedded ICD files and fails, without a
+// model, while nequick-data lacks them.
+func TestDefaultNeQuickData(t *testing.T) {
+	data, err := DefaultNeQuickData()
+	if _, statErr := fs.Stat(neQuickFiles, "nequick-data/ccir11.asc"); statErr != nil {
+		if err == nil || data != nil {
+			t.Errorf("NeQuick data loaded without the ICD files: %v", err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Az follows the modip of the receiver and is clamped to 0-400 sfu, 63.7
+// without coefficients.
+func TestNeQuickEffectiveIonisation(t *testing.T) {
+	data, err := LoadNeQuickData(neQuickTestFiles())
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiver := []float64{30 * math.Pi / 180, 0.5, 0}
+	for _, c := range []struct {
+		coefficients NeQuickCoefficients
+		want         float64
+	}{
+		{NeQuickCoefficients{}, 63.7},
+		{NeQuickCoefficients{Ai0: 100, Ai1: 0.5, Ai2: 0.01}, 100 + 0.5*30 + 0.01*900},
+		{NeQuickCoefficients{Ai0: 500}, 400},
+		{NeQuickCoefficients{Ai0: 10, Ai1: -1}, 0},
+	} {
+		n := NeQuickG{Data: data, Coefficients: c.coefficients}
+		if got := n.EffectiveIonisation(receiver); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("%+v: Az %v, want %v", c.coefficients, got, c.want)
+		}
+	}
+}
+
+func TestCubicInterpolation(t *testing.T) {
+	p := func(x float64) float64 { return 2*x*x*x - x*x + 3*x - 4 }
+	z := [4]float64{p(-1), p(0), p(1), p(2)}
+	for _, x := range []float64{0, 0.25, 0.5, 0.9, 1} {
+		if got := cubicInterpolation(z, x); math.Abs(got-p(x)) > 1e-12 {
+			t.Errorf("at %v: %v, want %v", x, got, p(x))
+		}
+	}
+}
+
+func TestGaussKronrod(t *testing.T) {
+	if got := gaussKronrod(math.Sin, 0, math.Pi, 1e-12, 0); math.Abs(got-2) > 1e-12 {
+		t.Errorf("sin over [0, π] %v", got)
+	}
+	// a Chapman like layer needs subdivision
+	peak := func(x float64) float64 { return math.Exp(-(x - 300) * (x - 300) / 800) }
+	if got, want := gaussKronrod(peak, -2000, 3000, 1e-9, 0), math.Sqrt(800*math.Pi); math.Abs(got-want) > 1e-6 {
+		t.Errorf("gaussian %v, want %v", got, want)
+	}
+}
+
+// The ray passes through the receiver and the satellite.
+func TestNeQuickRay(t *testing.T) {
+	deg := math.Pi / 180
+	receiver := []float64{40 * deg, 10 * deg, 100}
+	satellite := []float64{50 * deg, 25 * deg, 20200e3}
+	ray, err := newNeQuickRay(receiver, satellite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, end := range [][]float64{receiver, satellite} {
+		latitude, longitude, height := ray.point(ray.distance(end[2] / 1000))
+		if math.Abs(latitude-end[0]/deg) > 1e-6 || math.Abs(longitude-end[1]/deg) > 1e-6 || math.Abs(height-end[2]/1000) > 1e-6 {
+			t.Errorf("end %v: point %v %v %v", end, latitude, longitude, height)
+		}
+	}
+
+	vertical, err := newNeQuickRay(receiver, []float64{40 * deg, 10 * deg, 20200e3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latitude, longitude, height := vertical.point(vertical.distance(350)); math.Abs(latitude-40) > 1e-9 ||
+		math.Abs(longitude-10) > 1e-9 || math.Abs(height-350) > 1e-9 {
+		t.Errorf("vertical ray at %v %v %v", latitude, longitude, height)
+	}
+
+	if _, err := newNeQuickRay(receiver, []float64{-40 * deg, -170 * deg, 20200e3}); err == nil {
+		t.Error("ray to a satellite below the horizon")
+	}
+}
+
+// With the ICD data in nequick-data the slant TEC is a few to a few hundred
+// TECU and the delay goes with 1/f².
+func TestNeQuickDelay(t *testing.T) {
+	if _, err := DefaultNeQuickData(); err != nil {
+		t.Skip(err)
+	}
+	n := NeQuickG{Coefficients: NeQuickCoefficients{Ai0: 236.831641, Ai1: -0.39362878, Ai2: 0.00402826613}}
+	receiver := []float64{40 * math.Pi / 180, 10 * math.Pi / 180, 100}
+	satellite := []float64{15e6, 5e6, 20e6}
+	epoch := GPSTimeFromWeek(2325, 172800+12*SECS_IN_HR)
+	tec, err := n.SlantTEC(epoch, receiver, satellite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tec < 1 || tec > 500 {
+		t.Errorf("slant TEC %v TECU", tec)
+	}
+	e1, _ := n.Delay(epoch, receiver, satellite, GALILEO_E1)
+	e5a, _ := n.Delay(epoch, receiver, satellite, GALILEO_E5A)
+	if ratio := e5a / e1; math.Abs(ratio-GALILEO_E1*GALILEO_E1/(GALILEO_E5A*GALILEO_E5A)) > 1e-12 {
+		t.Errorf("E5a / E1 delay ratio %v", ratio)
+	}
+}
@@ -0,0 +1,224 @@
+package gnss
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  KEPLERIAN BROADCAST ORBITS
+// https://www.gps.gov/technical/icwg/IS-GPS-200N.pdf (20.3.3.4.3)
+// https://www.gsc-europa.eu/sites/default/files/sites/all/files/Galileo_OS_SIS_ICD_v2.0.pdf (5.1.1)
+// http://en.beidou.gov.cn/SYSTEMS/ICDs/ (BDS-SIS-ICD-B1I-3.0, 5.2.4.12)
+// GPS, QZSS, NavIC, Galileo and BeiDou share the same orbit model, only the
+// gravitational constant, the earth rotation rate and, for BeiDou GEO
+// satellites, the final rotation differ.
+
+type keplerOrbit struct {
+	sqrtA     float64
+	aDot      float64
+	deltaNDot float64
+	gm        float64
+	omegaE    float64
+	// BeiDou GEO satellites are computed in an inertial frame and rotated
+	// by -5° about x and by the earth rotation since toe about z
+	geo bool
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// satInfo returns ECEF position (m), velocity (m/s), clock error (s) and clock
+// rate (s/s), the relativistic correction included in the clock error. The
+// toe term of the node longitude uses the Ephemeris toe, kept in the time
+// system of the constellation.
+func (o keplerOrbit) satInfo(ephData Ephemeris, toc, toe GPSTime, time GPSTime) ([]float64, []float64, float64, float64) {
+	tdiff := time.Sub(toc)
+	clockErr := ephData.Af0() + tdiff*(ephData.Af1()+tdiff*ephData.Af2())
+	clockRateErr := ephData.Af1() + 2*tdiff*ephData.Af2()
+
+	tdiff = time.Sub(toe)
+
+	// CNAV / CNAV-2 broadcast rates for A and Delta n, both are zero for LNAV
+	a0 := o.sqrtA * o.sqrtA
+	a := a0 + o.aDot*tdiff
+	maDot := math.Sqrt(o.gm/(a0*a0*a0)) + ephData.DeltaN() + 0.5*o.deltaNDot*tdiff
+	ma := ephData.M0() + maDot*tdiff
+
+	ea := ma
+	eaOld := 2222.0
+	for math.Abs(ea-eaOld) > 1.0e-14 {
+		eaOld = ea
+		ea = ea + (ma-eaOld+ephData.Ecc()*math.Sin(eaOld))/(1.0-ephData.Ecc()*math.Cos(eaOld))
+	}
+
+	eaDot := maDot / (1.0 - ephData.Ecc()*math.Cos(ea))
+
+	// Relativistic correction term, F = -2 sqrt(GM) / c²
+	einstein := -2 * math.Sqrt(o.gm) / (SPEED_OF_LIGHT * SPEED_OF_LIGHT) * ephData.Ecc() * o.sqrtA * math.Sin(ea)
+
+	// Begin calc for True Anomaly and Argument of Latitude
+	tempd2 := math.Sqrt(1.0 - ephData.Ecc()*ephData.Ecc())
+	al := math.Atan2(tempd2*math.Sin(ea), math.Cos(ea)-ephData.Ecc()) + ephData.Omega()
+	alDot := tempd2 * eaDot / (1.0 - ephData.Ecc()*math.Cos(ea))
+
+	// Calculate corrected argument of latitude based on position
+	cal := al + ephData.Cus()*math.Sin(2.0*al) + ephData.Cuc()*math.Cos(2.0*al)
+	calDot := alDot * (1.0 + 2.0*(ephData.Cus()*math.Cos(2.0*al)-ephData.Cuc()*math.Sin(2.0*al)))
+
+	// Calculate corrected radius based on argument of latitude
+	r := a*(1.0-ephData.Ecc()*math.Cos(ea)) + ephData.Crc()*math.Cos(2.0*al) + ephData.Crs()*math.Sin(2.0*al)
+	rDot := a*ephData.Ecc()*math.Sin(ea)*eaDot + 2.0*alDot*(ephData.Crs()*math.Cos(2.0*al)-ephData.Crc()*math.Sin(2.0*al))
+
+	// Calculate inclination based on argument of latitude
+	inc := ephData.I0() + ephData.IDot()*tdiff + ephData.Cic()*math.Cos(2.0*al) + ephData.Cis()*math.Sin(2.0*al)
+	incDot := ephData.IDot() + 2.0*alDot*(ephData.Cis()*math.Cos(2.0*al)-ephData.Cic()*math.Sin(2.0*al))
+
+	// Calculate position and velocity in orbital plane
+	x := r * math.Cos(cal)
+	y := r * math.Sin(cal)
+	xDot := rDot*math.Cos(cal) - y*calDot
+	yDot := rDot*math.Sin(cal) + x*calDot
+
+	// Corrected longitude of ascending node
+	omDot := ephData.OmegaDot() - o.omegaE
+	if o.geo {
+		omDot = ephData.OmegaDot()
+	}
+	om := ephData.Omega0() + tdiff*omDot - o.omegaE*ephData.Toe()
+
+	// Compute the satellite's position in Earth-Centered Earth-Fixed coordinates
+	pos := make([]float64, 3)
+	pos[0] = x*math.Cos(om) - y*math.Cos(inc)*math.Sin(om)
+	pos[1] = x*math.Sin(om) + y*math.Cos(inc)*math.Cos(om)
+	pos[2] = y * math.Sin(inc)
+
+	tempd3 := yDot*math.Cos(inc) - y*math.Sin(inc)*incDot
+
+	// Compute the satellite's velocity in Earth-Centered Earth-Fixed coordinates
+	vel := make([]float64, 3)
+	vel[0] = -omDot*pos[1] + xDot*math.Cos(om) - tempd3*math.Sin(om)
+	vel[1] = omDot*pos[0] + xDot*math.Sin(om) + tempd3*math.Cos(om)
+	vel[2] = y*math.Cos(inc)*incDot + yDot*math.Sin(inc)
+
+	if o.geo {
+		pos, vel = o.rotateGEO(pos, vel, tdiff)
+	}
+
+	clockErr += einstein
+
+	return pos, vel, clockErr, clockRateErr
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// rotateGEO applies Rz(ωe tk) Rx(-5°) to the inertial GEO position, the
+// velocity picks up the derivative of Rz.
+func (o keplerOrbit) rotateGEO(pos, vel []float64, tdiff float64) ([]float64, []float64) {
+	sinX, cosX := math.Sincos(-5.0 * math.Pi / 180.0)
+	sinZ, cosZ := math.Sincos(o.omegaE * tdiff)
+
+	// Rx(-5°)
+	px := []float64{pos[0], cosX*pos[1] + sinX*pos[2], -sinX*pos[1] + cosX*pos[2]}
+	vx := []float64{vel[0], cosX*vel[1] + sinX*vel[2], -sinX*vel[1] + cosX*vel[2]}
+
+	p := []float64{
+		cosZ*px[0] + sinZ*px[1],
+		-sinZ*px[0] + cosZ*px[1],
+		px[2],
+	}
+	v := []float64{
+		cosZ*vx[0] + sinZ*vx[1] + o.omegaE*(-sinZ*px[0]+cosZ*px[1]),
+		-sinZ*vx[0] + cosZ*vx[1] + o.omegaE*(-cosZ*px[0]-sinZ*px[1]),
+		vx[2],
+	}
+	return p, v
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func (e GalileoEphemeris) GetSatInfo(time GPSTime) ([]float64, []float64, float64, float64, error) {
+	ephData, err := e.EphemerisData()
+	if err != nil {
+		return nil, nil, 0, 0, fmt.Errorf("failed to get ephemeris data: %v", err)
+	}
+
+	baseEph, err := e.BaseEphemeris()
+	if err != nil {
+		return nil, nil, 0, 0, fmt.Errorf("failed to get base ephemeris: %v", err)
+	}
+
+	if !baseEph.IsHealthy() {
+		return nil, nil, 0, 0, errors.New("unhealthy ephemeris")
+	}
+
+	toc, err := e.Toc()
+	if err != nil {
+		return nil, nil, 0, 0, fmt.Errorf("failed to get time of clock: %v", err)
+	}
+
+	toe, err := e.Toe()
+	if err != nil {
+		return nil, nil, 0, 0, fmt.Errorf("failed to get time of ephemeris: %v", err)
+	}
+
+	orbit := keplerOrbit{
+		sqrtA:  e.SquareRootOfSemiMajorAxis(),
+		gm:     GALILEO_GM,
+		omegaE: GALILEO_EARTH_ROTATION_RATE,
+	}
+	pos, vel, clockErr, clockRateErr := orbit.satInfo(ephData, toc, toe, time)
+	return pos, vel, clockErr, clockRateErr, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// toe and toc of BeiDouEphemeris are GPS time, the BDT toe used for the node
+// longitude is kept in the Ephemeris data.
+func (e BeiDouEphemeris) GetSatInfo(time GPSTime) ([]float64, []float64, float64, float64, error) {
+	ephData, err := e.EphemerisData()
+	if err != nil {
+		return nil, nil, 0, 0, fmt.Errorf("failed to get ephemeris data: %v", err)
+	}
+
+	baseEph, err := e.BaseEphemeris()
+	if err != nil {
+		return nil, nil, 0, 0, fmt.Errorf("failed to get base ephemeris: %v", err)
+	}
+
+	if !baseEph.IsHealthy() {
+		return nil, nil, 0, 0, errors.New("unhealthy ephemeris")
+	}
+
+	toc, err := e.Toc()
+	if err != nil {
+		return nil, nil, 0, 0, fmt.Errorf("failed to get time of clock: %v", err)
+	}
+
+	toe, err := e.Toe()
+	if err != nil {
+		return nil, nil, 0, 0, fmt.Errorf("failed to get time of ephemeris: %v", err)
+	}
+
+	svId := ephData.SvId()
+	orbit := keplerOrbit{
+		sqrtA:     e.SquareRootOfSemiMajorAxis(),
+		aDot:      e.ADot(),
+		deltaNDot: e.DeltaNDot(),
+		gm:        BEIDOU_GM,
+		omegaE:    BEIDOU_EARTH_ROTATION_RATE,
+		geo:       svId <= 5 || svId >= 59,
+	}
+	pos, vel, clockErr, clockRateErr := orbit.satInfo(ephData, toc, toe, time)
+	return pos, vel, clockErr, clockRateErr, nil
+}
@@ -0,0 +1,31 @@
+//go:build unix
+
+package gnss
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// mapFile maps filename read only, the returned function unmaps it.
+func mapFile(filename string) ([]byte, func() error, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening file: %v", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading file: %v", err)
+	}
+	if info.Size() == 0 {
+		return nil, func() error { return nil }, nil
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to map %s: %v", filename, err)
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
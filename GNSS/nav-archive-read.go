@@ -0,0 +1,17 @@
+//go:build !unix
+
+package gnss
+
+import (
+	"fmt"
+	"os"
+)
+
+// mapFile reads filename into memory on systems without mmap.
+func mapFile(filename string) ([]byte, func() error, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening file: %v", err)
+	}
+	return data, func() error { return nil }, nil
+}
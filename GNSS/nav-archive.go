@@ -0,0 +1,332 @@
+package gnss
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+
+	"capnproto.org/go/capnp/v3"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  NAVIGATION ARCHIVE
+// Keeps parsed navigation files for later queries without parsing RINEX
+// again. The archive file is a sequence of unpacked NavFile messages, one per
+// AppendArchive call. The index next to it (archive name + ".idx", an
+// ArchiveIndex message) holds one entry per message and PRN, sorted by PRN
+// and first epoch:
+//   PRN -> first / last epoch -> offset and size of the message
+//
+// OpenArchive maps the archive into memory (see nav-archive-mmap.go) and a
+// message is decoded in place the first time a query needs it, nothing is
+// copied. Records of an opened archive are read only. SBAS records are
+// archived but not indexed.
+
+const archiveIndexSuffix = ".idx"
+
+type archiveEntry struct {
+	prn    string
+	start  float64 // seconds since the GPS epoch
+	end    float64
+	offset uint64
+	size   uint64
+}
+
+// AppendArchive appends nav to the archive filename, which is created if
+// needed, and updates its index.
+func AppendArchive(filename string, nav *NavigationData) error {
+	file, err := nav.navFile()
+	if err != nil {
+		return err
+	}
+	entries, err := readArchiveIndex(filename + archiveIndexSuffix)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	data, err := file.Message().Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal navigation data: %v", err)
+	}
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening archive: %v", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("error reading archive: %v", err)
+	}
+	offset := uint64(info.Size())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("error writing archive: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error writing archive: %v", err)
+	}
+
+	ranges := make(map[string]*archiveEntry)
+	for _, eph := range navigationEphemerides(nav) {
+		epoch := gpsSeconds(eph.Epoch())
+		entry, ok := ranges[eph.PRN()]
+		if !ok {
+			entry = &archiveEntry{prn: eph.PRN(), start: epoch, end: epoch, offset: offset, size: uint64(len(data))}
+			ranges[eph.PRN()] = entry
+		}
+		if epoch < entry.start {
+			entry.start = epoch
+		}
+		if epoch > entry.end {
+			entry.end = epoch
+		}
+	}
+	for _, entry := range ranges {
+		entries = append(entries, *entry)
+	}
+	return writeArchiveIndex(filename+archiveIndexSuffix, entries)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func readArchiveIndex(filename string) ([]archiveEntry, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading archive index: %w", err)
+	}
+	msg, err := capnp.Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive index: %v", err)
+	}
+	liftReadLimit(msg)
+	index, err := ReadRootArchiveIndex(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ArchiveIndex: %v", err)
+	}
+	list, err := index.Entries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index entries: %v", err)
+	}
+
+	entries := make([]archiveEntry, list.Len())
+	for i := range entries {
+		e := list.At(i)
+		prn, err := e.Prn()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get PRN: %v", err)
+		}
+		start, err := e.Start()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get start: %v", err)
+		}
+		end, err := e.End()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get end: %v", err)
+		}
+		entries[i] = archiveEntry{prn: prn, start: gpsSeconds(start), end: gpsSeconds(end), offset: e.Offset(), size: e.Size()}
+	}
+	return entries, nil
+}
+
+// writeArchiveIndex sorts entries and replaces the index file.
+func writeArchiveIndex(filename string, entries []archiveEntry) error {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].prn != entries[j].prn {
+			return entries[i].prn < entries[j].prn
+		}
+		return entries[i].start < entries[j].start
+	})
+
+	_, seg, err := capnp.NewMessage(capnp.MultiSegment(nil))
+	if err != nil {
+		return fmt.Errorf("failed to create new message: %v", err)
+	}
+	index, err := NewRootArchiveIndex(seg)
+	if err != nil {
+		return fmt.Errorf("failed to create new ArchiveIndex: %v", err)
+	}
+	list, err := index.NewEntries(int32(len(entries)))
+	if err != nil {
+		return fmt.Errorf("failed to create new index entries: %v", err)
+	}
+	for i, entry := range entries {
+		e := list.At(i)
+		if err := e.SetPrn(entry.prn); err != nil {
+			return fmt.Errorf("failed to set PRN: %v", err)
+		}
+		start, err := e.NewStart()
+		if err != nil {
+			return fmt.Errorf("failed to create new start: %v", err)
+		}
+		setGPSSeconds(start, entry.start)
+		end, err := e.NewEnd()
+		if err != nil {
+			return fmt.Errorf("failed to create new end: %v", err)
+		}
+		setGPSSeconds(end, entry.end)
+		e.SetOffset(entry.offset)
+		e.SetSize(entry.size)
+	}
+
+	data, err := seg.Message().Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal archive index: %v", err)
+	}
+	tmp := filename + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("error writing archive index: %v", err)
+	}
+	if err := os.Rename(tmp, filename); err != nil {
+		return fmt.Errorf("error writing archive index: %v", err)
+	}
+	return nil
+}
+
+func gpsSeconds(t GPSTime) float64 {
+	return float64(t.Week())*SecondsInWeek + t.TimeOfWeek()
+}
+
+func setGPSSeconds(t GPSTime, seconds float64) {
+	week := int32(seconds / SecondsInWeek)
+	t.SetWeek(week)
+	t.SetTimeOfWeek(seconds - float64(week)*SecondsInWeek)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// Archive is an archive opened for queries, see OpenArchive.
+type Archive struct {
+	data    []byte
+	unmap   func() error
+	entries []archiveEntry
+
+	mu       sync.Mutex
+	messages map[uint64]map[string][]SatelliteEphemeris
+}
+
+// OpenArchive maps the archive filename and reads its index.
+func OpenArchive(filename string) (*Archive, error) {
+	entries, err := readArchiveIndex(filename + archiveIndexSuffix)
+	if err != nil {
+		return nil, err
+	}
+	data, unmap, err := mapFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.offset+entry.size > uint64(len(data)) {
+			unmap()
+			return nil, fmt.Errorf("archive index of %s refers past the end of the archive", filename)
+		}
+	}
+	return &Archive{
+		data:     data,
+		unmap:    unmap,
+		entries:  entries,
+		messages: make(map[uint64]map[string][]SatelliteEphemeris),
+	}, nil
+}
+
+// Close unmaps the archive, records returned by queries become invalid.
+func (a *Archive) Close() error {
+	return a.unmap()
+}
+
+// PRNs returns the archived satellites in ascending order.
+func (a *Archive) PRNs() []string {
+	var prns []string
+	for _, entry := range a.entries {
+		if len(prns) == 0 || prns[len(prns)-1] != entry.prn {
+			prns = append(prns, entry.prn)
+		}
+	}
+	return prns
+}
+
+// Ephemerides returns the archived records of prn with an epoch between
+// start and end, sorted by epoch. Only the messages the index lists for this
+// range are decoded.
+func (a *Archive) Ephemerides(prn string, start, end GPSTime) ([]SatelliteEphemeris, error) {
+	from, to := gpsSeconds(start), gpsSeconds(end)
+	var result []SatelliteEphemeris
+
+	i := sort.Search(len(a.entries), func(i int) bool { return a.entries[i].prn >= prn })
+	for ; i < len(a.entries) && a.entries[i].prn == prn; i++ {
+		entry := a.entries[i]
+		if entry.start > to {
+			break
+		}
+		if entry.end < from {
+			continue
+		}
+		records, err := a.message(entry)
+		if err != nil {
+			return nil, err
+		}
+		for _, eph := range records[prn] {
+			if epoch := gpsSeconds(eph.Epoch()); epoch >= from && epoch <= to {
+				result = append(result, eph)
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Epoch().Sub(result[j].Epoch()) < 0
+	})
+	return result, nil
+}
+
+// message decodes the message of entry, once, and groups its records by
+// PRN.
+func (a *Archive) message(entry archiveEntry) (map[string][]SatelliteEphemeris, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if records, ok := a.messages[entry.offset]; ok {
+		return records, nil
+	}
+
+	msg, err := capnp.Unmarshal(a.data[entry.offset : entry.offset+entry.size])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive message at %d: %v", entry.offset, err)
+	}
+	liftReadLimit(msg)
+	file, err := ReadRootNavFile(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NavFile at %d: %v", entry.offset, err)
+	}
+	nav, err := navigationDataFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make(map[string][]SatelliteEphemeris)
+	for _, eph := range navigationEphemerides(nav) {
+		records[eph.PRN()] = append(records[eph.PRN()], eph)
+	}
+	a.messages[entry.offset] = records
+	return records, nil
+}
+
+// AddArchive stores the archived records of every PRN with an epoch between
+// start and end.
+func (s *EphemerisStore) AddArchive(a *Archive, start, end GPSTime) (int, error) {
+	added := 0
+	for _, prn := range a.PRNs() {
+		ephs, err := a.Ephemerides(prn, start, end)
+		if err != nil {
+			return added, err
+		}
+		added += s.Add(ephs...)
+	}
+	return added, nil
+}
@@ -0,0 +1,153 @@
+package gnss
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// =========================================================================
+
+// =========================================================================
+
+func TestNavArchive(t *testing.T) {
+	gps, err := ParseNavFile(testFile(t, "abpo2120.24n"), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	glonass, err := ParseNavFile(testFile(t, "brdc2050.24g"), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(t.TempDir(), "nav.archive")
+	for _, nav := range []*NavigationData{gps, glonass} {
+		if err := AppendArchive(filename, nav); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archive, err := OpenArchive(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	want := make(map[string][]SatelliteEphemeris)
+	for _, nav := range []*NavigationData{gps, glonass} {
+		for _, eph := range navigationEphemerides(nav) {
+			want[eph.PRN()] = append(want[eph.PRN()], eph)
+		}
+	}
+	prns := archive.PRNs()
+	if len(prns) != len(want) {
+		t.Errorf("%d PRNs archived, want %d", len(prns), len(want))
+	}
+	for i := 1; i < len(prns); i++ {
+		if prns[i-1] >= prns[i] {
+			t.Fatalf("PRNs %s before %s", prns[i-1], prns[i])
+		}
+	}
+
+	for _, prn := range []string{"G05", "R01"} {
+		// the archive returns the records sorted by epoch
+		records := want[prn]
+		sort.SliceStable(records, func(i, j int) bool { return records[i].Epoch().Sub(records[j].Epoch()) < 0 })
+		if len(records) < 3 {
+			t.Fatalf("%d %s records", len(records), prn)
+		}
+		first, last := records[0].Epoch(), records[len(records)-1].Epoch()
+		all, err := archive.Ephemerides(prn, first, last)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(all) != len(records) {
+			t.Errorf("%s: %d records, want %d", prn, len(all), len(records))
+		}
+		for i := range all {
+			if all[i].PRN() != prn || all[i].Epoch().Sub(records[i].Epoch()) != 0 {
+				t.Errorf("%s: record %d %s %v, want %v", prn, i, all[i].PRN(),
+					all[i].Epoch().ToDateTime(), records[i].Epoch().ToDateTime())
+			}
+		}
+
+		// the bounds are inclusive
+		one, err := archive.Ephemerides(prn, records[1].Epoch(), records[1].Epoch())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(one) != 1 || one[0].Epoch().Sub(records[1].Epoch()) != 0 {
+			t.Errorf("%s: %d records at the second epoch", prn, len(one))
+		}
+		after, err := archive.Ephemerides(prn, last.Add(1), last.Add(SECS_IN_DAY))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(after) != 0 {
+			t.Errorf("%s: %d records after the last one", prn, len(after))
+		}
+	}
+
+	if n, err := archive.Ephemerides("G99", gps.GPS[0].Epoch(), gps.GPS[0].Epoch().Add(SECS_IN_DAY)); err != nil || len(n) != 0 {
+		t.Errorf("G99: %d records, %v", len(n), err)
+	}
+}
+
+// A store filled from the archive holds what it holds when filled from the
+// parsed file.
+func TestEphemerisStoreAddArchive(t *testing.T) {
+	nav, err := ParseNavFile(testFile(t, "abpo2120.24n"), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(t.TempDir(), "nav.archive")
+	// the second copy adds nothing to the store
+	for i := 0; i < 2; i++ {
+		if err := AppendArchive(filename, nav); err != nil {
+			t.Fatal(err)
+		}
+	}
+	archive, err := OpenArchive(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	want := NewEphemerisStore()
+	added := want.AddNavigationData(nav)
+	start := nav.GPS[0].Epoch().Add(-SECS_IN_DAY)
+	store := NewEphemerisStore()
+	n, err := store.AddArchive(archive, start, start.Add(3*SECS_IN_DAY))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != added {
+		t.Errorf("%d records added from the archive, want %d", n, added)
+	}
+	for _, prn := range want.PRNs() {
+		if got, want := len(store.Ephemerides(prn)), len(want.Ephemerides(prn)); got != want {
+			t.Errorf("%s: %d records, want %d", prn, got, want)
+		}
+	}
+}
+
+func TestOpenArchiveErrors(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "nav.archive")
+	if _, err := OpenArchive(filename); err == nil {
+		t.Error("missing archive opened")
+	}
+
+	nav, err := ParseNavFile(testFile(t, "abpo2120.24n"), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendArchive(filename, nav); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(filename, 64); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenArchive(filename); err == nil {
+		t.Error("archive shorter than its index opened")
+	}
+}
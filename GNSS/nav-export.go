@@ -0,0 +1,402 @@
+package gnss
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"capnproto.org/go/capnp/v3"
+	"github.com/mothergoose31/GNNS-GO/GNSS/helpers"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  MACHINE READABLE EXPORTS
+//   JSON      JSON Lines, the header then one object per record keyed by
+//             its NavFile list, field names from the capnp schema:
+//               {"header":{"version":3.04,"type":"N",...}}
+//               {"gps":{"baseEphemeris":{...},"ephemerisData":{...},...}}
+//   CSV       one row per record, SI units, columns that do not apply to
+//             the system are empty (GLONASS and SBAS states are converted
+//             from km)
+//   GeoJSON   a FeatureCollection with one ground track per satellite, a
+//             MultiLineString split where the track crosses the antimeridian
+// Positions for the CSV / JSON / GeoJSON writers come from
+// SatellitePositions.
+
+// WriteNavJSON writes nav as JSON Lines. GLONASS records leave out their
+// header, it is the first line.
+func WriteNavJSON(w io.Writer, nav *NavigationData) error {
+	e := &jsonEncoder{
+		w:    bufio.NewWriter(w),
+		omit: map[jsonField]bool{{RINEXEphemeris_TypeID, "header"}: true},
+	}
+	line := func(key string, typeID uint64, s capnp.Struct) error {
+		e.w.WriteString(`{"` + key + `":`)
+		if err := e.encodeStruct(typeID, s); err != nil {
+			return err
+		}
+		e.w.WriteString("}\n")
+		return nil
+	}
+
+	if err := line("header", RINEXHeader_TypeID, capnp.Struct(nav.Header)); err != nil {
+		return err
+	}
+	for _, eph := range nav.GPS {
+		if err := line("gps", GPSEphemeris_TypeID, capnp.Struct(eph)); err != nil {
+			return err
+		}
+	}
+	for _, eph := range nav.GLONASS {
+		if err := line("glonass", RINEXEphemeris_TypeID, capnp.Struct(eph)); err != nil {
+			return err
+		}
+	}
+	for _, eph := range nav.Galileo {
+		if err := line("galileo", GalileoEphemeris_TypeID, capnp.Struct(eph)); err != nil {
+			return err
+		}
+	}
+	for _, eph := range nav.BeiDou {
+		if err := line("beiDou", BeiDouEphemeris_TypeID, capnp.Struct(eph)); err != nil {
+			return err
+		}
+	}
+	for _, eph := range nav.QZSS {
+		if err := line("qzss", GPSEphemeris_TypeID, capnp.Struct(eph)); err != nil {
+			return err
+		}
+	}
+	for _, eph := range nav.NavIC {
+		if err := line("navIC", GPSEphemeris_TypeID, capnp.Struct(eph)); err != nil {
+			return err
+		}
+	}
+	for _, eph := range nav.SBAS {
+		if err := line("sbas", SBASEphemeris_TypeID, capnp.Struct(eph)); err != nil {
+			return err
+		}
+	}
+	if err := e.w.Flush(); err != nil {
+		return fmt.Errorf("failed to write JSON: %v", err)
+	}
+	return nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+var navCSVColumns = []string{
+	"prn", "system", "message_type", "gps_week", "gps_tow_s", "epoch_utc", "healthy",
+	"clock_bias_s", "clock_drift_s_s", "clock_drift_rate_s_s2",
+	// Keplerian records
+	"iode", "toe_week", "toe_s", "sqrt_a_sqrt_m", "ecc", "i0_rad", "omega0_rad", "omega_rad", "m0_rad",
+	"delta_n_rad_s", "omega_dot_rad_s", "idot_rad_s", "crs_m", "crc_m", "cuc_rad", "cus_rad", "cic_rad", "cis_rad",
+	"tgd_s", "accuracy_m",
+	// GLONASS and SBAS states
+	"x_m", "y_m", "z_m", "vx_m_s", "vy_m_s", "vz_m_s", "ax_m_s2", "ay_m_s2", "az_m_s2", "frequency_number",
+}
+
+const (
+	navCSVKeplerColumns = 20
+	navCSVStateColumns  = 10
+)
+
+// WriteNavCSV writes one row per record of nav.
+func WriteNavCSV(w io.Writer, nav *NavigationData) error {
+	cw := csv.NewWriter(w)
+	cw.Write(navCSVColumns)
+
+	kepler := func(base BaseEphemeris, data Ephemeris, sqrtA float64, messageType NavMessageType) error {
+		row, err := navCSVCommon(base, messageType.String(), data.Af0(), data.Af1(), data.Af2())
+		if err != nil {
+			return err
+		}
+		row = append(row,
+			formatCSVFloat(data.Iode()), strconv.Itoa(int(data.ToeWeek())), formatCSVFloat(data.Toe()),
+			formatCSVFloat(sqrtA), formatCSVFloat(data.Ecc()), formatCSVFloat(data.I0()),
+			formatCSVFloat(data.Omega0()), formatCSVFloat(data.Omega()), formatCSVFloat(data.M0()),
+			formatCSVFloat(data.DeltaN()), formatCSVFloat(data.OmegaDot()), formatCSVFloat(data.IDot()),
+			formatCSVFloat(data.Crs()), formatCSVFloat(data.Crc()), formatCSVFloat(data.Cuc()),
+			formatCSVFloat(data.Cus()), formatCSVFloat(data.Cic()), formatCSVFloat(data.Cis()),
+			formatCSVFloat(data.Tgd()), formatCSVFloat(data.SvAcc()),
+		)
+		return cw.Write(append(row, make([]string, navCSVStateColumns)...))
+	}
+	state := func(row []string, position, velocity, acceleration [3]float64, frequency string) error {
+		row = append(row, make([]string, navCSVKeplerColumns)...)
+		for _, v := range [][3]float64{position, velocity, acceleration} {
+			for _, c := range v {
+				row = append(row, formatCSVFloat(c*1000))
+			}
+		}
+		return cw.Write(append(row, frequency))
+	}
+
+	for _, group := range [][]GPSEphemeris{nav.GPS, nav.QZSS, nav.NavIC} {
+		for _, eph := range group {
+			base, data, err := keplerRecord(eph.BaseEphemeris, eph.EphemerisData)
+			if err != nil {
+				return err
+			}
+			if err := kepler(base, data, eph.SquareRootOfSemiMajorAxis(), eph.MessageType()); err != nil {
+				return err
+			}
+		}
+	}
+	for _, eph := range nav.Galileo {
+		base, data, err := keplerRecord(eph.BaseEphemeris, eph.EphemerisData)
+		if err != nil {
+			return err
+		}
+		if err := kepler(base, data, eph.SquareRootOfSemiMajorAxis(), eph.MessageType()); err != nil {
+			return err
+		}
+	}
+	for _, eph := range nav.BeiDou {
+		base, data, err := keplerRecord(eph.BaseEphemeris, eph.EphemerisData)
+		if err != nil {
+			return err
+		}
+		if err := kepler(base, data, eph.SquareRootOfSemiMajorAxis(), eph.MessageType()); err != nil {
+			return err
+		}
+	}
+	for _, eph := range nav.GLONASS {
+		base, err := eph.BaseEphemeris()
+		if err != nil {
+			return fmt.Errorf("failed to get base ephemeris: %v", err)
+		}
+		row, err := navCSVCommon(base, NavMessageType_fdma.String(), eph.ClockBias(), eph.RelativeFrequencyBias(), 0)
+		if err != nil {
+			return err
+		}
+		err = state(row,
+			[3]float64{eph.PositionX(), eph.PositionY(), eph.PositionZ()},
+			[3]float64{eph.VelocityX(), eph.VelocityY(), eph.VelocityZ()},
+			[3]float64{eph.AccelerationX(), eph.AccelerationY(), eph.AccelerationZ()},
+			strconv.Itoa(int(eph.FrequencyChannelOffset())))
+		if err != nil {
+			return err
+		}
+	}
+	for _, eph := range nav.SBAS {
+		base, err := eph.BaseEphemeris()
+		if err != nil {
+			return fmt.Errorf("failed to get base ephemeris: %v", err)
+		}
+		row, err := navCSVCommon(base, NavMessageType_sbas.String(), eph.ClockBias(), eph.RelativeFrequencyBias(), 0)
+		if err != nil {
+			return err
+		}
+		err = state(row,
+			[3]float64{eph.PositionX(), eph.PositionY(), eph.PositionZ()},
+			[3]float64{eph.VelocityX(), eph.VelocityY(), eph.VelocityZ()},
+			[3]float64{eph.AccelerationX(), eph.AccelerationY(), eph.AccelerationZ()},
+			"")
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %v", err)
+	}
+	return nil
+}
+
+func keplerRecord(baseEphemeris func() (BaseEphemeris, error), ephemerisData func() (Ephemeris, error)) (BaseEphemeris, Ephemeris, error) {
+	base, err := baseEphemeris()
+	if err != nil {
+		return BaseEphemeris{}, Ephemeris{}, fmt.Errorf("failed to get base ephemeris: %v", err)
+	}
+	data, err := ephemerisData()
+	if err != nil {
+		return BaseEphemeris{}, Ephemeris{}, fmt.Errorf("failed to get ephemeris data: %v", err)
+	}
+	return base, data, nil
+}
+
+// navCSVCommon returns the columns shared by every system.
+func navCSVCommon(base BaseEphemeris, messageType string, bias, drift, driftRate float64) ([]string, error) {
+	prn, err := base.PseudoRandomNumber()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PRN: %v", err)
+	}
+	epoch, err := base.Epoch()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch: %v", err)
+	}
+	row := make([]string, 0, len(navCSVColumns))
+	return append(row,
+		prn, satelliteSystemNames[column(prn, 0, 1)], messageType,
+		strconv.Itoa(int(epoch.Week())), formatCSVFloat(epoch.TimeOfWeek()),
+		epoch.ToUTC().Format(time.RFC3339Nano), strconv.FormatBool(base.IsHealthy()),
+		formatCSVFloat(bias), formatCSVFloat(drift), formatCSVFloat(driftRate),
+	), nil
+}
+
+func formatCSVFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// SatellitePosition is the state of a satellite computed from an ephemeris:
+// ECEF position (m), velocity (m/s) and clock bias (s), and the point below
+// it on the WGS84 ellipsoid.
+type SatellitePosition struct {
+	PRN        string     `json:"prn"`
+	Week       int        `json:"gpsWeek"`
+	TimeOfWeek float64    `json:"gpsTimeOfWeek"`
+	UTC        time.Time  `json:"utc"`
+	Position   [3]float64 `json:"position"`
+	Velocity   [3]float64 `json:"velocity"`
+	ClockBias  float64    `json:"clockBias"`
+	Latitude   float64    `json:"latitude"`
+	Longitude  float64    `json:"longitude"`
+	Height     float64    `json:"height"`
+}
+
+// SatellitePositions computes the positions of prns (all stored satellites
+// when empty) every step seconds from start to end. Times without a valid
+// ephemeris are skipped.
+func SatellitePositions(store *EphemerisStore, prns []string, start, end GPSTime, step float64) []SatellitePosition {
+	if len(prns) == 0 {
+		prns = store.PRNs()
+	}
+	if step <= 0 {
+		return nil
+	}
+
+	var positions []SatellitePosition
+	for _, prn := range prns {
+		for dt := 0.0; dt <= end.Sub(start); dt += step {
+			t := start.Add(dt)
+			eph, err := store.Get(prn, t)
+			if err != nil {
+				continue
+			}
+			pos, vel, clockBias, _, err := eph.GetSatInfo(t)
+			if err != nil {
+				continue
+			}
+			geodetic := helpers.ECEFToGeodetic([][]float64{pos}, false)[0]
+			positions = append(positions, SatellitePosition{
+				PRN:        prn,
+				Week:       int(t.Week()),
+				TimeOfWeek: t.TimeOfWeek(),
+				UTC:        t.ToUTC(),
+				Position:   [3]float64{pos[0], pos[1], pos[2]},
+				Velocity:   [3]float64{vel[0], vel[1], vel[2]},
+				ClockBias:  clockBias,
+				Latitude:   geodetic[0],
+				Longitude:  geodetic[1],
+				Height:     geodetic[2],
+			})
+		}
+	}
+	return positions
+}
+
+// WritePositionsJSON writes one JSON object per position (JSON Lines).
+func WritePositionsJSON(w io.Writer, positions []SatellitePosition) error {
+	encoder := json.NewEncoder(w)
+	for _, p := range positions {
+		if err := encoder.Encode(p); err != nil {
+			return fmt.Errorf("failed to write JSON: %v", err)
+		}
+	}
+	return nil
+}
+
+// WritePositionsCSV writes one row per position.
+func WritePositionsCSV(w io.Writer, positions []SatellitePosition) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{
+		"prn", "gps_week", "gps_tow_s", "utc", "x_m", "y_m", "z_m", "vx_m_s", "vy_m_s", "vz_m_s",
+		"clock_bias_s", "latitude_deg", "longitude_deg", "height_m",
+	})
+	for _, p := range positions {
+		cw.Write([]string{
+			p.PRN, strconv.Itoa(p.Week), formatCSVFloat(p.TimeOfWeek), p.UTC.Format(time.RFC3339Nano),
+			formatCSVFloat(p.Position[0]), formatCSVFloat(p.Position[1]), formatCSVFloat(p.Position[2]),
+			formatCSVFloat(p.Velocity[0]), formatCSVFloat(p.Velocity[1]), formatCSVFloat(p.Velocity[2]),
+			formatCSVFloat(p.ClockBias), formatCSVFloat(p.Latitude), formatCSVFloat(p.Longitude), formatCSVFloat(p.Height),
+		})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %v", err)
+	}
+	return nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string          `json:"type"`
+	Geometry   geoJSONGeometry `json:"geometry"`
+	Properties map[string]any  `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+// WriteGroundTracksGeoJSON writes the ground tracks of positions, in the
+// order of SatellitePositions (grouped by PRN, ascending time), as one
+// MultiLineString feature per satellite with [longitude, latitude] in
+// degrees.
+func WriteGroundTracksGeoJSON(w io.Writer, positions []SatellitePosition) error {
+	collection := geoJSONFeatureCollection{Type: "FeatureCollection", Features: []geoJSONFeature{}}
+	for i := 0; i < len(positions); {
+		j := i
+		var lines [][][2]float64
+		var line [][2]float64
+		for ; j < len(positions) && positions[j].PRN == positions[i].PRN; j++ {
+			p := positions[j]
+			if len(line) > 0 && math.Abs(p.Longitude-line[len(line)-1][0]) > 180 {
+				lines = append(lines, line)
+				line = nil
+			}
+			line = append(line, [2]float64{p.Longitude, p.Latitude})
+		}
+		lines = append(lines, line)
+
+		collection.Features = append(collection.Features, geoJSONFeature{
+			Type:     "Feature",
+			Geometry: geoJSONGeometry{Type: "MultiLineString", Coordinates: lines},
+			Properties: map[string]any{
+				"prn":   positions[i].PRN,
+				"start": positions[i].UTC.Format(time.RFC3339),
+				"end":   positions[j-1].UTC.Format(time.RFC3339),
+			},
+		})
+		i = j
+	}
+
+	if err := json.NewEncoder(w).Encode(collection); err != nil {
+		return fmt.Errorf("failed to write GeoJSON: %v", err)
+	}
+	return nil
+}
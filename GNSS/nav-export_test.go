@@ -0,0 +1,236 @@
+package gnss
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+
+func TestWriteNavJSON(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(mixedNavV3), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteNavJSON(&buf, nav); err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	var lines []map[string]map[string]any
+	for _, text := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		var line map[string]map[string]any
+		if err := json.Unmarshal([]byte(text), &line); err != nil {
+			t.Fatalf("%v: %s", err, text)
+		}
+		for key := range line {
+			keys = append(keys, key)
+		}
+		lines = append(lines, line)
+	}
+	if want := []string{"header", "gps", "glonass", "galileo", "beiDou"}; strings.Join(keys, " ") != strings.Join(want, " ") {
+		t.Fatalf("lines %v, want %v", keys, want)
+	}
+
+	if version := lines[0]["header"]["version"]; version != 3.04 {
+		t.Errorf("header version %v", version)
+	}
+	gps := lines[1]["gps"]
+	data, _ := nav.GPS[0].EphemerisData()
+	if af0 := gps["ephemerisData"].(map[string]any)["af0"]; af0 != data.Af0() {
+		t.Errorf("gps af0 %v, want %v", af0, data.Af0())
+	}
+	if prn := gps["baseEphemeris"].(map[string]any)["pseudoRandomNumber"]; prn != "G05" {
+		t.Errorf("gps PRN %v", prn)
+	}
+	if messageType := gps["messageType"]; messageType != "lnav" {
+		t.Errorf("gps message type %v", messageType)
+	}
+	glonass := lines[2]["glonass"]
+	if _, ok := glonass["header"]; ok {
+		t.Error("GLONASS record with its header")
+	}
+	if x := glonass["positionX"]; x != nav.GLONASS[0].PositionX() {
+		t.Errorf("glonass positionX %v, want %v", x, nav.GLONASS[0].PositionX())
+	}
+}
+
+func TestWriteNavCSV(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(mixedNavV3), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteNavCSV(&buf, nav); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("%d rows, want the header and 4 records", len(rows))
+	}
+	column := make(map[string]int)
+	for i, name := range rows[0] {
+		column[name] = i
+	}
+	value := func(row []string, name string) float64 {
+		t.Helper()
+		v, err := strconv.ParseFloat(row[column[name]], 64)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		return v
+	}
+
+	// Keplerian records first, GLONASS after them
+	var prns []string
+	for _, row := range rows[1:] {
+		prns = append(prns, row[column["prn"]])
+	}
+	if strings.Join(prns, " ") != "G05 E05 C19 R01" {
+		t.Errorf("rows %v", prns)
+	}
+	gps := rows[1]
+	data, _ := nav.GPS[0].EphemerisData()
+	if value(gps, "clock_bias_s") != data.Af0() || value(gps, "sqrt_a_sqrt_m") != nav.GPS[0].SquareRootOfSemiMajorAxis() {
+		t.Errorf("G05 row %v", gps)
+	}
+	if gps[column["x_m"]] != "" {
+		t.Errorf("G05 x_m %q", gps[column["x_m"]])
+	}
+	glonass := rows[4]
+	if value(glonass, "x_m") != nav.GLONASS[0].PositionX()*1000 || value(glonass, "vz_m_s") != nav.GLONASS[0].VelocityZ()*1000 {
+		t.Errorf("R01 row %v", glonass)
+	}
+	if glonass[column["ecc"]] != "" {
+		t.Errorf("R01 ecc %q", glonass[column["ecc"]])
+	}
+}
+
+// The positions agree with their velocities, and the geodetic coordinates
+// lead back to the ECEF position.
+func TestSatellitePositions(t *testing.T) {
+	_, ephs, err := ParseRINEXGPSFileV211(testFile(t, "abpo2120.24n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewEphemerisStore()
+	store.AddGPS(ephs)
+	start := store.Ephemerides("G05")[1].Epoch().Add(600)
+
+	positions := SatellitePositions(store, []string{"G05", "G99"}, start, start.Add(2), 1)
+	if len(positions) != 3 {
+		t.Fatalf("%d positions, want 3 of G05", len(positions))
+	}
+	p := positions[1]
+	if at := start.Add(1); p.PRN != "G05" || p.Week != int(at.Week()) || p.TimeOfWeek != at.TimeOfWeek() || !p.UTC.Equal(at.ToUTC()) {
+		t.Errorf("position %s %d %v %v", p.PRN, p.Week, p.TimeOfWeek, p.UTC)
+	}
+	for i := range p.Velocity {
+		if v := (positions[2].Position[i] - positions[0].Position[i]) / 2; math.Abs(v-p.Velocity[i]) > 1e-3 {
+			t.Errorf("velocity %d: %v, positions give %v", i, p.Velocity[i], v)
+		}
+	}
+
+	const a, f = 6378137.0, 1 / 298.257223563
+	e2 := f * (2 - f)
+	lat, lon := p.Latitude*math.Pi/180, p.Longitude*math.Pi/180
+	n := a / math.Sqrt(1-e2*math.Sin(lat)*math.Sin(lat))
+	ecef := [3]float64{
+		(n + p.Height) * math.Cos(lat) * math.Cos(lon),
+		(n + p.Height) * math.Cos(lat) * math.Sin(lon),
+		(n*(1-e2) + p.Height) * math.Sin(lat),
+	}
+	if d := distance(ecef[:], p.Position[:]); d > 0.01 {
+		t.Errorf("geodetic %v %v %v is %.3f m from the position", p.Latitude, p.Longitude, p.Height, d)
+	}
+
+	if all := SatellitePositions(store, nil, start, start.Add(1), 1); len(all) <= len(positions) {
+		t.Errorf("%d positions of every stored satellite", len(all))
+	}
+	if none := SatellitePositions(store, nil, start, start.Add(1), 0); none != nil {
+		t.Errorf("%d positions with a zero step", len(none))
+	}
+}
+
+func TestWritePositions(t *testing.T) {
+	positions := []SatellitePosition{{
+		PRN: "G05", Week: 2325, TimeOfWeek: 172800, UTC: time.Date(2024, 7, 29, 23, 59, 42, 0, time.UTC),
+		Position: [3]float64{1, 2, 3}, Velocity: [3]float64{4, 5, 6}, ClockBias: 1e-4,
+		Latitude: 10, Longitude: 20, Height: 2e7,
+	}}
+
+	var buf bytes.Buffer
+	if err := WritePositionsJSON(&buf, positions); err != nil {
+		t.Fatal(err)
+	}
+	var decoded SatellitePosition
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != positions[0] {
+		t.Errorf("JSON %+v", decoded)
+	}
+
+	buf.Reset()
+	if err := WritePositionsCSV(&buf, positions); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[1][0] != "G05" || rows[1][3] != "2024-07-29T23:59:42Z" || rows[1][10] != formatCSVFloat(1e-4) {
+		t.Errorf("CSV %v", rows)
+	}
+}
+
+// A track that crosses the antimeridian is split there.
+func TestWriteGroundTracksGeoJSON(t *testing.T) {
+	utc := time.Date(2024, 7, 30, 0, 0, 0, 0, time.UTC)
+	positions := []SatellitePosition{
+		{PRN: "G05", UTC: utc, Longitude: 178, Latitude: 10},
+		{PRN: "G05", UTC: utc.Add(time.Minute), Longitude: 179.5, Latitude: 11},
+		{PRN: "G05", UTC: utc.Add(2 * time.Minute), Longitude: -179, Latitude: 12},
+		{PRN: "R01", UTC: utc, Longitude: 0, Latitude: -50},
+	}
+	var buf bytes.Buffer
+	if err := WriteGroundTracksGeoJSON(&buf, positions); err != nil {
+		t.Fatal(err)
+	}
+	var collection geoJSONFeatureCollection
+	if err := json.Unmarshal(buf.Bytes(), &collection); err != nil {
+		t.Fatal(err)
+	}
+	if collection.Type != "FeatureCollection" || len(collection.Features) != 2 {
+		t.Fatalf("%s with %d features", collection.Type, len(collection.Features))
+	}
+	g05 := collection.Features[0]
+	want := [][][2]float64{{{178, 10}, {179.5, 11}}, {{-179, 12}}}
+	if g05.Geometry.Type != "MultiLineString" || len(g05.Geometry.Coordinates) != 2 ||
+		g05.Geometry.Coordinates[0][1] != want[0][1] || g05.Geometry.Coordinates[1][0] != want[1][0] {
+		t.Errorf("G05 %s %v, want %v", g05.Geometry.Type, g05.Geometry.Coordinates, want)
+	}
+	if g05.Properties["prn"] != "G05" || g05.Properties["end"] != "2024-07-30T00:02:00Z" {
+		t.Errorf("G05 properties %v", g05.Properties)
+	}
+
+	buf.Reset()
+	if err := WriteGroundTracksGeoJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(buf.String()) != `{"type":"FeatureCollection","features":[]}` {
+		t.Errorf("no positions: %s", buf.String())
+	}
+}
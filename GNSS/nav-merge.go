@@ -0,0 +1,546 @@
+package gnss
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"capnproto.org/go/capnp/v3"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  MERGING BROADCAST FILES
+// Station navigation files repeat the same broadcasts. Merging keeps one
+// record per issue of data:
+//   GPS, QZSS, NavIC   PRN + IODE + toe (week and seconds)
+//   Galileo            PRN + IODnav + toe + I/NAV or F/NAV
+//   BeiDou             PRN + AODE + toe
+//   GLONASS            PRN + tb (the record epoch)
+//   SBAS               PRN + toc
+// The first record of an issue is kept. A later record with the same issue but
+// different values is reported as a MergeConflict, values that depend on the
+// receiver (transmission time, Galileo data sources) are not compared.
+// The result is sorted by satellite and epoch. RINEX 4 messages (CNAV, CNAV-2,
+// CNV1-3) are merged by PRN + toe (+ IODE for BeiDou) per message, RINEX 2 / 3
+// output cannot hold them.
+
+type MergeConflict struct {
+	PRN         string
+	Epoch       time.Time
+	IssueOfData string
+	// Fields names the values that differ from the kept record
+	Fields []string
+}
+
+type MergeResult struct {
+	// Navigation holds the merged records under the header of the first
+	// input, ready for WriteNav
+	Navigation *NavigationData
+	Groups     []GroupedEphemerides
+	Duplicates int
+	Conflicts  []MergeConflict
+	// Unwritten counts the RINEX 4 records MergeNavFiles left out of its
+	// RINEX 2 / 3 output
+	Unwritten int
+}
+
+// MergeNavigation merges navs into one deduplicated and sorted product.
+func MergeNavigation(navs ...*NavigationData) (*MergeResult, error) {
+	if len(navs) == 0 {
+		return nil, fmt.Errorf("nothing to merge")
+	}
+	result := &MergeResult{Navigation: &NavigationData{Header: navs[0].Header}}
+	merged := result.Navigation
+
+	gps := make([][]GPSEphemeris, len(navs))
+	qzss := make([][]GPSEphemeris, len(navs))
+	navIC := make([][]GPSEphemeris, len(navs))
+	galileo := make([][]GalileoEphemeris, len(navs))
+	beiDou := make([][]BeiDouEphemeris, len(navs))
+	glonass := make([][]RINEXEphemeris, len(navs))
+	sbas := make([][]SBASEphemeris, len(navs))
+	for i, nav := range navs {
+		gps[i], qzss[i], navIC[i] = nav.GPS, nav.QZSS, nav.NavIC
+		galileo[i], beiDou[i], glonass[i], sbas[i] = nav.Galileo, nav.BeiDou, nav.GLONASS, nav.SBAS
+	}
+
+	var err error
+	if merged.GPS, err = mergeRecords(gps, gpsMergeRecord, gpsIssueOfData, result); err != nil {
+		return nil, err
+	}
+	if merged.QZSS, err = mergeRecords(qzss, gpsMergeRecord, gpsIssueOfData, result); err != nil {
+		return nil, err
+	}
+	if merged.NavIC, err = mergeRecords(navIC, gpsMergeRecord, gpsIssueOfData, result); err != nil {
+		return nil, err
+	}
+	if merged.Galileo, err = mergeRecords(galileo, galileoMergeRecord, galileoIssueOfData, result); err != nil {
+		return nil, err
+	}
+	if merged.BeiDou, err = mergeRecords(beiDou, beiDouMergeRecord, beiDouIssueOfData, result); err != nil {
+		return nil, err
+	}
+	if merged.GLONASS, err = mergeRecords(glonass, glonassMergeRecord, epochIssueOfData[RINEXEphemeris]("tb"), result); err != nil {
+		return nil, err
+	}
+	if merged.SBAS, err = mergeRecords(sbas, sbasMergeRecord, epochIssueOfData[SBASEphemeris]("toc"), result); err != nil {
+		return nil, err
+	}
+
+	if result.Groups, err = GroupEphemerides(merged); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// MergeNavFiles parses inputs, merges them and writes the product to output
+// as RINEX version. RINEX 2 output requires a single system, the RINEX 4
+// messages are left out of it (MergeResult.Unwritten).
+func MergeNavFiles(output string, version float64, inputs ...string) (*MergeResult, error) {
+	navs := make([]*NavigationData, len(inputs))
+	for i, input := range inputs {
+		nav, err := ParseNavFile(input, ParseOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", input, err)
+		}
+		navs[i] = nav
+	}
+	result, err := MergeNavigation(navs...)
+	if err != nil {
+		return nil, err
+	}
+	writable, unwritten := rinex3Navigation(result.Navigation)
+	if err := WriteNavFile(output, writable, version); err != nil {
+		return nil, err
+	}
+	result.Unwritten = unwritten
+	return result, nil
+}
+
+// rinex3Navigation returns nav without the records of RINEX 4 messages and
+// their number.
+func rinex3Navigation(nav *NavigationData) (*NavigationData, int) {
+	writable := *nav
+	var n, count int
+	lnav := func(t NavMessageType) bool { return t == NavMessageType_lnav }
+	writable.GPS, n = filterMessages(nav.GPS, lnav)
+	count += n
+	writable.QZSS, n = filterMessages(nav.QZSS, lnav)
+	count += n
+	writable.NavIC, n = filterMessages(nav.NavIC, lnav)
+	count += n
+	writable.BeiDou, n = filterMessages(nav.BeiDou, func(t NavMessageType) bool { return !beiDouCivilMessage(t) })
+	count += n
+	return &writable, count
+}
+
+// filterMessages returns the records of list with a message type accepted
+// by keep and the number of the others.
+func filterMessages[T interface{ MessageType() NavMessageType }](list []T, keep func(NavMessageType) bool) ([]T, int) {
+	var kept []T
+	for _, eph := range list {
+		if keep(eph.MessageType()) {
+			kept = append(kept, eph)
+		}
+	}
+	return kept, len(list) - len(kept)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+type mergeEntry[T any] struct {
+	eph    T
+	record navRecord
+}
+
+// mergeRecordFunc returns the values of a parsed record compared by
+// MergeNavigation and their names.
+type mergeRecordFunc[T any] func(T) (navRecord, []string, error)
+
+// mergeRecords keeps the first record of every issue of data and sorts the
+// kept records by PRN and epoch.
+func mergeRecords[T any](lists [][]T, record mergeRecordFunc[T], issueOfData func(T, navRecord) string, result *MergeResult) ([]T, error) {
+	var kept []mergeEntry[T]
+	seen := make(map[string]int)
+	for _, list := range lists {
+		for _, eph := range list {
+			r, names, err := record(eph)
+			if err != nil {
+				return nil, err
+			}
+			issue := issueOfData(eph, r)
+			key := r.prn() + " " + issue
+			i, ok := seen[key]
+			if !ok {
+				seen[key] = len(kept)
+				kept = append(kept, mergeEntry[T]{eph: eph, record: r})
+				continue
+			}
+
+			result.Duplicates++
+			if fields := differingFields(kept[i].record.values, r.values, names); len(fields) > 0 {
+				result.Conflicts = append(result.Conflicts, MergeConflict{
+					PRN:         r.prn(),
+					Epoch:       r.epoch,
+					IssueOfData: issue,
+					Fields:      fields,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool {
+		a, b := kept[i].record, kept[j].record
+		if a.prn() != b.prn() {
+			return a.prn() < b.prn()
+		}
+		return a.epoch.Before(b.epoch)
+	})
+	merged := make([]T, len(kept))
+	for i, entry := range kept {
+		merged[i] = entry.eph
+	}
+	return merged, nil
+}
+
+// differingFields compares two records, names without a value ("") are not
+// compared.
+func differingFields(a, b []float64, names []string) []string {
+	var fields []string
+	for i := range a {
+		if i < len(names) && names[i] == "" {
+			continue
+		}
+		if i >= len(b) || a[i] != b[i] {
+			name := fmt.Sprintf("value %d", i)
+			if i < len(names) {
+				name = names[i]
+			}
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func gpsIssueOfData(eph GPSEphemeris, r navRecord) string {
+	data, _ := eph.EphemerisData()
+	if eph.MessageType() != NavMessageType_lnav {
+		return fmt.Sprintf("toe %d/%.0f %s", data.ToeWeek(), data.Toe(), eph.MessageType())
+	}
+	return fmt.Sprintf("IODE %.0f toe %d/%.0f", data.Iode(), data.ToeWeek(), data.Toe())
+}
+
+func galileoIssueOfData(eph GalileoEphemeris, r navRecord) string {
+	data, _ := eph.EphemerisData()
+	return fmt.Sprintf("IODnav %.0f toe %d/%.0f %s", data.Iode(), data.ToeWeek(), data.Toe(), eph.MessageType())
+}
+
+func beiDouIssueOfData(eph BeiDouEphemeris, r navRecord) string {
+	data, _ := eph.EphemerisData()
+	if beiDouCivilMessage(eph.MessageType()) {
+		return fmt.Sprintf("IODE %.0f toe %d/%.0f %s", data.Iode(), data.ToeWeek(), data.Toe(), eph.MessageType())
+	}
+	return fmt.Sprintf("AODE %.0f toe %d/%.0f", eph.Aode(), data.ToeWeek(), data.Toe())
+}
+
+func epochIssueOfData[T any](name string) func(T, navRecord) string {
+	return func(_ T, r navRecord) string {
+		return name + " " + r.epoch.Format("2006-01-02 15:04:05")
+	}
+}
+
+var keplerFieldNames = []string{
+	"af0", "af1", "af2",
+	"IODE", "Crs", "Delta n", "M0",
+	"Cuc", "e", "Cus", "sqrt(A)",
+	"toe", "Cic", "OMEGA0", "Cis",
+	"i0", "Crc", "omega", "OMEGA DOT",
+	"IDOT",
+}
+
+// Field names in navRecord order, "" marks values that are not compared.
+var (
+	gpsFieldNames = append(append([]string{}, keplerFieldNames...),
+		"codes on L2", "week", "L2 P flag", "SV accuracy", "SV health", "TGD", "IODC", "", "fit interval")
+	galileoFieldNames = append(append([]string{}, keplerFieldNames...),
+		"", "week", "spare", "SISA", "SV health", "BGD E5a/E1", "BGD E5b/E1", "")
+	beiDouFieldNames = append(append([]string{}, keplerFieldNames...),
+		"spare", "week", "spare", "SV accuracy", "SatH1", "TGD1", "TGD2", "", "AODC")
+	glonassFieldNames = []string{
+		"TauN", "GammaN", "",
+		"X", "X velocity", "X acceleration", "health",
+		"Y", "Y velocity", "Y acceleration", "frequency number",
+		"Z", "Z velocity", "Z acceleration", "age",
+		"status flags", "group delay difference", "URAI", "health flags",
+	}
+	sbasFieldNames = []string{
+		"aGf0", "aGf1", "",
+		"X", "X velocity", "X acceleration", "health",
+		"Y", "Y velocity", "Y acceleration", "URA",
+		"Z", "Z velocity", "Z acceleration", "IODN",
+	}
+	// civilMergeValues order
+	gpsCivilFieldNames = append(append([]string{}, keplerFieldNames...),
+		"ADOT", "Delta n0 dot", "URAI ED", "SV health", "TGD", "",
+		"ISC L1CA", "ISC L2C", "ISC L5I5", "ISC L5Q5", "ISC L1Cd", "ISC L1Cp")
+	beiDouCivilFieldNames = append(append([]string{}, keplerFieldNames...),
+		"ADOT", "Delta n0 dot", "health", "TGD1", "TGD2", "IODC", "")
+)
+
+// =========================================================================
+
+// =========================================================================
+
+// The LNAV, I/NAV, F/NAV, D1 / D2, FDMA and SBAS records are compared in
+// their RINEX layout, the RINEX 4 CNAV, CNAV-2 and CNV1-3 records, which
+// RINEX 2 / 3 cannot hold, in the layout of civilMergeValues.
+
+func gpsMergeRecord(eph GPSEphemeris) (navRecord, []string, error) {
+	if eph.MessageType() == NavMessageType_lnav {
+		r, err := gpsNavRecord(eph)
+		return r, gpsFieldNames, err
+	}
+	r, err := civilMergeValues(eph)
+	if err != nil {
+		return navRecord{}, nil, err
+	}
+	data, _ := eph.EphemerisData()
+	r.values = append(r.values, data.SvAcc(), data.SvHealth(), data.Tgd(), data.TransmissionTime())
+	isc, err := eph.InterSignalCorrections()
+	if err != nil {
+		return navRecord{}, nil, fmt.Errorf("failed to get inter signal corrections: %v", err)
+	}
+	for i := 0; i < isc.Len(); i++ {
+		r.values = append(r.values, isc.At(i))
+	}
+	return r, gpsCivilFieldNames, nil
+}
+
+func galileoMergeRecord(eph GalileoEphemeris) (navRecord, []string, error) {
+	r, err := galileoNavRecord(eph)
+	return r, galileoFieldNames, err
+}
+
+func beiDouMergeRecord(eph BeiDouEphemeris) (navRecord, []string, error) {
+	if !beiDouCivilMessage(eph.MessageType()) {
+		r, err := beiDouNavRecord(eph)
+		return r, beiDouFieldNames, err
+	}
+	r, err := civilMergeValues(eph)
+	if err != nil {
+		return navRecord{}, nil, err
+	}
+	data, _ := eph.EphemerisData()
+	r.values = append(r.values, data.SvHealth(), eph.Tgd1(), eph.Tgd2(), data.Iodc(), data.TransmissionTime())
+	return r, beiDouCivilFieldNames, nil
+}
+
+func glonassMergeRecord(eph RINEXEphemeris) (navRecord, []string, error) {
+	r, err := glonassNavRecord(eph)
+	return r, glonassFieldNames, err
+}
+
+func sbasMergeRecord(eph SBASEphemeris) (navRecord, []string, error) {
+	r, err := sbasNavRecord(eph)
+	return r, sbasFieldNames, err
+}
+
+// civilEphemeris is a GPS or BeiDou record of a RINEX 4 message.
+type civilEphemeris interface {
+	EphemerisData() (Ephemeris, error)
+	BaseEphemeris() (BaseEphemeris, error)
+	SquareRootOfSemiMajorAxis() float64
+	MessageType() NavMessageType
+	ADot() float64
+	DeltaNDot() float64
+}
+
+// civilMergeValues returns the satellite, epoch and Keplerian values of a
+// RINEX 4 record followed by ADOT and Delta n0 dot.
+func civilMergeValues(eph civilEphemeris) (navRecord, error) {
+	data, err := eph.EphemerisData()
+	if err != nil {
+		return navRecord{}, fmt.Errorf("failed to get ephemeris data: %v", err)
+	}
+	base, err := eph.BaseEphemeris()
+	if err != nil {
+		return navRecord{}, fmt.Errorf("failed to get base ephemeris: %v", err)
+	}
+	system := byte('G')
+	if prn, _ := base.PseudoRandomNumber(); prn != "" {
+		system = prn[0]
+	}
+	values := append(keplerValues(data, eph.SquareRootOfSemiMajorAxis()), eph.ADot(), eph.DeltaNDot())
+	return navRecord{system: system, svId: int(data.SvId()), epoch: ephemerisDataEpoch(data),
+		messageType: eph.MessageType(), values: values}, nil
+}
+
+// beiDouCivilMessage tells the BDS-3 B-CNAV messages of RINEX 4.
+func beiDouCivilMessage(t NavMessageType) bool {
+	return t == NavMessageType_cnv1 || t == NavMessageType_cnv2 || t == NavMessageType_cnv3
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// GroupEphemerides returns one GroupedEphemerides per satellite of nav, in
+// the order G, R, E, C, J, I, S and by PRN, with the records sorted by epoch.
+// GLONASS records are kept in SortedEphemerides, the other systems in the
+// list of their type. All groups share one message.
+func GroupEphemerides(nav *NavigationData) ([]GroupedEphemerides, error) {
+	type group struct {
+		prn     string
+		svId    int
+		gps     []GPSEphemeris
+		glonass []RINEXEphemeris
+		galileo []GalileoEphemeris
+		beiDou  []BeiDouEphemeris
+		sbas    []SBASEphemeris
+	}
+	var order []*group
+	groups := make(map[string]*group)
+	get := func(r navRecord) *group {
+		g, ok := groups[r.prn()]
+		if !ok {
+			g = &group{prn: r.prn(), svId: r.svId}
+			groups[r.prn()] = g
+			order = append(order, g)
+		}
+		return g
+	}
+
+	for _, list := range [][]GPSEphemeris{nav.GPS, nav.QZSS, nav.NavIC} {
+		sorted, err := sortRecords(list, gpsMergeRecord)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range sorted {
+			g := get(e.record)
+			g.gps = append(g.gps, e.eph)
+		}
+	}
+	glonass, err := sortRecords(nav.GLONASS, glonassMergeRecord)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range glonass {
+		g := get(e.record)
+		g.glonass = append(g.glonass, e.eph)
+	}
+	galileo, err := sortRecords(nav.Galileo, galileoMergeRecord)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range galileo {
+		g := get(e.record)
+		g.galileo = append(g.galileo, e.eph)
+	}
+	beiDou, err := sortRecords(nav.BeiDou, beiDouMergeRecord)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range beiDou {
+		g := get(e.record)
+		g.beiDou = append(g.beiDou, e.eph)
+	}
+	sbas, err := sortRecords(nav.SBAS, sbasMergeRecord)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range sbas {
+		g := get(e.record)
+		g.sbas = append(g.sbas, e.eph)
+	}
+
+	systemOrder := "GRECJIS"
+	sort.SliceStable(order, func(i, j int) bool {
+		si, sj := strings.IndexByte(systemOrder, order[i].prn[0]), strings.IndexByte(systemOrder, order[j].prn[0])
+		if si != sj {
+			return si < sj
+		}
+		return order[i].prn < order[j].prn
+	})
+
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new message: %v", err)
+	}
+	list, err := NewGroupedEphemerides_List(seg, int32(len(order)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new GroupedEphemerides list: %v", err)
+	}
+	grouped := make([]GroupedEphemerides, len(order))
+	for i, g := range order {
+		dst := list.At(i)
+		dst.SetSatelliteId(int32(g.svId))
+		if err := dst.SetPrn(g.prn); err != nil {
+			return nil, fmt.Errorf("failed to set PRN: %v", err)
+		}
+		if err := copyEphemerides(g.gps, dst.NewGps); err != nil {
+			return nil, err
+		}
+		if err := copyEphemerides(g.glonass, dst.NewSortedEphemerides); err != nil {
+			return nil, err
+		}
+		if err := copyEphemerides(g.galileo, dst.NewGalileo); err != nil {
+			return nil, err
+		}
+		if err := copyEphemerides(g.beiDou, dst.NewBeiDou); err != nil {
+			return nil, err
+		}
+		if err := copyEphemerides(g.sbas, dst.NewSbas); err != nil {
+			return nil, err
+		}
+		grouped[i] = dst
+	}
+	return grouped, nil
+}
+
+// SortEphemerisBySatelliteID groups GLONASS records per satellite, sorted by
+// epoch.
+func SortEphemerisBySatelliteID(ephs []RINEXEphemeris) ([]GroupedEphemerides, error) {
+	return GroupEphemerides(&NavigationData{GLONASS: ephs})
+}
+
+func sortRecords[T any](list []T, record mergeRecordFunc[T]) ([]mergeEntry[T], error) {
+	entries := make([]mergeEntry[T], len(list))
+	for i, eph := range list {
+		r, _, err := record(eph)
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = mergeEntry[T]{eph: eph, record: r}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].record.epoch.Before(entries[j].record.epoch)
+	})
+	return entries, nil
+}
+
+// copyEphemerides copies src into a new list of the group message, empty
+// lists are not allocated.
+func copyEphemerides[T ~capnp.StructKind](src []T, newList func(int32) (capnp.StructList[T], error)) error {
+	if len(src) == 0 {
+		return nil
+	}
+	list, err := newList(int32(len(src)))
+	if err != nil {
+		return fmt.Errorf("failed to create new ephemeris list: %v", err)
+	}
+	for i, eph := range src {
+		if err := list.Set(i, eph); err != nil {
+			return fmt.Errorf("failed to copy ephemeris: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package gnss
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// =========================================================================
+
+// =========================================================================
+
+func TestMergeNavigationDuplicates(t *testing.T) {
+	path := testFile(t, "abpo2120.24n")
+	first, err := ParseNavFile(path, ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := ParseNavFile(path, ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues := make(map[string]bool)
+	for _, eph := range first.GPS {
+		r, _ := gpsNavRecord(eph)
+		issues[r.prn()+" "+gpsIssueOfData(eph, r)] = true
+	}
+
+	result, err := MergeNavigation(first, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Navigation.GPS) != len(issues) {
+		t.Errorf("%d records kept, want %d", len(result.Navigation.GPS), len(issues))
+	}
+	if want := 2*len(first.GPS) - len(issues); result.Duplicates != want {
+		t.Errorf("%d duplicates, want %d", result.Duplicates, want)
+	}
+	if len(result.Conflicts) != 0 {
+		t.Errorf("conflicts %v", result.Conflicts)
+	}
+
+	merged := navRecords(t, result.Navigation)
+	for i := 1; i < len(merged); i++ {
+		a, b := merged[i-1], merged[i]
+		if a.prn() > b.prn() || a.prn() == b.prn() && b.epoch.Before(a.epoch) {
+			t.Fatalf("records %d and %d out of order: %s %v, %s %v", i-1, i, a.prn(), a.epoch, b.prn(), b.epoch)
+		}
+	}
+}
+
+// A repeated issue of data with other values is kept once and reported, the
+// transmission time differs between receivers and is not compared.
+func TestMergeNavigationConflicts(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(mixedNavV3), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// af0 of G05 and the transmission time of E05
+	changed := strings.Replace(mixedNavV3, "G05 2024 07 29 23 59 44-1.814444549382E-04", "G05 2024 07 29 23 59 44-1.814444549383E-04", 1)
+	changed = strings.Replace(changed, "     1.656180000000E+05\nC19", "     1.656240000000E+05\nC19", 1)
+	other, err := ParseNav(strings.NewReader(changed), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := MergeNavigation(nav, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Duplicates != 4 {
+		t.Errorf("%d duplicates, want 4", result.Duplicates)
+	}
+	if len(result.Conflicts) != 1 {
+		t.Fatalf("conflicts %+v, want the G05 af0 only", result.Conflicts)
+	}
+	c := result.Conflicts[0]
+	if c.PRN != "G05" || !reflect.DeepEqual(c.Fields, []string{"af0"}) || c.IssueOfData != "IODE 16 toe 2325/172784" {
+		t.Errorf("conflict %+v", c)
+	}
+	// the first record is kept
+	data, _ := result.Navigation.GPS[0].EphemerisData()
+	if data.Af0() != -1.814444549382e-04 {
+		t.Errorf("af0 %v of the later record kept", data.Af0())
+	}
+}
+
+func TestMergeNavFiles(t *testing.T) {
+	gps, glonass := testFile(t, "abpo2120.24n"), testFile(t, "brdc2050.24g")
+	output := filepath.Join(t.TempDir(), "merged.rnx")
+	result, err := MergeNavFiles(output, 3.04, gps, glonass, gps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// groups are ordered by system, then PRN
+	var prns []string
+	for _, g := range result.Groups {
+		prn, _ := g.Prn()
+		prns = append(prns, prn)
+	}
+	for i := 1; i < len(prns); i++ {
+		a, b := prns[i-1], prns[i]
+		if a[0] == 'R' && b[0] == 'G' || a[0] == b[0] && a >= b {
+			t.Fatalf("groups %s before %s", a, b)
+		}
+	}
+
+	written, err := ParseNavFile(output, ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareNavRecords(t, "merged file", navRecords(t, written), navRecords(t, result.Navigation))
+
+	if _, err := MergeNavFiles(output, 2.11, gps, glonass); err == nil {
+		t.Error("GPS and GLONASS merged into RINEX 2.11")
+	}
+	if _, err := MergeNavigation(); err == nil {
+		t.Error("merge of nothing succeeded")
+	}
+}
+
+// RINEX 4 CNAV and CNV1 records are merged and compared on their own values,
+// RINEX 3 output leaves them out.
+func TestMergeNavigationV4(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(navV4Messages), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ADOT of G10, TGD B1Cp of C25 and the transmission time of E07
+	changed := strings.Replace(navV4Messages, "     1.500000000000E-02-1.039375", "     1.600000000000E-02-1.039375", 1)
+	changed = strings.Replace(changed, "2.000000000000E-10 3.000000000000E-09 4.000000000000E-09", "2.000000000000E-10 3.500000000000E-09 4.000000000000E-09", 1)
+	changed = strings.Replace(changed, "1.723200000000E+05", "1.723300000000E+05", 1)
+	other, err := ParseNav(strings.NewReader(changed), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := MergeNavigation(nav, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged := result.Navigation
+	if len(merged.GPS) != 2 || len(merged.Galileo) != 1 || len(merged.BeiDou) != 5 || len(merged.GLONASS) != 1 || len(merged.SBAS) != 1 {
+		t.Errorf("%d GPS, %d Galileo, %d BeiDou, %d GLONASS, %d SBAS records kept", len(merged.GPS), len(merged.Galileo),
+			len(merged.BeiDou), len(merged.GLONASS), len(merged.SBAS))
+	}
+	if result.Duplicates != 10 {
+		t.Errorf("%d duplicates, want 10", result.Duplicates)
+	}
+	want := []MergeConflict{
+		{PRN: "G10", IssueOfData: "toe 2325/172800 cnav", Fields: []string{"ADOT"}},
+		{PRN: "C25", IssueOfData: "IODE 7 toe 969/172800 cnv1", Fields: []string{"TGD1"}},
+	}
+	if len(result.Conflicts) != len(want) {
+		t.Fatalf("conflicts %+v, want %+v", result.Conflicts, want)
+	}
+	for i, c := range result.Conflicts {
+		if c.PRN != want[i].PRN || c.IssueOfData != want[i].IssueOfData || !reflect.DeepEqual(c.Fields, want[i].Fields) {
+			t.Errorf("conflict %+v, want %+v", c, want[i])
+		}
+	}
+	if len(result.Groups) != 10 {
+		t.Errorf("%d groups, want 10", len(result.Groups))
+	}
+
+	// LNAV and CNAV records of one satellite are kept apart
+	lnav, err := ParseNav(strings.NewReader(strings.Replace(navV4, "G05", "G10", 2)), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, err := MergeNavigation(nav, lnav); err != nil || len(result.Navigation.GPS) != 3 || result.Duplicates != 0 {
+		t.Errorf("LNAV and CNAV merge: %v", err)
+	}
+
+	input := filepath.Join(t.TempDir(), "v4.rnx")
+	if err := os.WriteFile(input, []byte(navV4Messages), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(t.TempDir(), "merged.rnx")
+	result, err = MergeNavFiles(output, 3.05, input, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Unwritten != 5 {
+		t.Errorf("%d records left out, want the 2 GPS and 3 BeiDou RINEX 4 ones", result.Unwritten)
+	}
+	written, err := ParseNavFile(output, ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writable, _ := rinex3Navigation(result.Navigation)
+	compareNavRecords(t, "merged file", navRecords(t, written), navRecords(t, writable))
+	if len(written.BeiDou) != 2 || len(written.GPS) != 0 {
+		t.Errorf("%d GPS and %d BeiDou records written", len(written.GPS), len(written.BeiDou))
+	}
+}
@@ -62,3 +62,33 @@ func (k ObservationKind) String() string {
 	}
 	return "Unknown"
 }
+
+// ObservationKindFromCode maps a RINEX observation code (C1C, P2, D1, ...) of
+// a satellite system ("G", "R", ...) to its kind. Carrier phase and signal
+// strength have no kind yet and map to UNKNOWN.
+func ObservationKindFromCode(system, code string) ObservationKind {
+	if code == "" {
+		return UNKNOWN
+	}
+	switch code[0] {
+	case 'C', 'P':
+		switch system {
+		case "G":
+			return PSEUDORANGE_GPS
+		case "R":
+			return PSEUDORANGE_GLONASS
+		default:
+			return PSEUDORANGE
+		}
+	case 'D':
+		switch system {
+		case "G":
+			return PSEUDORANGE_RATE_GPS
+		case "R":
+			return PSEUDORANGE_RATE_GLONASS
+		default:
+			return PSEUDORANGE_RATE
+		}
+	}
+	return UNKNOWN
+}
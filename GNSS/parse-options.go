@@ -0,0 +1,183 @@
+package gnss
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  PARSE OPTIONS AND ERRORS
+// The reader based parsers never print. Malformed fields are reported as
+// ParseError: in lenient mode (the default) the field reads as zero, the
+// error is collected as a warning and logged when a Logger is set; in strict
+// mode parsing stops at the first one. Records that cannot be decoded at all
+// (wrong line count, too short) are skipped with a warning in lenient mode.
+
+type ParseOptions struct {
+	// FileName locates errors and is kept as the file of every parsed
+	// navigation record (BaseEphemeris.fileName), ParseNavFile sets it
+	FileName string
+	// Logger receives warnings and debug summaries, nil keeps parsing quiet
+	Logger *slog.Logger
+	// Strict fails on the first malformed field or record
+	Strict bool
+}
+
+// ParseError locates malformed input. Line and columns are 1-based, columns
+// are inclusive as in the RINEX format tables and zero when the error
+// concerns a whole line or record.
+type ParseError struct {
+	File        string
+	Line        int
+	StartColumn int
+	EndColumn   int
+	Text        string
+	Err         error
+}
+
+func (e *ParseError) Error() string {
+	file := e.File
+	if file == "" {
+		file = "<input>"
+	}
+	if e.StartColumn == 0 {
+		return fmt.Sprintf("%s:%d: %v: %q", file, e.Line, e.Err, e.Text)
+	}
+	return fmt.Sprintf("%s:%d:%d-%d: %v: %q", file, e.Line, e.StartColumn, e.EndColumn, e.Err, e.Text)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// lineScanner counts the lines of the input and collects the warnings of a
+// lenient parse.
+type lineScanner struct {
+	*bufio.Scanner
+	opts     ParseOptions
+	line     int
+	warnings []*ParseError
+}
+
+func newLineScanner(r io.Reader, opts ParseOptions) *lineScanner {
+	return &lineScanner{Scanner: bufio.NewScanner(r), opts: opts}
+}
+
+func (s *lineScanner) Scan() bool {
+	if !s.Scanner.Scan() {
+		return false
+	}
+	s.line++
+	return true
+}
+
+// report returns err in strict mode, otherwise keeps it as a warning.
+func (s *lineScanner) report(err *ParseError) error {
+	if s.opts.Strict {
+		return err
+	}
+	s.warnings = append(s.warnings, err)
+	if s.opts.Logger != nil {
+		s.opts.Logger.Warn("malformed input", "file", err.File, "line", err.Line,
+			"columns", fmt.Sprintf("%d-%d", err.StartColumn, err.EndColumn), "text", err.Text, "error", err.Err)
+	}
+	return nil
+}
+
+// recordError reports a record that could not be decoded, field errors of a
+// strict parse are passed through unchanged.
+func (s *lineScanner) recordError(record *lineRecord, err error) error {
+	if pe, ok := err.(*ParseError); ok {
+		return pe
+	}
+	return s.report(&ParseError{File: s.opts.FileName, Line: record.numbers[0], Text: record.lines[0], Err: err})
+}
+
+func (s *lineScanner) debug(msg string, args ...any) {
+	if s.opts.Logger != nil {
+		s.opts.Logger.Debug(msg, args...)
+	}
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// lineRecord holds the lines of one record with their line numbers and reads
+// fixed column fields from them. The first malformed field of a strict parse
+// is kept in err.
+type lineRecord struct {
+	scanner *lineScanner
+	lines   []string
+	numbers []int
+	err     error
+}
+
+func (s *lineScanner) newRecord() *lineRecord {
+	// room for the 8 lines of a GPS record
+	return &lineRecord{scanner: s, lines: make([]string, 0, 8), numbers: make([]int, 0, 8)}
+}
+
+// add appends the line last returned by the scanner.
+func (r *lineRecord) add(line string) {
+	r.lines = append(r.lines, line)
+	r.numbers = append(r.numbers, r.scanner.line)
+}
+
+func (r *lineRecord) len() int {
+	return len(r.lines)
+}
+
+func (r *lineRecord) fieldError(i, from, to int, err error) {
+	if r.err != nil {
+		return
+	}
+	text := column(r.lines[i], from, to)
+	r.err = r.scanner.report(&ParseError{
+		File:        r.scanner.opts.FileName,
+		Line:        r.numbers[i],
+		StartColumn: from + 1,
+		EndColumn:   from + len(text),
+		Text:        text,
+		Err:         err,
+	})
+}
+
+// float reads columns [from, to) of line i, blank fields are zero.
+func (r *lineRecord) float(i, from, to int) float64 {
+	var f float64
+	r.decodeField(i, floatField("value", from, to-from), &f)
+	return f
+}
+
+// integer reads columns [from, to) of line i, blank fields are zero.
+func (r *lineRecord) integer(i, from, to int) int {
+	var n int
+	r.decodeField(i, intField("value", from, to-from), &n)
+	return n
+}
+
+// orbitValues reads the three D19.12 clock fields of the first line and the
+// four of every BROADCAST ORBIT line, the fields start at column indent (3 in
+// RINEX 2, 4 in RINEX 3). Lines are allowed to end early, as the last one
+// usually does, the missing fields are returned as zero.
+func (r *lineRecord) orbitValues(indent int) []float64 {
+	values := make([]float64, 3+4*(len(r.lines)-1))
+	for j := 0; j < 3; j++ {
+		r.decodeField(0, navValue(indent, j+1), &values[j])
+	}
+	for i := 1; i < len(r.lines); i++ {
+		for j := 0; j < 4; j++ {
+			r.decodeField(i, navValue(indent, j), &values[4*i-1+j])
+		}
+	}
+	return values
+}
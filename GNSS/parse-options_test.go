@@ -0,0 +1,146 @@
+package gnss
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// G05 with a malformed eccentricity on line 5, G06 and the first five lines
+// of G11
+const malformedNav = `     2.11           N: GPS NAV DATA                         RINEX VERSION / TYPE
+                                                            END OF HEADER
+ 5 24  7 29 23 59 44.0-1.814444549382D-04-1.250555214938D-12 0.000000000000D+00
+    1.600000000000D+01-1.039375000000D+02 4.031239345871D-09-2.266875691854D+00
+   -5.450099706650D-06 5.96335X577520D-03 7.448717951775D-06 5.153655038834D+03
+    1.727840000000D+05 7.264316082001D-08 1.893942802914D+00 3.166496753693D-08
+    9.721468054966D-01 2.340000000000D+02 1.298159075228D+00-7.757823144473D-09
+    2.596536727606D-10 1.000000000000D+00 2.325000000000D+03 0.000000000000D+00
+    2.000000000000D+00 0.000000000000D+00-1.071020960808D-08 1.600000000000D+01
+    1.656180000000D+05 4.000000000000D+00
+ 6 24  7 30  0  0  0.0 1.311199739575D-04-2.353317540837D-11 0.000000000000D+00
+    7.900000000000D+01-4.953125000000D+01 3.785157667010D-09 1.740854840815D+00
+   -2.482905983925D-06 2.942717866972D-03 2.874061465263D-06 5.153666456223D+03
+    1.728000000000D+05 2.607703208923D-08 9.096283094188D-01 1.024454832077D-07
+    9.896258180019D-01 3.386875000000D+02-7.237152276099D-01-7.947831059065D-09
+   -2.392956819114D-11 1.000000000000D+00 2.325000000000D+03 0.000000000000D+00
+    2.000000000000D+00 0.000000000000D+00 3.725290298462D-09 7.900000000000D+01
+    1.656180000000D+05 4.000000000000D+00
+11 24  7 30  0  0  0.0-7.133646868169D-04-6.252776074689D-12 0.000000000000D+00
+    8.000000000000D+01-4.600000000000D+01 4.267677765909D-09 2.959025243484D+00
+   -2.231448888779D-06 1.520002027974D-03 2.579763531685D-06 5.153749794006D+03
+    1.728000000000D+05 2.793967723846D-08 9.412842035567D-01 7.450580596924D-09
+    9.661823480472D-01 3.326250000000D+02-2.587747854085D+00-8.230342826815D-09
+`
+
+// =========================================================================
+
+// =========================================================================
+
+func TestParseNavStrict(t *testing.T) {
+	_, err := ParseNav(strings.NewReader(malformedNav), ParseOptions{FileName: "bad.24n", Strict: true})
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("error %v, want a ParseError", err)
+	}
+	if pe.File != "bad.24n" || pe.Line != 5 || pe.StartColumn != 23 || pe.EndColumn != 41 || pe.Text != " 5.96335X577520D-03" {
+		t.Errorf("error at %s:%d:%d-%d %q, want bad.24n:5:23-41", pe.File, pe.Line, pe.StartColumn, pe.EndColumn, pe.Text)
+	}
+	if want := `bad.24n:5:23-41: `; !strings.HasPrefix(pe.Error(), want) {
+		t.Errorf("message %q, want prefix %q", pe.Error(), want)
+	}
+}
+
+// A lenient parse reads the malformed field as zero, skips the short record
+// and reports both.
+func TestParseNavLenient(t *testing.T) {
+	var log bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&log, nil))
+	nav, err := ParseNav(strings.NewReader(malformedNav), ParseOptions{FileName: "bad.24n", Logger: logger})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nav.GPS) != 2 || nav.GPS[0].PRN() != "G05" || nav.GPS[1].PRN() != "G06" {
+		t.Fatalf("%d records", len(nav.GPS))
+	}
+	data, err := nav.GPS[0].EphemerisData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Ecc() != 0 || data.Cus() != 7.448717951775e-06 {
+		t.Errorf("eccentricity %v cus %v, want 0 and the next field", data.Ecc(), data.Cus())
+	}
+
+	if len(nav.Warnings) != 2 {
+		t.Fatalf("%d warnings, want 2: %v", len(nav.Warnings), nav.Warnings)
+	}
+	if w := nav.Warnings[0]; w.Line != 5 || w.StartColumn != 23 || w.EndColumn != 41 {
+		t.Errorf("warning %v, want line 5 columns 23-41", w)
+	}
+	if w := nav.Warnings[1]; w.Line != 19 || w.StartColumn != 0 {
+		t.Errorf("warning %v, want the record of line 19", w)
+	}
+	if n := strings.Count(log.String(), "level=WARN"); n != 2 {
+		t.Errorf("%d warnings logged, want 2:\n%s", n, log.String())
+	}
+
+	// no logger, no output
+	quiet, err := ParseNav(strings.NewReader(malformedNav), ParseOptions{})
+	if err != nil || len(quiet.Warnings) != 2 || quiet.Warnings[0].File != "" {
+		t.Errorf("quiet parse: %v, %d warnings", err, len(quiet.Warnings))
+	}
+}
+
+func TestParseObservationErrors(t *testing.T) {
+	const body = `     3.04           OBSERVATION DATA    M                   RINEX VERSION / TYPE
+G    2 C1C L1C                                              SYS / # / OBS TYPES
+                                                            END OF HEADER
+> 2024 07 30 00 00  0.0000000  0  1
+G05  23000000.0X0 8 120000000.00018
+`
+	_, _, err := ParseObservation(strings.NewReader(body), ParseOptions{Strict: true})
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Line != 5 || pe.StartColumn != 4 || pe.EndColumn != 17 {
+		t.Errorf("error %v, want line 5 columns 4-17", err)
+	}
+	_, epochs, err := ParseObservation(strings.NewReader(body), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sat, _ := epochs[0].Satellite("G05")
+	if m, _ := sat.Measurement("L1C"); m.Value() != 120000000 {
+		t.Errorf("L1C %v after the malformed field", m.Value())
+	}
+}
+
+// A malformed TIME OF FIRST OBS fails a strict parse at its field.
+func TestParseObservationHeaderEpoch(t *testing.T) {
+	const body = `     3.04           OBSERVATION DATA    M                   RINEX VERSION / TYPE
+G    2 C1C L1C                                              SYS / # / OBS TYPES
+  2024     7    3X     0     0    0.0000000     GPS         TIME OF FIRST OBS
+                                                            END OF HEADER
+`
+	_, _, err := ParseObservation(strings.NewReader(body), ParseOptions{Strict: true})
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Line != 3 || pe.StartColumn != 13 || pe.EndColumn != 18 {
+		t.Errorf("error %v, want line 3 columns 13-18", err)
+	}
+}
+
+func TestParseSP3FieldErrors(t *testing.T) {
+	const body = `#dP2024  4 13 23  0  0.00000000       1 ORBIT IGb20 FIT  WHU
++    1   G01  0  0  0  0  0  0  0  0  0  0  0  0  0  0  0  0
+*  2024  4 13 23  0  0.00000000
+PG01  -9786.445316  22881.1X3781   8574.306434    -47.307452
+`
+	_, err := ParseSP3(strings.NewReader(body), ParseOptions{Strict: true})
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Line != 4 || pe.StartColumn != 19 || pe.EndColumn != 32 {
+		t.Errorf("error %v, want line 4 columns 19-32", err)
+	}
+	if _, err := ParseSP3(strings.NewReader(body), ParseOptions{}); err != nil {
+		t.Errorf("lenient parse: %v", err)
+	}
+}
@@ -0,0 +1,421 @@
+package gnss
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mothergoose31/GNNS-GO/GNSS/helpers"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  SINGLE POINT POSITIONING
+// Least squares receiver position from the code pseudoranges of one epoch
+// and broadcast or precise orbits:
+//   P = ρ + c dtr(sys) - c dts + ε
+// The satellite state is taken at the transmission time t - P/c - dts and
+// rotated by the Earth rotation during the signal travel time (Sagnac).
+// Every satellite system gets its own receiver clock, which absorbs the
+// system time offsets and inter-system biases. The tropospheric delay of
+// PositionOptions.Troposphere is subtracted once a first position is known,
+// no ionospheric or group delays are applied: expect errors of a few meters
+// to tens of meters.
+//
+// Satellites below the elevation mask are dropped once a first position is
+// known.
+
+// defaultPseudorangeCodes lists the codes tried per system, first found wins.
+// RINEX 2 files use the two letter codes.
+var defaultPseudorangeCodes = map[string][]string{
+	"G": {"C1C", "C1W", "C1", "P1", "C2W", "P2", "C5Q", "C5X"},
+	"R": {"C1C", "C1P", "C1", "P1", "C2C", "C2P", "P2"},
+	"E": {"C1C", "C1X", "C1", "C5Q", "C5X", "C7Q", "C7X"},
+	"C": {"C2I", "C1I", "C2", "C1P", "C1X", "C5X", "C6I"},
+	"J": {"C1C", "C1X", "C1", "C5Q", "C5X"},
+	"I": {"C5A", "C5", "C9A"},
+}
+
+const (
+	maxPositionIterations = 10
+	positionConvergence   = 1e-4 // m
+)
+
+type PositionOptions struct {
+	// Systems limits the satellites used ("GRE"), empty uses all
+	Systems string
+	// ElevationMask in degrees
+	ElevationMask float64
+	// Codes replaces the pseudorange codes tried for a system
+	Codes map[string][]string
+	// InitialPosition (ECEF m) speeds up convergence, the default is the
+	// center of the Earth
+	InitialPosition []float64
+	// Troposphere, when set, gives the zenith delays mapped to each
+	// satellite by Mapping, Niell when nil
+	Troposphere TroposphereModel
+	Mapping     MappingFunction
+}
+
+// PositionSolution is the receiver state of one epoch. Residuals and Orbits,
+// the file of each broadcast orbit used (see EphemerisFile), are in the
+// order of Satellites, ClockBias is in seconds per system.
+type PositionSolution struct {
+	Week       int                `json:"gpsWeek"`
+	TimeOfWeek float64            `json:"gpsTimeOfWeek"`
+	UTC        time.Time          `json:"utc"`
+	Position   [3]float64         `json:"position"`
+	Latitude   float64            `json:"latitude"`
+	Longitude  float64            `json:"longitude"`
+	Height     float64            `json:"height"`
+	ClockBias  map[string]float64 `json:"clockBias"`
+	Satellites []string           `json:"satellites"`
+	Orbits     []string           `json:"orbits"`
+	Residuals  []float64          `json:"residuals"`
+	PDOP       float64            `json:"pdop"`
+	Iterations int                `json:"iterations"`
+}
+
+type rangeObservation struct {
+	prn       string
+	system    string
+	value     float64
+	position  []float64
+	clockBias float64
+	orbit     string
+	// tropospheric delay, m
+	troposphere float64
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// SolvePosition computes the receiver position of epoch from the orbits of
+// store.
+func SolvePosition(store *EphemerisStore, epoch ObservationEpoch, opts PositionOptions) (*PositionSolution, error) {
+	t, err := epoch.GpsTime()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch time: %v", err)
+	}
+	observations, err := pseudoranges(store, epoch, t, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var x [3]float64
+	if len(opts.InitialPosition) == 3 {
+		copy(x[:], opts.InitialPosition)
+	}
+	clocks := make(map[string]float64)
+	mask := opts.ElevationMask * math.Pi / 180
+	mapping := opts.Mapping
+	if mapping == nil {
+		mapping = Niell{}
+	}
+
+	solution := &PositionSolution{}
+	var used []rangeObservation
+	var residuals []float64
+	var q [][]float64
+	for iteration := 1; ; iteration++ {
+		// the mask needs a position to compute elevations from
+		located := math.Sqrt(x[0]*x[0]+x[1]*x[1]+x[2]*x[2]) > 1e6
+
+		var receiver []float64
+		if located {
+			receiver = helpers.ECEFToGeodetic([][]float64{x[:]}, true)[0]
+		}
+
+		var systems []string
+		var geometry [][3]float64
+		var columns []int
+		used, residuals = used[:0], residuals[:0]
+		for _, obs := range observations {
+			sat := sagnac(obs.position, x[:])
+			if located && (mask > 0 || opts.Troposphere != nil) {
+				_, elevation, _ := helpers.AzimuthElevation(x[:], sat)
+				if elevation < mask {
+					continue
+				}
+				if opts.Troposphere != nil && elevation > 0 {
+					obs.troposphere = TroposphericDelay(opts.Troposphere, mapping, t, receiver, elevation)
+				}
+			}
+			d := helpers.Subtract(sat, x[:])
+			r := math.Sqrt(d[0]*d[0] + d[1]*d[1] + d[2]*d[2])
+
+			column := len(systems)
+			for i, system := range systems {
+				if system == obs.system {
+					column = i
+				}
+			}
+			if column == len(systems) {
+				systems = append(systems, obs.system)
+			}
+			geometry = append(geometry, [3]float64{-d[0] / r, -d[1] / r, -d[2] / r})
+			columns = append(columns, column)
+			used = append(used, obs)
+			residuals = append(residuals, obs.value-(r+clocks[obs.system]-SPEED_OF_LIGHT*obs.clockBias+obs.troposphere))
+		}
+
+		unknowns := 3 + len(systems)
+		if len(used) < unknowns {
+			return nil, fmt.Errorf("%d satellites for %d unknowns", len(used), unknowns)
+		}
+		a := make([][]float64, len(used))
+		for i := range a {
+			a[i] = make([]float64, unknowns)
+			copy(a[i], geometry[i][:])
+			a[i][3+columns[i]] = 1
+		}
+		var dx []float64
+		dx, q, err = leastSquares(a, residuals)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range x {
+			x[i] += dx[i]
+		}
+		for i, system := range systems {
+			clocks[system] += dx[3+i]
+		}
+		solution.Iterations = iteration
+		if math.Sqrt(dx[0]*dx[0]+dx[1]*dx[1]+dx[2]*dx[2]) < positionConvergence {
+			break
+		}
+		if iteration == maxPositionIterations {
+			return nil, errors.New("position did not converge")
+		}
+	}
+
+	geodetic := helpers.ECEFToGeodetic([][]float64{x[:]}, false)[0]
+	solution.Week = int(t.Week())
+	solution.TimeOfWeek = t.TimeOfWeek()
+	solution.UTC = t.ToUTC()
+	solution.Position = x
+	solution.Latitude, solution.Longitude, solution.Height = geodetic[0], geodetic[1], geodetic[2]
+	solution.ClockBias = make(map[string]float64, len(clocks))
+	for system, clock := range clocks {
+		solution.ClockBias[system] = clock / SPEED_OF_LIGHT
+	}
+	for _, obs := range used {
+		solution.Satellites = append(solution.Satellites, obs.prn)
+		solution.Orbits = append(solution.Orbits, obs.orbit)
+	}
+	// residuals of the last linearization, corrected by its update
+	for i := range residuals {
+		residuals[i] = used[i].value - SPEED_OF_LIGHT*(solution.ClockBias[used[i].system]-used[i].clockBias) -
+			rangeTo(sagnac(used[i].position, x[:]), x[:]) - used[i].troposphere
+	}
+	solution.Residuals = residuals
+	solution.PDOP = math.Sqrt(q[0][0] + q[1][1] + q[2][2])
+	return solution, nil
+}
+
+// pseudoranges selects the pseudorange of every usable satellite of epoch and
+// computes the satellite position and clock at the transmission time.
+func pseudoranges(store *EphemerisStore, epoch ObservationEpoch, t GPSTime, opts PositionOptions) ([]rangeObservation, error) {
+	satellites, err := epoch.Satellites()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get satellites: %v", err)
+	}
+
+	var observations []rangeObservation
+	for i := 0; i < satellites.Len(); i++ {
+		sat := satellites.At(i)
+		prn, err := sat.Prn()
+		if err != nil || prn == "" {
+			continue
+		}
+		system := prn[:1]
+		if opts.Systems != "" && !strings.Contains(opts.Systems, system) {
+			continue
+		}
+		codes, ok := opts.Codes[system]
+		if !ok {
+			codes = defaultPseudorangeCodes[system]
+		}
+		value := 0.0
+		for _, code := range codes {
+			if m, ok := sat.Measurement(code); ok && m.HasValue() && m.Value() > 0 {
+				value = m.Value()
+				break
+			}
+		}
+		if value == 0 {
+			continue
+		}
+
+		eph, err := store.Get(prn, t)
+		if err != nil {
+			continue
+		}
+		// the satellite clock moves the transmission time by up to a
+		// millisecond, evaluate twice
+		tx := t.Add(-value / SPEED_OF_LIGHT)
+		_, _, clockBias, _, err := eph.GetSatInfo(tx)
+		if err != nil {
+			continue
+		}
+		pos, _, clockBias, _, err := eph.GetSatInfo(tx.Add(-clockBias))
+		if err != nil {
+			continue
+		}
+		observations = append(observations, rangeObservation{
+			prn:       prn,
+			system:    system,
+			value:     value,
+			position:  pos,
+			clockBias: clockBias,
+			orbit:     EphemerisFile(eph),
+		})
+	}
+
+	sort.Slice(observations, func(i, j int) bool { return observations[i].prn < observations[j].prn })
+	return observations, nil
+}
+
+// sagnac rotates the satellite position by the Earth rotation during the
+// travel time of the signal to receiver.
+func sagnac(satellite, receiver []float64) []float64 {
+	theta := EARTH_ROTATION_RATE * rangeTo(satellite, receiver) / SPEED_OF_LIGHT
+	sin, cos := math.Sincos(theta)
+	return []float64{
+		cos*satellite[0] + sin*satellite[1],
+		-sin*satellite[0] + cos*satellite[1],
+		satellite[2],
+	}
+}
+
+func rangeTo(a, b []float64) float64 {
+	d := helpers.Subtract(a, b)
+	return math.Sqrt(d[0]*d[0] + d[1]*d[1] + d[2]*d[2])
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// leastSquares solves a x = b in the least squares sense and returns x and
+// the cofactor matrix (aᵀa)⁻¹.
+func leastSquares(a [][]float64, b []float64) ([]float64, [][]float64, error) {
+	n := len(a[0])
+	normal := make([][]float64, n)
+	for i := range normal {
+		normal[i] = make([]float64, n)
+		for j := range normal[i] {
+			for k := range a {
+				normal[i][j] += a[k][i] * a[k][j]
+			}
+		}
+	}
+	q, err := invert(normal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	atb := make([]float64, n)
+	for i := range atb {
+		for k := range a {
+			atb[i] += a[k][i] * b[k]
+		}
+	}
+	x := make([]float64, n)
+	for i := range x {
+		for j := range atb {
+			x[i] += q[i][j] * atb[j]
+		}
+	}
+	return x, q, nil
+}
+
+// invert inverts m by Gauss-Jordan elimination with partial pivoting.
+func invert(m [][]float64) ([][]float64, error) {
+	n := len(m)
+	work := make([][]float64, n)
+	for i := range work {
+		work[i] = make([]float64, 2*n)
+		copy(work[i], m[i])
+		work[i][n+i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(work[row][col]) > math.Abs(work[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(work[pivot][col]) < 1e-12 {
+			return nil, errors.New("singular geometry")
+		}
+		work[col], work[pivot] = work[pivot], work[col]
+
+		scale := work[col][col]
+		for j := range work[col] {
+			work[col][j] /= scale
+		}
+		for row := 0; row < n; row++ {
+			if row == col || work[row][col] == 0 {
+				continue
+			}
+			factor := work[row][col]
+			for j := range work[row] {
+				work[row][j] -= factor * work[col][j]
+			}
+		}
+	}
+
+	inverse := make([][]float64, n)
+	for i := range inverse {
+		inverse[i] = work[i][n:]
+	}
+	return inverse, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// LookAngle is the direction of a satellite seen from a receiver, angles in
+// degrees, azimuth clockwise from north.
+type LookAngle struct {
+	PRN        string    `json:"prn"`
+	Week       int       `json:"gpsWeek"`
+	TimeOfWeek float64   `json:"gpsTimeOfWeek"`
+	UTC        time.Time `json:"utc"`
+	Azimuth    float64   `json:"azimuth"`
+	Elevation  float64   `json:"elevation"`
+	Range      float64   `json:"range"`
+}
+
+// LookAngles returns the azimuth, elevation and range of positions (see
+// SatellitePositions) seen from receiver (ECEF m). Positions below
+// elevationMask (degrees) are left out.
+func LookAngles(receiver []float64, positions []SatellitePosition, elevationMask float64) []LookAngle {
+	var angles []LookAngle
+	for _, p := range positions {
+		azimuth, elevation, distance := helpers.AzimuthElevation(receiver, p.Position[:])
+		elevation *= 180 / math.Pi
+		if elevation < elevationMask {
+			continue
+		}
+		angles = append(angles, LookAngle{
+			PRN:        p.PRN,
+			Week:       p.Week,
+			TimeOfWeek: p.TimeOfWeek,
+			UTC:        p.UTC,
+			Azimuth:    azimuth * 180 / math.Pi,
+			Elevation:  elevation,
+			Range:      distance,
+		})
+	}
+	return angles
+}
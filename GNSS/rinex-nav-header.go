@@ -0,0 +1,244 @@
+package gnss
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  RINEX NAVIGATION HEADER CORRECTIONS
+// https://files.igs.org/pub/data/format/rinex211.pdf (Table A4, A10)
+// https://files.igs.org/pub/data/format/rinex_4.01.pdf (Table A5)
+// RINEX 2 header records are mapped onto their RINEX 3 equivalents:
+//   ION ALPHA / ION BETA       -> IONOSPHERIC CORR GPSA / GPSB
+//   DELTA-UTC: A0,A1,T,W       -> TIME SYSTEM CORR GPUT
+//   CORR TO SYSTEM TIME        -> TIME SYSTEM CORR GLUT (-TauC, reference date)
+//
+// RINEX 3 record layouts:
+//   IONOSPHERIC CORR  A4,1X,4D12.4,1X,A1,1X,I2   (GAL has 3 parameters)
+//   TIME SYSTEM CORR  A4,1X,D17.10,D16.9,1X,I6,1X,I4,1X,A5,1X,I2
+//   LEAP SECONDS      4I6,A3   (current, future, week, day, time system)
+
+type ionosphericRecord struct {
+	correctionType string
+	parameters     []float64
+	timeMark       string
+	satelliteId    string
+}
+
+type timeSystemRecord struct {
+	correctionType string
+	a0             float64
+	a1             float64
+	referenceTime  int
+	referenceWeek  int
+	referenceDate  time.Time
+	source         string
+	utcIdentifier  int
+}
+
+type navHeaderCorrections struct {
+	ionospheric []ionosphericRecord
+	timeSystem  []timeSystemRecord
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// parseLine handles the correction and leap second records, it reports
+// whether label was one of them. Malformed fields are left in record.err.
+func (c *navHeaderCorrections) parseLine(header RINEXHeader, label string, record *lineRecord) bool {
+	line := record.lines[0]
+	switch label {
+	case "ION ALPHA", "ION BETA":
+		correctionType := "GPSA"
+		if label == "ION BETA" {
+			correctionType = "GPSB"
+		}
+		parameters := make([]float64, 4)
+		for i := range parameters {
+			parameters[i] = record.float(0, 2+i*12, 14+i*12)
+		}
+		c.ionospheric = append(c.ionospheric, ionosphericRecord{correctionType: correctionType, parameters: parameters})
+	case "IONOSPHERIC CORR":
+		correctionType := strings.TrimSpace(column(line, 0, 4))
+		count := 4
+		if correctionType == "GAL" {
+			count = 3
+		}
+		parameters := make([]float64, count)
+		for i := range parameters {
+			parameters[i] = record.float(0, 5+i*12, 17+i*12)
+		}
+		c.ionospheric = append(c.ionospheric, ionosphericRecord{
+			correctionType: correctionType,
+			parameters:     parameters,
+			timeMark:       strings.TrimSpace(column(line, 54, 55)),
+			satelliteId:    strings.TrimSpace(column(line, 56, 59)),
+		})
+	case "DELTA-UTC: A0,A1,T,W":
+		c.timeSystem = append(c.timeSystem, timeSystemRecord{
+			correctionType: "GPUT",
+			a0:             record.float(0, 3, 22),
+			a1:             record.float(0, 22, 41),
+			referenceTime:  record.integer(0, 41, 50),
+			referenceWeek:  record.integer(0, 50, 59),
+		})
+	case "CORR TO SYSTEM TIME":
+		c.timeSystem = append(c.timeSystem, timeSystemRecord{
+			correctionType: "GLUT",
+			a0:             record.float(0, 21, 40),
+			referenceDate: time.Date(record.integer(0, 0, 6), time.Month(record.integer(0, 6, 12)),
+				record.integer(0, 12, 18), 0, 0, 0, 0, time.UTC),
+		})
+	case "TIME SYSTEM CORR":
+		c.timeSystem = append(c.timeSystem, timeSystemRecord{
+			correctionType: strings.TrimSpace(column(line, 0, 4)),
+			a0:             record.float(0, 5, 22),
+			a1:             record.float(0, 22, 38),
+			referenceTime:  record.integer(0, 38, 45),
+			referenceWeek:  record.integer(0, 45, 50),
+			source:         strings.TrimSpace(column(line, 51, 56)),
+			utcIdentifier:  record.integer(0, 57, 59),
+		})
+	case "LEAP SECONDS":
+		header.SetLeapSeconds(int32(record.integer(0, 0, 6)))
+		header.SetFutureLeapSeconds(int32(record.integer(0, 6, 12)))
+		header.SetLeapSecondsWeek(int32(record.integer(0, 12, 18)))
+		header.SetLeapSecondsDay(int32(record.integer(0, 18, 24)))
+		header.SetLeapSecondsTimeSystem(strings.TrimSpace(column(line, 24, 27)))
+	default:
+		return false
+	}
+	return true
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// set writes the collected records to header, called at END OF HEADER once
+// the list sizes are known.
+func (c *navHeaderCorrections) set(header RINEXHeader) error {
+	if err := setIonosphericCorrections(header, c.ionospheric); err != nil {
+		return err
+	}
+
+	timeSystem, err := header.NewTimeSystemCorrections(int32(len(c.timeSystem)))
+	if err != nil {
+		return fmt.Errorf("failed to create new TimeSystemCorrections: %v", err)
+	}
+	for i, r := range c.timeSystem {
+		corr := timeSystem.At(i)
+		corr.SetCorrectionType(r.correctionType)
+		corr.SetA0(r.a0)
+		corr.SetA1(r.a1)
+		corr.SetReferenceTime(int32(r.referenceTime))
+		corr.SetReferenceWeek(int32(r.referenceWeek))
+		corr.SetSource(r.source)
+		corr.SetUtcIdentifier(int32(r.utcIdentifier))
+		if !r.referenceDate.IsZero() {
+			date, err := corr.NewReferenceDate()
+			if err != nil {
+				return fmt.Errorf("failed to create new Time struct: %v", err)
+			}
+			date.SetSeconds(r.referenceDate.Unix())
+		}
+	}
+	return nil
+}
+
+func setIonosphericCorrections(header RINEXHeader, records []ionosphericRecord) error {
+	ionospheric, err := header.NewIonosphericCorrections(int32(len(records)))
+	if err != nil {
+		return fmt.Errorf("failed to create new IonosphericCorrections: %v", err)
+	}
+	for i, r := range records {
+		corr := ionospheric.At(i)
+		corr.SetCorrectionType(r.correctionType)
+		corr.SetTimeMark(r.timeMark)
+		corr.SetSatelliteId(r.satelliteId)
+		parameters, err := corr.NewParameters(int32(len(r.parameters)))
+		if err != nil {
+			return fmt.Errorf("failed to create new Parameters: %v", err)
+		}
+		for j, p := range r.parameters {
+			parameters.Set(j, p)
+		}
+	}
+	return nil
+}
+
+// addIonosphericCorrections appends the ION records of a RINEX 4 file to the
+// IONOSPHERIC CORR list of header.
+func addIonosphericCorrections(header RINEXHeader, records []ionosphericRecord) error {
+	var all []ionosphericRecord
+	if corrections, err := header.IonosphericCorrections(); err == nil {
+		for i := 0; i < corrections.Len(); i++ {
+			corr := corrections.At(i)
+			r := ionosphericRecord{}
+			r.correctionType, _ = corr.CorrectionType()
+			r.timeMark, _ = corr.TimeMark()
+			r.satelliteId, _ = corr.SatelliteId()
+			if list, err := corr.Parameters(); err == nil {
+				r.parameters = make([]float64, list.Len())
+				for j := range r.parameters {
+					r.parameters[j] = list.At(j)
+				}
+			}
+			all = append(all, r)
+		}
+	}
+	return setIonosphericCorrections(header, append(all, records...))
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// IonosphericCorrection returns the parameters of the first IONOSPHERIC CORR
+// record of correctionType (GPSA, GPSB, GAL, QZSA, BDSA, IRNA...), RINEX 2
+// ION ALPHA / ION BETA are found as GPSA / GPSB.
+func (h RINEXHeader) IonosphericCorrection(correctionType string) ([]float64, bool) {
+	corrections, err := h.IonosphericCorrections()
+	if err != nil {
+		return nil, false
+	}
+	for i := 0; i < corrections.Len(); i++ {
+		corr := corrections.At(i)
+		if t, _ := corr.CorrectionType(); t != correctionType {
+			continue
+		}
+		list, err := corr.Parameters()
+		if err != nil {
+			return nil, false
+		}
+		parameters := make([]float64, list.Len())
+		for j := range parameters {
+			parameters[j] = list.At(j)
+		}
+		return parameters, true
+	}
+	return nil, false
+}
+
+// TimeSystemCorrection returns the first TIME SYSTEM CORR record of
+// correctionType (GPUT, GAUT, GAGP, GLUT, BDUT...), RINEX 2 DELTA-UTC and
+// CORR TO SYSTEM TIME are found as GPUT and GLUT.
+func (h RINEXHeader) TimeSystemCorrection(correctionType string) (TimeSystemCorrection, bool) {
+	corrections, err := h.TimeSystemCorrections()
+	if err != nil {
+		return TimeSystemCorrection{}, false
+	}
+	for i := 0; i < corrections.Len(); i++ {
+		corr := corrections.At(i)
+		if t, _ := corr.CorrectionType(); t == correctionType {
+			return corr, true
+		}
+	}
+	return TimeSystemCorrection{}, false
+}
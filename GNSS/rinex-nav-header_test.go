@@ -0,0 +1,127 @@
+package gnss
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const navHeaderV3 = `     3.05           N: GNSS NAV DATA    M: Mixed            RINEX VERSION / TYPE
+BCEmerge            congo               20240731 003155 GMT PGM / RUN BY / DATE
+GAL    2.9250e+01  3.9062e-02  1.8768e-02                   IONOSPHERIC CORR
+GPSA   1.1176e-08  2.2352e-08 -5.9605e-08 -1.1921e-07       IONOSPHERIC CORR
+GPSB   9.6256e+04  1.3107e+05 -6.5536e+04 -5.2429e+05       IONOSPHERIC CORR
+BDSA   2.6077e-08  2.9802e-08 -4.1723e-07  6.5565e-07 A 01  IONOSPHERIC CORR
+GAUT -1.8626451492e-09-8.881784197e-16 259200 2325          TIME SYSTEM CORR
+GPUT -1.8626451492e-09-2.664535259e-15 319488 2325 IGS      TIME SYSTEM CORR
+    18    18  2185     7GPS                                 LEAP SECONDS
+                                                            END OF HEADER
+`
+
+const navHeaderV211 = `     2.11           N: GPS NAV DATA                         RINEX VERSION / TYPE
+teqc  2018Dec12     gpsops              20240731 00:17:01UTCPGM / RUN BY / DATE
+    0.1118D-07  0.2235D-07 -0.5960D-07 -0.1192D-06          ION ALPHA
+    0.9626D+05  0.1311D+06 -0.6554D+05 -0.5243D+06          ION BETA
+   -0.186264514923D-08-0.266453525910D-14   319488     2325 DELTA-UTC: A0,A1,T,W
+    18                                                      LEAP SECONDS
+                                                            END OF HEADER
+`
+
+// =========================================================================
+
+// =========================================================================
+
+func TestNavHeaderCorrectionsV3(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(navHeaderV3), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := nav.Header
+	for correctionType, want := range map[string][]float64{
+		"GAL":  {29.25, 3.9062e-02, 1.8768e-02},
+		"GPSA": {1.1176e-08, 2.2352e-08, -5.9605e-08, -1.1921e-07},
+		"GPSB": {9.6256e+04, 1.3107e+05, -6.5536e+04, -5.2429e+05},
+		"BDSA": {2.6077e-08, 2.9802e-08, -4.1723e-07, 6.5565e-07},
+	} {
+		if got, ok := header.IonosphericCorrection(correctionType); !ok || !reflect.DeepEqual(got, want) {
+			t.Errorf("%s %v %v, want %v", correctionType, got, ok, want)
+		}
+	}
+	if _, ok := header.IonosphericCorrection("QZSA"); ok {
+		t.Error("QZSA found")
+	}
+	corrections, _ := header.IonosphericCorrections()
+	bdsa := corrections.At(3)
+	if mark, _ := bdsa.TimeMark(); mark != "A" {
+		t.Errorf("BDSA time mark %q, want A", mark)
+	}
+	if id, _ := bdsa.SatelliteId(); id != "01" {
+		t.Errorf("BDSA satellite %q, want 01", id)
+	}
+
+	gput, ok := header.TimeSystemCorrection("GPUT")
+	if !ok {
+		t.Fatal("no GPUT")
+	}
+	source, _ := gput.Source()
+	if gput.A0() != -1.8626451492e-09 || gput.A1() != -2.664535259e-15 || gput.ReferenceTime() != 319488 ||
+		gput.ReferenceWeek() != 2325 || source != "IGS" {
+		t.Errorf("GPUT %v %v %d %d %q", gput.A0(), gput.A1(), gput.ReferenceTime(), gput.ReferenceWeek(), source)
+	}
+	if gaut, ok := header.TimeSystemCorrection("GAUT"); !ok || gaut.A1() != -8.881784197e-16 || gaut.ReferenceTime() != 259200 {
+		t.Errorf("GAUT %v %v %d", ok, gaut.A1(), gaut.ReferenceTime())
+	}
+
+	system, _ := header.LeapSecondsTimeSystem()
+	if header.LeapSeconds() != 18 || header.FutureLeapSeconds() != 18 || header.LeapSecondsWeek() != 2185 ||
+		header.LeapSecondsDay() != 7 || system != "GPS" {
+		t.Errorf("leap seconds %d %d %d %d %q", header.LeapSeconds(), header.FutureLeapSeconds(),
+			header.LeapSecondsWeek(), header.LeapSecondsDay(), system)
+	}
+}
+
+// RINEX 2 records are found under their RINEX 3 names.
+func TestNavHeaderCorrectionsV211(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(navHeaderV211), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := nav.Header
+	if got, ok := header.IonosphericCorrection("GPSA"); !ok || !reflect.DeepEqual(got, []float64{0.1118e-07, 0.2235e-07, -0.5960e-07, -0.1192e-06}) {
+		t.Errorf("GPSA %v %v", got, ok)
+	}
+	if got, ok := header.IonosphericCorrection("GPSB"); !ok || !reflect.DeepEqual(got, []float64{0.9626e+05, 0.1311e+06, -0.6554e+05, -0.5243e+06}) {
+		t.Errorf("GPSB %v %v", got, ok)
+	}
+	gput, ok := header.TimeSystemCorrection("GPUT")
+	if !ok || gput.A0() != -0.186264514923e-08 || gput.A1() != -0.266453525910e-14 ||
+		gput.ReferenceTime() != 319488 || gput.ReferenceWeek() != 2325 {
+		t.Errorf("GPUT %v %v %v %d %d", ok, gput.A0(), gput.A1(), gput.ReferenceTime(), gput.ReferenceWeek())
+	}
+	if header.LeapSeconds() != 18 {
+		t.Errorf("leap seconds %d, want 18", header.LeapSeconds())
+	}
+}
+
+func TestNavHeaderCorrectionsGLONASS(t *testing.T) {
+	nav, err := ParseNavFile(testFile(t, "brdc2050.24g"), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//   2024     7    23    0.931322574616D-09                    CORR TO SYSTEM TIME
+	glut, ok := nav.Header.TimeSystemCorrection("GLUT")
+	if !ok {
+		t.Fatal("no GLUT")
+	}
+	if glut.A0() != 0.931322574616e-09 {
+		t.Errorf("GLUT A0 %v", glut.A0())
+	}
+	date, err := glut.ReferenceDate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := time.Unix(date.Seconds(), 0).UTC(), time.Date(2024, 7, 23, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("GLUT reference date %v, want %v", got, want)
+	}
+}
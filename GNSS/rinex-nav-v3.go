@@ -0,0 +1,596 @@
+package gnss
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  MIXED NAVIGATION FORMAT (RINEX 3.0x / 4.0x)
+// https://files.igs.org/pub/data/format/rinex305.pdf
+// https://files.igs.org/pub/data/format/rinex_4.00.pdf
+//
+// RINEX 3 records start with the satellite id (G01, R05, E12, C30, J02, I05,
+// S27) in columns 1-3, BROADCAST ORBIT lines are indented by four blanks:
+// G01 2024 07 23 00 00 00 2.301931381226E-04-1.000444171950E-11 0.000000000000E+00
+//      7.700000000000E+01-1.056250000000E+01 4.436970516493E-09-1.583451245021E+00
+//
+// RINEX 4 wraps every record in a "> EPH G01 LNAV" line naming the message
+// type. ION records ("> ION E08 IFNV") are returned as IONOSPHERIC CORR
+// records, STO and EOP records are skipped.
+//
+// Lines per record (SV/EPOCH line included):
+//   LNAV, INAV, FNAV, D1, D2     8
+//   CNAV, CNV3                   9
+//   CNV1, CNV2                  10
+//   FDMA                         4 (5 from RINEX 3.05 on)
+//   SBAS                         4
+
+// NavigationData holds every ephemeris of a navigation file, split per
+// satellite system. QZSS and NavIC broadcast GPS style Keplerian records and
+// are kept as GPSEphemeris, their PRN carries the J / I system letter.
+// Warnings lists the malformed fields and records of a lenient parse.
+// File is the root of the single message a parsed file is read into, it
+// holds the header and every record, the slices refer to its list elements.
+type NavigationData struct {
+	File     NavFile
+	Header   RINEXHeader
+	GPS      []GPSEphemeris
+	GLONASS  []RINEXEphemeris
+	Galileo  []GalileoEphemeris
+	BeiDou   []BeiDouEphemeris
+	QZSS     []GPSEphemeris
+	NavIC    []GPSEphemeris
+	SBAS     []SBASEphemeris
+	Warnings []*ParseError
+}
+
+// ParseRINEXNavFileV3 reads a RINEX 3/4 navigation file, see ParseNav for
+// the reader based, configurable variant.
+func ParseRINEXNavFileV3(filename string) (*NavigationData, error) {
+	nav, err := ParseNavFile(filename, ParseOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if nav.Header.Version() < 3 {
+		return nil, fmt.Errorf("unsupported RINEX version %.2f, expected 3.xx or 4.xx", nav.Header.Version())
+	}
+	return nav, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func parseMixedEphemeris(scanner *lineScanner, version float64) ([]navRecord, []ionosphericRecord, error) {
+	var records []navRecord
+	var ionospheric []ionosphericRecord
+	record := scanner.newRecord()
+	messageType := NavMessageType_unknown
+	skip := false
+	// satellite and message of the current ION record
+	var ion []string
+
+	flush := func() error {
+		if record.len() == 0 {
+			return nil
+		}
+		current := record
+		record = scanner.newRecord()
+		if ion != nil {
+			r, err := decodeIonosphericRecord(current, ion[0], ion[1])
+			if err != nil {
+				return scanner.recordError(current, err)
+			}
+			ionospheric = append(ionospheric, r...)
+			return nil
+		}
+		r, err := decodeNavRecord(current, version, messageType)
+		if err != nil {
+			return scanner.recordError(current, err)
+		}
+		records = append(records, r)
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if line[0] == '>' {
+			if err := flush(); err != nil {
+				return nil, nil, err
+			}
+			fields := strings.Fields(line[1:])
+			skip = len(fields) < 3 || (fields[0] != "EPH" && fields[0] != "ION")
+			ion = nil
+			if !skip && fields[0] == "ION" {
+				ion = fields[1:3]
+			} else if !skip {
+				messageType = NavMessageTypeFromString(strings.ToLower(fields[2]))
+			}
+			continue
+		}
+		if skip {
+			continue
+		}
+
+		if line[0] != ' ' {
+			if err := flush(); err != nil {
+				return nil, nil, err
+			}
+		}
+		record.add(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, nil, err
+	}
+	return records, ionospheric, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// RINEX 4 ION records, an epoch line then the parameters in the BROADCAST
+// ORBIT layout:
+//
+//	IFNV (Galileo)            ai0, ai1, ai2, disturbance flags
+//	LNAV, D1D2 (Klobuchar)    alpha0-3, beta0-3, region code
+//	CNVX (BDGIM)              alpha1-9
+//
+// They map to the IONOSPHERIC CORR types of RINEX 3 (GAL, GPSA / GPSB...),
+// BDGIM to the type BDGM which RINEX 3 lacks, other messages are skipped.
+func decodeIonosphericRecord(record *lineRecord, satellite, message string) ([]ionosphericRecord, error) {
+	if len(record.lines[0]) < 23 {
+		return nil, fmt.Errorf("line 1 is too short: %d characters", len(record.lines[0]))
+	}
+	values := record.orbitValues(4)
+	if record.err != nil {
+		return nil, record.err
+	}
+
+	// the header records carry the satellite number only
+	id := satellite[1:]
+	switch message {
+	case "IFNV":
+		return []ionosphericRecord{{correctionType: "GAL", parameters: values[:3], satelliteId: id}}, nil
+	case "LNAV", "D1D2":
+		types, ok := klobucharCorrections[satellite[0]]
+		if !ok {
+			return nil, nil
+		}
+		if len(values) < 8 {
+			return nil, fmt.Errorf("invalid number of lines for ION %s record: %d", message, record.len())
+		}
+		return []ionosphericRecord{
+			{correctionType: types[0], parameters: values[0:4], satelliteId: id},
+			{correctionType: types[1], parameters: values[4:8], satelliteId: id},
+		}, nil
+	case "CNVX":
+		if satellite[0] != 'C' {
+			return nil, nil
+		}
+		if len(values) < bdgimCoefficientCount {
+			return nil, fmt.Errorf("invalid number of lines for ION %s record: %d", message, record.len())
+		}
+		return []ionosphericRecord{{correctionType: "BDGM", parameters: values[:bdgimCoefficientCount], satelliteId: id}}, nil
+	}
+	return nil, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func navRecordLines(messageType NavMessageType, version float64) int {
+	switch messageType {
+	case NavMessageType_cnav, NavMessageType_cnv3:
+		return 9
+	case NavMessageType_cnv1, NavMessageType_cnv2:
+		return 10
+	case NavMessageType_fdma:
+		if version >= 3.05 {
+			return 5
+		}
+		return 4
+	case NavMessageType_sbas:
+		return 4
+	default:
+		return 8
+	}
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// RINEX 3 records carry no message type, it follows from the system (and
+// for BeiDou from the PRN: GEO satellites broadcast D2).
+func defaultNavMessageType(system byte, svId int) NavMessageType {
+	switch system {
+	case 'R':
+		return NavMessageType_fdma
+	case 'S':
+		return NavMessageType_sbas
+	case 'E':
+		return NavMessageType_inav
+	case 'C':
+		if svId <= 5 || svId >= 59 {
+			return NavMessageType_d2
+		}
+		return NavMessageType_d1
+	default:
+		return NavMessageType_lnav
+	}
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// decodeNavRecord checks the layout of a record and reads its fields, the
+// ephemeris is filled once the whole file is read (see newNavigationData).
+func decodeNavRecord(record *lineRecord, version float64, messageType NavMessageType) (navRecord, error) {
+	lines := record.lines
+	if len(lines[0]) < 23 {
+		return navRecord{}, fmt.Errorf("line 1 is too short: %d characters", len(lines[0]))
+	}
+
+	system := lines[0][0]
+	if !strings.ContainsRune("GJIERCS", rune(system)) {
+		return navRecord{}, fmt.Errorf("unsupported satellite system %q", system)
+	}
+	var svId int
+	var epoch epochFields
+	record.decode(0, navEpochV3, append([]any{&svId}, epoch.dst()...)...)
+	if record.err != nil {
+		return navRecord{}, record.err
+	}
+	if messageType == NavMessageType_unknown {
+		messageType = defaultNavMessageType(system, svId)
+	}
+	if expected := navRecordLines(messageType, version); len(lines) != expected {
+		return navRecord{}, fmt.Errorf("invalid number of lines for %s %s record: %d, expected %d", lines[0][:3], messageType, len(lines), expected)
+	}
+
+	epochTime := epoch.time()
+	values := record.orbitValues(4)
+	if record.err != nil {
+		return navRecord{}, record.err
+	}
+	return navRecord{system: system, svId: svId, epoch: epochTime, messageType: messageType, values: values}, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// GPS / QZSS CNAV and CNAV-2 (RINEX 4):
+//
+//	ORBIT 1: ADOT, Crs, Delta n0, M0
+//	ORBIT 3: top, Cic, OMEGA0, Cis (toe equals toc)
+//	ORBIT 5: IDOT, Delta n0 dot, URAI NED0, URAI NED1
+//	ORBIT 6: URAI ED, SV health, TGD, URAI NED2
+//	ORBIT 7: ISC L1CA, ISC L2C, ISC L5I5, ISC L5Q5
+//	ORBIT 8: (CNV2 only) ISC L1Cd, ISC L1Cp
+//	last   : transmission time, week
+func fillGPSCivilEphemeris(eph GPSEphemeris, prn string, svId int, epochTime time.Time, messageType NavMessageType, v []float64) error {
+	data, err := eph.NewEphemerisData()
+	if err != nil {
+		return fmt.Errorf("failed to create new Ephemeris: %v", err)
+	}
+	fillKeplerEphemeris(data, svId, epochTime, v)
+
+	tocTime := GPSTimeFromDateTime(epochTime)
+	data.SetIode(0)
+	data.SetToe(tocTime.TimeOfWeek())
+	data.SetToc(tocTime.TimeOfWeek())
+	data.SetToeWeek(uint16(tocTime.Week()))
+	data.SetTocWeek(uint16(tocTime.Week()))
+	data.SetSvAcc(v[23])
+	data.SetSvHealth(v[24])
+	data.SetTgd(v[25])
+
+	iscCount := 4
+	last := 31
+	if messageType == NavMessageType_cnv2 {
+		iscCount = 6
+		last = 35
+	}
+	data.SetTransmissionTime(v[last])
+	data.SetTowCount(uint32(v[last]))
+
+	isc, err := eph.NewInterSignalCorrections(int32(iscCount))
+	if err != nil {
+		return fmt.Errorf("failed to create new inter signal corrections: %v", err)
+	}
+	for i := 0; i < iscCount; i++ {
+		isc.Set(i, v[27+i])
+	}
+
+	toe, err := eph.NewToe()
+	if err != nil {
+		return fmt.Errorf("failed to create new toe: %v", err)
+	}
+	setGPSTime(toe, tocTime)
+	toc, err := eph.NewToc()
+	if err != nil {
+		return fmt.Errorf("failed to create new toc: %v", err)
+	}
+	setGPSTime(toc, tocTime)
+
+	eph.SetSquareRootOfSemiMajorAxis(v[10])
+	eph.SetMessageType(messageType)
+	eph.SetADot(v[3])
+	eph.SetDeltaNDot(v[20])
+
+	base, err := eph.NewBaseEphemeris()
+	if err != nil {
+		return fmt.Errorf("failed to create new BaseEphemeris: %v", err)
+	}
+	return setBaseEphemeris(base, prn, tocTime, v[24] == 0)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// Galileo I/NAV and F/NAV:
+//
+//	ORBIT 1: IODnav, Crs, Delta n, M0
+//	ORBIT 5: IDOT, data sources, GAL week (aligned to GPS week), spare
+//	ORBIT 6: SISA (m), SV health, BGD E5a/E1, BGD E5b/E1
+//	ORBIT 7: transmission time
+//
+// Data sources bit 1 marks F/NAV, bits 0 and 2 I/NAV.
+func fillGalileoEphemeris(eph GalileoEphemeris, prn string, svId int, epochTime time.Time, messageType NavMessageType, v []float64) error {
+	data, err := eph.NewEphemerisData()
+	if err != nil {
+		return fmt.Errorf("failed to create new Ephemeris: %v", err)
+	}
+	fillKeplerEphemeris(data, svId, epochTime, v)
+
+	dataSources := uint16(v[20])
+	if dataSources&0x2 != 0 {
+		messageType = NavMessageType_fnav
+	}
+	week := int(v[21])
+	data.SetSvAcc(v[23])
+	data.SetSvHealth(v[24])
+	data.SetTgd(v[25])
+	data.SetTransmissionTime(v[27])
+	data.SetTowCount(uint32(v[27]))
+
+	// Galileo system time is steered to GPS time, the week numbers in RINEX
+	// are already aligned
+	tocTime := GPSTimeFromDateTime(epochTime)
+	data.SetToc(tocTime.TimeOfWeek())
+	data.SetTocWeek(uint16(tocTime.Week()))
+	data.SetToeWeek(uint16(week))
+
+	toe, err := eph.NewToe()
+	if err != nil {
+		return fmt.Errorf("failed to create new toe: %v", err)
+	}
+	toe.SetWeek(int32(week))
+	toe.SetTimeOfWeek(v[11])
+	toc, err := eph.NewToc()
+	if err != nil {
+		return fmt.Errorf("failed to create new toc: %v", err)
+	}
+	setGPSTime(toc, tocTime)
+
+	eph.SetSquareRootOfSemiMajorAxis(v[10])
+	eph.SetMessageType(messageType)
+	eph.SetDataSources(dataSources)
+	eph.SetSisa(v[23])
+	eph.SetBgdE5aE1(v[25])
+	eph.SetBgdE5bE1(v[26])
+
+	base, err := eph.NewBaseEphemeris()
+	if err != nil {
+		return fmt.Errorf("failed to create new BaseEphemeris: %v", err)
+	}
+	return setBaseEphemeris(base, prn, tocTime, v[24] == 0)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// BeiDou D1 / D2 (epoch and toe in BDT):
+//
+//	ORBIT 1: AODE, Crs, Delta n, M0
+//	ORBIT 5: IDOT, spare, BDT week, spare
+//	ORBIT 6: SV accuracy, SatH1, TGD1 B1/B3, TGD2 B2/B3
+//	ORBIT 7: transmission time, AODC
+//
+// BeiDou CNV1 / CNV2 / CNV3 (RINEX 4, no week number in the record):
+//
+//	ORBIT 1: ADOT, Crs, Delta n0, M0
+//	ORBIT 5: IDOT, Delta n0 dot, SatType, top
+//	CNV1/2 ORBIT 7: ISC, ISC, TGD B1Cp, TGD B2ap
+//	CNV1/2 ORBIT 8: SISMAI, health, integrity flags, IODC
+//	CNV1/2 ORBIT 9: transmission time, spare, spare, IODE
+//	CNV3   ORBIT 7: SISMAI, health, integrity flags, TGD B2bI
+//	CNV3   ORBIT 8: transmission time
+//
+// toe / toc are stored in GPS time, Ephemeris keeps the BDT values of the file.
+func fillBeiDouEphemeris(eph BeiDouEphemeris, prn string, svId int, epochTime time.Time, messageType NavMessageType, v []float64) error {
+	data, err := eph.NewEphemerisData()
+	if err != nil {
+		return fmt.Errorf("failed to create new Ephemeris: %v", err)
+	}
+	fillKeplerEphemeris(data, svId, epochTime, v)
+
+	tocBDT := GPSTimeFromDateTime(epochTime)
+	bdtWeek := int(tocBDT.Week()) - BDT_GPS_WEEK_OFFSET
+	var health, ttm float64
+
+	switch messageType {
+	case NavMessageType_cnv1, NavMessageType_cnv2:
+		data.SetIode(v[38])
+		data.SetIodc(v[34])
+		eph.SetAode(v[38])
+		eph.SetAodc(v[34])
+		eph.SetTgd1(v[29])
+		eph.SetTgd2(v[30])
+		health, ttm = v[32], v[35]
+	case NavMessageType_cnv3:
+		data.SetIode(0)
+		eph.SetTgd2(v[30])
+		health, ttm = v[28], v[31]
+	default:
+		bdtWeek = int(v[21])
+		data.SetSvAcc(v[23])
+		data.SetTgd(v[25])
+		data.SetIodc(v[28])
+		eph.SetAode(v[3])
+		eph.SetAodc(v[28])
+		eph.SetTgd1(v[25])
+		eph.SetTgd2(v[26])
+		health, ttm = v[24], v[27]
+	}
+	if messageType == NavMessageType_cnv1 || messageType == NavMessageType_cnv2 || messageType == NavMessageType_cnv3 {
+		eph.SetADot(v[3])
+		eph.SetDeltaNDot(v[20])
+	}
+	data.SetSvHealth(health)
+	data.SetTransmissionTime(ttm)
+	data.SetTowCount(uint32(ttm))
+	data.SetToc(tocBDT.TimeOfWeek())
+	data.SetTocWeek(uint16(bdtWeek))
+	data.SetToeWeek(uint16(bdtWeek))
+
+	tocTime := tocBDT.Add(BDT_GPS_SECONDS_OFFSET)
+	toeTime := GPSTimeFromWeek(bdtWeek+BDT_GPS_WEEK_OFFSET, v[11]).Add(BDT_GPS_SECONDS_OFFSET)
+
+	toe, err := eph.NewToe()
+	if err != nil {
+		return fmt.Errorf("failed to create new toe: %v", err)
+	}
+	setGPSTime(toe, toeTime)
+	toc, err := eph.NewToc()
+	if err != nil {
+		return fmt.Errorf("failed to create new toc: %v", err)
+	}
+	setGPSTime(toc, tocTime)
+
+	eph.SetSquareRootOfSemiMajorAxis(v[10])
+	eph.SetMessageType(messageType)
+
+	base, err := eph.NewBaseEphemeris()
+	if err != nil {
+		return fmt.Errorf("failed to create new BaseEphemeris: %v", err)
+	}
+	return setBaseEphemeris(base, prn, tocTime, health == 0)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// GLONASS FDMA, same fields as the RINEX 2 record plus, from RINEX 3.05 on,
+// ORBIT 4: status flags, L1/L2 group delay difference, URAI, health flags.
+// The epoch is UTC.
+func fillGLONASSEphemeris(eph RINEXEphemeris, svId int, epochTime time.Time, v []float64) error {
+	eph.SetSatelliteId(int32(svId))
+
+	epoch, err := eph.NewEpoch()
+	if err != nil {
+		return fmt.Errorf("failed to create new Time: %v", err)
+	}
+	epoch.SetSeconds(epochTime.Unix())
+	epoch.SetNanoseconds(int32(epochTime.Nanosecond()))
+
+	eph.SetClockBias(v[0])
+	eph.SetRelativeFrequencyBias(v[1])
+	eph.SetMessageFrameTime(v[2])
+
+	eph.SetPositionX(v[3])
+	eph.SetVelocityX(v[4])
+	eph.SetAccelerationX(v[5])
+	eph.SetHealth(v[6])
+
+	eph.SetPositionY(v[7])
+	eph.SetVelocityY(v[8])
+	eph.SetAccelerationY(v[9])
+	eph.SetFrequencyChannelOffset(int32(v[10]))
+
+	eph.SetPositionZ(v[11])
+	eph.SetVelocityZ(v[12])
+	eph.SetAccelerationZ(v[13])
+	eph.SetInformationAge(v[14])
+
+	if len(v) >= 19 {
+		eph.SetStatusFlags(v[15])
+		eph.SetGroupDelayDifference(v[16])
+		eph.SetUrai(v[17])
+		eph.SetHealthFlags(v[18])
+	}
+
+	tb, err := eph.glonassEpoch()
+	if err != nil {
+		return err
+	}
+	base, err := eph.NewBaseEphemeris()
+	if err != nil {
+		return fmt.Errorf("failed to create new BaseEphemeris: %v", err)
+	}
+	return setBaseEphemeris(base, fmt.Sprintf("R%02d", svId), tb, v[6] == 0)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// SBAS (epoch in GPS time):
+//
+//	SV / EPOCH: aGf0, aGf1, transmission time
+//	ORBIT 1: X (km), X velocity, X acceleration, health
+//	ORBIT 2: Y (km), Y velocity, Y acceleration, accuracy code (URA)
+//	ORBIT 3: Z (km), Z velocity, Z acceleration, IODN
+func fillSBASEphemeris(eph SBASEphemeris, prn string, svId int, epochTime time.Time, v []float64) error {
+	eph.SetSatelliteId(int32(svId))
+
+	tocTime := GPSTimeFromDateTime(epochTime)
+	toc, err := eph.NewToc()
+	if err != nil {
+		return fmt.Errorf("failed to create new toc: %v", err)
+	}
+	setGPSTime(toc, tocTime)
+
+	eph.SetClockBias(v[0])
+	eph.SetRelativeFrequencyBias(v[1])
+	eph.SetTransmissionTime(v[2])
+
+	eph.SetPositionX(v[3])
+	eph.SetVelocityX(v[4])
+	eph.SetAccelerationX(v[5])
+	eph.SetHealth(v[6])
+
+	eph.SetPositionY(v[7])
+	eph.SetVelocityY(v[8])
+	eph.SetAccelerationY(v[9])
+	eph.SetUraIndex(v[10])
+
+	eph.SetPositionZ(v[11])
+	eph.SetVelocityZ(v[12])
+	eph.SetAccelerationZ(v[13])
+	eph.SetIodn(v[14])
+
+	base, err := eph.NewBaseEphemeris()
+	if err != nil {
+		return fmt.Errorf("failed to create new BaseEphemeris: %v", err)
+	}
+	return setBaseEphemeris(base, prn, tocTime, v[6] == 0)
+}
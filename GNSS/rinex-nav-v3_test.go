@@ -0,0 +1,363 @@
+package gnss
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// G05 of abpo2120.24n, a Galileo and a BeiDou record sharing its orbit and
+// R01 of brdc2050.24g
+const mixedNavV3 = `     3.04           N: GNSS NAV DATA    M: MIXED            RINEX VERSION / TYPE
+GAL    1.0000E+02  2.0000E-01  3.0000E-03  0.0000E+00       IONOSPHERIC CORR
+                                                            END OF HEADER
+G05 2024 07 29 23 59 44-1.814444549382E-04-1.250555214938E-12 0.000000000000E+00
+     1.600000000000E+01-1.039375000000E+02 4.031239345871E-09-2.266875691854E+00
+    -5.450099706650E-06 5.963351577520E-03 7.448717951775E-06 5.153655038834E+03
+     1.727840000000E+05 7.264316082001E-08 1.893942802914E+00 3.166496753693E-08
+     9.721468054966E-01 2.340000000000E+02 1.298159075228E+00-7.757823144473E-09
+     2.596536727606E-10 1.000000000000E+00 2.325000000000E+03 0.000000000000E+00
+     2.000000000000E+00 0.000000000000E+00-1.071020960808E-08 1.600000000000E+01
+     1.656180000000E+05 4.000000000000E+00
+E05 2024 07 29 23 59 44-1.814444549382E-04-1.250555214938E-12 0.000000000000E+00
+     1.600000000000E+01-1.039375000000E+02 4.031239345871E-09-2.266875691854E+00
+    -5.450099706650E-06 5.963351577520E-03 7.448717951775E-06 5.153655038834E+03
+     1.727840000000E+05 7.264316082001E-08 1.893942802914E+00 3.166496753693E-08
+     9.721468054966E-01 2.340000000000E+02 1.298159075228E+00-7.757823144473E-09
+     2.596536727606E-10 5.170000000000E+02 2.325000000000E+03 0.000000000000E+00
+     3.120000000000E+00 0.000000000000E+00-1.000000000000E-09-1.100000000000E-09
+     1.656180000000E+05
+C19 2024 07 29 23 59 30-1.814444549382E-04-1.250555214938E-12 0.000000000000E+00
+     1.000000000000E+00-1.039375000000E+02 4.031239345871E-09-2.266875691854E+00
+    -5.450099706650E-06 5.963351577520E-03 7.448717951775E-06 5.153655038834E+03
+     1.727700000000E+05 7.264316082001E-08 1.893942802914E+00 3.166496753693E-08
+     9.721468054966E-01 2.340000000000E+02 1.298159075228E+00-7.757823144473E-09
+     2.596536727606E-10 0.000000000000E+00 9.690000000000E+02 0.000000000000E+00
+     2.000000000000E+00 0.000000000000E+00 1.200000000000E-08-3.000000000000E-09
+     1.656040000000E+05 1.000000000000E+00
+R01 2024 07 23 00 15 00 9.220279753210E-05 9.094947017730E-13 0.000000000000E+00
+     1.477649560550E+04 1.511134147640E+00 0.000000000000E+00 0.000000000000E+00
+    -1.594140185550E+04-1.039849281310E+00 1.862645149230E-09 1.000000000000E+00
+     1.335883056640E+04-2.910436630250E+00-1.862645149230E-09 0.000000000000E+00
+`
+
+// the G05 record and a Galileo ION record of RINEX 4, the STO record is
+// skipped
+const navV4 = `     4.00           N: GNSS NAV DATA    M: MIXED            RINEX VERSION / TYPE
+                                                            END OF HEADER
+> EPH G05 LNAV
+G05 2024 07 29 23 59 44-1.814444549382E-04-1.250555214938E-12 0.000000000000E+00
+     1.600000000000E+01-1.039375000000E+02 4.031239345871E-09-2.266875691854E+00
+    -5.450099706650E-06 5.963351577520E-03 7.448717951775E-06 5.153655038834E+03
+     1.727840000000E+05 7.264316082001E-08 1.893942802914E+00 3.166496753693E-08
+     9.721468054966E-01 2.340000000000E+02 1.298159075228E+00-7.757823144473E-09
+     2.596536727606E-10 1.000000000000E+00 2.325000000000E+03 0.000000000000E+00
+     2.000000000000E+00 0.000000000000E+00-1.071020960808E-08 1.600000000000E+01
+     1.656180000000E+05 4.000000000000E+00
+> STO G05 LNAV
+    2024 07 29 23 59 44 GPUT     UTC(USNO)
+     1.656180000000E+05 0.000000000000E+00 0.000000000000E+00 0.000000000000E+00
+> ION E05 IFNV
+    2024 07 29 23 50 00 9.575000000000E+01 3.906250000000E-02 1.281738281250E-02
+     0.000000000000E+00
+`
+
+// one record of every other RINEX 4 message, the Keplerian ones on the orbit
+// of G05
+const navV4Messages = `     4.00           N: GNSS NAV DATA    M: MIXED            RINEX VERSION / TYPE
+                                                            END OF HEADER
+> EPH G10 CNAV
+G10 2024 07 30 00 00 00-1.814444549382E-04-1.250555214938E-12 0.000000000000E+00
+     1.500000000000E-02-1.039375000000E+02 4.031239345871E-09-2.266875691854E+00
+    -5.450099706650E-06 5.963351577520E-03 7.448717951775E-06 5.153655038834E+03
+     1.656000000000E+05 7.264316082001E-08 1.893942802914E+00 3.166496753693E-08
+     9.721468054966E-01 2.340000000000E+02 1.298159075228E+00-7.757823144473E-09
+     2.596536727606E-10-2.000000000000E-14 1.000000000000E+00 0.000000000000E+00
+     2.000000000000E+00 0.000000000000E+00-1.100000000000E-08 0.000000000000E+00
+     1.000000000000E-10 2.000000000000E-10 3.000000000000E-10 4.000000000000E-10
+     1.656180000000E+05 2.325000000000E+03
+> EPH G11 CNV2
+G11 2024 07 30 00 00 00-1.814444549382E-04-1.250555214938E-12 0.000000000000E+00
+    -2.500000000000E-02-1.039375000000E+02 4.031239345871E-09-2.266875691854E+00
+    -5.450099706650E-06 5.963351577520E-03 7.448717951775E-06 5.153655038834E+03
+     1.656000000000E+05 7.264316082001E-08 1.893942802914E+00 3.166496753693E-08
+     9.721468054966E-01 2.340000000000E+02 1.298159075228E+00-7.757823144473E-09
+     2.596536727606E-10-2.000000000000E-14 1.000000000000E+00 0.000000000000E+00
+     2.000000000000E+00 0.000000000000E+00-1.200000000000E-08 0.000000000000E+00
+     1.000000000000E-10 2.000000000000E-10 3.000000000000E-10 4.000000000000E-10
+     5.000000000000E-10 6.000000000000E-10
+     1.656240000000E+05 2.325000000000E+03
+> EPH E07 FNAV
+E07 2024 07 30 00 00 00-1.814444549382E-04-1.250555214938E-12 0.000000000000E+00
+     3.000000000000E+01-1.039375000000E+02 4.031239345871E-09-2.266875691854E+00
+    -5.450099706650E-06 5.963351577520E-03 7.448717951775E-06 5.153655038834E+03
+     1.728000000000E+05 7.264316082001E-08 1.893942802914E+00 3.166496753693E-08
+     9.721468054966E-01 2.340000000000E+02 1.298159075228E+00-7.757823144473E-09
+     2.596536727606E-10 2.580000000000E+02 2.325000000000E+03 0.000000000000E+00
+     3.120000000000E+00 0.000000000000E+00-1.000000000000E-09 0.000000000000E+00
+     1.723200000000E+05
+> EPH C06 D1
+C06 2024 07 29 23 59 30-1.814444549382E-04-1.250555214938E-12 0.000000000000E+00
+     1.000000000000E+00-1.039375000000E+02 4.031239345871E-09-2.266875691854E+00
+    -5.450099706650E-06 5.963351577520E-03 7.448717951775E-06 5.153655038834E+03
+     1.727700000000E+05 7.264316082001E-08 1.893942802914E+00 3.166496753693E-08
+     9.721468054966E-01 2.340000000000E+02 1.298159075228E+00-7.757823144473E-09
+     2.596536727606E-10 0.000000000000E+00 9.690000000000E+02 0.000000000000E+00
+     2.000000000000E+00 0.000000000000E+00 1.200000000000E-08-3.000000000000E-09
+     1.727040000000E+05 1.000000000000E+00
+> EPH C01 D2
+C01 2024 07 29 23 59 30-1.814444549382E-04-1.250555214938E-12 0.000000000000E+00
+     2.000000000000E+00-1.039375000000E+02 4.031239345871E-09-2.266875691854E+00
+    -5.450099706650E-06 5.963351577520E-03 7.448717951775E-06 5.153655038834E+03
+     1.727700000000E+05 7.264316082001E-08 1.893942802914E+00 3.166496753693E-08
+     9.721468054966E-01 2.340000000000E+02 1.298159075228E+00-7.757823144473E-09
+     2.596536727606E-10 0.000000000000E+00 9.690000000000E+02 0.000000000000E+00
+     2.000000000000E+00 0.000000000000E+00 2.200000000000E-08-4.000000000000E-09
+     1.727050000000E+05 3.000000000000E+00
+> EPH C25 CNV1
+C25 2024 07 30 00 00 00-1.814444549382E-04-1.250555214938E-12 0.000000000000E+00
+     2.500000000000E-01-1.039375000000E+02 4.031239345871E-09-2.266875691854E+00
+    -5.450099706650E-06 5.963351577520E-03 7.448717951775E-06 5.153655038834E+03
+     1.728000000000E+05 7.264316082001E-08 1.893942802914E+00 3.166496753693E-08
+     9.721468054966E-01 2.340000000000E+02 1.298159075228E+00-7.757823144473E-09
+     2.596536727606E-10-3.000000000000E-14 3.000000000000E+00 1.728000000000E+05
+     0.000000000000E+00 0.000000000000E+00 0.000000000000E+00 0.000000000000E+00
+     1.000000000000E-10 2.000000000000E-10 3.000000000000E-09 4.000000000000E-09
+     1.000000000000E+00 0.000000000000E+00 0.000000000000E+00 7.000000000000E+00
+     1.727400000000E+05                                       7.000000000000E+00
+> EPH C26 CNV2
+C26 2024 07 30 00 00 00-1.814444549382E-04-1.250555214938E-12 0.000000000000E+00
+     5.000000000000E-01-1.039375000000E+02 4.031239345871E-09-2.266875691854E+00
+    -5.450099706650E-06 5.963351577520E-03 7.448717951775E-06 5.153655038834E+03
+     1.728000000000E+05 7.264316082001E-08 1.893942802914E+00 3.166496753693E-08
+     9.721468054966E-01 2.340000000000E+02 1.298159075228E+00-7.757823144473E-09
+     2.596536727606E-10-3.000000000000E-14 3.000000000000E+00 1.728000000000E+05
+     0.000000000000E+00 0.000000000000E+00 0.000000000000E+00 0.000000000000E+00
+     1.000000000000E-10 2.000000000000E-10 5.000000000000E-09 6.000000000000E-09
+     1.000000000000E+00 0.000000000000E+00 0.000000000000E+00 9.000000000000E+00
+     1.727460000000E+05                                       9.000000000000E+00
+> EPH C27 CNV3
+C27 2024 07 30 00 00 00-1.814444549382E-04-1.250555214938E-12 0.000000000000E+00
+     7.500000000000E-01-1.039375000000E+02 4.031239345871E-09-2.266875691854E+00
+    -5.450099706650E-06 5.963351577520E-03 7.448717951775E-06 5.153655038834E+03
+     1.728000000000E+05 7.264316082001E-08 1.893942802914E+00 3.166496753693E-08
+     9.721468054966E-01 2.340000000000E+02 1.298159075228E+00-7.757823144473E-09
+     2.596536727606E-10-3.000000000000E-14 3.000000000000E+00 1.728000000000E+05
+     0.000000000000E+00 0.000000000000E+00 0.000000000000E+00 0.000000000000E+00
+     1.000000000000E+00 0.000000000000E+00 0.000000000000E+00-5.000000000000E-09
+     1.727520000000E+05
+> EPH R02 FDMA
+R02 2024 07 23 00 15 00-9.220279753210E-05 0.000000000000E+00 1.800000000000E+03
+     1.477649560550E+04 1.511134147640E+00 0.000000000000E+00 0.000000000000E+00
+    -1.594140185550E+04-1.039849281310E+00 1.862645149230E-09-4.000000000000E+00
+     1.335883056640E+04-2.910436630250E+00-1.862645149230E-09 0.000000000000E+00
+     1.000000000000E+00-2.000000000000E-09 1.000000000000E+00 0.000000000000E+00
+> EPH S27 SBAS
+S27 2024 07 30 00 00 00-1.000000000000E-08 0.000000000000E+00 1.728000000000E+05
+     4.216400000000E+04 0.000000000000E+00 0.000000000000E+00 0.000000000000E+00
+     0.000000000000E+00 0.000000000000E+00 0.000000000000E+00 3.276700000000E+04
+     0.000000000000E+00 0.000000000000E+00 0.000000000000E+00 1.200000000000E+01
+`
+
+// =========================================================================
+
+// =========================================================================
+
+func TestParseNavMixedV3(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(mixedNavV3), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nav.GPS) != 1 || len(nav.Galileo) != 1 || len(nav.BeiDou) != 1 || len(nav.GLONASS) != 1 {
+		t.Fatalf("%d GPS, %d Galileo, %d BeiDou, %d GLONASS records, want 1 of each",
+			len(nav.GPS), len(nav.Galileo), len(nav.BeiDou), len(nav.GLONASS))
+	}
+	for _, c := range []struct{ got, want string }{
+		{nav.GPS[0].PRN(), "G05"},
+		{nav.Galileo[0].PRN(), "E05"},
+		{nav.BeiDou[0].PRN(), "C19"},
+	} {
+		if c.got != c.want {
+			t.Errorf("PRN %q, want %q", c.got, c.want)
+		}
+	}
+	if n, ok := NeQuickFromHeader(nav.Header); !ok || n != (NeQuickCoefficients{Ai0: 100, Ai1: 0.2, Ai2: 0.003}) {
+		t.Errorf("GAL coefficients %+v %v", n, ok)
+	}
+
+	galileo := nav.Galileo[0]
+	if galileo.MessageType() != NavMessageType_inav {
+		t.Errorf("Galileo message %v, want inav", galileo.MessageType())
+	}
+	data, err := galileo.EphemerisData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.SvAcc() != 3.12 || data.Tgd() != -1e-9 {
+		t.Errorf("Galileo SISA %v BGD %v, want 3.12 -1e-9", data.SvAcc(), data.Tgd())
+	}
+
+	beiDou := nav.BeiDou[0]
+	if beiDou.MessageType() != NavMessageType_d1 {
+		t.Errorf("BeiDou message %v, want d1", beiDou.MessageType())
+	}
+	if beiDou.Tgd1() != 1.2e-8 || beiDou.Tgd2() != -3e-9 {
+		t.Errorf("BeiDou TGD1 %v TGD2 %v, want 1.2e-8 -3e-9", beiDou.Tgd1(), beiDou.Tgd2())
+	}
+
+	glonass := nav.GLONASS[0]
+	if glonass.SatelliteId() != 1 {
+		t.Errorf("GLONASS slot %d, want 1", glonass.SatelliteId())
+	}
+	if glonass.PositionX() != 14776.4956055 || glonass.VelocityY() != -1.03984928131 || glonass.FrequencyChannelOffset() != 1 {
+		t.Errorf("GLONASS x %v vy %v k %d", glonass.PositionX(), glonass.VelocityY(), glonass.FrequencyChannelOffset())
+	}
+}
+
+// At toe the Keplerian position does not depend on the gravitational
+// constant, so the Galileo record sharing the orbit of G05 is at the same
+// place.
+func TestGalileoPositionAtToe(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(mixedNavV3), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	toe, err := nav.GPS[0].Toe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpsPos, _, gpsClock, _, err := nav.GPS[0].GetSatInfo(toe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	galileoPos, _, galileoClock, _, err := nav.Galileo[0].GetSatInfo(toe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := distance(gpsPos, galileoPos); d > 1e-3 {
+		t.Errorf("Galileo position %.4f m from GPS", d)
+	}
+	if d := math.Abs(gpsClock - galileoClock); d > 1e-12 {
+		t.Errorf("Galileo clock %v, GPS %v", galileoClock, gpsClock)
+	}
+
+	beiDou := nav.BeiDou[0]
+	beiDouToe, err := beiDou.Toe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pos, _, _, _, err := beiDou.GetSatInfo(beiDouToe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := math.Sqrt(pos[0]*pos[0] + pos[1]*pos[1] + pos[2]*pos[2]); math.Abs(r-26.56e6) > 0.2e6 {
+		t.Errorf("BeiDou orbit radius %.0f m", r)
+	}
+}
+
+func TestParseNavV4(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(navV4), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nav.Header.Version() != 4 {
+		t.Errorf("version %v, want 4", nav.Header.Version())
+	}
+	if len(nav.GPS) != 1 {
+		t.Fatalf("%d GPS records, want 1", len(nav.GPS))
+	}
+	if nav.GPS[0].MessageType() != NavMessageType_lnav {
+		t.Errorf("message %v, want lnav", nav.GPS[0].MessageType())
+	}
+	want := NeQuickCoefficients{Ai0: 95.75, Ai1: 3.90625e-2, Ai2: 1.28173828125e-2}
+	if n, ok := NeQuickFromHeader(nav.Header); !ok || n != want {
+		t.Errorf("ION IFNV coefficients %+v %v, want %+v", n, ok, want)
+	}
+}
+
+func TestParseNavV4Messages(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(navV4Messages), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nav.GPS) != 2 || len(nav.Galileo) != 1 || len(nav.BeiDou) != 5 || len(nav.GLONASS) != 1 || len(nav.SBAS) != 1 {
+		t.Fatalf("%d GPS, %d Galileo, %d BeiDou, %d GLONASS, %d SBAS records", len(nav.GPS), len(nav.Galileo),
+			len(nav.BeiDou), len(nav.GLONASS), len(nav.SBAS))
+	}
+
+	// GPS CNAV and CNAV-2: toe is the epoch, ISCs in ORBIT 7 (and 8)
+	for i, c := range []struct {
+		prn      string
+		message  NavMessageType
+		aDot     float64
+		tgd      float64
+		isc      []float64
+		transmit float64
+	}{
+		{"G10", NavMessageType_cnav, 1.5e-2, -1.1e-8, []float64{1e-10, 2e-10, 3e-10, 4e-10}, 165618},
+		{"G11", NavMessageType_cnv2, -2.5e-2, -1.2e-8, []float64{1e-10, 2e-10, 3e-10, 4e-10, 5e-10, 6e-10}, 165624},
+	} {
+		eph := nav.GPS[i]
+		data, _ := eph.EphemerisData()
+		list, _ := eph.InterSignalCorrections()
+		isc := make([]float64, list.Len())
+		for j := range isc {
+			isc[j] = list.At(j)
+		}
+		if eph.PRN() != c.prn || eph.MessageType() != c.message || eph.ADot() != c.aDot || eph.DeltaNDot() != -2e-14 {
+			t.Errorf("%s: %s %v ADOT %v Delta n0 dot %v", c.prn, eph.PRN(), eph.MessageType(), eph.ADot(), eph.DeltaNDot())
+		}
+		if data.Toe() != 172800 || data.ToeWeek() != 2325 || data.Iode() != 0 || data.SvAcc() != 2 || data.Tgd() != c.tgd ||
+			data.TransmissionTime() != c.transmit || !reflect.DeepEqual(isc, c.isc) {
+			t.Errorf("%s: toe %d/%v IODE %v URAI %v TGD %v transmission %v ISC %v", c.prn, data.ToeWeek(), data.Toe(),
+				data.Iode(), data.SvAcc(), data.Tgd(), data.TransmissionTime(), isc)
+		}
+	}
+
+	galileo := nav.Galileo[0]
+	if data, _ := galileo.EphemerisData(); galileo.MessageType() != NavMessageType_fnav || galileo.DataSources() != 258 ||
+		data.Iode() != 30 || galileo.Sisa() != 3.12 || data.TransmissionTime() != 172320 {
+		t.Errorf("E07: %v data sources %d IODnav %v SISA %v transmission %v", galileo.MessageType(), galileo.DataSources(),
+			data.Iode(), galileo.Sisa(), data.TransmissionTime())
+	}
+
+	for i, c := range []struct {
+		prn              string
+		message          NavMessageType
+		aode, aodc       float64
+		tgd1, tgd2, aDot float64
+		transmit         float64
+	}{
+		{"C06", NavMessageType_d1, 1, 1, 1.2e-8, -3e-9, 0, 172704},
+		{"C01", NavMessageType_d2, 2, 3, 2.2e-8, -4e-9, 0, 172705},
+		{"C25", NavMessageType_cnv1, 7, 7, 3e-9, 4e-9, 0.25, 172740},
+		{"C26", NavMessageType_cnv2, 9, 9, 5e-9, 6e-9, 0.5, 172746},
+		{"C27", NavMessageType_cnv3, 0, 0, 0, -5e-9, 0.75, 172752},
+	} {
+		eph := nav.BeiDou[i]
+		data, _ := eph.EphemerisData()
+		if eph.PRN() != c.prn || eph.MessageType() != c.message || eph.Aode() != c.aode || eph.Aodc() != c.aodc ||
+			eph.Tgd1() != c.tgd1 || eph.Tgd2() != c.tgd2 || eph.ADot() != c.aDot {
+			t.Errorf("%s: %s %v AODE %v AODC %v TGD %v %v ADOT %v", c.prn, eph.PRN(), eph.MessageType(), eph.Aode(),
+				eph.Aodc(), eph.Tgd1(), eph.Tgd2(), eph.ADot())
+		}
+		if data.ToeWeek() != 969 || data.TransmissionTime() != c.transmit || data.SvHealth() != 0 {
+			t.Errorf("%s: BDT week %d transmission %v health %v", c.prn, data.ToeWeek(), data.TransmissionTime(), data.SvHealth())
+		}
+	}
+
+	glonass := nav.GLONASS[0]
+	if glonass.FrequencyChannelOffset() != -4 || glonass.StatusFlags() != 1 || glonass.GroupDelayDifference() != -2e-9 ||
+		glonass.Urai() != 1 || glonass.HealthFlags() != 0 || glonass.MessageFrameTime() != 1800 {
+		t.Errorf("R02: k %d status %v delay %v URAI %v health %v tk %v", glonass.FrequencyChannelOffset(), glonass.StatusFlags(),
+			glonass.GroupDelayDifference(), glonass.Urai(), glonass.HealthFlags(), glonass.MessageFrameTime())
+	}
+
+	sbas := nav.SBAS[0]
+	if sbas.SatelliteId() != 27 || sbas.ClockBias() != -1e-8 || sbas.TransmissionTime() != 172800 || sbas.PositionX() != 42164 ||
+		sbas.UraIndex() != 32767 || sbas.Iodn() != 12 {
+		t.Errorf("S27: %d aGf0 %v transmission %v X %v URA %v IODN %v", sbas.SatelliteId(), sbas.ClockBias(),
+			sbas.TransmissionTime(), sbas.PositionX(), sbas.UraIndex(), sbas.Iodn())
+	}
+}
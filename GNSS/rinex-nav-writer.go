@@ -0,0 +1,476 @@
+package gnss
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  NAVIGATION FILE WRITER (RINEX 2.11 / 3.0x)
+// WriteNav is the inverse of ParseNav. Values are written as D19.12 with a 13
+// digit mantissa (1.234567890123D+00), enough to restore every float the
+// parser read from a D19.12 or 0.xD19.12 field, so parse -> write -> parse
+// returns the same records.
+//
+// RINEX 2 files hold a single system: GPS ("N") or GLONASS ("G"). RINEX 3
+// files take every system of NavigationData with the RINEX 3 message types
+// (LNAV, INAV/FNAV, D1/D2, FDMA, SBAS), the RINEX 4 only CNAV, CNV1-3 records
+// cannot be written. GLONASS records get the fifth line from version 3.05 on.
+//
+// Header correction records are translated to the target version, see
+// rinex-nav-header.go for the mapping.
+
+// WriteNav writes nav as a RINEX navigation file of version (2.11, 3.04...).
+func WriteNav(w io.Writer, nav *NavigationData, version float64) error {
+	bw := bufio.NewWriter(w)
+	var err error
+	if version < 3 {
+		err = writeNavV2(bw, nav, version)
+	} else {
+		err = writeNavV3(bw, nav, version)
+	}
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// WriteNavFile creates filename and writes nav to it with WriteNav.
+func WriteNavFile(filename string, nav *NavigationData, version float64) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	if err := WriteNav(file, nav, version); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func writeNavV2(w *bufio.Writer, nav *NavigationData, version float64) error {
+	if len(nav.Galileo)+len(nav.BeiDou)+len(nav.QZSS)+len(nav.NavIC)+len(nav.SBAS) > 0 {
+		return fmt.Errorf("RINEX %.2f navigation files hold GPS or GLONASS records only", version)
+	}
+	if len(nav.GPS) > 0 && len(nav.GLONASS) > 0 {
+		return fmt.Errorf("RINEX %.2f navigation files hold a single system, got GPS and GLONASS", version)
+	}
+
+	fileType := "N: GPS NAV DATA"
+	glonass := len(nav.GLONASS) > 0
+	if !glonass && len(nav.GPS) == 0 {
+		t, _ := nav.Header.Type()
+		glonass = strings.HasPrefix(t, "G")
+	}
+	if glonass {
+		fileType = "G: GLONASS NAV DATA"
+	}
+	if err := writeNavHeader(w, nav.Header, version, fileType, ""); err != nil {
+		return err
+	}
+
+	for _, eph := range nav.GPS {
+		if err := writeRecord(w, gpsNavRecord, eph, version); err != nil {
+			return err
+		}
+	}
+	for _, eph := range nav.GLONASS {
+		if err := writeGLONASSRecord(w, eph, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeNavV3(w *bufio.Writer, nav *NavigationData, version float64) error {
+	systems := ""
+	for _, s := range []struct {
+		system string
+		count  int
+	}{
+		{"G", len(nav.GPS)}, {"R", len(nav.GLONASS)}, {"E", len(nav.Galileo)}, {"C", len(nav.BeiDou)},
+		{"J", len(nav.QZSS)}, {"I", len(nav.NavIC)}, {"S", len(nav.SBAS)},
+	} {
+		if s.count > 0 {
+			systems += s.system
+		}
+	}
+	system := "M: MIXED"
+	if len(systems) == 1 {
+		system = systems + ": " + satelliteSystemNames[systems]
+	}
+	if err := writeNavHeader(w, nav.Header, version, "N: GNSS NAV DATA", system); err != nil {
+		return err
+	}
+
+	for _, eph := range nav.GPS {
+		if err := writeRecord(w, gpsNavRecord, eph, version); err != nil {
+			return err
+		}
+	}
+	for _, eph := range nav.GLONASS {
+		if err := writeGLONASSRecord(w, eph, version); err != nil {
+			return err
+		}
+	}
+	for _, eph := range nav.Galileo {
+		if err := writeRecord(w, galileoNavRecord, eph, version); err != nil {
+			return err
+		}
+	}
+	for _, eph := range nav.BeiDou {
+		if err := writeRecord(w, beiDouNavRecord, eph, version); err != nil {
+			return err
+		}
+	}
+	for _, group := range [][]GPSEphemeris{nav.QZSS, nav.NavIC} {
+		for _, eph := range group {
+			if err := writeRecord(w, gpsNavRecord, eph, version); err != nil {
+				return err
+			}
+		}
+	}
+	for _, eph := range nav.SBAS {
+		if err := writeRecord(w, sbasNavRecord, eph, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeRecord[T any](w *bufio.Writer, record func(T) (navRecord, error), eph T, version float64) error {
+	r, err := record(eph)
+	if err != nil {
+		return err
+	}
+	writeNavRecord(w, r, version)
+	return nil
+}
+
+var satelliteSystemNames = map[string]string{
+	"G": "GPS",
+	"R": "GLONASS",
+	"E": "Galileo",
+	"C": "BeiDou",
+	"J": "QZSS",
+	"I": "IRNSS",
+	"S": "SBAS",
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// writeNavHeader writes header for the target version. The file type and
+// satellite system of header are kept when they agree with fileType.
+func writeNavHeader(w *bufio.Writer, header RINEXHeader, version float64, fileType, system string) error {
+	if t, _ := header.Type(); t != "" && t[0] == fileType[0] && (version >= 3) == (header.Version() >= 3) {
+		fileType = t
+		if s, _ := header.SatelliteSystem(); s != "" && system != "" && s[0] == system[0] {
+			system = s
+		}
+	}
+	writeHeaderLine(w, fmt.Sprintf("%9.2f%11s%-20.20s%-20.20s", version, "", fileType, system), "RINEX VERSION / TYPE")
+
+	program, _ := header.ProgramName()
+	agency, _ := header.Agency()
+	date := ""
+	if d, err := header.Date(); err == nil && d.Seconds() != 0 {
+		date = time.Unix(d.Seconds(), int64(d.Nanoseconds())).UTC().Format("20060102 150405") + " UTC"
+	}
+	writeHeaderLine(w, fmt.Sprintf("%-20.20s%-20.20s%-20.20s", program, agency, date), "PGM / RUN BY / DATE")
+
+	if comments, err := header.Comments(); err == nil {
+		for i := 0; i < comments.Len(); i++ {
+			comment, _ := comments.At(i)
+			writeHeaderLine(w, comment, "COMMENT")
+		}
+	}
+
+	if version < 3 {
+		writeNavHeaderCorrectionsV2(w, header, fileType[0] == 'G')
+	} else {
+		writeNavHeaderCorrectionsV3(w, header)
+	}
+
+	if header.FutureLeapSeconds() != 0 || header.LeapSecondsWeek() != 0 || header.LeapSecondsDay() != 0 {
+		system, _ := header.LeapSecondsTimeSystem()
+		writeHeaderLine(w, fmt.Sprintf("%6d%6d%6d%6d%-3s", header.LeapSeconds(), header.FutureLeapSeconds(),
+			header.LeapSecondsWeek(), header.LeapSecondsDay(), system), "LEAP SECONDS")
+	} else if header.LeapSeconds() != 0 {
+		writeHeaderLine(w, fmt.Sprintf("%6d", header.LeapSeconds()), "LEAP SECONDS")
+	}
+
+	writeHeaderLine(w, "", "END OF HEADER")
+	return nil
+}
+
+func writeNavHeaderCorrectionsV2(w *bufio.Writer, header RINEXHeader, glonass bool) {
+	if glonass {
+		if corr, ok := header.TimeSystemCorrection("GLUT"); ok {
+			date := glonassCorrectionDate(corr)
+			writeHeaderLine(w, fmt.Sprintf("%6d%6d%6d%3s%s", date.Year(), date.Month(), date.Day(), "", formatNavValue(corr.A0(), 'D')),
+				"CORR TO SYSTEM TIME")
+		}
+		return
+	}
+
+	for _, label := range []struct {
+		correctionType, label string
+	}{{"GPSA", "ION ALPHA"}, {"GPSB", "ION BETA"}} {
+		parameters, ok := header.IonosphericCorrection(label.correctionType)
+		if !ok {
+			continue
+		}
+		line := "  "
+		for _, p := range parameters {
+			line += formatHeaderValue(p, 'D')
+		}
+		writeHeaderLine(w, line, label.label)
+	}
+	if corr, ok := header.TimeSystemCorrection("GPUT"); ok {
+		writeHeaderLine(w, fmt.Sprintf("%3s%s%s%9d%9d", "", formatNavValue(corr.A0(), 'D'), formatNavValue(corr.A1(), 'D'),
+			corr.ReferenceTime(), corr.ReferenceWeek()), "DELTA-UTC: A0,A1,T,W")
+	}
+}
+
+func writeNavHeaderCorrectionsV3(w *bufio.Writer, header RINEXHeader) {
+	if corrections, err := header.IonosphericCorrections(); err == nil {
+		for i := 0; i < corrections.Len(); i++ {
+			corr := corrections.At(i)
+			correctionType, _ := corr.CorrectionType()
+			if correctionType == "BDGM" {
+				// BDGIM coefficients only exist in RINEX 4 ION records
+				continue
+			}
+			line := fmt.Sprintf("%-4s ", correctionType)
+			if list, err := corr.Parameters(); err == nil {
+				for j := 0; j < list.Len(); j++ {
+					line += formatHeaderValue(list.At(j), 'E')
+				}
+			}
+			timeMark, _ := corr.TimeMark()
+			satellite, _ := corr.SatelliteId()
+			if timeMark != "" || satellite != "" {
+				line = fmt.Sprintf("%-53s %1s %-3s", line, timeMark, satellite)
+			}
+			writeHeaderLine(w, line, "IONOSPHERIC CORR")
+		}
+	}
+
+	if corrections, err := header.TimeSystemCorrections(); err == nil {
+		for i := 0; i < corrections.Len(); i++ {
+			corr := corrections.At(i)
+			correctionType, _ := corr.CorrectionType()
+			source, _ := corr.Source()
+			referenceTime, referenceWeek := int(corr.ReferenceTime()), int(corr.ReferenceWeek())
+			if corr.HasReferenceDate() && referenceTime == 0 && referenceWeek == 0 {
+				reference := GPSTimeFromDateTime(glonassCorrectionDate(corr))
+				referenceTime, referenceWeek = int(reference.TimeOfWeek()), int(reference.Week())
+			}
+			line := fmt.Sprintf("%-4s %17.10E%16.9E %6d %4d %-5s", correctionType, corr.A0(), corr.A1(), referenceTime, referenceWeek, source)
+			if corr.UtcIdentifier() != 0 {
+				line += fmt.Sprintf(" %2d", corr.UtcIdentifier())
+			}
+			writeHeaderLine(w, line, "TIME SYSTEM CORR")
+		}
+	}
+}
+
+// glonassCorrectionDate returns the reference date of a GLUT record, RINEX 3
+// records only carry week and seconds.
+func glonassCorrectionDate(corr TimeSystemCorrection) time.Time {
+	if corr.HasReferenceDate() {
+		if date, err := corr.ReferenceDate(); err == nil {
+			return time.Unix(date.Seconds(), 0).UTC()
+		}
+	}
+	return GPSTimeFromWeek(int(corr.ReferenceWeek()), float64(corr.ReferenceTime())).ToDateTime()
+}
+
+func writeHeaderLine(w *bufio.Writer, content, label string) {
+	fmt.Fprintf(w, "%-60.60s%s\n", content, label)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// formatNavValue writes v as D19.12, exponent is 'D' (RINEX 2) or 'E'.
+func formatNavValue(v float64, exponent byte) string {
+	s := fmt.Sprintf("%19.12E", v)
+	if exponent != 'E' {
+		s = strings.Replace(s, "E", string(exponent), 1)
+	}
+	return s
+}
+
+// formatHeaderValue writes an ionospheric parameter as D12.4.
+func formatHeaderValue(v float64, exponent byte) string {
+	s := fmt.Sprintf("%12.4E", v)
+	if exponent != 'E' {
+		s = strings.Replace(s, "E", string(exponent), 1)
+	}
+	return s
+}
+
+func writeNavRecord(w *bufio.Writer, r navRecord, version float64) {
+	exponent, indent := byte('D'), "   "
+	if version >= 3 {
+		exponent, indent = 'E', "    "
+	}
+	w.WriteString(navEpochLine(r.system, r.svId, r.epoch, version))
+	for i, v := range r.values {
+		if i == 3 || i > 3 && (i-3)%4 == 0 {
+			w.WriteString("\n" + indent)
+		}
+		w.WriteString(formatNavValue(v, exponent))
+	}
+	w.WriteString("\n")
+}
+
+// navEpochLine formats the SV / EPOCH fields: I2,1X,I2.2,4(1X,I2),F5.1 in
+// RINEX 2, A3,1X,I4,5(1X,I2.2) in RINEX 3.
+func navEpochLine(system byte, svId int, epoch time.Time, version float64) string {
+	if version < 3 {
+		seconds := float64(epoch.Second()) + float64(epoch.Nanosecond())/1e9
+		return fmt.Sprintf("%2d %02d %2d %2d %2d %2d%5.1f", svId, epoch.Year()%100, epoch.Month(), epoch.Day(),
+			epoch.Hour(), epoch.Minute(), seconds)
+	}
+	return fmt.Sprintf("%c%02d %04d %02d %02d %02d %02d %02d", system, svId, epoch.Year(), epoch.Month(), epoch.Day(),
+		epoch.Hour(), epoch.Minute(), epoch.Second())
+}
+
+// ephemerisDataEpoch returns the toc date of a Kepler record as written in
+// the file.
+func ephemerisDataEpoch(data Ephemeris) time.Time {
+	second := float64(data.Second())
+	whole := int(second)
+	return time.Date(int(data.Year()), time.Month(data.Month()), int(data.Day()), int(data.Hour()), int(data.Minute()),
+		whole, int((second-float64(whole))*1e9+0.5), time.UTC)
+}
+
+// keplerValues returns the clock terms, BROADCAST ORBIT 1-4 and IDOT in file
+// order, the inverse of fillKeplerEphemeris.
+func keplerValues(data Ephemeris, sqrtA float64) []float64 {
+	return []float64{
+		data.Af0(), data.Af1(), data.Af2(),
+		data.Iode(), data.Crs(), data.DeltaN(), data.M0(),
+		data.Cuc(), data.Ecc(), data.Cus(), sqrtA,
+		data.Toe(), data.Cic(), data.Omega0(), data.Cis(),
+		data.I0(), data.Crc(), data.Omega(), data.OmegaDot(),
+		data.IDot(),
+	}
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// gpsNavRecord returns an LNAV record of GPS, QZSS or NavIC.
+func gpsNavRecord(eph GPSEphemeris) (navRecord, error) {
+	if eph.MessageType() != NavMessageType_lnav {
+		return navRecord{}, fmt.Errorf("cannot write %s records to RINEX 2 / 3", eph.MessageType())
+	}
+	data, err := eph.EphemerisData()
+	if err != nil {
+		return navRecord{}, fmt.Errorf("failed to get ephemeris data: %v", err)
+	}
+	base, err := eph.BaseEphemeris()
+	if err != nil {
+		return navRecord{}, fmt.Errorf("failed to get base ephemeris: %v", err)
+	}
+	system := byte('G')
+	if prn, _ := base.PseudoRandomNumber(); prn != "" {
+		system = prn[0]
+	}
+
+	values := append(keplerValues(data, eph.SquareRootOfSemiMajorAxis()),
+		data.CodesL2(), float64(data.ToeWeek()), data.L2(),
+		data.SvAcc(), data.SvHealth(), data.Tgd(), data.Iodc(),
+		data.TransmissionTime(), data.FitInterval())
+	return navRecord{system: system, svId: int(data.SvId()), epoch: ephemerisDataEpoch(data), values: values}, nil
+}
+
+func galileoNavRecord(eph GalileoEphemeris) (navRecord, error) {
+	data, err := eph.EphemerisData()
+	if err != nil {
+		return navRecord{}, fmt.Errorf("failed to get ephemeris data: %v", err)
+	}
+	values := append(keplerValues(data, eph.SquareRootOfSemiMajorAxis()),
+		float64(eph.DataSources()), float64(data.ToeWeek()), 0,
+		eph.Sisa(), data.SvHealth(), eph.BgdE5aE1(), eph.BgdE5bE1(),
+		data.TransmissionTime())
+	return navRecord{system: 'E', svId: int(data.SvId()), epoch: ephemerisDataEpoch(data), values: values}, nil
+}
+
+func beiDouNavRecord(eph BeiDouEphemeris) (navRecord, error) {
+	switch eph.MessageType() {
+	case NavMessageType_d1, NavMessageType_d2, NavMessageType_unknown:
+	default:
+		return navRecord{}, fmt.Errorf("cannot write BeiDou %s records to RINEX 3", eph.MessageType())
+	}
+	data, err := eph.EphemerisData()
+	if err != nil {
+		return navRecord{}, fmt.Errorf("failed to get ephemeris data: %v", err)
+	}
+	values := append(keplerValues(data, eph.SquareRootOfSemiMajorAxis()),
+		0, float64(data.ToeWeek()), 0,
+		data.SvAcc(), data.SvHealth(), eph.Tgd1(), eph.Tgd2(),
+		data.TransmissionTime(), eph.Aodc())
+	return navRecord{system: 'C', svId: int(data.SvId()), epoch: ephemerisDataEpoch(data), values: values}, nil
+}
+
+// glonassNavRecord returns the FDMA record including the RINEX 3.05 line,
+// writeGLONASSRecord drops it for older versions.
+func glonassNavRecord(eph RINEXEphemeris) (navRecord, error) {
+	epoch, err := eph.Epoch()
+	if err != nil {
+		return navRecord{}, fmt.Errorf("failed to get epoch: %v", err)
+	}
+	values := []float64{
+		eph.ClockBias(), eph.RelativeFrequencyBias(), eph.MessageFrameTime(),
+		eph.PositionX(), eph.VelocityX(), eph.AccelerationX(), eph.Health(),
+		eph.PositionY(), eph.VelocityY(), eph.AccelerationY(), float64(eph.FrequencyChannelOffset()),
+		eph.PositionZ(), eph.VelocityZ(), eph.AccelerationZ(), eph.InformationAge(),
+		eph.StatusFlags(), eph.GroupDelayDifference(), eph.Urai(), eph.HealthFlags(),
+	}
+	epochTime := time.Unix(epoch.Seconds(), int64(epoch.Nanoseconds())).UTC()
+	return navRecord{system: 'R', svId: int(eph.SatelliteId()), epoch: epochTime, values: values}, nil
+}
+
+func sbasNavRecord(eph SBASEphemeris) (navRecord, error) {
+	toc, err := eph.Toc()
+	if err != nil {
+		return navRecord{}, fmt.Errorf("failed to get toc: %v", err)
+	}
+	values := []float64{
+		eph.ClockBias(), eph.RelativeFrequencyBias(), eph.TransmissionTime(),
+		eph.PositionX(), eph.VelocityX(), eph.AccelerationX(), eph.Health(),
+		eph.PositionY(), eph.VelocityY(), eph.AccelerationY(), eph.UraIndex(),
+		eph.PositionZ(), eph.VelocityZ(), eph.AccelerationZ(), eph.Iodn(),
+	}
+	return navRecord{system: 'S', svId: int(eph.SatelliteId()), epoch: toc.ToDateTime(), values: values}, nil
+}
+
+func writeGLONASSRecord(w *bufio.Writer, eph RINEXEphemeris, version float64) error {
+	record, err := glonassNavRecord(eph)
+	if err != nil {
+		return err
+	}
+	if version < 3.05 {
+		record.values = record.values[:15]
+	}
+	writeNavRecord(w, record, version)
+	return nil
+}
@@ -0,0 +1,157 @@
+package gnss
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// navRecords returns the records of every system of nav in writer order.
+func navRecords(tb testing.TB, nav *NavigationData) []navRecord {
+	tb.Helper()
+	var records []navRecord
+	add := func(r navRecord, err error) {
+		if err != nil {
+			tb.Fatal(err)
+		}
+		records = append(records, r)
+	}
+	for _, eph := range nav.GPS {
+		add(gpsNavRecord(eph))
+	}
+	for _, eph := range nav.GLONASS {
+		add(glonassNavRecord(eph))
+	}
+	for _, eph := range nav.Galileo {
+		add(galileoNavRecord(eph))
+	}
+	for _, eph := range nav.BeiDou {
+		add(beiDouNavRecord(eph))
+	}
+	for _, group := range [][]GPSEphemeris{nav.QZSS, nav.NavIC} {
+		for _, eph := range group {
+			add(gpsNavRecord(eph))
+		}
+	}
+	for _, eph := range nav.SBAS {
+		add(sbasNavRecord(eph))
+	}
+	return records
+}
+
+// rewriteNav writes nav as version and parses the output.
+func rewriteNav(tb testing.TB, nav *NavigationData, version float64) (*NavigationData, []byte) {
+	tb.Helper()
+	var buf bytes.Buffer
+	if err := WriteNav(&buf, nav, version); err != nil {
+		tb.Fatalf("RINEX %.2f: %v", version, err)
+	}
+	text := buf.Bytes()
+	parsed, err := ParseNav(bytes.NewReader(text), ParseOptions{Strict: true})
+	if err != nil {
+		tb.Fatalf("RINEX %.2f output: %v", version, err)
+	}
+	return parsed, text
+}
+
+func compareNavRecords(tb testing.TB, name string, got, want []navRecord) {
+	tb.Helper()
+	if len(got) != len(want) {
+		tb.Fatalf("%s: %d records, want %d", name, len(got), len(want))
+	}
+	for i := range got {
+		g, w := got[i], want[i]
+		if g.prn() != w.prn() || !g.epoch.Equal(w.epoch) || !reflect.DeepEqual(g.values, w.values) {
+			tb.Errorf("%s: record %d %s %v %v, want %s %v %v", name, i, g.prn(), g.epoch, g.values, w.prn(), w.epoch, w.values)
+		}
+	}
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// A RINEX 2.11 file written as 3.04 and back as 2.11 keeps every value.
+func TestWriteNavRoundTrip(t *testing.T) {
+	for _, c := range []struct {
+		file    string
+		version float64
+	}{
+		{"abpo2120.24n", 3.04},
+		{"brdc2050.24g", 3.05},
+	} {
+		nav, err := ParseNavFile(testFile(t, c.file), ParseOptions{Strict: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := navRecords(t, nav)
+
+		v2, first := rewriteNav(t, nav, 2.11)
+		compareNavRecords(t, c.file+" as 2.11", navRecords(t, v2), want)
+		v3, _ := rewriteNav(t, v2, c.version)
+		if v3.Header.Version() != c.version {
+			t.Errorf("%s: version %v, want %v", c.file, v3.Header.Version(), c.version)
+		}
+		compareNavRecords(t, c.file+" as 3", navRecords(t, v3), want)
+		back, second := rewriteNav(t, v3, 2.11)
+		compareNavRecords(t, c.file+" back as 2.11", navRecords(t, back), want)
+		// the header keeps the file type text of the original version
+		_, firstBody, _ := bytes.Cut(first, []byte("END OF HEADER"))
+		_, secondBody, _ := bytes.Cut(second, []byte("END OF HEADER"))
+		if !bytes.Equal(firstBody, secondBody) {
+			t.Errorf("%s: the 2.11 records changed after a RINEX 3 round trip", c.file)
+		}
+	}
+}
+
+func TestWriteNavMixed(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(mixedNavV3), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v3, text := rewriteNav(t, nav, 3.04)
+	compareNavRecords(t, "3.04", navRecords(t, v3), navRecords(t, nav))
+	if v3.Galileo[0].MessageType() != NavMessageType_inav || v3.BeiDou[0].MessageType() != NavMessageType_d1 {
+		t.Errorf("message types %v %v", v3.Galileo[0].MessageType(), v3.BeiDou[0].MessageType())
+	}
+	if !strings.Contains(strings.SplitN(string(text), "\n", 2)[0], "M: MIXED") {
+		t.Errorf("first line %q", strings.SplitN(string(text), "\n", 2)[0])
+	}
+	if n, ok := NeQuickFromHeader(v3.Header); !ok || n != (NeQuickCoefficients{Ai0: 100, Ai1: 0.2, Ai2: 0.003}) {
+		t.Errorf("GAL coefficients %+v %v", n, ok)
+	}
+
+	if err := WriteNav(&bytes.Buffer{}, nav, 2.11); err == nil {
+		t.Error("Galileo and BeiDou records written as RINEX 2.11")
+	}
+}
+
+// The RINEX 2 header records are translated to RINEX 3 and back. TIME SYSTEM
+// CORR holds A0 and A1 with 10 and 9 decimals only.
+func TestWriteNavHeaderCorrections(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(navHeaderV211), ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v3, _ := rewriteNav(t, nav, 3.04)
+	back, _ := rewriteNav(t, v3, 2.11)
+	for _, header := range []RINEXHeader{v3.Header, back.Header} {
+		for _, correctionType := range []string{"GPSA", "GPSB"} {
+			got, ok := header.IonosphericCorrection(correctionType)
+			want, _ := nav.Header.IonosphericCorrection(correctionType)
+			if !ok || !reflect.DeepEqual(got, want) {
+				t.Errorf("RINEX %v %s %v, want %v", header.Version(), correctionType, got, want)
+			}
+		}
+		gput, ok := header.TimeSystemCorrection("GPUT")
+		if !ok || math.Abs(gput.A0()+0.186264514923e-08) > 1e-19 || math.Abs(gput.A1()+0.266453525910e-14) > 1e-24 ||
+			gput.ReferenceTime() != 319488 || gput.ReferenceWeek() != 2325 {
+			t.Errorf("RINEX %v GPUT %v %v %v", header.Version(), ok, gput.A0(), gput.A1())
+		}
+		if header.LeapSeconds() != 18 {
+			t.Errorf("RINEX %v leap seconds %d", header.Version(), header.LeapSeconds())
+		}
+	}
+}
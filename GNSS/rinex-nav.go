@@ -0,0 +1,315 @@
+package gnss
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"capnproto.org/go/capnp/v3"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  NAVIGATION FILE ENTRY POINTS
+// ParseNav reads any supported navigation file from a reader, the header
+// decides the record layout:
+//   RINEX 2 "N: GPS NAV DATA"        -> NavigationData.GPS
+//   RINEX 2 "G: GLONASS NAV DATA"    -> NavigationData.GLONASS
+//   RINEX 3 / 4 (any system)         -> split per system
+// RINEX 2 GEO (H) and Galileo (L, E) files fail with errors.ErrUnsupported.
+// The file based functions (ParseRINEXFileV201, ParseRINEXGPSFileV211,
+// ParseRINEXNavFileV3) are thin wrappers with the default options.
+//
+// A file is read into a single message rooted at a NavFile: records are
+// decoded first, then each list is allocated once with the record count of
+// its system. The segments of the arena grow with the file, GLONASS records
+// point at the header of the same message.
+
+func ParseNav(r io.Reader, opts ParseOptions) (*NavigationData, error) {
+	scanner := newLineScanner(r, opts)
+
+	_, seg, err := capnp.NewMessage(capnp.MultiSegment(nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new message: %v", err)
+	}
+	file, err := NewRootNavFile(seg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new NavFile: %v", err)
+	}
+	header, err := file.NewHeader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new RINEXHeader: %v", err)
+	}
+	if err := parseRINEXHeader(scanner, header); err != nil {
+		return nil, fmt.Errorf("error parsing header: %w", err)
+	}
+
+	var records []navRecord
+	var ionospheric []ionosphericRecord
+	if header.Version() >= 3 {
+		records, ionospheric, err = parseMixedEphemeris(scanner, header.Version())
+	} else {
+		fileType, _ := header.Type()
+		switch {
+		case len(fileType) > 0 && fileType[0] == 'N':
+			records, err = parseGPSEphemeris(scanner)
+		case len(fileType) > 0 && fileType[0] == 'G':
+			records, err = parseRINEXEphemeris(scanner)
+		default:
+			return nil, fmt.Errorf("RINEX %.2f navigation file type %q: %w", header.Version(), fileType, errors.ErrUnsupported)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ephemerides: %w", err)
+	}
+	if len(ionospheric) > 0 {
+		if err := addIonosphericCorrections(header, ionospheric); err != nil {
+			return nil, fmt.Errorf("error parsing ionospheric corrections: %w", err)
+		}
+	}
+
+	nav, err := newNavigationData(file, records, newNavSource(header, opts.FileName))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ephemerides: %w", err)
+	}
+	nav.Warnings = scanner.warnings
+	return nav, nil
+}
+
+// ParseNavFile opens filename and parses it with ParseNav, opts.FileName
+// defaults to filename.
+func ParseNavFile(filename string, opts ParseOptions) (*NavigationData, error) {
+	file, err := openFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if opts.FileName == "" {
+		opts.FileName = filename
+	}
+	return ParseNav(file, opts)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// navRecord holds the SV / EPOCH fields and the values of a record in file
+// order, three on the first line and four per BROADCAST ORBIT line.
+type navRecord struct {
+	system      byte
+	svId        int
+	epoch       time.Time
+	messageType NavMessageType
+	values      []float64
+}
+
+func (r navRecord) prn() string {
+	return fmt.Sprintf("%c%02d", r.system, r.svId)
+}
+
+// navSource is the provenance written into the BaseEphemeris of every record
+// of a file: its name, the date and the program / agency of the
+// PGM / RUN BY / DATE header line.
+type navSource struct {
+	fileName  string
+	fileEpoch *GPSTime
+	source    string
+}
+
+func newNavSource(header RINEXHeader, fileName string) navSource {
+	src := navSource{fileName: fileName}
+	if header.HasDate() {
+		if date, err := header.Date(); err == nil {
+			epoch := UTCToGPST(time.Unix(date.Seconds(), int64(date.Nanoseconds())).UTC())
+			src.fileEpoch = &epoch
+		}
+	}
+	program, _ := header.ProgramName()
+	agency, _ := header.Agency()
+	switch {
+	case program != "" && agency != "":
+		src.source = program + " / " + agency
+	default:
+		src.source = program + agency
+	}
+	return src
+}
+
+func (s navSource) set(base BaseEphemeris) error {
+	if err := base.SetFileName(s.fileName); err != nil {
+		return fmt.Errorf("failed to set file name: %v", err)
+	}
+	if err := base.SetFileSource(s.source); err != nil {
+		return fmt.Errorf("failed to set file source: %v", err)
+	}
+	if s.fileEpoch == nil {
+		return nil
+	}
+	epoch, err := base.NewFileEpoch()
+	if err != nil {
+		return fmt.Errorf("failed to create new file epoch: %v", err)
+	}
+	setGPSTime(epoch, *s.fileEpoch)
+	return nil
+}
+
+// newNavigationData fills the lists of file with records, in file order
+// within each system, and marks every record with source.
+func newNavigationData(file NavFile, records []navRecord, source navSource) (*NavigationData, error) {
+	header, err := file.Header()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header: %v", err)
+	}
+	counts := make(map[byte]int32)
+	for _, r := range records {
+		counts[r.system]++
+	}
+
+	gps, err := file.NewGps(counts['G'])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new GPS list: %v", err)
+	}
+	glonass, err := file.NewGlonass(counts['R'])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new GLONASS list: %v", err)
+	}
+	galileo, err := file.NewGalileo(counts['E'])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new Galileo list: %v", err)
+	}
+	beiDou, err := file.NewBeiDou(counts['C'])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new BeiDou list: %v", err)
+	}
+	qzss, err := file.NewQzss(counts['J'])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new QZSS list: %v", err)
+	}
+	navIC, err := file.NewNavIC(counts['I'])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new NavIC list: %v", err)
+	}
+	sbas, err := file.NewSbas(counts['S'])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new SBAS list: %v", err)
+	}
+
+	// the nav message coefficients are only in the header of the file
+	klobuchar := make(map[byte]KlobucharCoefficients)
+	for system := range klobucharCorrections {
+		if k, ok := KlobucharFromHeader(header, system); ok {
+			klobuchar[system] = k
+		}
+	}
+
+	next := make(map[byte]int)
+	for _, r := range records {
+		i := next[r.system]
+		next[r.system]++
+		var base func() (BaseEphemeris, error)
+		var data func() (Ephemeris, error)
+		switch r.system {
+		case 'G', 'J', 'I':
+			list := gps
+			if r.system == 'J' {
+				list = qzss
+			} else if r.system == 'I' {
+				list = navIC
+			}
+			eph := list.At(i)
+			if r.messageType == NavMessageType_cnav || r.messageType == NavMessageType_cnv2 {
+				err = fillGPSCivilEphemeris(eph, r.prn(), r.svId, r.epoch, r.messageType, r.values)
+			} else {
+				err = fillGPSEphemeris(eph, r.prn(), r.svId, r.epoch, r.values)
+			}
+			base, data = eph.BaseEphemeris, eph.EphemerisData
+		case 'E':
+			eph := galileo.At(i)
+			err = fillGalileoEphemeris(eph, r.prn(), r.svId, r.epoch, r.messageType, r.values)
+			base = eph.BaseEphemeris
+		case 'C':
+			eph := beiDou.At(i)
+			err = fillBeiDouEphemeris(eph, r.prn(), r.svId, r.epoch, r.messageType, r.values)
+			base, data = eph.BaseEphemeris, eph.EphemerisData
+		case 'R':
+			eph := glonass.At(i)
+			if err = fillGLONASSEphemeris(eph, r.svId, r.epoch, r.values); err == nil {
+				err = eph.SetHeader(header)
+			}
+			base = eph.BaseEphemeris
+		case 'S':
+			eph := sbas.At(i)
+			err = fillSBASEphemeris(eph, r.prn(), r.svId, r.epoch, r.values)
+			base = eph.BaseEphemeris
+		default:
+			err = fmt.Errorf("unsupported satellite system %q", r.system)
+		}
+		if err == nil {
+			var b BaseEphemeris
+			if b, err = base(); err == nil {
+				err = source.set(b)
+			}
+		}
+		if k, ok := klobuchar[r.system]; ok && err == nil {
+			var d Ephemeris
+			if d, err = data(); err == nil {
+				err = k.setEphemeris(d)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %v", r.prn(), r.epoch.Format("2006-01-02 15:04:05"), err)
+		}
+	}
+	return navigationDataFromFile(file)
+}
+
+// navigationDataFromFile splits the lists of a parsed or loaded file into
+// NavigationData, the slices share the message of file.
+func navigationDataFromFile(file NavFile) (*NavigationData, error) {
+	header, err := file.Header()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header: %v", err)
+	}
+	nav := &NavigationData{File: file, Header: header}
+	if nav.GPS, err = listElements(file.Gps); err != nil {
+		return nil, err
+	}
+	if nav.GLONASS, err = listElements(file.Glonass); err != nil {
+		return nil, err
+	}
+	if nav.Galileo, err = listElements(file.Galileo); err != nil {
+		return nil, err
+	}
+	if nav.BeiDou, err = listElements(file.BeiDou); err != nil {
+		return nil, err
+	}
+	if nav.QZSS, err = listElements(file.Qzss); err != nil {
+		return nil, err
+	}
+	if nav.NavIC, err = listElements(file.NavIC); err != nil {
+		return nil, err
+	}
+	if nav.SBAS, err = listElements(file.Sbas); err != nil {
+		return nil, err
+	}
+	return nav, nil
+}
+
+func listElements[T ~capnp.StructKind](get func() (capnp.StructList[T], error)) ([]T, error) {
+	list, err := get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list: %v", err)
+	}
+	if list.Len() == 0 {
+		return nil, nil
+	}
+	elements := make([]T, list.Len())
+	for i := range elements {
+		elements[i] = list.At(i)
+	}
+	return elements, nil
+}
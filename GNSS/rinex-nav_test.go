@@ -0,0 +1,139 @@
+package gnss
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	capnp "capnproto.org/go/capnp/v3"
+)
+
+// BenchmarkParseNav parses the bundled navigation files from memory, one
+// case per layout: RINEX 2 GPS, RINEX 2 GLONASS and RINEX 3 mixed.
+func BenchmarkParseNav(b *testing.B) {
+	for _, name := range []string{"abpo2120.24n", "brdc2050.24g", "brdc2050.nav"} {
+		b.Run(name, func(b *testing.B) {
+			data, err := os.ReadFile("../" + name)
+			if err != nil {
+				b.Skip(err)
+			}
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := ParseNav(bytes.NewReader(data), ParseOptions{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// Every record of a parsed file is in the message of its NavFile, GLONASS
+// records point at the header of that message.
+func TestParseNavSingleMessage(t *testing.T) {
+	for _, name := range []string{"abpo2120.24n", "brdc2050.24g", "brdc2050.nav"} {
+		nav, err := ParseNavFile(testFile(t, name), ParseOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg := nav.File.Message()
+		records := 0
+		check := func(system string, i int, m *capnp.Message) {
+			records++
+			if m != msg {
+				t.Fatalf("%s: %s record %d is in a message of its own", name, system, i)
+			}
+		}
+		if nav.Header.Message() != msg {
+			t.Errorf("%s: header is in a message of its own", name)
+		}
+		for i, eph := range nav.GPS {
+			check("GPS", i, eph.Message())
+		}
+		for i, eph := range nav.GLONASS {
+			check("GLONASS", i, eph.Message())
+			header, err := eph.Header()
+			if err != nil || header.Message() != msg || header.Version() != nav.Header.Version() {
+				t.Fatalf("%s: GLONASS record %d header %v", name, i, err)
+			}
+		}
+		for i, eph := range nav.Galileo {
+			check("Galileo", i, eph.Message())
+		}
+		for i, eph := range nav.BeiDou {
+			check("BeiDou", i, eph.Message())
+		}
+		for i, eph := range nav.SBAS {
+			check("SBAS", i, eph.Message())
+		}
+		if want := navRecordCount(t, testFile(t, name)); records != want {
+			t.Errorf("%s: %d records, want %d", name, records, want)
+		}
+	}
+}
+
+// Every record carries the file name, the PGM / RUN BY / DATE line and the
+// validity of its constellation.
+func TestParseNavProvenance(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		source string
+		date   time.Time
+	}{
+		{"abpo2120.24n", "teqc  2018Dec12 / gpsops", time.Date(2024, 7, 31, 0, 17, 1, 0, time.UTC)},
+		{"brdc2050.24g", "CCRINEXG V1.4 UX / CDDIS", time.Date(2024, 7, 23, 21, 18, 0, 0, time.UTC)},
+	} {
+		path := testFile(t, c.name)
+		nav, err := ParseNavFile(path, ParseOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var bases []BaseEphemeris
+		for _, eph := range nav.GPS {
+			base, _ := eph.BaseEphemeris()
+			bases = append(bases, base)
+		}
+		for _, eph := range nav.GLONASS {
+			base, _ := eph.BaseEphemeris()
+			bases = append(bases, base)
+		}
+		for _, base := range bases {
+			prn, _ := base.PseudoRandomNumber()
+			name, _ := base.FileName()
+			source, _ := base.FileSource()
+			if name != path || source != c.source {
+				t.Fatalf("%s %s: file %q source %q", c.name, prn, name, source)
+			}
+			epoch, err := base.FileEpoch()
+			if err != nil || !epoch.ToUTC().Equal(c.date) {
+				t.Fatalf("%s %s: file epoch %v, want %v", c.name, prn, epoch.ToUTC(), c.date)
+			}
+			want := map[byte]float64{'G': 7200, 'R': 1800}[prn[0]]
+			if base.EphemerisType() != NAV || base.MaximumTimeDifference() != want {
+				t.Fatalf("%s %s: type %v validity %v", c.name, prn, base.EphemerisType(), base.MaximumTimeDifference())
+			}
+		}
+	}
+
+	// no name, date or program
+	nav, err := ParseNav(strings.NewReader(mixedNavV3), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, _ := nav.Galileo[0].BaseEphemeris()
+	name, _ := base.FileName()
+	source, _ := base.FileSource()
+	if name != "" || source != "" || base.HasFileEpoch() || base.MaximumTimeDifference() != 10800 {
+		t.Errorf("Galileo file %q source %q epoch %v validity %v", name, source, base.HasFileEpoch(), base.MaximumTimeDifference())
+	}
+	if base, _ := nav.BeiDou[0].BaseEphemeris(); base.MaximumTimeDifference() != 3600 {
+		t.Errorf("BeiDou validity %v", base.MaximumTimeDifference())
+	}
+}
@@ -1,3 +1,734 @@
 package gnss
 
-// TODO Reiciver independant exchange format
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"capnproto.org/go/capnp/v3"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  OBSERVATION DATA FORMAT (RINEX 2.11 / 3.0x)
+// https://files.igs.org/pub/data/format/rinex211.txt
+// https://files.igs.org/pub/data/format/rinex305.pdf
+//
+// RINEX 2.11 epoch record, up to 12 satellites per line, then one record per
+// satellite holding 5 observations per line:
+//  24  7 30  0  0  0.0000000  0  8G05G13G15G18G20G23G24G29
+//   23619095.450 8  23619092.930 4 124121447.862 8  96718097.77644
+//
+// RINEX 3.0x epoch record, one line per satellite:
+// > 2024 07 30 00 00  0.0000000  0 31
+// G05  23619095.450 8 124121447.862 8      2919.730 8        49.000
+//
+// Every observation is F14.3 followed by the loss of lock indicator (LLI) and
+// the signal strength (1-9), both optional.
+// Event flags: 0 OK, 1 power failure since previous epoch, 2 start moving,
+// 3 new site occupation, 4 header information, 5 external event, 6 cycle
+// slip records. For flags 2-5 the satellite count is the number of special
+// records that follow.
+
+const observationFieldWidth = 16
+
+// ParseRINEXObservationFile reads filename with the default options, see
+// ParseObservation.
+func ParseRINEXObservationFile(filename string) (*ObservationHeader, []ObservationEpoch, error) {
+	file, err := openFile(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	return ParseObservation(file, ParseOptions{FileName: filename})
+}
+
+// ParseObservation reads a RINEX 2.11 / 3.0x observation file from r. The
+// warnings of a lenient parse go to opts.Logger, Parse also returns them in
+// ParsedFile.Warnings.
+func ParseObservation(r io.Reader, opts ParseOptions) (*ObservationHeader, []ObservationEpoch, error) {
+	header, epochs, _, err := parseObservation(r, opts)
+	return header, epochs, err
+}
+
+func parseObservation(r io.Reader, opts ParseOptions) (*ObservationHeader, []ObservationEpoch, []*ParseError, error) {
+	scanner := newLineScanner(r, opts)
+	// RINEX 3 satellite lines grow with the number of observation types
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	header, codes, err := parseObservationHeader(scanner)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error parsing header: %w", err)
+	}
+
+	epochs, err := parseObservationEpochs(scanner, header, codes)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error parsing observations: %w", err)
+	}
+
+	return &header, epochs, scanner.warnings, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// Satellite returns the observations of prn (G05, R12, ...) in the epoch.
+func (e ObservationEpoch) Satellite(prn string) (SatelliteObservation, bool) {
+	satellites, err := e.Satellites()
+	if err != nil {
+		return SatelliteObservation{}, false
+	}
+	for i := 0; i < satellites.Len(); i++ {
+		sat := satellites.At(i)
+		if p, _ := sat.Prn(); p == prn {
+			return sat, true
+		}
+	}
+	return SatelliteObservation{}, false
+}
+
+// Measurement returns the observation of the given code (C1C, L1, S2W, ...).
+// Blank fields of the file are returned with HasValue false.
+func (s SatelliteObservation) Measurement(code string) (Measurement, bool) {
+	measurements, err := s.Measurements()
+	if err != nil {
+		return Measurement{}, false
+	}
+	for i := 0; i < measurements.Len(); i++ {
+		m := measurements.At(i)
+		if c, _ := m.Code(); c == code {
+			return m, true
+		}
+	}
+	return Measurement{}, false
+}
+
+// Codes returns the observation codes recorded for a satellite system
+// ("G", "R", ...). RINEX 2 files declare one list for all systems.
+func (h ObservationHeader) Codes(system string) []string {
+	types, err := h.ObservationTypes()
+	if err != nil {
+		return nil
+	}
+	var fallback []string
+	for i := 0; i < types.Len(); i++ {
+		t := types.At(i)
+		s, _ := t.System()
+		if s != system && s != "" {
+			continue
+		}
+		list, err := t.Codes()
+		if err != nil {
+			return nil
+		}
+		codes := make([]string, list.Len())
+		for j := range codes {
+			codes[j], _ = list.At(j)
+		}
+		if s == system {
+			return codes
+		}
+		fallback = codes
+	}
+	return fallback
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// observationCodes tracks the observation types in use. They are declared in
+// the header and may be redefined by event records with flag 4.
+type observationCodes struct {
+	systems []string
+	codes   map[string][]string
+	last    string
+}
+
+func newObservationCodes() *observationCodes {
+	return &observationCodes{codes: make(map[string][]string)}
+}
+
+func (c *observationCodes) reset(system string) {
+	if _, ok := c.codes[system]; !ok {
+		c.systems = append(c.systems, system)
+	}
+	c.codes[system] = nil
+	c.last = system
+}
+
+// addTypesLine reads line i of record, a "# / TYPES OF OBSERV" (RINEX 2, 9
+// codes per line) or "SYS / # / OBS TYPES" (RINEX 3, 13 codes per line)
+// header line. A blank count or system marks a continuation line.
+func (c *observationCodes) addTypesLine(label string, record *lineRecord, i int) {
+	var start string
+	layout := obsTypesV2
+	if label == "SYS / # / OBS TYPES" {
+		layout = obsTypesV3
+	}
+	fields := make([]string, len(layout)-1)
+	dst := []any{&start}
+	for j := range fields {
+		dst = append(dst, &fields[j])
+	}
+	record.decode(i, layout, dst...)
+
+	if start != "" {
+		if label == "SYS / # / OBS TYPES" {
+			c.reset(start)
+		} else {
+			c.reset("")
+		}
+	}
+	for _, code := range fields {
+		if code != "" {
+			c.codes[c.last] = append(c.codes[c.last], code)
+		}
+	}
+}
+
+func (c *observationCodes) forSystem(system string) []string {
+	if codes, ok := c.codes[system]; ok {
+		return codes
+	}
+	return c.codes[""]
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func parseObservationHeader(scanner *lineScanner) (ObservationHeader, *observationCodes, error) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return ObservationHeader{}, nil, fmt.Errorf("failed to create new message: %v", err)
+	}
+	header, err := NewRootObservationHeader(seg)
+	if err != nil {
+		return ObservationHeader{}, nil, fmt.Errorf("failed to create new ObservationHeader: %v", err)
+	}
+
+	codes := newObservationCodes()
+	var comments []string
+	timeSystem := ""
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) < 61 {
+			continue
+		}
+		record := scanner.newRecord()
+		record.add(line)
+		label := strings.TrimSpace(line[60:])
+		switch label {
+		case "RINEX VERSION / TYPE":
+			var version float64
+			var fileType, system string
+			record.decode(0, obsVersionLine, &version, &fileType, &system)
+			header.SetVersion(version)
+			header.SetType(fileType)
+			header.SetSatelliteSystem(system)
+		case "PGM / RUN BY / DATE":
+			var program, agency, date string
+			record.decode(0, obsProgramLine, &program, &agency, &date)
+			header.SetProgramName(program)
+			header.SetAgency(agency)
+			if parsedDate, ok := parseHeaderDate(date); ok {
+				newDate, err := header.NewDate()
+				if err != nil {
+					return ObservationHeader{}, nil, fmt.Errorf("failed to create new Time struct: %v", err)
+				}
+				newDate.SetSeconds(parsedDate.Unix())
+				newDate.SetNanoseconds(int32(parsedDate.Nanosecond()))
+			}
+		case "MARKER NAME", "COMMENT":
+			var text string
+			record.decode(0, obsTextLine, &text)
+			if label == "COMMENT" {
+				comments = append(comments, text)
+			} else {
+				header.SetMarkerName(text)
+			}
+		case "MARKER NUMBER":
+			var number string
+			record.decode(0, obsMarkerNumberLine, &number)
+			header.SetMarkerNumber(number)
+		case "OBSERVER / AGENCY":
+			var observer, agency string
+			record.decode(0, obsObserverLine, &observer, &agency)
+			header.SetObserver(observer)
+			header.SetObserverAgency(agency)
+		case "REC # / TYPE / VERS":
+			var number, receiverType, version string
+			record.decode(0, obsReceiverLine, &number, &receiverType, &version)
+			header.SetReceiverNumber(number)
+			header.SetReceiverType(receiverType)
+			header.SetReceiverVersion(version)
+		case "ANT # / TYPE":
+			var number, antennaType string
+			record.decode(0, obsAntennaLine, &number, &antennaType)
+			header.SetAntennaNumber(number)
+			header.SetAntennaType(antennaType)
+		case "APPROX POSITION XYZ":
+			if err := setFloat64List(header.NewApproximatePosition, record, obsVectorLine); err != nil {
+				return ObservationHeader{}, nil, err
+			}
+		case "ANTENNA: DELTA H/E/N":
+			if err := setFloat64List(header.NewAntennaDelta, record, obsVectorLine); err != nil {
+				return ObservationHeader{}, nil, err
+			}
+		case "# / TYPES OF OBSERV", "SYS / # / OBS TYPES":
+			codes.addTypesLine(label, record, 0)
+		case "SIGNAL STRENGTH UNIT":
+			var unit string
+			record.decode(0, obsSignalStrengthLine, &unit)
+			header.SetSignalStrengthUnit(unit)
+		case "INTERVAL":
+			var interval float64
+			record.decode(0, obsIntervalLine, &interval)
+			header.SetInterval(interval)
+		case "TIME OF FIRST OBS", "TIME OF LAST OBS":
+			var epoch epochFields
+			var system string
+			record.decode(0, obsHeaderEpoch, append(epoch.dst(), &system)...)
+			if record.err != nil {
+				break
+			}
+			t := epoch.time()
+			newTime := header.NewTimeOfFirstObservation
+			if label == "TIME OF LAST OBS" {
+				newTime = header.NewTimeOfLastObservation
+			}
+			obsTime, err := newTime()
+			if err != nil {
+				return ObservationHeader{}, nil, fmt.Errorf("failed to create new Time struct: %v", err)
+			}
+			obsTime.SetSeconds(t.Unix())
+			obsTime.SetNanoseconds(int32(t.Nanosecond()))
+			if system != "" {
+				timeSystem = system
+			}
+		case "LEAP SECONDS":
+			var leapSeconds int
+			record.decode(0, obsLeapSecondsLine, &leapSeconds)
+			header.SetLeapSeconds(int32(leapSeconds))
+		case "END OF HEADER":
+			// Single system files may leave the time system blank
+			if timeSystem == "" {
+				system, _ := header.SatelliteSystem()
+				timeSystem = defaultTimeSystem(system)
+			}
+			header.SetTimeSystem(timeSystem)
+
+			commentList, err := header.NewComments(int32(len(comments)))
+			if err != nil {
+				return ObservationHeader{}, nil, fmt.Errorf("failed to create new Comments: %v", err)
+			}
+			for i, comment := range comments {
+				commentList.Set(i, comment)
+			}
+
+			if err := setObservationTypes(header, codes); err != nil {
+				return ObservationHeader{}, nil, err
+			}
+			return header, codes, nil
+		}
+		if record.err != nil {
+			return ObservationHeader{}, nil, record.err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return ObservationHeader{}, nil, err
+	}
+	return ObservationHeader{}, nil, fmt.Errorf("end of header not found")
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func setObservationTypes(header ObservationHeader, codes *observationCodes) error {
+	types, err := header.NewObservationTypes(int32(len(codes.systems)))
+	if err != nil {
+		return fmt.Errorf("failed to create new ObservationTypes: %v", err)
+	}
+	for i, system := range codes.systems {
+		t := types.At(i)
+		t.SetSystem(system)
+		list, err := t.NewCodes(int32(len(codes.codes[system])))
+		if err != nil {
+			return fmt.Errorf("failed to create new codes: %v", err)
+		}
+		for j, code := range codes.codes[system] {
+			list.Set(j, code)
+		}
+	}
+	return nil
+}
+
+func setFloat64List(newList func(int32) (capnp.Float64List, error), record *lineRecord, layout recordLayout) error {
+	values := make([]float64, len(layout))
+	dst := make([]any, len(layout))
+	for i := range values {
+		dst[i] = &values[i]
+	}
+	record.decode(0, layout, dst...)
+	list, err := newList(int32(len(values)))
+	if err != nil {
+		return fmt.Errorf("failed to create new list: %v", err)
+	}
+	for i, v := range values {
+		list.Set(i, v)
+	}
+	return nil
+}
+
+// RINEX 2 writes "30-Jul-24 00:00", RINEX 3 "20240730 000000 UTC", teqc
+// "20240731 00:17:01UTC"
+func parseHeaderDate(s string) (time.Time, bool) {
+	for _, layout := range []string{"02-Jan-06 15:04", "20060102 150405 MST", "20060102 150405", "20060102 15:04:05MST"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func defaultTimeSystem(satelliteSystem string) string {
+	switch column(satelliteSystem, 0, 1) {
+	case "R":
+		return "GLO"
+	case "E":
+		return "GAL"
+	case "C":
+		return "BDT"
+	case "J":
+		return "QZS"
+	case "I":
+		return "IRN"
+	default:
+		return "GPS"
+	}
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// satelliteRecord holds the decoded observations of one satellite, one per
+// observation code.
+type satelliteRecord struct {
+	prn          string
+	codes        []string
+	measurements []measurementFields
+}
+
+// measurementFields is an F14.3 observation with its loss of lock indicator
+// and signal strength (I1 each). Any of the three may be blank.
+type measurementFields struct {
+	value    float64
+	hasValue bool
+	lli      int
+	strength int
+}
+
+// parseObservationEpochs reads the epochs as records of the epoch line, its
+// continuation lines and the satellite or event lines that follow. Records
+// that cannot be read are skipped with a warning in lenient mode.
+func parseObservationEpochs(scanner *lineScanner, header ObservationHeader, codes *observationCodes) ([]ObservationEpoch, error) {
+	version := header.Version()
+	timeSystem, _ := header.TimeSystem()
+	leapSeconds := int(header.LeapSeconds())
+
+	var epochs []ObservationEpoch
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		record := scanner.newRecord()
+		record.add(line)
+
+		var epochTime time.Time
+		var flag, count int
+		var clock float64
+		var err error
+		if version >= 3 {
+			epochTime, flag, count, clock, err = parseEpochLineV3(record)
+		} else {
+			epochTime, flag, count, clock, err = parseEpochLineV2(record)
+		}
+		if err == nil && record.err != nil {
+			return nil, record.err
+		}
+
+		var records []string
+		var satellites []satelliteRecord
+		if err == nil && flag >= 2 && flag <= 5 {
+			if err = readLines(scanner, record, count); err == nil {
+				records = record.lines[1:]
+			}
+			if err == nil && flag == 4 {
+				for i := 1; i < record.len(); i++ {
+					if len(record.lines[i]) > 60 {
+						codes.addTypesLine(strings.TrimSpace(record.lines[i][60:]), record, i)
+					}
+				}
+			}
+		} else if err == nil && version >= 3 {
+			satellites, err = readSatellitesV3(scanner, record, count, codes)
+		} else if err == nil {
+			satellites, err = readSatellitesV2(scanner, record, count, codes)
+		}
+		if err == nil {
+			err = record.err
+		}
+		if err != nil {
+			if ioErr := scanner.Err(); ioErr != nil {
+				return nil, ioErr
+			}
+			if err := scanner.recordError(record, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		gpsTime := systemTimeToGPSTime(epochTime, timeSystem, leapSeconds)
+		epoch, err := newObservationEpoch(epochTime, gpsTime, flag, clock, satellites, records)
+		if err != nil {
+			return nil, err
+		}
+		epochs = append(epochs, epoch)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return epochs, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// RINEX 2.11: 1X,I2.2,4(1X,I2),F11.7,2X,I1,I3,12(A1,I2),F12.9
+func parseEpochLineV2(record *lineRecord) (time.Time, int, int, float64, error) {
+	if len(record.lines[0]) < 32 {
+		return time.Time{}, 0, 0, 0, fmt.Errorf("epoch line is too short")
+	}
+	t, flag, count, clock := decodeEpochLine(record, obsEpochV2)
+	return t, flag, count, clock, nil
+}
+
+// RINEX 3.0x: A1,1X,I4,4(1X,I2.2),F11.7,2X,I1,I3,6X,F15.12
+func parseEpochLineV3(record *lineRecord) (time.Time, int, int, float64, error) {
+	if line := record.lines[0]; len(line) < 35 || line[0] != '>' {
+		return time.Time{}, 0, 0, 0, fmt.Errorf("invalid epoch line")
+	}
+	t, flag, count, clock := decodeEpochLine(record, obsEpochV3)
+	return t, flag, count, clock, nil
+}
+
+// decodeEpochLine reads the date, epoch flag, number of satellites and
+// receiver clock offset of an observation epoch line.
+func decodeEpochLine(record *lineRecord, layout recordLayout) (time.Time, int, int, float64) {
+	epoch := epochFields{shortYear: layout[0].width == 2}
+	var flag, count int
+	var clock float64
+	record.decode(0, layout, append(epoch.dst(), &flag, &count, &clock)...)
+	return epoch.time(), flag, count, clock
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// readSatellitesV2 reads the satellite list of the epoch line of record and
+// its continuation lines, then the observation lines of every satellite.
+func readSatellitesV2(scanner *lineScanner, record *lineRecord, count int, codes *observationCodes) ([]satelliteRecord, error) {
+	prns := make([]string, 0, count)
+	for line := record.lines[0]; ; line = record.lines[record.len()-1] {
+		for i := 0; i < 12 && len(prns) < count; i++ {
+			prns = append(prns, normalizePRN(column(line, 32+3*i, 35+3*i)))
+		}
+		if len(prns) == count {
+			break
+		}
+		if err := readLines(scanner, record, 1); err != nil {
+			return nil, fmt.Errorf("missing satellite list continuation line")
+		}
+	}
+
+	satellites := make([]satelliteRecord, count)
+	for i, prn := range prns {
+		types := codes.forSystem(prn[:1])
+		lineCount := (len(types) + 4) / 5
+		if lineCount == 0 {
+			lineCount = 1
+		}
+		first := record.len()
+		if err := readLines(scanner, record, lineCount); err != nil {
+			return nil, fmt.Errorf("%s: %v", prn, err)
+		}
+		measurements := make([]measurementFields, len(types))
+		for j := range types {
+			measurements[j] = decodeMeasurement(record, first+j/5, (j%5)*observationFieldWidth)
+		}
+		satellites[i] = satelliteRecord{prn: prn, codes: types, measurements: measurements}
+	}
+	return satellites, nil
+}
+
+func readSatellitesV3(scanner *lineScanner, record *lineRecord, count int, codes *observationCodes) ([]satelliteRecord, error) {
+	if err := readLines(scanner, record, count); err != nil {
+		return nil, err
+	}
+	satellites := make([]satelliteRecord, count)
+	for i := range satellites {
+		prn := normalizePRN(column(record.lines[1+i], 0, 3))
+		types := codes.forSystem(prn[:1])
+		measurements := make([]measurementFields, len(types))
+		for j := range types {
+			measurements[j] = decodeMeasurement(record, 1+i, 3+j*observationFieldWidth)
+		}
+		satellites[i] = satelliteRecord{prn: prn, codes: types, measurements: measurements}
+	}
+	return satellites, nil
+}
+
+// decodeMeasurement reads the observation field at column from of line i.
+func decodeMeasurement(record *lineRecord, i, from int) measurementFields {
+	var m measurementFields
+	if strings.TrimSpace(column(record.lines[i], from, from+14)) != "" {
+		m.value = record.float(i, from, from+14)
+		m.hasValue = true
+	}
+	m.lli = record.integer(i, from+14, from+15)
+	m.strength = record.integer(i, from+15, from+16)
+	return m
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func newObservationEpoch(epochTime time.Time, gpsTime GPSTime, flag int, clock float64, satellites []satelliteRecord, records []string) (ObservationEpoch, error) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return ObservationEpoch{}, fmt.Errorf("failed to create new message: %v", err)
+	}
+	epoch, err := NewRootObservationEpoch(seg)
+	if err != nil {
+		return ObservationEpoch{}, fmt.Errorf("failed to create new ObservationEpoch: %v", err)
+	}
+
+	t, err := epoch.NewEpoch()
+	if err != nil {
+		return ObservationEpoch{}, fmt.Errorf("failed to create new Time struct: %v", err)
+	}
+	t.SetSeconds(epochTime.Unix())
+	t.SetNanoseconds(int32(epochTime.Nanosecond()))
+
+	gt, err := epoch.NewGpsTime()
+	if err != nil {
+		return ObservationEpoch{}, fmt.Errorf("failed to create new GPSTime: %v", err)
+	}
+	setGPSTime(gt, gpsTime)
+
+	epoch.SetEventFlag(uint8(flag))
+	epoch.SetReceiverClockOffset(clock)
+
+	recordList, err := epoch.NewEventRecords(int32(len(records)))
+	if err != nil {
+		return ObservationEpoch{}, fmt.Errorf("failed to create new event records: %v", err)
+	}
+	for i, record := range records {
+		recordList.Set(i, record)
+	}
+
+	satList, err := epoch.NewSatellites(int32(len(satellites)))
+	if err != nil {
+		return ObservationEpoch{}, fmt.Errorf("failed to create new satellites: %v", err)
+	}
+	for i, sat := range satellites {
+		s := satList.At(i)
+		s.SetPrn(sat.prn)
+		measurements, err := s.NewMeasurements(int32(len(sat.codes)))
+		if err != nil {
+			return ObservationEpoch{}, fmt.Errorf("failed to create new measurements: %v", err)
+		}
+		for j, code := range sat.codes {
+			m := measurements.At(j)
+			f := sat.measurements[j]
+			m.SetCode(code)
+			m.SetValue(f.value)
+			m.SetHasValue(f.hasValue)
+			m.SetLossOfLockIndicator(uint8(f.lli))
+			m.SetSignalStrength(uint8(f.strength))
+		}
+	}
+	return epoch, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// RINEX and SP3 epochs are given in the time system of the file, GLONASS
+// files use UTC. leapSeconds is taken from the table when zero.
+func systemTimeToGPSTime(t time.Time, timeSystem string, leapSeconds int) GPSTime {
+	switch timeSystem {
+	case "TAI":
+		return GPSTimeFromDateTime(t.Add(-19 * time.Second))
+	case "GLO", "UTC":
+		if leapSeconds > 0 {
+			return GPSTimeFromDateTime(t.Add(time.Duration(leapSeconds) * time.Second))
+		}
+		return UTCToGPST(t)
+	case "BDT":
+		return GPSTimeFromDateTime(t).Add(BDT_GPS_SECONDS_OFFSET)
+	default:
+		return GPSTimeFromDateTime(t)
+	}
+}
+
+// RINEX 2 allows a blank system letter for GPS and blanks in the number
+func normalizePRN(s string) string {
+	b := []byte(fmt.Sprintf("%-3s", s))
+	if b[0] == ' ' {
+		b[0] = 'G'
+	}
+	if b[1] == ' ' {
+		b[1] = '0'
+	}
+	return string(b)
+}
+
+// readLines adds the next count lines to record.
+func readLines(scanner *lineScanner, record *lineRecord, count int) error {
+	for i := 0; i < count; i++ {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return err
+			}
+			return fmt.Errorf("unexpected end of file, %d of %d lines read", i, count)
+		}
+		record.add(scanner.Text())
+	}
+	return nil
+}
+
+// column returns line[from:to] clipped to the length of the line. Trailing
+// blanks are often stripped from RINEX lines.
+func column(line string, from, to int) string {
+	if from >= len(line) {
+		return ""
+	}
+	if to > len(line) {
+		to = len(line)
+	}
+	return line[from:to]
+}
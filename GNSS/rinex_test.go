@@ -0,0 +1,157 @@
+package gnss
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+
+func TestParseObservationV211(t *testing.T) {
+	header, epochs, err := ParseRINEXObservationFile("testdata/obs-211.24o")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Version() != 2.11 {
+		t.Errorf("version %v, want 2.11", header.Version())
+	}
+	if got, want := header.Codes("G"), []string{"L1", "L2", "C1", "P2", "P1", "S1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("codes %v, want %v", got, want)
+	}
+	if len(epochs) != 3 {
+		t.Fatalf("%d epochs, want 3", len(epochs))
+	}
+
+	// 13 satellites, the last on the continuation line of the epoch line,
+	// each with S1 on a second line
+	first := epochs[0]
+	if first.EventFlag() != 0 {
+		t.Errorf("event flag %d, want 0", first.EventFlag())
+	}
+	satellites, err := first.Satellites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if satellites.Len() != 13 {
+		t.Fatalf("%d satellites, want 13", satellites.Len())
+	}
+	for i := 0; i < satellites.Len(); i++ {
+		sat := satellites.At(i)
+		prn, _ := sat.Prn()
+		for _, c := range []struct {
+			code  string
+			value float64
+		}{
+			{"L1", 120000000 + float64(i)},
+			{"C1", 23000000 + float64(i)},
+			{"S1", 45},
+		} {
+			m, ok := sat.Measurement(c.code)
+			if !ok || !m.HasValue() || m.Value() != c.value || m.SignalStrength() != 8 {
+				t.Errorf("%s %s: %v %v strength %d, want %v", prn, c.code, ok, m.Value(), m.SignalStrength(), c.value)
+			}
+		}
+	}
+	prns := make([]string, 0, 3)
+	for _, i := range []int{1, 2, 12} {
+		prn, _ := satellites.At(i).Prn()
+		prns = append(prns, prn)
+	}
+	if want := []string{"R12", "G01", "G11"}; !reflect.DeepEqual(prns, want) {
+		t.Errorf("PRNs %v, want %v", prns, want)
+	}
+
+	// flag 4 redefines the observation types for the following epochs
+	event := epochs[1]
+	if event.EventFlag() != 4 {
+		t.Errorf("event flag %d, want 4", event.EventFlag())
+	}
+	records, err := event.EventRecords()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if records.Len() != 1 {
+		t.Fatalf("%d event records, want 1", records.Len())
+	}
+	if record, _ := records.At(0); !strings.HasSuffix(record, "# / TYPES OF OBSERV") {
+		t.Errorf("event record %q", record)
+	}
+
+	last := epochs[2]
+	epochTime, err := last.Epoch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := time.Unix(epochTime.Seconds(), 0).UTC(), time.Date(2024, 7, 30, 0, 1, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("epoch %v, want %v", got, want)
+	}
+	sat, ok := last.Satellite("G05")
+	if !ok {
+		t.Fatal("no G05")
+	}
+	measurements, _ := sat.Measurements()
+	if measurements.Len() != 2 {
+		t.Errorf("%d measurements, want 2", measurements.Len())
+	}
+	if m, ok := sat.Measurement("C1"); !ok || m.Value() != 22000000 {
+		t.Errorf("C1 %v %v, want 22000000", ok, m.Value())
+	}
+	if m, ok := sat.Measurement("L1"); !ok || m.Value() != 110000000 || m.LossOfLockIndicator() != 1 || m.SignalStrength() != 5 {
+		t.Errorf("L1 %v %v LLI %d strength %d, want 110000000 1 5", ok, m.Value(), m.LossOfLockIndicator(), m.SignalStrength())
+	}
+}
+
+func TestParseObservationV304(t *testing.T) {
+	header, epochs, err := ParseRINEXObservationFile("testdata/obs-304.24o")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for system, want := range map[string][]string{
+		"G": {"C1C", "L1C", "D1C", "S1C"},
+		"E": {"C1X", "L1X"},
+	} {
+		if got := header.Codes(system); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s codes %v, want %v", system, got, want)
+		}
+	}
+	if len(epochs) != 1 {
+		t.Fatalf("%d epochs, want 1", len(epochs))
+	}
+
+	gps, ok := epochs[0].Satellite("G05")
+	if !ok {
+		t.Fatal("no G05")
+	}
+	for _, c := range []struct {
+		code          string
+		value         float64
+		lli, strength uint8
+	}{
+		{"C1C", 23000000, 0, 8},
+		{"L1C", 120000000, 1, 8},
+		{"D1C", -2919.73, 0, 8},
+		{"S1C", 49, 0, 8},
+	} {
+		m, ok := gps.Measurement(c.code)
+		if !ok || !m.HasValue() || m.Value() != c.value || m.LossOfLockIndicator() != c.lli || m.SignalStrength() != c.strength {
+			t.Errorf("G05 %s: %v %v LLI %d strength %d, want %v %d %d", c.code, ok, m.Value(),
+				m.LossOfLockIndicator(), m.SignalStrength(), c.value, c.lli, c.strength)
+		}
+	}
+
+	// the line of E11 ends after its first field
+	galileo, ok := epochs[0].Satellite("E11")
+	if !ok {
+		t.Fatal("no E11")
+	}
+	if m, ok := galileo.Measurement("C1X"); !ok || !m.HasValue() || m.Value() != 25000000 {
+		t.Errorf("E11 C1X %v %v, want 25000000", ok, m.Value())
+	}
+	if m, ok := galileo.Measurement("L1X"); !ok || m.HasValue() {
+		t.Errorf("E11 L1X %v has value %v, want a blank field", ok, m.HasValue())
+	}
+}
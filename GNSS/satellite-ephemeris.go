@@ -0,0 +1,189 @@
+package gnss
+
+import "fmt"
+
+// =========================================================================
+
+// =========================================================================
+//  SATELLITE EPHEMERIS
+// Broadcast ephemerides of every constellation and interpolated SP3 orbits
+// behind one interface. Broadcast records take PRN, epoch, health and the
+// validity interval (maximumTimeDifference) from their BaseEphemeris.
+//
+// RINEXEphemeris already has a generated Epoch() accessor returning the UTC
+// record time, GLONASSEphemeris wraps it to satisfy the interface.
+
+type SatelliteEphemeris interface {
+	PRN() string
+	Epoch() GPSTime
+	Valid(time GPSTime) bool
+	Healthy() bool
+	Type() EphemerisType
+	GetSatInfo(time GPSTime) ([]float64, []float64, float64, float64, error)
+}
+
+var (
+	_ SatelliteEphemeris = GPSEphemeris{}
+	_ SatelliteEphemeris = GalileoEphemeris{}
+	_ SatelliteEphemeris = BeiDouEphemeris{}
+	_ SatelliteEphemeris = GLONASSEphemeris{}
+	_ SatelliteEphemeris = (*SP3Interpolator)(nil)
+)
+
+type GLONASSEphemeris struct {
+	RINEXEphemeris
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func basePRN(base BaseEphemeris, err error) string {
+	if err != nil {
+		return ""
+	}
+	prn, _ := base.PseudoRandomNumber()
+	return prn
+}
+
+func baseEpoch(base BaseEphemeris, err error) GPSTime {
+	if err != nil {
+		return GPSTime{}
+	}
+	epoch, _ := base.Epoch()
+	return epoch
+}
+
+func baseType(base BaseEphemeris, err error) EphemerisType {
+	if err != nil {
+		return NAV
+	}
+	return base.EphemerisType()
+}
+
+func baseHealthy(base BaseEphemeris, err error) bool {
+	return err == nil && base.IsHealthy()
+}
+
+func baseValid(base BaseEphemeris, err error, time GPSTime) bool {
+	return err == nil && base.Valid(time)
+}
+
+// EphemerisFile returns the name of the file an ephemeris was parsed from,
+// empty for records built without a file name. SP3 orbits without a name
+// give the agency and start of their header.
+func EphemerisFile(eph SatelliteEphemeris) string {
+	var base BaseEphemeris
+	var err error
+	switch e := eph.(type) {
+	case *SP3Interpolator:
+		if e.FileName != "" || e.agency == "" {
+			return e.FileName
+		}
+		return fmt.Sprintf("%s %s", e.agency, e.start.ToUTC().Format("2006-01-02 15:04:05"))
+	case GPSEphemeris:
+		base, err = e.BaseEphemeris()
+	case GalileoEphemeris:
+		base, err = e.BaseEphemeris()
+	case BeiDouEphemeris:
+		base, err = e.BaseEphemeris()
+	case GLONASSEphemeris:
+		base, err = e.BaseEphemeris()
+	default:
+		return ""
+	}
+	if err != nil {
+		return ""
+	}
+	name, _ := base.FileName()
+	return name
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func (e GPSEphemeris) PRN() string         { return basePRN(e.BaseEphemeris()) }
+func (e GPSEphemeris) Epoch() GPSTime      { return baseEpoch(e.BaseEphemeris()) }
+func (e GPSEphemeris) Healthy() bool       { return baseHealthy(e.BaseEphemeris()) }
+func (e GPSEphemeris) Type() EphemerisType { return baseType(e.BaseEphemeris()) }
+
+func (e GPSEphemeris) Valid(time GPSTime) bool {
+	base, err := e.BaseEphemeris()
+	return baseValid(base, err, time)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func (e GalileoEphemeris) PRN() string         { return basePRN(e.BaseEphemeris()) }
+func (e GalileoEphemeris) Epoch() GPSTime      { return baseEpoch(e.BaseEphemeris()) }
+func (e GalileoEphemeris) Healthy() bool       { return baseHealthy(e.BaseEphemeris()) }
+func (e GalileoEphemeris) Type() EphemerisType { return baseType(e.BaseEphemeris()) }
+
+func (e GalileoEphemeris) Valid(time GPSTime) bool {
+	base, err := e.BaseEphemeris()
+	return baseValid(base, err, time)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func (e BeiDouEphemeris) PRN() string         { return basePRN(e.BaseEphemeris()) }
+func (e BeiDouEphemeris) Epoch() GPSTime      { return baseEpoch(e.BaseEphemeris()) }
+func (e BeiDouEphemeris) Healthy() bool       { return baseHealthy(e.BaseEphemeris()) }
+func (e BeiDouEphemeris) Type() EphemerisType { return baseType(e.BaseEphemeris()) }
+
+func (e BeiDouEphemeris) Valid(time GPSTime) bool {
+	base, err := e.BaseEphemeris()
+	return baseValid(base, err, time)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func (e GLONASSEphemeris) PRN() string         { return basePRN(e.BaseEphemeris()) }
+func (e GLONASSEphemeris) Epoch() GPSTime      { return baseEpoch(e.BaseEphemeris()) }
+func (e GLONASSEphemeris) Healthy() bool       { return baseHealthy(e.BaseEphemeris()) }
+func (e GLONASSEphemeris) Type() EphemerisType { return baseType(e.BaseEphemeris()) }
+
+func (e GLONASSEphemeris) Valid(time GPSTime) bool {
+	base, err := e.BaseEphemeris()
+	return baseValid(base, err, time)
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// PRN returns the satellite the interpolator was built for.
+func (s *SP3Interpolator) PRN() string { return s.prn }
+
+// Epoch returns the time of the first SP3 sample of the satellite.
+func (s *SP3Interpolator) Epoch() GPSTime { return s.reference }
+
+// Healthy is always true, bad SP3 samples are dropped when the interpolator
+// is built.
+func (s *SP3Interpolator) Healthy() bool { return true }
+
+func (s *SP3Interpolator) Type() EphemerisType { return s.OrbitType }
+
+// Agency returns the agency of the SP3 header.
+func (s *SP3Interpolator) Agency() string { return s.agency }
+
+// Start returns the first epoch of the SP3 header.
+func (s *SP3Interpolator) Start() GPSTime { return s.start }
+
+// Valid reports whether time can be interpolated: it lies inside the data
+// span and the window around it holds no gap.
+func (s *SP3Interpolator) Valid(time GPSTime) bool {
+	t := time.Sub(s.reference)
+	if _, _, err := s.interpolatePosition(t); err != nil {
+		return false
+	}
+	_, _, err := s.interpolateClock(t)
+	return err == nil
+}
@@ -0,0 +1,93 @@
+package gnss
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+
+func TestSatelliteEphemeris(t *testing.T) {
+	nav, err := ParseNav(strings.NewReader(mixedNavV3), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the epochs are the toc of the records in GPS time, tb for GLONASS
+	toc := time.Date(2024, 7, 29, 23, 59, 44, 0, time.UTC)
+	for _, c := range []struct {
+		eph     SatelliteEphemeris
+		prn     string
+		epoch   time.Time
+		maxDiff float64
+	}{
+		{nav.GPS[0], "G05", toc, 7200},
+		{nav.Galileo[0], "E05", toc, 10800},
+		{nav.BeiDou[0], "C19", toc, 3600},
+		{GLONASSEphemeris{nav.GLONASS[0]}, "R01", time.Date(2024, 7, 23, 0, 15, 18, 0, time.UTC), 1800},
+	} {
+		if c.eph.PRN() != c.prn {
+			t.Errorf("PRN %q, want %q", c.eph.PRN(), c.prn)
+		}
+		epoch := c.eph.Epoch()
+		if !epoch.ToDateTime().Equal(c.epoch) {
+			t.Errorf("%s: epoch %v, want %v", c.prn, epoch.ToDateTime(), c.epoch)
+		}
+		if !c.eph.Healthy() || c.eph.Type() != NAV {
+			t.Errorf("%s: healthy %v type %v", c.prn, c.eph.Healthy(), c.eph.Type())
+		}
+		if !c.eph.Valid(epoch.Add(-c.maxDiff)) || !c.eph.Valid(epoch.Add(c.maxDiff)) {
+			t.Errorf("%s: not valid %.0f s from the epoch", c.prn, c.maxDiff)
+		}
+		if c.eph.Valid(epoch.Add(c.maxDiff + 1)) {
+			t.Errorf("%s: valid %.0f s from the epoch", c.prn, c.maxDiff+1)
+		}
+		if _, _, _, _, err := c.eph.GetSatInfo(epoch.Add(60)); err != nil {
+			t.Errorf("%s: %v", c.prn, err)
+		}
+	}
+}
+
+func TestSP3SatelliteEphemeris(t *testing.T) {
+	s, _, start := newTestInterpolator(t)
+	var eph SatelliteEphemeris = s
+	if eph.PRN() != "G05" || eph.Type() != FINAL_ORBIT || !eph.Healthy() {
+		t.Errorf("PRN %q type %v healthy %v", eph.PRN(), eph.Type(), eph.Healthy())
+	}
+	if eph.Epoch().Sub(start) != 0 {
+		t.Errorf("epoch %v, want the first sample %v", eph.Epoch().ToDateTime(), start.ToDateTime())
+	}
+	if !eph.Valid(start) || !eph.Valid(start.Add(7200)) || eph.Valid(start.Add(7201)) {
+		t.Error("validity does not follow the data span")
+	}
+}
+
+func TestEphemerisFile(t *testing.T) {
+	path := testFile(t, "abpo2120.24n")
+	nav, err := ParseNavFile(path, ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := EphemerisFile(nav.GPS[0]); name != path {
+		t.Errorf("GPS record of %q, want %q", name, path)
+	}
+	unnamed, err := ParseNav(strings.NewReader(mixedNavV3), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := EphemerisFile(unnamed.GPS[0]); name != "" {
+		t.Errorf("record parsed without a name is from %q", name)
+	}
+
+	// SP3 orbits without a name are labelled by agency and start
+	s, _, _ := newTestInterpolator(t)
+	if label := EphemerisFile(s); label != "TST 2024-07-29 22:59:26" {
+		t.Errorf("SP3 orbit from %q", label)
+	}
+	s.FileName = "whu23250.sp3"
+	if label := EphemerisFile(s); label != s.FileName {
+		t.Errorf("SP3 orbit from %q, want %q", label, s.FileName)
+	}
+}
@@ -0,0 +1,245 @@
+package gnss
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  SP3 INTERPOLATION
+// Positions are interpolated with a Lagrange polynomial through Window
+// consecutive epochs around the requested time, velocities are the
+// derivative of that polynomial. Clocks are interpolated linearly between the
+// two closest valid samples, bad clocks (999999.999999) are skipped.
+// Epochs with a 0.000000 position are dropped, a window is never stretched
+// across a gap larger than MaxGap seconds.
+
+const (
+	sp3BadClock         = 999999.0
+	defaultSP3Window    = 10
+	defaultSP3GapFactor = 1.5
+)
+
+type SP3Interpolator struct {
+	Window int
+	MaxGap float64
+	// OrbitType ranks the product against other sources, SP3 files do not
+	// carry it so it defaults to FINAL_ORBIT
+	OrbitType EphemerisType
+	// FileName is the file the samples were read from, see EphemerisFile
+	FileName string
+
+	prn       string
+	reference GPSTime
+	// agency and start of the SP3 header
+	agency string
+	start  GPSTime
+	// positions, in meters, and their time since reference
+	positionTimes []float64
+	positions     [][3]float64
+	// valid clocks, in seconds, and their time since reference
+	clockTimes []float64
+	clocks     []float64
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// NewSP3Interpolator collects the samples of prn from sp3. window is the
+// number of epochs used for the position polynomial (its order plus one),
+// zero selects 10. MaxGap defaults to 1.5 epoch intervals.
+func NewSP3Interpolator(sp3 SP3FormatEphemeris, prn string, window int) (*SP3Interpolator, error) {
+	if window == 0 {
+		window = defaultSP3Window
+	}
+	if window < 2 {
+		return nil, fmt.Errorf("interpolation window must hold at least 2 epochs, got %d", window)
+	}
+
+	header, err := sp3.Header()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SP3 header: %v", err)
+	}
+	epochs, err := sp3.Epochs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SP3 epochs: %v", err)
+	}
+
+	s := &SP3Interpolator{
+		Window:    window,
+		MaxGap:    defaultSP3GapFactor * header.EpochInterval(),
+		OrbitType: FINAL_ORBIT,
+		prn:       prn,
+	}
+	s.agency, _ = header.Agency()
+	if start, err := header.Start(); err == nil {
+		timeSystem, _ := header.TimeSystem()
+		s.start = systemTimeToGPSTime(time.Unix(start.Seconds(), int64(start.Nanoseconds())).UTC(), timeSystem, 0)
+	}
+
+	for i := 0; i < epochs.Len(); i++ {
+		epoch := epochs.At(i)
+		entry, ok := findSP3Entry(epoch, prn)
+		if !ok {
+			continue
+		}
+		t, err := epoch.GpsTime()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get epoch time: %v", err)
+		}
+		if len(s.positionTimes) == 0 && len(s.clockTimes) == 0 {
+			s.reference = GPSTimeFromWeek(int(t.Week()), t.TimeOfWeek())
+		}
+		dt := t.Sub(s.reference)
+
+		x, y, z := entry.XPosition(), entry.YPosition(), entry.ZPosition()
+		if x != 0 || y != 0 || z != 0 {
+			s.positionTimes = append(s.positionTimes, dt)
+			s.positions = append(s.positions, [3]float64{x * 1e3, y * 1e3, z * 1e3})
+		}
+		if clock := entry.ClockBias(); clock < sp3BadClock {
+			s.clockTimes = append(s.clockTimes, dt)
+			s.clocks = append(s.clocks, clock*1e-6)
+		}
+	}
+
+	if len(s.positionTimes) < window {
+		return nil, fmt.Errorf("%s has %d valid positions, need %d", prn, len(s.positionTimes), window)
+	}
+	return s, nil
+}
+
+func findSP3Entry(epoch SP3Epoch, prn string) (SP3Entry, bool) {
+	entries, err := epoch.Entries()
+	if err != nil {
+		return SP3Entry{}, false
+	}
+	for i := 0; i < entries.Len(); i++ {
+		entry := entries.At(i)
+		if sv, _ := entry.SatelliteVehicleNumber(); sv == prn {
+			return entry, true
+		}
+	}
+	return SP3Entry{}, false
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// GetSatInfo returns ECEF position (m), velocity (m/s), clock error (s) and
+// clock rate (s/s) at time, matching GPSEphemeris.GetSatInfo. As for the
+// broadcast orbits the relativistic clock correction is included in the
+// clock error.
+func (s *SP3Interpolator) GetSatInfo(time GPSTime) ([]float64, []float64, float64, float64, error) {
+	t := time.Sub(s.reference)
+
+	pos, vel, err := s.interpolatePosition(t)
+	if err != nil {
+		return nil, nil, 0, 0, err
+	}
+	clockErr, clockRateErr, err := s.interpolateClock(t)
+	if err != nil {
+		return nil, nil, 0, 0, err
+	}
+
+	clockErr -= 2 * (pos[0]*vel[0] + pos[1]*vel[1] + pos[2]*vel[2]) / (SPEED_OF_LIGHT * SPEED_OF_LIGHT)
+	return pos, vel, clockErr, clockRateErr, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func (s *SP3Interpolator) interpolatePosition(t float64) ([]float64, []float64, error) {
+	times := s.positionTimes
+	n := len(times)
+	if t < times[0] || t > times[n-1] {
+		return nil, nil, errors.New("time outside of the SP3 orbit span")
+	}
+
+	last := 0
+	for last < n-1 && times[last+1] <= t {
+		last++
+	}
+
+	// The window has to stay inside the continuous arc holding t
+	first, final := last, last
+	for first > 0 && s.continuous(times[first-1], times[first]) {
+		first--
+	}
+	for final < n-1 && s.continuous(times[final], times[final+1]) {
+		final++
+	}
+	if t > times[final] || final-first+1 < s.Window {
+		return nil, nil, fmt.Errorf("gap in the SP3 orbit of %s, %d epochs around the requested time", s.prn, final-first+1)
+	}
+
+	// Centre the window on t, shift it at the ends of the arc
+	start := last - s.Window/2 + 1
+	if start < first {
+		start = first
+	}
+	if start > final+1-s.Window {
+		start = final + 1 - s.Window
+	}
+	end := start + s.Window
+
+	pos := make([]float64, 3)
+	vel := make([]float64, 3)
+	for j := start; j < end; j++ {
+		// Lagrange basis polynomial L_j(t) and its derivative
+		l := 1.0
+		dl := 0.0
+		for m := start; m < end; m++ {
+			if m == j {
+				continue
+			}
+			d := times[j] - times[m]
+			dl = dl*(t-times[m])/d + l/d
+			l *= (t - times[m]) / d
+		}
+		for k := 0; k < 3; k++ {
+			pos[k] += l * s.positions[j][k]
+			vel[k] += dl * s.positions[j][k]
+		}
+	}
+	return pos, vel, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func (s *SP3Interpolator) interpolateClock(t float64) (float64, float64, error) {
+	times := s.clockTimes
+	n := len(times)
+	if n == 0 {
+		return 0, 0, fmt.Errorf("no valid clock for %s", s.prn)
+	}
+	if t < times[0] || t > times[n-1] {
+		return 0, 0, errors.New("time outside of the SP3 clock span")
+	}
+	if n == 1 {
+		return s.clocks[0], 0, nil
+	}
+
+	i := 0
+	for i < n-2 && times[i+1] < t {
+		i++
+	}
+	dt := times[i+1] - times[i]
+	if !s.continuous(times[i], times[i+1]) {
+		return 0, 0, fmt.Errorf("gap of %.0f s in the SP3 clock of %s", dt, s.prn)
+	}
+	rate := (s.clocks[i+1] - s.clocks[i]) / dt
+	return s.clocks[i] + rate*(t-times[i]), rate, nil
+}
+
+func (s *SP3Interpolator) continuous(t0, t1 float64) bool {
+	return s.MaxGap <= 0 || t1-t0 <= s.MaxGap
+}
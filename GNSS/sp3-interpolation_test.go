@@ -0,0 +1,131 @@
+package gnss
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// sp3FromEphemeris writes an SP3-d file of count epochs, interval seconds
+// apart from start, sampling the broadcast orbit of eph. The clocks leave
+// out the relativistic correction, as precise products do. Epochs listed in
+// zero have a 0.000000 position.
+func sp3FromEphemeris(tb testing.TB, eph GPSEphemeris, start GPSTime, interval float64, count int, zero ...int) string {
+	tb.Helper()
+	var b strings.Builder
+	first := start.ToDateTime()
+	fmt.Fprintf(&b, "#dP%4d %2d %2d %2d %2d %11.8f %7d ORBIT IGb20 BCT  TST\n",
+		first.Year(), first.Month(), first.Day(), first.Hour(), first.Minute(), float64(first.Second()), count)
+	fmt.Fprintf(&b, "## %4d %15.8f %14.8f %5d %15.13f\n", start.Week(), start.TimeOfWeek(), interval, 0, 0.0)
+	fmt.Fprintf(&b, "+    1   %s  0  0  0  0  0  0  0  0  0  0  0  0  0  0  0  0\n", eph.PRN())
+	b.WriteString("%c G  cc GPS ccc cccc cccc cccc cccc ccccc ccccc ccccc ccccc\n")
+	for i := 0; i < count; i++ {
+		at := start.Add(float64(i) * interval)
+		pos, vel, clock, _, err := eph.GetSatInfo(at)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		clock += 2 * (pos[0]*vel[0] + pos[1]*vel[1] + pos[2]*vel[2]) / (SPEED_OF_LIGHT * SPEED_OF_LIGHT)
+		for _, z := range zero {
+			if z == i {
+				pos = []float64{0, 0, 0}
+			}
+		}
+		t := at.ToDateTime()
+		fmt.Fprintf(&b, "*  %4d %2d %2d %2d %2d %11.8f\n", t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), float64(t.Second()))
+		fmt.Fprintf(&b, "P%s%14.6f%14.6f%14.6f%14.6f\n", eph.PRN(), pos[0]/1e3, pos[1]/1e3, pos[2]/1e3, clock*1e6)
+	}
+	b.WriteString("EOF\n")
+	return b.String()
+}
+
+func newTestInterpolator(tb testing.TB, zero ...int) (*SP3Interpolator, GPSEphemeris, GPSTime) {
+	tb.Helper()
+	_, ephs, err := ParseRINEXGPSFileV211(testFile(tb, "abpo2120.24n"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	eph := ephs[0]
+	toe, err := eph.Toe()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	start := toe.Add(-3600)
+	sp3, err := ParseSP3(strings.NewReader(sp3FromEphemeris(tb, eph, start, 300, 25, zero...)), ParseOptions{Strict: true})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	s, err := NewSP3Interpolator(*sp3, eph.PRN(), 0)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return s, eph, start
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// Between the 5 minute samples of a broadcast orbit the interpolated state
+// must reproduce the orbit it was sampled from.
+func TestSP3InterpolationMatchesOrbit(t *testing.T) {
+	s, eph, start := newTestInterpolator(t)
+	for _, dt := range []float64{0, 150, 1234.5, 3600, 5850, 7200} {
+		at := start.Add(dt)
+		pos, vel, clock, clockRate, err := s.GetSatInfo(at)
+		if err != nil {
+			t.Fatalf("%+v s: %v", dt, err)
+		}
+		wantPos, wantVel, wantClock, wantClockRate, err := eph.GetSatInfo(at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := distance(pos, wantPos); d > 0.01 {
+			t.Errorf("%+v s: position %.4f m from the orbit", dt, d)
+		}
+		if d := distance(vel, wantVel); d > 1e-4 {
+			t.Errorf("%+v s: velocity %.6f m/s from the orbit", dt, d)
+		}
+		if d := math.Abs(clock-wantClock) * SPEED_OF_LIGHT; d > 0.01 {
+			t.Errorf("%+v s: clock %.4f m from the broadcast clock", dt, d)
+		}
+		// the clocks of the file are rounded to 1 ps
+		if d := math.Abs(clockRate - wantClockRate); d > 5e-14 {
+			t.Errorf("%+v s: clock rate %v, broadcast %v", dt, clockRate, wantClockRate)
+		}
+	}
+}
+
+func TestSP3InterpolationSpan(t *testing.T) {
+	s, _, start := newTestInterpolator(t)
+	for _, dt := range []float64{-1, 7201} {
+		if _, _, _, _, err := s.GetSatInfo(start.Add(dt)); err == nil {
+			t.Errorf("%+v s: no error outside of the orbit span", dt)
+		}
+	}
+	if _, err := NewSP3Interpolator(SP3FormatEphemeris{}, "G05", 1); err == nil {
+		t.Error("window of 1 epoch accepted")
+	}
+}
+
+// A missing position splits the samples in arcs of 17 and 7 epochs, the
+// window may not cross the gap.
+func TestSP3InterpolationGap(t *testing.T) {
+	s, eph, start := newTestInterpolator(t, 17)
+	if _, _, _, _, err := s.GetSatInfo(start.Add(5150)); err == nil {
+		t.Error("no error inside the gap")
+	}
+	at := start.Add(1000)
+	pos, _, _, _, err := s.GetSatInfo(at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _, _, _, _ := eph.GetSatInfo(at)
+	if d := distance(pos, want); d > 0.01 {
+		t.Errorf("position %.4f m from the orbit before the gap", d)
+	}
+	if _, _, _, _, err := s.GetSatInfo(start.Add(6000)); err == nil {
+		t.Error("no error in the arc shorter than the window")
+	}
+}
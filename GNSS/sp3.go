@@ -0,0 +1,471 @@
+package gnss
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"capnproto.org/go/capnp/v3"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  PRECISE ORBIT FORMAT (SP3-c / SP3-d)
+// https://files.igs.org/pub/data/format/sp3c.txt
+// https://files.igs.org/pub/data/format/sp3d.pdf
+//
+// #dP2024  4 13 23  0  0.00000000     289 ORBIT IGb20 FIT  WHU
+// ## 2309 601200.00000000   300.00000000 60413 0.9583333333333
+// +  121   G01G02G03G04G05G06G07G08G09G10G11G12G13G14G15G16G17
+// ++         4  4  4  4  4  4  4  4  4  4  4  4  4  4  4  4  4
+// %c M  cc GPS ccc cccc cccc cccc cccc ccccc ccccc ccccc ccccc
+// %f  1.2500000  1.025000000  0.00000000000  0.000000000000000
+// %i    0    0    0    0      0      0      0      0         0
+// /* comment
+// *  2024  4 13 23  0  0.00000000
+// PG01  -9786.445316  22881.113781   8574.306434    -47.307452 10 12  9 133 E   MP
+// EP    55   55   55     222  1234567 -1234567  5999999      -30       21 -1230000
+// VG01 -27120.234756  -8976.563104  24345.837105    -12.345678
+// EV    22   22   22     111  1234567  1234567  1234567  1234567  1234567  1234567
+// EOF
+//
+// Satellite ids are a system letter and a two digit PRN:
+// G: GPS (US)
+// R: GLONASS (Russia)
+// E: Galileo (EU)
+// C: BeiDou (China)
+// J: QZSS (Japan)
+// I: NavIC (India)
+// S: SBAS
+//
+// Values are kept in the units of the file: positions in km, clocks in
+// microseconds, velocities in dm/s and clock rates in 1e-4 microseconds/s.
+// Bad or missing positions are 0.000000, bad or missing clocks 999999.999999.
+// Standard deviations of P/V records are exponents of the %f bases, those of
+// EP/EV records are mm (0.0001 mm/s) and psec (0.0001 psec/s), correlations
+// are scaled by 1e7.
+
+// sp3Record collects the decoded P, EP, V and EV lines of one satellite in
+// an epoch. Records that are absent stay nil.
+type sp3Record struct {
+	position     sp3State
+	positionCorr *sp3Correlation
+	velocity     *sp3State
+	velocityCorr *sp3Correlation
+}
+
+type sp3EpochRecords struct {
+	time    time.Time
+	records []*sp3Record
+}
+
+// ParseSP3File opens filename and parses it with ParseSP3, opts.FileName
+// defaults to filename.
+func ParseSP3File(filename string, opts ParseOptions) (*SP3FormatEphemeris, error) {
+	file, err := openFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if opts.FileName == "" {
+		opts.FileName = filename
+	}
+	return ParseSP3(file, opts)
+}
+
+// ParseSP3 reads an SP3-c / SP3-d file from r. The warnings of a lenient
+// parse go to opts.Logger, Parse also returns them in ParsedFile.Warnings.
+func ParseSP3(r io.Reader, opts ParseOptions) (*SP3FormatEphemeris, error) {
+	sp3, _, err := parseSP3(r, opts)
+	return sp3, err
+}
+
+func parseSP3(r io.Reader, opts ParseOptions) (*SP3FormatEphemeris, []*ParseError, error) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create new message: %v", err)
+	}
+	sp3, err := NewRootSP3FormatEphemeris(seg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create new SP3FormatEphemeris: %v", err)
+	}
+	header, err := sp3.NewHeader()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create new SP3Header: %v", err)
+	}
+
+	scanner := newLineScanner(r, opts)
+	epochs, err := parseSP3Lines(scanner, header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timeSystem, _ := header.TimeSystem()
+	epochList, err := sp3.NewEpochs(int32(len(epochs)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create new epochs: %v", err)
+	}
+	for i, e := range epochs {
+		if err := fillSP3Epoch(epochList.At(i), e, timeSystem); err != nil {
+			return nil, nil, fmt.Errorf("epoch %s: %v", e.time.Format(time.RFC3339), err)
+		}
+	}
+	return &sp3, scanner.warnings, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// parseSP3Lines fills the header and groups the data records per epoch. A
+// satellite is identified by its P record, the V, EP and EV records that
+// follow belong to it. In lenient mode records that cannot be placed are
+// skipped with a warning, an unreadable epoch line drops its records.
+func parseSP3Lines(scanner *lineScanner, header SP3Header) ([]sp3EpochRecords, error) {
+	var epochs []sp3EpochRecords
+	var satellites, comments []string
+	var accuracy []int32
+	var current *sp3Record
+	skip := false
+	percentC, percentF := 0, 0
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		record := scanner.newRecord()
+		record.add(line)
+
+		var err error
+		switch {
+		case scanner.line == 1:
+			if err := parseSP3FirstLine(record, header); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "##"):
+			var week, mjd int32
+			var seconds, interval, fraction float64
+			record.decode(0, sp3TimeLine, &week, &seconds, &interval, &mjd, &fraction)
+			header.SetGpsWeek(week)
+			header.SetSecondsOfWeek(seconds)
+			header.SetEpochInterval(interval)
+			header.SetModifiedJulianDay(mjd)
+			header.SetFractionalDay(fraction)
+		case strings.HasPrefix(line, "++"):
+			var values [17]int32
+			dst := make([]any, len(values))
+			for i := range values {
+				dst[i] = &values[i]
+			}
+			record.decode(0, sp3AccuracyLine, dst...)
+			accuracy = append(accuracy, values[:]...)
+		case strings.HasPrefix(line, "+"):
+			if strings.TrimSpace(column(line, 1, 6)) != "" {
+				header.SetNumberOfSatellites(int32(record.integer(0, 1, 6)))
+			}
+			for i := 0; i < 17; i++ {
+				satellites = append(satellites, column(line, 9+3*i, 12+3*i))
+			}
+		case strings.HasPrefix(line, "%c"):
+			// only the first %c line is defined
+			if percentC++; percentC == 1 {
+				header.SetFileType(strings.TrimSpace(column(line, 3, 5)))
+				if ts := strings.TrimSpace(column(line, 9, 12)); ts != "" && ts != "ccc" {
+					header.SetTimeSystem(ts)
+				}
+			}
+		case strings.HasPrefix(line, "%f"):
+			if percentF++; percentF == 1 {
+				var positionBase, clockBase float64
+				record.decode(0, sp3BaseLine, &positionBase, &clockBase)
+				header.SetPositionBase(positionBase)
+				header.SetClockBase(clockBase)
+			}
+		case strings.HasPrefix(line, "%i"):
+			// integer fields are reserved
+		case strings.HasPrefix(line, "/*"):
+			comments = append(comments, strings.TrimSpace(column(line, 3, len(line))))
+		case strings.HasPrefix(line, "*"):
+			current = nil
+			if skip = len(line) < 31; skip {
+				err = fmt.Errorf("epoch line is too short")
+				break
+			}
+			var epoch epochFields
+			record.decode(0, sp3Date, epoch.dst()...)
+			epochs = append(epochs, sp3EpochRecords{time: epoch.time()})
+		case strings.HasPrefix(line, "EOF"):
+			return epochs, finishSP3Header(header, satellites, accuracy, comments)
+		case line[0] == 'P' || line[0] == 'V' || strings.HasPrefix(line, "EP") || strings.HasPrefix(line, "EV"):
+			if skip {
+				continue
+			}
+			err = addSP3Record(record, epochs, &current)
+		default:
+			err = fmt.Errorf("unknown record %q", column(line, 0, 2))
+		}
+		if err == nil {
+			err = record.err
+		}
+		if err != nil {
+			if err := scanner.recordError(record, err); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	// Some producers omit the EOF line
+	return epochs, finishSP3Header(header, satellites, accuracy, comments)
+}
+
+// addSP3Record decodes the P, V, EP or EV line of record into the last
+// epoch, current is the satellite of the last P record.
+func addSP3Record(record *lineRecord, epochs []sp3EpochRecords, current **sp3Record) error {
+	line := record.lines[0]
+	if len(epochs) == 0 {
+		return fmt.Errorf("data record before the first epoch")
+	}
+	e := &epochs[len(epochs)-1]
+	switch {
+	case line[0] == 'P':
+		r := &sp3Record{}
+		r.position.decode(record)
+		e.records = append(e.records, r)
+		*current = r
+	case *current == nil:
+		return fmt.Errorf("%s record without position record", column(line, 0, 2))
+	case line[0] == 'V':
+		var v sp3State
+		v.decode(record)
+		if v.satellite != (*current).position.satellite {
+			return fmt.Errorf("velocity record of %s follows position of %s", v.satellite, (*current).position.satellite)
+		}
+		(*current).velocity = &v
+	case line[1] == 'P':
+		(*current).positionCorr = decodeSP3Correlation(record)
+	default:
+		(*current).velocityCorr = decodeSP3Correlation(record)
+	}
+	return nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// #cP2024  4 13 23  0  0.00000000     289 ORBIT IGb20 FIT  WHU
+func parseSP3FirstLine(record *lineRecord, header SP3Header) error {
+	line := record.lines[0]
+	if len(line) < 31 || line[0] != '#' {
+		return fmt.Errorf("line 1: not an SP3 file: %q", line)
+	}
+	version := line[1:2]
+	if version != "c" && version != "d" && version != "a" && version != "b" {
+		return fmt.Errorf("line 1: unsupported SP3 version %q", version)
+	}
+	header.SetVersion(version)
+	header.SetPositionVelocityFlag(line[2:3])
+
+	start, err := header.NewStart()
+	if err != nil {
+		return fmt.Errorf("failed to create new Time struct: %v", err)
+	}
+	var epoch epochFields
+	var epochs int32
+	var dataUsed, frame, orbitType, agency string
+	record.decode(0, sp3Date, epoch.dst()...)
+	record.decode(0, sp3FirstLine, &epochs, &dataUsed, &frame, &orbitType, &agency)
+	if record.err != nil {
+		return record.err
+	}
+	t := epoch.time()
+	start.SetSeconds(t.Unix())
+	start.SetNanoseconds(int32(t.Nanosecond()))
+	header.SetNumberOfEpochs(epochs)
+	header.SetDataUsed(dataUsed)
+	header.SetCoordinateSystem(frame)
+	header.SetOrbitType(orbitType)
+	header.SetAgency(agency)
+	// SP3-a/b have no %c line, they are always GPS time
+	header.SetTimeSystem("GPS")
+	return nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func finishSP3Header(header SP3Header, satellites []string, accuracy []int32, comments []string) error {
+	n := int(header.NumberOfSatellites())
+	if n > len(satellites) {
+		return fmt.Errorf("header lists %d of %d satellites", len(satellites), n)
+	}
+	if n > len(accuracy) {
+		n = len(accuracy)
+	}
+
+	satList, err := header.NewSatellites(header.NumberOfSatellites())
+	if err != nil {
+		return fmt.Errorf("failed to create new satellites: %v", err)
+	}
+	for i := 0; i < satList.Len(); i++ {
+		satList.Set(i, normalizePRN(satellites[i]))
+	}
+
+	accuracyList, err := header.NewAccuracyExponents(int32(n))
+	if err != nil {
+		return fmt.Errorf("failed to create new accuracy exponents: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		accuracyList.Set(i, accuracy[i])
+	}
+
+	commentList, err := header.NewComments(int32(len(comments)))
+	if err != nil {
+		return fmt.Errorf("failed to create new Comments: %v", err)
+	}
+	for i, comment := range comments {
+		commentList.Set(i, comment)
+	}
+	return nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func fillSP3Epoch(epoch SP3Epoch, e sp3EpochRecords, timeSystem string) error {
+	t, err := epoch.NewTime()
+	if err != nil {
+		return fmt.Errorf("failed to create new Time struct: %v", err)
+	}
+	t.SetSeconds(e.time.Unix())
+	t.SetNanoseconds(int32(e.time.Nanosecond()))
+
+	gpsTime, err := epoch.NewGpsTime()
+	if err != nil {
+		return fmt.Errorf("failed to create new GPSTime: %v", err)
+	}
+	setGPSTime(gpsTime, systemTimeToGPSTime(e.time, timeSystem, 0))
+
+	entries, err := epoch.NewEntries(int32(len(e.records)))
+	if err != nil {
+		return fmt.Errorf("failed to create new entries: %v", err)
+	}
+	for i, r := range e.records {
+		if err := fillSP3Entry(entries.At(i), r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// P / V record, see sp3StateLine
+func fillSP3Entry(entry SP3Entry, r *sp3Record) error {
+	s := r.position
+	entry.SetSatelliteVehicleNumber(normalizePRN(s.satellite))
+	entry.SetXPosition(s.values[0])
+	entry.SetYPosition(s.values[1])
+	entry.SetZPosition(s.values[2])
+	entry.SetClockBias(s.values[3])
+
+	exps, err := entry.NewPositionStdDevExponents(3)
+	if err != nil {
+		return fmt.Errorf("failed to create new standard deviations: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		exps.Set(i, s.exponents[i])
+	}
+	entry.SetClockStdDevExponent(s.exponents[3])
+	entry.SetClockEvent(s.flags[0] == "E")
+	entry.SetClockPredicted(s.flags[1] == "P")
+	entry.SetManeuver(s.flags[2] == "M")
+	entry.SetOrbitPredicted(s.flags[3] == "P")
+
+	if v := r.velocity; v != nil {
+		entry.SetHasVelocity(true)
+		entry.SetXVelocity(v.values[0])
+		entry.SetYVelocity(v.values[1])
+		entry.SetZVelocity(v.values[2])
+		entry.SetClockRate(v.values[3])
+
+		exps, err := entry.NewVelocityStdDevExponents(3)
+		if err != nil {
+			return fmt.Errorf("failed to create new standard deviations: %v", err)
+		}
+		for i := 0; i < 3; i++ {
+			exps.Set(i, v.exponents[i])
+		}
+		entry.SetClockRateStdDevExponent(v.exponents[3])
+	}
+
+	if r.positionCorr != nil {
+		corr, err := entry.NewPositionCorrelation()
+		if err != nil {
+			return fmt.Errorf("failed to create new SP3Correlation: %v", err)
+		}
+		r.positionCorr.set(corr)
+	}
+	if r.velocityCorr != nil {
+		corr, err := entry.NewVelocityCorrelation()
+		if err != nil {
+			return fmt.Errorf("failed to create new SP3Correlation: %v", err)
+		}
+		r.velocityCorr.set(corr)
+	}
+	return nil
+}
+
+// sp3State is a P or V record: the satellite, x, y, z and clock, their
+// standard deviation exponents and the four flag columns.
+type sp3State struct {
+	satellite string
+	values    [4]float64
+	exponents [4]int32
+	flags     [4]string
+}
+
+func (s *sp3State) decode(record *lineRecord) {
+	record.decode(0, sp3StateLine, &s.satellite,
+		&s.values[0], &s.values[1], &s.values[2], &s.values[3],
+		&s.exponents[0], &s.exponents[1], &s.exponents[2], &s.exponents[3],
+		&s.flags[0], &s.flags[1], &s.flags[2], &s.flags[3])
+}
+
+// sp3Correlation is an EP / EV record, see sp3CorrelationLine: the x, y, z
+// and clock standard deviations, then the xy, xz, xc, yz, yc, zc
+// correlations.
+type sp3Correlation [10]int32
+
+func decodeSP3Correlation(record *lineRecord) *sp3Correlation {
+	var c sp3Correlation
+	dst := make([]any, len(c))
+	for i := range c {
+		dst[i] = &c[i]
+	}
+	record.decode(0, sp3CorrelationLine, dst...)
+	return &c
+}
+
+func (c *sp3Correlation) set(corr SP3Correlation) {
+	corr.SetXStdDev(c[0])
+	corr.SetYStdDev(c[1])
+	corr.SetZStdDev(c[2])
+	corr.SetClockStdDev(c[3])
+	corr.SetXyCorrelation(c[4])
+	corr.SetXzCorrelation(c[5])
+	corr.SetXClockCorrelation(c[6])
+	corr.SetYzCorrelation(c[7])
+	corr.SetYClockCorrelation(c[8])
+	corr.SetZClockCorrelation(c[9])
+}
@@ -0,0 +1,154 @@
+package gnss
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+
+func TestParseSP3(t *testing.T) {
+	sp3, err := ParseSP3File("testdata/whu-short.sp3", ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := sp3.Header()
+	if err != nil {
+		t.Fatal(err)
+	}
+	version, _ := header.Version()
+	flag, _ := header.PositionVelocityFlag()
+	agency, _ := header.Agency()
+	frame, _ := header.CoordinateSystem()
+	fileType, _ := header.FileType()
+	timeSystem, _ := header.TimeSystem()
+	for _, c := range []struct{ name, got, want string }{
+		{"version", version, "d"},
+		{"position / velocity flag", flag, "V"},
+		{"agency", agency, "WHU"},
+		{"frame", frame, "IGb20"},
+		{"file type", fileType, "M"},
+		{"time system", timeSystem, "GPS"},
+	} {
+		if c.got != c.want {
+			t.Errorf("%s %q, want %q", c.name, c.got, c.want)
+		}
+	}
+	if header.NumberOfEpochs() != 2 || header.GpsWeek() != 2309 || header.SecondsOfWeek() != 601200 ||
+		header.EpochInterval() != 300 || header.ModifiedJulianDay() != 60413 {
+		t.Errorf("epochs %d week %d seconds %v interval %v MJD %d", header.NumberOfEpochs(), header.GpsWeek(),
+			header.SecondsOfWeek(), header.EpochInterval(), header.ModifiedJulianDay())
+	}
+	if header.PositionBase() != 1.25 || header.ClockBase() != 1.025 {
+		t.Errorf("bases %v %v, want 1.25 1.025", header.PositionBase(), header.ClockBase())
+	}
+	satellites, _ := header.Satellites()
+	accuracy, _ := header.AccuracyExponents()
+	var prns []string
+	var exponents []int32
+	for i := 0; i < satellites.Len(); i++ {
+		prn, _ := satellites.At(i)
+		prns = append(prns, prn)
+	}
+	for i := 0; i < accuracy.Len(); i++ {
+		exponents = append(exponents, accuracy.At(i))
+	}
+	if !reflect.DeepEqual(prns, []string{"G01", "R02"}) || !reflect.DeepEqual(exponents, []int32{4, 5}) {
+		t.Errorf("satellites %v accuracy %v, want [G01 R02] [4 5]", prns, exponents)
+	}
+	if comments, _ := header.Comments(); comments.Len() != 1 {
+		t.Errorf("%d comments, want 1", comments.Len())
+	}
+
+	epochs, err := sp3.Epochs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if epochs.Len() != 2 {
+		t.Fatalf("%d epochs, want 2", epochs.Len())
+	}
+	first := epochs.At(0)
+	epochTime, _ := first.Time()
+	if got, want := time.Unix(epochTime.Seconds(), 0).UTC(), time.Date(2024, 4, 13, 23, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("epoch %v, want %v", got, want)
+	}
+	gpsTime, _ := first.GpsTime()
+	if gpsTime.Week() != 2309 || gpsTime.TimeOfWeek() != 601200 {
+		t.Errorf("GPS time week %d tow %v, want 2309 601200", gpsTime.Week(), gpsTime.TimeOfWeek())
+	}
+	entries, _ := first.Entries()
+	if entries.Len() != 2 {
+		t.Fatalf("%d entries, want 2", entries.Len())
+	}
+
+	// PG01  -9786.445316  22881.113781   8574.306434    -47.307452 10 12  9 133 E   MP
+	// EP    55   55   55     222  1234567 -1234567  5999999      -30       21 -1230000
+	// VG01 -27120.234756  -8976.563104  24345.837105    -12.345678
+	g01 := entries.At(0)
+	if prn, _ := g01.SatelliteVehicleNumber(); prn != "G01" {
+		t.Errorf("PRN %q, want G01", prn)
+	}
+	if g01.XPosition() != -9786.445316 || g01.YPosition() != 22881.113781 || g01.ZPosition() != 8574.306434 || g01.ClockBias() != -47.307452 {
+		t.Errorf("G01 position %v %v %v clock %v", g01.XPosition(), g01.YPosition(), g01.ZPosition(), g01.ClockBias())
+	}
+	exps, _ := g01.PositionStdDevExponents()
+	if exps.At(0) != 10 || exps.At(1) != 12 || exps.At(2) != 9 || g01.ClockStdDevExponent() != 133 {
+		t.Errorf("G01 exponents %d %d %d %d", exps.At(0), exps.At(1), exps.At(2), g01.ClockStdDevExponent())
+	}
+	if !g01.ClockEvent() || g01.ClockPredicted() || !g01.Maneuver() || !g01.OrbitPredicted() {
+		t.Errorf("G01 flags event %v predicted %v maneuver %v orbit predicted %v",
+			g01.ClockEvent(), g01.ClockPredicted(), g01.Maneuver(), g01.OrbitPredicted())
+	}
+	if !g01.HasVelocity() || g01.XVelocity() != -27120.234756 || g01.ClockRate() != -12.345678 {
+		t.Errorf("G01 velocity %v %v clock rate %v", g01.HasVelocity(), g01.XVelocity(), g01.ClockRate())
+	}
+	if !g01.HasPositionCorrelation() {
+		t.Fatal("G01 has no EP record")
+	}
+	corr, _ := g01.PositionCorrelation()
+	if corr.XStdDev() != 55 || corr.ClockStdDev() != 222 || corr.XzCorrelation() != -1234567 || corr.ZClockCorrelation() != -1230000 {
+		t.Errorf("G01 EP %d %d %d %d", corr.XStdDev(), corr.ClockStdDev(), corr.XzCorrelation(), corr.ZClockCorrelation())
+	}
+	if g01.HasVelocityCorrelation() {
+		t.Error("G01 has an EV record")
+	}
+
+	r02 := entries.At(1)
+	if prn, _ := r02.SatelliteVehicleNumber(); prn != "R02" || r02.ClockBias() != 999999.999999 || !r02.HasVelocity() {
+		t.Errorf("R02 %q clock %v velocity %v", prn, r02.ClockBias(), r02.HasVelocity())
+	}
+
+	second, _ := epochs.At(1).Entries()
+	if second.Len() != 1 || second.At(0).XPosition() != -9790.445316 || second.At(0).HasVelocity() {
+		t.Errorf("second epoch: %d entries", second.Len())
+	}
+}
+
+func TestParseSP3Errors(t *testing.T) {
+	const body = `#dP2024  4 13 23  0  0.00000000       1 ORBIT IGb20 FIT  WHU
++    1   G01  0  0  0  0  0  0  0  0  0  0  0  0  0  0  0  0
+*  2024  4 13 23  0  0.00000000
+VG01 -27120.234756  -8976.563104  24345.837105    -12.345678
+PG01  -9786.445316  22881.113781   8574.306434    -47.307452
+EOF
+`
+	if _, err := ParseSP3(strings.NewReader(body), ParseOptions{Strict: true}); err == nil {
+		t.Error("strict parse of a velocity record without position record succeeded")
+	}
+	sp3, err := ParseSP3(strings.NewReader(body), ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	epochs, _ := sp3.Epochs()
+	if entries, _ := epochs.At(0).Entries(); entries.Len() != 1 || entries.At(0).HasVelocity() {
+		t.Errorf("lenient parse kept %d entries", entries.Len())
+	}
+
+	if _, err := ParseSP3(strings.NewReader("#eP2024  4 13 23  0  0.00000000\n"), ParseOptions{}); err == nil {
+		t.Error("unsupported version accepted")
+	}
+}
@@ -1,9 +1,258 @@
 package gnss
 
-// TODO -TROPOSPHERIC DELAY
+import (
+	"math"
+	"time"
+)
 
-/*
-https://gssc.esa.int/navipedia/index.php/Tropospheric_Delay
-http://ftp.aiub.unibe.ch/BERN42/DOCU/DOCU42_5.pdf
-https://www.researchgate.net/publication/228746230_Tropospheric_Delay_Estimation_for_Pseudolite_Positioning
-https://www.swsc-journal.org/articles/swsc/full_html/2018/01/swsc170068/swsc170068.html */
+// =========================================================================
+
+// =========================================================================
+//  TROPOSPHERIC DELAY
+// The slant delay is split into a hydrostatic and a wet part, each a zenith
+// delay scaled by its mapping function:
+//   T = ZHD mh(el) + ZWD mw(el)
+// A TroposphereModel gives the zenith delays (Saastamoinen and Hopfield from
+// surface meteorology, UNB3m and GPT2w / GPT3 from empirical tables), a
+// MappingFunction the elevation dependence (Niell, VMF1, VMF3, Hopfield).
+// Receiver positions are geodetic latitude and longitude in radians and
+// ellipsoidal height in meters, as returned by helpers.ECEFToGeodetic with
+// radians set, elevations are in radians.
+//
+// https://gssc.esa.int/navipedia/index.php/Tropospheric_Delay
+// http://ftp.aiub.unibe.ch/BERN42/DOCU/DOCU42_5.pdf
+// https://www.researchgate.net/publication/228746230_Tropospheric_Delay_Estimation_for_Pseudolite_Positioning
+// https://www.swsc-journal.org/articles/swsc/full_html/2018/01/swsc170068/swsc170068.html
+
+// ZenithDelay is in meters.
+type ZenithDelay struct {
+	Hydrostatic float64
+	Wet         float64
+}
+
+type TroposphereModel interface {
+	ZenithDelay(t GPSTime, geodetic []float64) ZenithDelay
+}
+
+type MappingFunction interface {
+	// Mapping returns the hydrostatic and wet mapping factors
+	Mapping(t GPSTime, geodetic []float64, elevation float64) (float64, float64)
+}
+
+// TroposphericDelay returns the slant delay in meters of a signal arriving
+// at geodetic from elevation.
+func TroposphericDelay(model TroposphereModel, mapping MappingFunction, t GPSTime, geodetic []float64, elevation float64) float64 {
+	zenith := model.ZenithDelay(t, geodetic)
+	mh, mw := mapping.Mapping(t, geodetic, elevation)
+	return zenith.Hydrostatic*mh + zenith.Wet*mw
+}
+
+const (
+	// refractivity constants (Bevis et al. 1994), K/hPa and K²/hPa
+	tropoK1 = 77.604
+	tropoK2 = 64.79
+	tropoK3 = 377600.0
+	// dry air molar mass (kg/mol), gas constant (J/(mol K)), gravity (m/s²)
+	dryAirMolarMass = 28.965e-3
+	gasConstant     = 8.3143
+	standardGravity = 9.80665
+)
+
+// =========================================================================
+
+// =========================================================================
+//  METEOROLOGY
+
+// Meteo is the surface meteorology at the receiver.
+type Meteo struct {
+	// total pressure, hPa
+	Pressure float64
+	// temperature, K
+	Temperature float64
+	// water vapour partial pressure, hPa
+	WaterVapour float64
+}
+
+// StandardAtmosphere returns the meteorology of the Berg standard atmosphere
+// at height (m): 1013.25 hPa, 18 °C and 50 % relative humidity at sea level.
+func StandardAtmosphere(height float64) Meteo {
+	pressure := 1013.25 * math.Pow(1-0.0000226*height, 5.225)
+	temperature := 291.15 - 0.0065*height
+	humidity := 50 * math.Exp(-0.0006396*height)
+	return Meteo{
+		Pressure:    pressure,
+		Temperature: temperature,
+		WaterVapour: humidity / 100 * math.Exp(-37.2465+0.213166*temperature-0.000256908*temperature*temperature),
+	}
+}
+
+// meteoAt returns m, or the standard atmosphere at the height of geodetic
+// when m is nil.
+func meteoAt(m *Meteo, geodetic []float64) Meteo {
+	if m != nil {
+		return *m
+	}
+	return StandardAtmosphere(math.Max(geodetic[2], 0))
+}
+
+// saastamoinenHydrostatic is the hydrostatic zenith delay of pressure (hPa)
+// with the gravity correction of Davis et al. (1985).
+func saastamoinenHydrostatic(pressure float64, geodetic []float64) float64 {
+	return 0.0022768 * pressure / (1 - 0.00266*math.Cos(2*geodetic[0]) - 0.28e-6*geodetic[2])
+}
+
+// askneWet is the wet zenith delay of Askne and Nordius (1987) from the
+// water vapour pressure (hPa), its mean temperature Tm (K) and decrease
+// factor lambda, as used with GPT2w / GPT3.
+func askneWet(waterVapour, meanTemperature, lambda float64) float64 {
+	k2 := tropoK2 - tropoK1*18.0152/28.9644
+	rd := gasConstant / dryAirMolarMass
+	return 1e-6 * (k2 + tropoK3/meanTemperature) * rd / (lambda + 1) / standardGravity * waterVapour
+}
+
+// =========================================================================
+
+// =========================================================================
+//  SAASTAMOINEN / HOPFIELD
+
+// Saastamoinen zenith delays from Meteo, the standard atmosphere when nil.
+type Saastamoinen struct {
+	Meteo *Meteo
+}
+
+func (s Saastamoinen) ZenithDelay(t GPSTime, geodetic []float64) ZenithDelay {
+	m := meteoAt(s.Meteo, geodetic)
+	return ZenithDelay{
+		Hydrostatic: saastamoinenHydrostatic(m.Pressure, geodetic),
+		Wet:         0.002277 * (1255/m.Temperature + 0.05) * m.WaterVapour,
+	}
+}
+
+// Hopfield quartic profile model from Meteo, the standard atmosphere when
+// nil. It is also a MappingFunction, the usual Hopfield elevation scaling.
+type Hopfield struct {
+	Meteo *Meteo
+}
+
+func (h Hopfield) ZenithDelay(t GPSTime, geodetic []float64) ZenithDelay {
+	m := meteoAt(h.Meteo, geodetic)
+	// heights of the top of the dry and wet layers above the receiver, m
+	hd := 40136 + 148.72*(m.Temperature-273.16)
+	hw := 11000.0
+	return ZenithDelay{
+		Hydrostatic: 1e-6 / 5 * tropoK1 * m.Pressure / m.Temperature * hd,
+		Wet:         1e-6 / 5 * (-12.96*m.Temperature + 3.718e5) * m.WaterVapour / (m.Temperature * m.Temperature) * hw,
+	}
+}
+
+func (h Hopfield) Mapping(t GPSTime, geodetic []float64, elevation float64) (float64, float64) {
+	e := elevation * 180 / math.Pi
+	mh := 1 / math.Sin(math.Sqrt(e*e+6.25)*math.Pi/180)
+	mw := 1 / math.Sin(math.Sqrt(e*e+2.25)*math.Pi/180)
+	return mh, mw
+}
+
+// =========================================================================
+
+// =========================================================================
+//  UNB3m
+// Leandro, Santos and Langley (2006): pressure, temperature, relative
+// humidity, temperature lapse rate and water vapour decrease factor from a
+// latitude table of yearly averages and amplitudes (minimum at day 28 in
+// the north), reduced to the receiver height.
+
+type UNB3m struct{}
+
+// unb3mLatitudes are the table rows, degrees
+var unb3mLatitudes = [5]float64{15, 30, 45, 60, 75}
+
+// pressure (hPa), temperature (K), relative humidity (%), lapse rate
+// (K/m), lambda
+var unb3mAverage = [5][5]float64{
+	{1013.25, 299.65, 75.0, 6.30e-3, 2.77},
+	{1017.25, 294.15, 80.0, 6.05e-3, 3.15},
+	{1015.75, 283.15, 76.0, 5.58e-3, 2.57},
+	{1011.75, 272.15, 77.5, 5.39e-3, 1.81},
+	{1013.00, 263.65, 82.5, 4.53e-3, 1.55},
+}
+
+var unb3mAmplitude = [5][5]float64{
+	{0.00, 0.00, 0.0, 0.00e-3, 0.00},
+	{-3.75, 7.00, 0.0, 0.25e-3, 0.33},
+	{-2.25, 11.00, -1.0, 0.32e-3, 0.46},
+	{-1.75, 15.00, -2.5, 0.81e-3, 0.74},
+	{-0.50, 14.50, 2.5, 0.62e-3, 0.30},
+}
+
+func (UNB3m) ZenithDelay(t GPSTime, geodetic []float64) ZenithDelay {
+	latitude := geodetic[0] * 180 / math.Pi
+	height := geodetic[2]
+
+	day := dayOfYear(t)
+	if latitude < 0 {
+		day += 182.625
+	}
+	cosPhase := math.Cos((day - 28) * 2 * math.Pi / 365.25)
+
+	var v [5]float64
+	for i := range v {
+		average := interpolateLatitude(unb3mLatitudes[:], math.Abs(latitude), func(j int) float64 { return unb3mAverage[j][i] })
+		amplitude := interpolateLatitude(unb3mLatitudes[:], math.Abs(latitude), func(j int) float64 { return unb3mAmplitude[j][i] })
+		v[i] = average - amplitude*cosPhase
+	}
+	p0, t0, humidity, beta, lambda := v[0], v[1], v[2], v[3], v[4]
+
+	// relative humidity to water vapour pressure at sea level, hPa
+	saturation := 0.01 * math.Exp(1.2378847e-5*t0*t0-1.9121316e-2*t0+33.93711047-6.3431645e3/t0)
+	enhancement := 1.00062 + 3.14e-6*p0 + 5.6e-7*(t0-273.15)*(t0-273.15)
+	e0 := humidity / 100 * saturation * enhancement
+
+	rd := gasConstant / dryAirMolarMass
+	ep := standardGravity / rd / beta
+	temperature := t0 - beta*height
+	pressure := p0 * math.Pow(temperature/t0, ep)
+	waterVapour := e0 * math.Pow(temperature/t0, ep*(lambda+1))
+
+	geocentric := math.Atan((1 - 6.69437999014e-3) * math.Tan(geodetic[0]))
+	gravityRatio := 1 - 2.66e-3*math.Cos(2*geocentric) - 2.8e-7*height
+	gm := 9.784 * gravityRatio
+	den := (lambda + 1) * gm
+	meanTemperature := temperature * (1 - beta*rd/den)
+	k2 := tropoK2 - tropoK1*18.0152/28.9644
+
+	return ZenithDelay{
+		Hydrostatic: 1e-6 * tropoK1 * rd * pressure / gm,
+		Wet:         1e-6 * (k2 + tropoK3/meanTemperature) * rd * waterVapour / den,
+	}
+}
+
+// interpolateLatitude interpolates linearly between the table rows of
+// latitudes (degrees, increasing), clamped to the first and last row.
+func interpolateLatitude(latitudes []float64, latitude float64, row func(int) float64) float64 {
+	last := len(latitudes) - 1
+	if latitude <= latitudes[0] {
+		return row(0)
+	}
+	if latitude >= latitudes[last] {
+		return row(last)
+	}
+	i := 0
+	for latitude > latitudes[i+1] {
+		i++
+	}
+	f := (latitude - latitudes[i]) / (latitudes[i+1] - latitudes[i])
+	return row(i)*(1-f) + row(i+1)*f
+}
+
+// dayOfYear returns the day of year of t with its fraction, 1.0 at the
+// start of January 1st.
+func dayOfYear(t GPSTime) float64 {
+	date := t.ToDateTime()
+	start := date.Truncate(24 * time.Hour)
+	return float64(date.YearDay()) + date.Sub(start).Hours()/24
+}
+
+// modifiedJulianDate of t, in GPS time.
+func modifiedJulianDate(t GPSTime) float64 {
+	return float64(t.ToDateTime().UnixNano())/1e9/SECS_IN_DAY + 40587
+}
@@ -0,0 +1,88 @@
+package gnss
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+
+func TestStandardAtmosphere(t *testing.T) {
+	m := StandardAtmosphere(0)
+	if m.Pressure != 1013.25 || m.Temperature != 291.15 {
+		t.Errorf("sea level %v hPa %v K", m.Pressure, m.Temperature)
+	}
+	// half the saturation pressure of 18 °C, Magnus formula
+	saturation := 6.112 * math.Exp(17.62*18/(243.12+18))
+	if math.Abs(m.WaterVapour/(saturation/2)-1) > 0.03 {
+		t.Errorf("water vapour %v hPa, want about %v", m.WaterVapour, saturation/2)
+	}
+	high := StandardAtmosphere(2000)
+	if high.Pressure >= m.Pressure || high.Temperature != 291.15-13 || high.WaterVapour >= m.WaterVapour {
+		t.Errorf("2000 m %+v", high)
+	}
+}
+
+// At 45° the gravity correction of Saastamoinen vanishes at the ellipsoid.
+func TestSaastamoinen(t *testing.T) {
+	geodetic := []float64{math.Pi / 4, 0, 0}
+	dry := Saastamoinen{Meteo: &Meteo{Pressure: 1013.25, Temperature: 288.15}}
+	z := dry.ZenithDelay(GPSTime{}, geodetic)
+	if math.Abs(z.Hydrostatic-0.0022768*1013.25) > 1e-9 || z.Wet != 0 {
+		t.Errorf("zenith %+v", z)
+	}
+	wet := Saastamoinen{Meteo: &Meteo{Pressure: 1013.25, Temperature: 290, WaterVapour: 10}}
+	if z := wet.ZenithDelay(GPSTime{}, geodetic); math.Abs(z.Wet-0.002277*(1255.0/290+0.05)*10) > 1e-12 {
+		t.Errorf("wet %v m", z.Wet)
+	}
+
+	// Hopfield integrates the same atmosphere to within a centimeter
+	standard := Saastamoinen{}.ZenithDelay(GPSTime{}, geodetic)
+	hopfield := Hopfield{}.ZenithDelay(GPSTime{}, geodetic)
+	if math.Abs(standard.Hydrostatic-hopfield.Hydrostatic) > 0.01 {
+		t.Errorf("hydrostatic Saastamoinen %v m, Hopfield %v m", standard.Hydrostatic, hopfield.Hydrostatic)
+	}
+}
+
+// UNB3m reduces its table pressure as Saastamoinen does, the seasons are
+// reversed in the south.
+func TestUNB3m(t *testing.T) {
+	// day 28, the minimum of the northern cycle
+	january := GPSTimeFromDateTime(time.Date(2024, 1, 28, 0, 0, 0, 0, time.UTC))
+	north := []float64{math.Pi / 4, 0, 0}
+	z := UNB3m{}.ZenithDelay(january, north)
+	want := Saastamoinen{Meteo: &Meteo{Pressure: 1015.75 + 2.25}}.ZenithDelay(january, north)
+	if math.Abs(z.Hydrostatic-want.Hydrostatic) > 1e-3 {
+		t.Errorf("hydrostatic %v m, want %v m", z.Hydrostatic, want.Hydrostatic)
+	}
+	if z.Wet < 0.05 || z.Wet > 0.3 {
+		t.Errorf("wet %v m", z.Wet)
+	}
+
+	july := january.Add(182.625 * SECS_IN_DAY)
+	south := UNB3m{}.ZenithDelay(july, []float64{-math.Pi / 4, 0, 0})
+	if math.Abs(south.Hydrostatic-z.Hydrostatic) > 1e-9 || math.Abs(south.Wet-z.Wet) > 1e-9 {
+		t.Errorf("south in July %+v, north in January %+v", south, z)
+	}
+
+	high := UNB3m{}.ZenithDelay(january, []float64{math.Pi / 4, 0, 1000})
+	if high.Hydrostatic >= z.Hydrostatic || high.Wet >= z.Wet {
+		t.Errorf("1000 m %+v, sea level %+v", high, z)
+	}
+}
+
+func TestTroposphericDelay(t *testing.T) {
+	geodetic := []float64{0.8, 0.2, 300}
+	epoch := GPSTimeFromWeek(2325, 172800)
+	z := Saastamoinen{}.ZenithDelay(epoch, geodetic)
+	if got := TroposphericDelay(Saastamoinen{}, Niell{}, epoch, geodetic, math.Pi/2); math.Abs(got-z.Hydrostatic-z.Wet) > 1e-9 {
+		t.Errorf("zenith %v m, want %v m", got, z.Hydrostatic+z.Wet)
+	}
+	mh, mw := Niell{}.Mapping(epoch, geodetic, 0.2)
+	if got := TroposphericDelay(Saastamoinen{}, Niell{}, epoch, geodetic, 0.2); math.Abs(got-z.Hydrostatic*mh-z.Wet*mw) > 1e-9 {
+		t.Errorf("slant %v m", got)
+	}
+}
@@ -0,0 +1,298 @@
+package gnss
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  GPT2w / GPT3
+// Global Pressure and Temperature models of TU Wien (Boehm et al. 2015,
+// Landskron and Boehm 2018), read from their grid files (gpt2_1w.grd,
+// gpt2_5w.grd, gpt3_1.grd, gpt3_5.grd, optionally compressed). Every cell of
+// the 1° or 5° grid holds a mean, annual and semiannual terms of pressure,
+// temperature, specific humidity, temperature lapse rate, the VMF a
+// coefficients, water vapour decrease factor and mean temperature, plus the
+// geoid undulation and height of the cell. GPT3 adds the hydrostatic and wet
+// gradients. The four cells around the receiver are reduced to its height
+// and interpolated bilinearly.
+// https://vmf.geo.tuwien.ac.at/codes/
+
+// GPTValues are the GPT2w / GPT3 quantities at a receiver.
+type GPTValues struct {
+	// hPa
+	Pressure float64
+	// K
+	Temperature float64
+	// K/m, negative when the temperature decreases with height
+	LapseRate float64
+	// weighted mean temperature of the water vapour, K
+	MeanTemperature float64
+	// water vapour partial pressure, hPa
+	WaterVapour float64
+	// VMF1 (GPT2w) or VMF3 (GPT3) a coefficients
+	Ah, Aw float64
+	// water vapour decrease factor
+	Lambda float64
+	// geoid undulation, m
+	Undulation float64
+	// hydrostatic and wet north / east gradient coefficients of GPT3, m
+	GradientNorthH, GradientEastH, GradientNorthW, GradientEastW float64
+}
+
+// gptSeries are the mean, annual cos / sin and semiannual cos / sin terms of
+// one quantity.
+type gptSeries [5]float64
+
+func (s gptSeries) at(cosY, sinY, cosH, sinH float64) float64 {
+	return s[0] + s[1]*cosY + s[2]*sinY + s[3]*cosH + s[4]*sinH
+}
+
+type gptCell struct {
+	pressure, temperature, humidity, lapseRate gptSeries
+	undulation, height                         float64
+	ah, aw, lambda, meanTemperature            gptSeries
+	gradients                                  [4]gptSeries
+}
+
+// GPTGrid is a loaded GPT2w or GPT3 grid, it is a TroposphereModel.
+type GPTGrid struct {
+	// GPT3 grids carry gradients and VMF3 a coefficients
+	GPT3 bool
+	// VMF3 are the b and c coefficients GPT3 grids map with
+	VMF3       *VMF3Coefficients
+	resolution float64
+	rows, cols int
+	cells      []gptCell
+}
+
+// LoadGPTGrid reads a GPT2w or GPT3 grid file, the version and resolution
+// are taken from the file. GPT3 grids fail to load while
+// DefaultVMF3Coefficients fails, use LoadGPT3Grid for them.
+func LoadGPTGrid(filename string) (*GPTGrid, error) {
+	grid, err := loadGPTGrid(filename)
+	if err != nil || !grid.GPT3 {
+		return grid, err
+	}
+	if grid.VMF3, err = DefaultVMF3Coefficients(); err != nil {
+		return nil, fmt.Errorf("%s: GPT3 grid needs the VMF3 coefficients: %v", filename, err)
+	}
+	return grid, nil
+}
+
+// LoadGPT3Grid reads a GPT3 grid file that maps with the VMF3 coefficients.
+func LoadGPT3Grid(filename string, coefficients *VMF3Coefficients) (*GPTGrid, error) {
+	grid, err := loadGPTGrid(filename)
+	if err != nil {
+		return nil, err
+	}
+	if !grid.GPT3 {
+		return nil, fmt.Errorf("%s: not a GPT3 grid", filename)
+	}
+	grid.VMF3 = coefficients
+	return grid, nil
+}
+
+func loadGPTGrid(filename string) (*GPTGrid, error) {
+	f, err := openFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	grid := &GPTGrid{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var latitudes, longitudes []float64
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || text[0] == '%' {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 44 && len(fields) != 64 {
+			return nil, fmt.Errorf("%s:%d: expected 44 (GPT2w) or 64 (GPT3) columns, got %d", filename, line, len(fields))
+		}
+		values := make([]float64, len(fields))
+		for i, field := range fields {
+			if values[i], err = strconv.ParseFloat(field, 64); err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid value %q", filename, line, field)
+			}
+		}
+		grid.GPT3 = len(values) == 64
+		latitudes = append(latitudes, values[0])
+		longitudes = append(longitudes, values[1])
+		grid.cells = append(grid.cells, newGPTCell(values))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", filename, err)
+	}
+	if len(grid.cells) < 2 {
+		return nil, fmt.Errorf("%s: no grid cells", filename)
+	}
+
+	// rows run from the north pole, longitudes from 0 east
+	grid.resolution = math.Abs(longitudes[1] - longitudes[0])
+	if grid.resolution != 1 && grid.resolution != 5 {
+		return nil, fmt.Errorf("%s: unsupported grid resolution %g°", filename, grid.resolution)
+	}
+	grid.rows = int(180 / grid.resolution)
+	grid.cols = int(360 / grid.resolution)
+	if len(grid.cells) != grid.rows*grid.cols {
+		return nil, fmt.Errorf("%s: %d cells, expected %d", filename, len(grid.cells), grid.rows*grid.cols)
+	}
+	for i := range grid.cells {
+		row, col := grid.index(latitudes[i], longitudes[i])
+		if row*grid.cols+col != i {
+			return nil, fmt.Errorf("%s: cell %g %g out of order", filename, latitudes[i], longitudes[i])
+		}
+	}
+	return grid, nil
+}
+
+// newGPTCell splits a line of the grid: lat, lon, p (Pa), T (K), Q (g/kg),
+// dT (mK/m), undulation, height (m), ah, aw (1e-3), lambda, Tm (K) and for
+// GPT3 the gradients (1e-5 m).
+func newGPTCell(v []float64) gptCell {
+	series := func(from int, scale float64) gptSeries {
+		var s gptSeries
+		for i := range s {
+			s[i] = v[from+i] * scale
+		}
+		return s
+	}
+	cell := gptCell{
+		pressure:        series(2, 1),
+		temperature:     series(7, 1),
+		humidity:        series(12, 1e-3),
+		lapseRate:       series(17, 1e-3),
+		undulation:      v[22],
+		height:          v[23],
+		ah:              series(24, 1e-3),
+		aw:              series(29, 1e-3),
+		lambda:          series(34, 1),
+		meanTemperature: series(39, 1),
+	}
+	if len(v) == 64 {
+		for i := range cell.gradients {
+			cell.gradients[i] = series(44+5*i, 1e-5)
+		}
+	}
+	return cell
+}
+
+// index returns the cell containing latitude and longitude (degrees).
+func (g *GPTGrid) index(latitude, longitude float64) (int, int) {
+	row := int(math.Floor((90 - latitude) / g.resolution))
+	col := int(math.Floor(math.Mod(longitude+360, 360) / g.resolution))
+	return min(max(row, 0), g.rows-1), col % g.cols
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// Values returns the GPT quantities at geodetic and t.
+func (g *GPTGrid) Values(t GPSTime, geodetic []float64) GPTValues {
+	// seasonal terms from January 1st 2000 12:00
+	days := modifiedJulianDate(t) - 51544.5
+	cosY, sinY := math.Cos(days/365.25*2*math.Pi), math.Sin(days/365.25*2*math.Pi)
+	cosH, sinH := math.Cos(days/365.25*4*math.Pi), math.Sin(days/365.25*4*math.Pi)
+
+	// position within the grid in cells, from the center of the first one
+	colatitude := 90 - geodetic[0]*180/math.Pi
+	longitude := math.Mod(geodetic[1]*180/math.Pi+360, 360)
+	y := colatitude/g.resolution - 0.5
+	x := longitude/g.resolution - 0.5
+	row0, col0 := int(math.Floor(y)), int(math.Floor(x))
+	fy, fx := y-float64(row0), x-float64(col0)
+
+	var v GPTValues
+	for _, corner := range [4]struct {
+		row, col int
+		weight   float64
+	}{
+		{row0, col0, (1 - fy) * (1 - fx)},
+		{row0 + 1, col0, fy * (1 - fx)},
+		{row0, col0 + 1, (1 - fy) * fx},
+		{row0 + 1, col0 + 1, fy * fx},
+	} {
+		row := min(max(corner.row, 0), g.rows-1)
+		col := (corner.col + g.cols) % g.cols
+		c := g.cells[row*g.cols+col].at(geodetic[2], cosY, sinY, cosH, sinH)
+		w := corner.weight
+		v.Pressure += w * c.Pressure
+		v.Temperature += w * c.Temperature
+		v.LapseRate += w * c.LapseRate
+		v.MeanTemperature += w * c.MeanTemperature
+		v.WaterVapour += w * c.WaterVapour
+		v.Ah += w * c.Ah
+		v.Aw += w * c.Aw
+		v.Lambda += w * c.Lambda
+		v.Undulation += w * c.Undulation
+		v.GradientNorthH += w * c.GradientNorthH
+		v.GradientEastH += w * c.GradientEastH
+		v.GradientNorthW += w * c.GradientNorthW
+		v.GradientEastW += w * c.GradientEastW
+	}
+	return v
+}
+
+// at reduces the cell to the ellipsoidal height of the receiver.
+func (c gptCell) at(height, cosY, sinY, cosH, sinH float64) GPTValues {
+	// orthometric height of the receiver above the cell
+	reduction := height - c.undulation - c.height
+
+	t0 := c.temperature.at(cosY, sinY, cosH, sinH)
+	p0 := c.pressure.at(cosY, sinY, cosH, sinH)
+	q := c.humidity.at(cosY, sinY, cosH, sinH)
+	lapseRate := c.lapseRate.at(cosY, sinY, cosH, sinH)
+	lambda := c.lambda.at(cosY, sinY, cosH, sinH)
+
+	virtual := t0 * (1 + 0.6077*q)
+	pressure := p0 * math.Exp(-standardGravity*dryAirMolarMass/(gasConstant*virtual)*reduction) / 100
+	e0 := q * p0 / (0.622 + 0.378*q) / 100
+
+	return GPTValues{
+		Pressure:        pressure,
+		Temperature:     t0 + lapseRate*reduction,
+		LapseRate:       lapseRate,
+		MeanTemperature: c.meanTemperature.at(cosY, sinY, cosH, sinH),
+		WaterVapour:     e0 * math.Pow(100*pressure/p0, lambda+1),
+		Ah:              c.ah.at(cosY, sinY, cosH, sinH),
+		Aw:              c.aw.at(cosY, sinY, cosH, sinH),
+		Lambda:          lambda,
+		Undulation:      c.undulation,
+		GradientNorthH:  c.gradients[0].at(cosY, sinY, cosH, sinH),
+		GradientEastH:   c.gradients[1].at(cosY, sinY, cosH, sinH),
+		GradientNorthW:  c.gradients[2].at(cosY, sinY, cosH, sinH),
+		GradientEastW:   c.gradients[3].at(cosY, sinY, cosH, sinH),
+	}
+}
+
+// ZenithDelay is the Saastamoinen hydrostatic and Askne-Nordius wet delay of
+// the GPT meteorology.
+func (g *GPTGrid) ZenithDelay(t GPSTime, geodetic []float64) ZenithDelay {
+	v := g.Values(t, geodetic)
+	return ZenithDelay{
+		Hydrostatic: saastamoinenHydrostatic(v.Pressure, geodetic),
+		Wet:         askneWet(v.WaterVapour, v.MeanTemperature, v.Lambda),
+	}
+}
+
+// Mapping returns VMF1 with the GPT2w a coefficients and VMF3 with the GPT3
+// ones and the VMF3 coefficients of the grid.
+func (g *GPTGrid) Mapping(t GPSTime, geodetic []float64, elevation float64) (float64, float64) {
+	v := g.Values(t, geodetic)
+	if g.GPT3 {
+		return VMF3{Ah: v.Ah, Aw: v.Aw, Coefficients: g.VMF3}.Mapping(t, geodetic, elevation)
+	}
+	return VMF1{Ah: v.Ah, Aw: v.Aw}.Mapping(t, geodetic, elevation)
+}
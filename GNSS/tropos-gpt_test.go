@@ -0,0 +1,131 @@
+package gnss
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeGPTGrid writes a 5° grid with the same cell everywhere: 1013.25 hPa
+// and 15 °C at sea level, a lapse rate of -6.5 mK/m, dry air, no seasons.
+// GPT3 grids get a hydrostatic north gradient of 1e-5 m.
+func writeGPTGrid(tb testing.TB, gpt3 bool) string {
+	tb.Helper()
+	constant := func(v float64) string { return fmt.Sprintf("%g 0 0 0 0", v) }
+	cell := strings.Join([]string{
+		constant(101325), constant(288.15), constant(0), constant(-6.5), "0 0",
+		constant(1.21), constant(0.58), constant(3), constant(270),
+	}, " ")
+	if gpt3 {
+		cell += " " + strings.Join([]string{constant(1), constant(0), constant(0), constant(0)}, " ")
+	}
+	var b strings.Builder
+	b.WriteString("% lat lon p:a0 ...\n")
+	for latitude := 87.5; latitude > -90; latitude -= 5 {
+		for longitude := 2.5; longitude < 360; longitude += 5 {
+			fmt.Fprintf(&b, "%g %g %s\n", latitude, longitude, cell)
+		}
+	}
+	filename := filepath.Join(tb.TempDir(), "gpt_5.grd")
+	if err := os.WriteFile(filename, []byte(b.String()), 0644); err != nil {
+		tb.Fatal(err)
+	}
+	return filename
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func TestGPTGrid(t *testing.T) {
+	grid, err := LoadGPTGrid(writeGPTGrid(t, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grid.GPT3 {
+		t.Error("GPT2w grid read as GPT3")
+	}
+	epoch := GPSTimeFromWeek(2325, 172800)
+	geodetic := []float64{0.8, 0.2, 0}
+	v := grid.Values(epoch, geodetic)
+	if math.Abs(v.Pressure-1013.25) > 1e-9 || math.Abs(v.Temperature-288.15) > 1e-9 || v.WaterVapour != 0 ||
+		math.Abs(v.Ah-0.00121) > 1e-15 || math.Abs(v.Lambda-3) > 1e-12 {
+		t.Errorf("sea level %+v", v)
+	}
+	z := grid.ZenithDelay(epoch, geodetic)
+	want := Saastamoinen{Meteo: &Meteo{Pressure: 1013.25}}.ZenithDelay(epoch, geodetic)
+	if math.Abs(z.Hydrostatic-want.Hydrostatic) > 1e-9 || z.Wet != 0 {
+		t.Errorf("zenith %+v, want %+v", z, want)
+	}
+	mh, mw := grid.Mapping(epoch, geodetic, 0.3)
+	if wantH, wantW := (VMF1{Ah: v.Ah, Aw: v.Aw}).Mapping(epoch, geodetic, 0.3); mh != wantH || mw != wantW {
+		t.Errorf("mapping %v %v, VMF1 %v %v", mh, mw, wantH, wantW)
+	}
+
+	// barometric reduction with the virtual temperature of dry air
+	high := grid.Values(epoch, []float64{-1.2, 5.5, 1000})
+	pressure := 1013.25 * math.Exp(-standardGravity*dryAirMolarMass/(gasConstant*288.15)*1000)
+	if math.Abs(high.Pressure-pressure) > 1e-9 || math.Abs(high.Temperature-(288.15-6.5)) > 1e-9 {
+		t.Errorf("1000 m %v hPa %v K, want %v hPa", high.Pressure, high.Temperature, pressure)
+	}
+}
+
+// GPT3 grids map with VMF3 and their coefficients, never with VMF1.
+func TestGPT3Grid(t *testing.T) {
+	filename := writeGPTGrid(t, true)
+	if _, err := DefaultVMF3Coefficients(); err != nil {
+		if _, err := LoadGPTGrid(filename); err == nil {
+			t.Error("GPT3 grid loaded without VMF3 coefficients")
+		}
+	}
+	if _, err := LoadGPT3Grid(writeGPTGrid(t, false), nil); err == nil {
+		t.Error("GPT2w grid loaded as GPT3")
+	}
+
+	c, err := LoadVMF3Coefficients(vmf3File(map[[2]int]float64{{0, 0}: 0.003, {4, 0}: 0.07}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid, err := LoadGPT3Grid(filename, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !grid.GPT3 {
+		t.Fatal("GPT3 grid read as GPT2w")
+	}
+	epoch := GPSTimeFromWeek(2325, 172800)
+	geodetic := []float64{0.3, 3, 0}
+	v := grid.Values(epoch, geodetic)
+	if math.Abs(v.GradientNorthH-1e-5) > 1e-18 || v.GradientEastH != 0 {
+		t.Errorf("gradients %v %v", v.GradientNorthH, v.GradientEastH)
+	}
+	mh, mw := grid.Mapping(epoch, geodetic, 0.3)
+	if wantH, wantW := (VMF3{Ah: v.Ah, Aw: v.Aw, Coefficients: c}).Mapping(epoch, geodetic, 0.3); mh != wantH || mw != wantW {
+		t.Errorf("mapping %v %v, VMF3 %v %v", mh, mw, wantH, wantW)
+	}
+	if vh, _ := (VMF1{Ah: v.Ah, Aw: v.Aw}).Mapping(epoch, geodetic, 0.3); mh == vh {
+		t.Errorf("mapping %v is VMF1", mh)
+	}
+}
+
+func TestLoadGPTGridErrors(t *testing.T) {
+	dir := t.TempDir()
+	for name, text := range map[string]string{
+		"columns":    "87.5 2.5 1 2 3\n",
+		"resolution": strings.Repeat("87.5 2.5"+strings.Repeat(" 0", 42)+"\n", 3),
+	} {
+		filename := filepath.Join(dir, name)
+		if err := os.WriteFile(filename, []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadGPTGrid(filename); err == nil {
+			t.Errorf("%s: grid loaded", name)
+		}
+	}
+	if _, err := LoadGPTGrid(filepath.Join(dir, "missing.grd")); err == nil {
+		t.Error("missing grid loaded")
+	}
+}
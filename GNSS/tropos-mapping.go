@@ -0,0 +1,284 @@
+package gnss
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io/fs"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  MAPPING FUNCTIONS
+// Continued fraction form of Marini (1972) normalized to one at the zenith:
+//   m(el) = (1 + a/(1 + b/(1 + c))) / (sin el + a/(sin el + b/(sin el + c)))
+// Niell (1996) takes a, b and c from latitude and season tables, VMF1 (Boehm
+// et al. 2006) and VMF3 (Landskron and Boehm 2018) take a from numerical
+// weather models (ah / aw of the VMF grids or GPT2w / GPT3) and b, c from
+// empirical functions. The hydrostatic part gets the Niell height
+// correction.
+
+func marini(elevation, a, b, c float64) float64 {
+	sine := math.Sin(elevation)
+	return (1 + a/(1+b/(1+c))) / (sine + a/(sine+b/(sine+c)))
+}
+
+// heightCorrection is added to the hydrostatic mapping of a receiver height
+// meters above the sea.
+func heightCorrection(elevation, height float64) float64 {
+	return (1/math.Sin(elevation) - marini(elevation, 2.53e-5, 5.49e-3, 1.14e-3)) * height / 1000
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// Niell mapping function (NMF), no meteorological input.
+type Niell struct{}
+
+var niellLatitudes = []float64{15, 30, 45, 60, 75}
+
+// hydrostatic a, b, c averages and amplitudes, wet a, b, c per latitude row
+var niellCoefficients = [9][5]float64{
+	{1.2769934e-3, 1.2683230e-3, 1.2465397e-3, 1.2196049e-3, 1.2045996e-3},
+	{2.9153695e-3, 2.9152299e-3, 2.9288445e-3, 2.9022565e-3, 2.9024912e-3},
+	{62.610505e-3, 62.837393e-3, 63.721774e-3, 63.824265e-3, 64.258455e-3},
+	{0, 1.2709626e-5, 2.6523662e-5, 3.4000452e-5, 4.1202191e-5},
+	{0, 2.1414979e-5, 3.0160779e-5, 7.2562722e-5, 11.723375e-5},
+	{0, 9.0128400e-5, 4.3497037e-5, 84.795348e-5, 170.37206e-5},
+	{5.8021897e-4, 5.6794847e-4, 5.8118019e-4, 5.9727542e-4, 6.1641693e-4},
+	{1.4275268e-3, 1.5138625e-3, 1.4572752e-3, 1.5007428e-3, 1.7599082e-3},
+	{4.3472961e-2, 4.6729510e-2, 4.3908931e-2, 4.4626982e-2, 5.4736038e-2},
+}
+
+func (Niell) Mapping(t GPSTime, geodetic []float64, elevation float64) (float64, float64) {
+	latitude := geodetic[0] * 180 / math.Pi
+	// seasons are reversed in the south
+	y := (dayOfYear(t) - 28) / 365.25
+	if latitude < 0 {
+		y += 0.5
+	}
+	cosy := math.Cos(2 * math.Pi * y)
+	latitude = math.Abs(latitude)
+
+	var h, w [3]float64
+	for i := 0; i < 3; i++ {
+		average := interpolateLatitude(niellLatitudes, latitude, func(j int) float64 { return niellCoefficients[i][j] })
+		amplitude := interpolateLatitude(niellLatitudes, latitude, func(j int) float64 { return niellCoefficients[i+3][j] })
+		h[i] = average - amplitude*cosy
+		w[i] = interpolateLatitude(niellLatitudes, latitude, func(j int) float64 { return niellCoefficients[i+6][j] })
+	}
+	mh := marini(elevation, h[0], h[1], h[2]) + heightCorrection(elevation, geodetic[2])
+	return mh, marini(elevation, w[0], w[1], w[2])
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// VMF1 with the a coefficients of a VMF1 grid or site file, or of GPT2w.
+type VMF1 struct {
+	Ah float64
+	Aw float64
+}
+
+func (v VMF1) Mapping(t GPSTime, geodetic []float64, elevation float64) (float64, float64) {
+	doy := modifiedJulianDate(t) - 44239 + 1 - 28
+
+	phase, c11, c10 := 0.0, 0.005, 0.001
+	if geodetic[0] < 0 {
+		phase, c11, c10 = math.Pi, 0.007, 0.002
+	}
+	ch := 0.062 + ((math.Cos(doy/365.25*2*math.Pi+phase)+1)*c11/2+c10)*(1-math.Cos(geodetic[0]))
+
+	mh := marini(elevation, v.Ah, 0.0029, ch) + heightCorrection(elevation, geodetic[2])
+	return mh, marini(elevation, v.Aw, 0.00146, 0.04391)
+}
+
+// =========================================================================
+
+// =========================================================================
+//  VMF3 EMPIRICAL COEFFICIENTS
+// The b and c coefficients of VMF3 (vmf3.m of TU Wien) are spherical
+// harmonic expansions to degree and order 12 of the receiver position, each
+// term with mean, annual and semiannual parts:
+//   b = Σ anm(doy) Vnm + bnm(doy) Wnm
+// Vnm, Wnm are the unnormalized harmonics cos / sin(m λ) Pnm(sin φ). The
+// tables of vmf3.m (anm_bh, bnm_bh, anm_bw, bnm_bw, anm_ch, bnm_ch, anm_cw,
+// bnm_cw in that order, rows n = 0 ... 12, m = 0 ... n) are read from
+// vmf3-coefficients.txt. The copy in vmf3-data is embedded in the package.
+// https://vmf.geo.tuwien.ac.at/codes/vmf3.m
+
+const (
+	vmf3Degree    = 12
+	vmf3TermCount = (vmf3Degree + 1) * (vmf3Degree + 2) / 2
+	vmf3FileName  = "vmf3-coefficients.txt"
+)
+
+// vmf3Expansion holds the cosine and sine terms of one coefficient.
+type vmf3Expansion struct {
+	anm, bnm [vmf3TermCount]gptSeries
+}
+
+// VMF3Coefficients are the empirical b and c expansions of VMF3.
+type VMF3Coefficients struct {
+	bh, bw, ch, cw vmf3Expansion
+}
+
+//go:embed vmf3-data
+var vmf3Files embed.FS
+
+var (
+	defaultVMF3Once         sync.Once
+	defaultVMF3Coefficients *VMF3Coefficients
+	defaultVMF3Err          error
+)
+
+// DefaultVMF3Coefficients returns the expansions embedded from vmf3-data,
+// read once.
+func DefaultVMF3Coefficients() (*VMF3Coefficients, error) {
+	defaultVMF3Once.Do(func() {
+		fsys, err := fs.Sub(vmf3Files, "vmf3-data")
+		if err != nil {
+			defaultVMF3Err = fmt.Errorf("failed to open embedded VMF3 coefficients: %v", err)
+			return
+		}
+		if defaultVMF3Coefficients, err = LoadVMF3Coefficients(fsys); err != nil {
+			defaultVMF3Err = fmt.Errorf("failed to load embedded VMF3 coefficients: %v", err)
+		}
+	})
+	return defaultVMF3Coefficients, defaultVMF3Err
+}
+
+// LoadVMF3Coefficients reads vmf3-coefficients.txt from fsys.
+func LoadVMF3Coefficients(fsys fs.FS) (*VMF3Coefficients, error) {
+	values, err := readDataValues(fsys, vmf3FileName, 8*vmf3TermCount*5)
+	if err != nil {
+		return nil, err
+	}
+	c := &VMF3Coefficients{}
+	for _, e := range []*vmf3Expansion{&c.bh, &c.bw, &c.ch, &c.cw} {
+		for _, table := range []*[vmf3TermCount]gptSeries{&e.anm, &e.bnm} {
+			for i := range table {
+				copy(table[i][:], values)
+				values = values[5:]
+			}
+		}
+	}
+	return c, nil
+}
+
+// readDataValues reads the count numbers of a data file, Fortran D
+// exponents included.
+func readDataValues(fsys fs.FS, name string, count int) ([]float64, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+	defer f.Close()
+	r, err := Decompress(f)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing %s: %v", name, err)
+	}
+
+	values := make([]float64, 0, count)
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		word := strings.Replace(scanner.Text(), "D", "E", 1)
+		v, err := strconv.ParseFloat(word, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid value %q", name, scanner.Text())
+		}
+		values = append(values, v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", name, err)
+	}
+	if len(values) != count {
+		return nil, fmt.Errorf("%s: %d values, expected %d", name, len(values), count)
+	}
+	return values, nil
+}
+
+// At returns bh, bw, ch and cw at t and geodetic (latitude, longitude in
+// radians).
+func (c *VMF3Coefficients) At(t GPSTime, geodetic []float64) (float64, float64, float64, float64) {
+	doy := modifiedJulianDate(t) - 44239 + 1 - 28
+	angle := doy / 365.25 * 2 * math.Pi
+	cosY, sinY := math.Cos(angle), math.Sin(angle)
+	cosH, sinH := math.Cos(2*angle), math.Sin(2*angle)
+
+	v, w := vmf3Harmonics(geodetic[0], geodetic[1])
+	sum := func(e *vmf3Expansion) float64 {
+		total := 0.0
+		for i := range v {
+			total += e.anm[i].at(cosY, sinY, cosH, sinH)*v[i] + e.bnm[i].at(cosY, sinY, cosH, sinH)*w[i]
+		}
+		return total
+	}
+	return sum(&c.bh), sum(&c.bw), sum(&c.ch), sum(&c.cw)
+}
+
+// vmf3Harmonics returns Vnm and Wnm in the row order of the tables, by the
+// recursions of vmf3.m on the unit vector of the position.
+func vmf3Harmonics(latitude, longitude float64) ([vmf3TermCount]float64, [vmf3TermCount]float64) {
+	x := math.Cos(latitude) * math.Cos(longitude)
+	y := math.Cos(latitude) * math.Sin(longitude)
+	z := math.Sin(latitude)
+
+	var vnm, wnm [vmf3Degree + 1][vmf3Degree + 1]float64
+	vnm[0][0] = 1
+	vnm[1][0] = z
+	for n := 2; n <= vmf3Degree; n++ {
+		vnm[n][0] = (float64(2*n-1)*z*vnm[n-1][0] - float64(n-1)*vnm[n-2][0]) / float64(n)
+	}
+	for m := 1; m <= vmf3Degree; m++ {
+		vnm[m][m] = float64(2*m-1) * (x*vnm[m-1][m-1] - y*wnm[m-1][m-1])
+		wnm[m][m] = float64(2*m-1) * (x*wnm[m-1][m-1] + y*vnm[m-1][m-1])
+		if m < vmf3Degree {
+			vnm[m+1][m] = float64(2*m+1) * z * vnm[m][m]
+			wnm[m+1][m] = float64(2*m+1) * z * wnm[m][m]
+		}
+		for n := m + 2; n <= vmf3Degree; n++ {
+			vnm[n][m] = (float64(2*n-1)*z*vnm[n-1][m] - float64(n+m-1)*vnm[n-2][m]) / float64(n-m)
+			wnm[n][m] = (float64(2*n-1)*z*wnm[n-1][m] - float64(n+m-1)*wnm[n-2][m]) / float64(n-m)
+		}
+	}
+
+	var v, w [vmf3TermCount]float64
+	i := 0
+	for n := 0; n <= vmf3Degree; n++ {
+		for m := 0; m <= n; m++ {
+			v[i], w[i] = vnm[n][m], wnm[n][m]
+			i++
+		}
+	}
+	return v, w
+}
+
+// VMF3 with the a coefficients of a VMF3 grid or of GPT3 and the empirical
+// b and c of Coefficients, nil for DefaultVMF3Coefficients. Without
+// coefficients the mapping factors are NaN.
+type VMF3 struct {
+	Ah, Aw       float64
+	Coefficients *VMF3Coefficients
+}
+
+func (v VMF3) Mapping(t GPSTime, geodetic []float64, elevation float64) (float64, float64) {
+	c := v.Coefficients
+	if c == nil {
+		var err error
+		if c, err = DefaultVMF3Coefficients(); err != nil {
+			return math.NaN(), math.NaN()
+		}
+	}
+	bh, bw, ch, cw := c.At(t, geodetic)
+	mh := marini(elevation, v.Ah, bh, ch) + heightCorrection(elevation, geodetic[2])
+	return mh, marini(elevation, v.Aw, bw, cw)
+}
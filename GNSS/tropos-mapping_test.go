@@ -0,0 +1,100 @@
+package gnss
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// vmf3File returns vmf3-coefficients.txt with every term zero except the
+// mean of set, keyed by table (0 anm_bh ... 7 bnm_cw) and row.
+func vmf3File(set map[[2]int]float64) fstest.MapFS {
+	var b strings.Builder
+	for table := 0; table < 8; table++ {
+		for row := 0; row < vmf3TermCount; row++ {
+			fmt.Fprintf(&b, "%g 0 0 0 0\n", set[[2]int{table, row}])
+		}
+	}
+	return fstest.MapFS{vmf3FileName: {Data: []byte(b.String())}}
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// Every mapping is one at the zenith and, in between, above one and below
+// the flat Earth 1/sin(el). The wet layer is lower, closer to flat.
+func TestMappingFunctions(t *testing.T) {
+	epoch := GPSTimeFromWeek(2325, 172800)
+	geodetic := []float64{0.8, 0.2, 0}
+	for _, c := range []struct {
+		name    string
+		mapping MappingFunction
+	}{
+		{"Niell", Niell{}},
+		{"VMF1", VMF1{Ah: 0.00121, Aw: 0.00058}},
+		{"Hopfield", Hopfield{}},
+	} {
+		mh, mw := c.mapping.Mapping(epoch, geodetic, math.Pi/2)
+		if math.Abs(mh-1) > 1e-6 || math.Abs(mw-1) > 1e-6 {
+			t.Errorf("%s: zenith %v %v", c.name, mh, mw)
+		}
+		for _, elevation := range []float64{5, 10, 30, 60} {
+			e := elevation * math.Pi / 180
+			mh, mw := c.mapping.Mapping(epoch, geodetic, e)
+			if mh <= 1 || mh >= mw || mw >= 1/math.Sin(e) {
+				t.Errorf("%s: %v° %v %v, flat %v", c.name, elevation, mh, mw, 1/math.Sin(e))
+			}
+		}
+	}
+
+	// the height correction raises the hydrostatic factor only
+	low, lowWet := Niell{}.Mapping(epoch, geodetic, 0.1)
+	high, highWet := Niell{}.Mapping(epoch, []float64{0.8, 0.2, 2000}, 0.1)
+	if high <= low || highWet != lowWet {
+		t.Errorf("2000 m %v %v, sea level %v %v", high, highWet, low, lowWet)
+	}
+}
+
+// VMF3 with the constant b and c of VMF1 at the equator is VMF1.
+func TestVMF3(t *testing.T) {
+	c, err := LoadVMF3Coefficients(vmf3File(map[[2]int]float64{
+		{0, 0}: 0.0029, {2, 0}: 0.00146, {4, 0}: 0.062, {6, 0}: 0.04391,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	epoch := GPSTimeFromWeek(2325, 172800)
+	equator := []float64{0, 1, 500}
+	for _, elevation := range []float64{0.1, 0.5, 1.2} {
+		mh, mw := VMF3{Ah: 0.00121, Aw: 0.00058, Coefficients: c}.Mapping(epoch, equator, elevation)
+		wantH, wantW := VMF1{Ah: 0.00121, Aw: 0.00058}.Mapping(epoch, equator, elevation)
+		if math.Abs(mh-wantH) > 1e-12 || math.Abs(mw-wantW) > 1e-12 {
+			t.Errorf("%v rad: %v %v, VMF1 %v %v", elevation, mh, mw, wantH, wantW)
+		}
+	}
+
+	// V10 = sin φ, W11 = cos φ sin λ
+	c, err = LoadVMF3Coefficients(vmf3File(map[[2]int]float64{{0, 1}: 1, {3, 2}: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	geodetic := []float64{0.7, 2.1, 0}
+	bh, bw, ch, cw := c.At(epoch, geodetic)
+	if math.Abs(bh-math.Sin(0.7)) > 1e-12 || math.Abs(bw-math.Cos(0.7)*math.Sin(2.1)) > 1e-12 || ch != 0 || cw != 0 {
+		t.Errorf("coefficients %v %v %v %v", bh, bw, ch, cw)
+	}
+
+	short := fstest.MapFS{vmf3FileName: {Data: []byte("1 2 3\n")}}
+	if _, err := LoadVMF3Coefficients(short); err == nil {
+		t.Error("short coefficient file loaded")
+	}
+	if _, err := DefaultVMF3Coefficients(); err != nil {
+		mh, mw := VMF3{Ah: 0.00121, Aw: 0.00058}.Mapping(epoch, geodetic, 0.5)
+		if !math.IsNaN(mh) || !math.IsNaN(mw) {
+			t.Errorf("mapping %v %v without coefficients", mh, mw)
+		}
+	}
+}
@@ -0,0 +1,360 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"capnproto.org/go/capnp/v3"
+	gnss "github.com/mothergoose31/GNNS-GO/GNSS"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  CONVERT
+// Reads a text file (format detected from its content) or, with -from, a
+// capnp message written by "convert -format capnp", keeps the records within
+// the -start / -end and -systems selection and writes them as:
+//   navigation    rinex (-version 2.11 / 3.0x), capnp, json, csv
+//   observation   capnp, json
+//   SP3           capnp, json
+// -packed reads and writes packed capnp messages.
+
+var convertFormats = []string{"rinex", "capnp", "json", "csv"}
+
+func runConvert(fs *flag.FlagSet, args []string) error {
+	var c commonFlags
+	c.register(fs, convertFormats...)
+	from := fs.String("from", "auto", "input: auto (detect a text file), or a capnp message of nav, obs or sp3")
+	version := fs.Float64("version", 3.04, "RINEX version of -format rinex")
+	packed := fs.Bool("packed", false, "capnp messages are packed")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return errUsage
+	}
+	if err := c.parse(convertFormats...); err != nil {
+		return err
+	}
+
+	file, err := loadConvertInput(fs.Arg(0), *from, *packed)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case file.Navigation != nil:
+		nav := filterNav(file.Navigation, &c)
+		return c.write(func(w io.Writer) error {
+			switch c.format {
+			case "rinex":
+				return gnss.WriteNav(w, nav, *version)
+			case "capnp":
+				return gnss.SaveNav(w, nav, *packed)
+			case "json":
+				return gnss.WriteNavJSON(w, nav)
+			default:
+				return gnss.WriteNavCSV(w, nav)
+			}
+		})
+
+	case file.Observation != nil:
+		if c.format != "capnp" && c.format != "json" {
+			return fmt.Errorf("observation files cannot be written as %s", c.format)
+		}
+		epochs, err := filterObservations(file.Epochs, &c)
+		if err != nil {
+			return err
+		}
+		return c.write(func(w io.Writer) error {
+			if c.format == "capnp" {
+				return gnss.SaveObservation(w, file.Observation, epochs, *packed)
+			}
+			bw := bufio.NewWriter(w)
+			if err := jsonLine(bw, "header", gnss.ObservationHeader_TypeID, capnp.Struct(*file.Observation)); err != nil {
+				return err
+			}
+			for _, epoch := range epochs {
+				if err := jsonLine(bw, "epoch", gnss.ObservationEpoch_TypeID, capnp.Struct(epoch)); err != nil {
+					return err
+				}
+			}
+			return bw.Flush()
+		})
+
+	case file.SP3 != nil:
+		if c.format != "capnp" && c.format != "json" {
+			return fmt.Errorf("SP3 files cannot be written as %s", c.format)
+		}
+		sp3, err := filterSP3(*file.SP3, &c)
+		if err != nil {
+			return err
+		}
+		return c.write(func(w io.Writer) error {
+			if c.format == "capnp" {
+				return gnss.SaveSP3(w, &sp3, *packed)
+			}
+			bw := bufio.NewWriter(w)
+			header, err := sp3.Header()
+			if err != nil {
+				return fmt.Errorf("failed to get SP3 header: %v", err)
+			}
+			if err := jsonLine(bw, "header", gnss.SP3Header_TypeID, capnp.Struct(header)); err != nil {
+				return err
+			}
+			epochs, err := sp3.Epochs()
+			if err != nil {
+				return fmt.Errorf("failed to get SP3 epochs: %v", err)
+			}
+			for i := 0; i < epochs.Len(); i++ {
+				if err := jsonLine(bw, "epoch", gnss.SP3Epoch_TypeID, capnp.Struct(epochs.At(i))); err != nil {
+					return err
+				}
+			}
+			return bw.Flush()
+		})
+	}
+	return fmt.Errorf("%s files cannot be converted", file.Format)
+}
+
+// jsonLine writes {"key":s} and a newline, the layout of gnss.WriteNavJSON.
+func jsonLine(w *bufio.Writer, key string, typeID uint64, s capnp.Struct) error {
+	w.WriteString(`{"` + key + `":`)
+	if err := gnss.EncodeJSON(w, typeID, s); err != nil {
+		return err
+	}
+	w.WriteString("}\n")
+	return nil
+}
+
+func loadConvertInput(filename, from string, packed bool) (*gnss.ParsedFile, error) {
+	if from == "auto" {
+		return parse(filename)
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %v", err)
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+
+	file := &gnss.ParsedFile{}
+	switch from {
+	case "nav":
+		file.Format = gnss.FORMAT_RINEX_NAV
+		file.Navigation, err = gnss.LoadNav(r, packed)
+	case "obs":
+		file.Format = gnss.FORMAT_RINEX_OBS
+		file.Observation, file.Epochs, err = gnss.LoadObservation(r, packed)
+	case "sp3":
+		file.Format = gnss.FORMAT_SP3
+		file.SP3, err = gnss.LoadSP3(r, packed)
+	default:
+		return nil, fmt.Errorf("unknown input %q, expected auto, nav, obs or sp3", from)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return file, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// filterObservations keeps the epochs within the window. With -systems the
+// epochs are copied into a new message without the other satellites.
+func filterObservations(epochs []gnss.ObservationEpoch, c *commonFlags) ([]gnss.ObservationEpoch, error) {
+	var seg *capnp.Segment
+	if c.systems != "" {
+		_, s, err := capnp.NewMessage(capnp.MultiSegment(nil))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new message: %v", err)
+		}
+		seg = s
+	}
+
+	var kept []gnss.ObservationEpoch
+	for _, epoch := range epochs {
+		t, err := epoch.GpsTime()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get epoch time: %v", err)
+		}
+		if !c.inWindow(t) {
+			continue
+		}
+		if seg == nil {
+			kept = append(kept, epoch)
+			continue
+		}
+
+		copied, err := gnss.NewObservationEpoch(seg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new epoch: %v", err)
+		}
+		if err := copied.SetGpsTime(t); err != nil {
+			return nil, fmt.Errorf("failed to set epoch time: %v", err)
+		}
+		if date, err := epoch.Epoch(); err == nil {
+			copied.SetEpoch(date)
+		}
+		if records, err := epoch.EventRecords(); err == nil {
+			copied.SetEventRecords(records)
+		}
+		copied.SetEventFlag(epoch.EventFlag())
+		copied.SetReceiverClockOffset(epoch.ReceiverClockOffset())
+
+		satellites, err := epoch.Satellites()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get satellites: %v", err)
+		}
+		var selected []gnss.SatelliteObservation
+		for i := 0; i < satellites.Len(); i++ {
+			if prn, _ := satellites.At(i).Prn(); c.selected(prn) {
+				selected = append(selected, satellites.At(i))
+			}
+		}
+		list, err := copied.NewSatellites(int32(len(selected)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new satellites: %v", err)
+		}
+		for i, sat := range selected {
+			if err := list.Set(i, sat); err != nil {
+				return nil, fmt.Errorf("failed to copy satellite: %v", err)
+			}
+		}
+		kept = append(kept, copied)
+	}
+	return kept, nil
+}
+
+// filterSP3 copies the epochs within the window and the satellites of the
+// selected systems into a new message.
+func filterSP3(sp3 gnss.SP3FormatEphemeris, c *commonFlags) (gnss.SP3FormatEphemeris, error) {
+	if !c.bounded[0] && !c.bounded[1] && c.systems == "" {
+		return sp3, nil
+	}
+	header, err := sp3.Header()
+	if err != nil {
+		return sp3, fmt.Errorf("failed to get SP3 header: %v", err)
+	}
+	epochs, err := sp3.Epochs()
+	if err != nil {
+		return sp3, fmt.Errorf("failed to get SP3 epochs: %v", err)
+	}
+
+	_, seg, err := capnp.NewMessage(capnp.MultiSegment(nil))
+	if err != nil {
+		return sp3, fmt.Errorf("failed to create new message: %v", err)
+	}
+	filtered, err := gnss.NewRootSP3FormatEphemeris(seg)
+	if err != nil {
+		return sp3, fmt.Errorf("failed to create new SP3FormatEphemeris: %v", err)
+	}
+	if err := filtered.SetHeader(header); err != nil {
+		return sp3, fmt.Errorf("failed to set SP3 header: %v", err)
+	}
+	copiedHeader, err := filtered.Header()
+	if err != nil {
+		return sp3, fmt.Errorf("failed to get SP3 header: %v", err)
+	}
+	if err := filterSP3Satellites(copiedHeader, c); err != nil {
+		return sp3, err
+	}
+
+	var kept []gnss.SP3Epoch
+	for i := 0; i < epochs.Len(); i++ {
+		t, err := epochs.At(i).GpsTime()
+		if err != nil {
+			return sp3, fmt.Errorf("failed to get epoch time: %v", err)
+		}
+		if c.inWindow(t) {
+			kept = append(kept, epochs.At(i))
+		}
+	}
+	list, err := filtered.NewEpochs(int32(len(kept)))
+	if err != nil {
+		return sp3, fmt.Errorf("failed to create new SP3 epochs: %v", err)
+	}
+	for i, epoch := range kept {
+		copied := list.At(i)
+		if t, err := epoch.Time(); err == nil {
+			copied.SetTime(t)
+		}
+		if t, err := epoch.GpsTime(); err == nil {
+			copied.SetGpsTime(t)
+		}
+		entries, err := epoch.Entries()
+		if err != nil {
+			return sp3, fmt.Errorf("failed to get SP3 entries: %v", err)
+		}
+		var selected []gnss.SP3Entry
+		for j := 0; j < entries.Len(); j++ {
+			if prn, _ := entries.At(j).SatelliteVehicleNumber(); c.selected(prn) {
+				selected = append(selected, entries.At(j))
+			}
+		}
+		copiedEntries, err := copied.NewEntries(int32(len(selected)))
+		if err != nil {
+			return sp3, fmt.Errorf("failed to create new SP3 entries: %v", err)
+		}
+		for j, entry := range selected {
+			if err := copiedEntries.Set(j, entry); err != nil {
+				return sp3, fmt.Errorf("failed to copy SP3 entry: %v", err)
+			}
+		}
+	}
+	copiedHeader.SetNumberOfEpochs(int32(len(kept)))
+	if len(kept) > 0 {
+		if t, err := kept[0].Time(); err == nil {
+			copiedHeader.SetStart(t)
+		}
+	}
+	return filtered, nil
+}
+
+// filterSP3Satellites drops the satellites of other systems, and their
+// accuracy exponents, from the header.
+func filterSP3Satellites(header gnss.SP3Header, c *commonFlags) error {
+	satellites, err := header.Satellites()
+	if err != nil {
+		return fmt.Errorf("failed to get SP3 satellites: %v", err)
+	}
+	exponents, err := header.AccuracyExponents()
+	if err != nil {
+		return fmt.Errorf("failed to get SP3 accuracy exponents: %v", err)
+	}
+
+	var prns []string
+	var accuracy []int32
+	for i := 0; i < satellites.Len(); i++ {
+		prn, _ := satellites.At(i)
+		if !c.selected(prn) {
+			continue
+		}
+		prns = append(prns, prn)
+		if i < exponents.Len() {
+			accuracy = append(accuracy, exponents.At(i))
+		}
+	}
+
+	list, err := header.NewSatellites(int32(len(prns)))
+	if err != nil {
+		return fmt.Errorf("failed to create new SP3 satellites: %v", err)
+	}
+	for i, prn := range prns {
+		list.Set(i, prn)
+	}
+	exponentList, err := header.NewAccuracyExponents(int32(len(accuracy)))
+	if err != nil {
+		return fmt.Errorf("failed to create new SP3 accuracy exponents: %v", err)
+	}
+	for i, exponent := range accuracy {
+		exponentList.Set(i, exponent)
+	}
+	header.SetNumberOfSatellites(int32(len(prns)))
+	return nil
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	gnss "github.com/mothergoose31/GNNS-GO/GNSS"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  SHARED FLAGS
+// Every command takes the same selection flags:
+//   -start, -end   UTC time window, RFC 3339 ("2024-07-30T06:00:00Z") or
+//                  "2024-07-30 06:00:00", open when empty
+//   -systems       satellite systems by RINEX letter ("GRE"), all when empty
+//   -format        output format, the choices depend on the command
+//   -o             output file, stdout when empty
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+type commonFlags struct {
+	start   string
+	end     string
+	systems string
+	format  string
+	output  string
+
+	from, to gnss.GPSTime
+	bounded  [2]bool
+}
+
+func (c *commonFlags) register(fs *flag.FlagSet, formats ...string) {
+	fs.StringVar(&c.start, "start", "", "first time (UTC) of the window")
+	fs.StringVar(&c.end, "end", "", "last time (UTC) of the window")
+	fs.StringVar(&c.systems, "systems", "", "satellite systems to keep, e.g. GRE (default all)")
+	fs.StringVar(&c.format, "format", formats[0], "output format: "+strings.Join(formats, ", "))
+	fs.StringVar(&c.output, "o", "", "output file (default stdout)")
+}
+
+// parse checks the flag values once fs has been parsed.
+func (c *commonFlags) parse(formats ...string) error {
+	if !contains(formats, c.format) {
+		return fmt.Errorf("unknown format %q, expected one of %s", c.format, strings.Join(formats, ", "))
+	}
+	for i, value := range []string{c.start, c.end} {
+		if value == "" {
+			continue
+		}
+		t, err := parseTime(value)
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			c.from = gnss.UTCToGPST(t)
+		} else {
+			c.to = gnss.UTCToGPST(t)
+		}
+		c.bounded[i] = true
+	}
+	if c.bounded[0] && c.bounded[1] && c.to.Sub(c.from) < 0 {
+		return fmt.Errorf("end %s is before start %s", c.end, c.start)
+	}
+	c.systems = strings.ToUpper(c.systems)
+	return nil
+}
+
+// inWindow reports whether t lies in the -start / -end window.
+func (c *commonFlags) inWindow(t gnss.GPSTime) bool {
+	if c.bounded[0] && t.Sub(c.from) < 0 {
+		return false
+	}
+	if c.bounded[1] && t.Sub(c.to) > 0 {
+		return false
+	}
+	return true
+}
+
+// selected reports whether the system of prn passes -systems.
+func (c *commonFlags) selected(prn string) bool {
+	return c.systems == "" || prn != "" && strings.Contains(c.systems, prn[:1])
+}
+
+// window returns the bounds of the time window, unbounded sides are taken
+// from first and last.
+func (c *commonFlags) window(first, last gnss.GPSTime) (gnss.GPSTime, gnss.GPSTime) {
+	if c.bounded[0] {
+		first = c.from
+	}
+	if c.bounded[1] {
+		last = c.to
+	}
+	return first, last
+}
+
+// write runs fn on the -o file, or stdout.
+func (c *commonFlags) write(fn func(w io.Writer) error) error {
+	if c.output == "" {
+		return fn(os.Stdout)
+	}
+	file, err := os.Create(c.output)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	if err := fn(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func parseTime(value string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected e.g. 2024-07-30T06:00:00Z", value)
+}
+
+// parseFloats reads n comma separated numbers.
+func parseFloats(value string, n int) ([]float64, error) {
+	fields := strings.Split(value, ",")
+	if len(fields) != n {
+		return nil, fmt.Errorf("expected %d comma separated values, got %q", n, value)
+	}
+	values := make([]float64, n)
+	for i, field := range fields {
+		v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", field)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// table collects the output of a command: rows for the text and csv formats,
+// one record per row for json.
+type table struct {
+	columns []string
+	rows    [][]string
+	records []any
+}
+
+func (t *table) add(record any, row ...string) {
+	t.rows = append(t.rows, row)
+	t.records = append(t.records, record)
+}
+
+// write prints the table as format: text (aligned columns), csv, or json
+// (JSON Lines).
+func (t *table) write(w io.Writer, format string) error {
+	switch format {
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write(t.columns)
+		cw.WriteAll(t.rows)
+		if err := cw.Error(); err != nil {
+			return fmt.Errorf("failed to write CSV: %v", err)
+		}
+	case "json":
+		encoder := json.NewEncoder(w)
+		for _, record := range t.records {
+			if err := encoder.Encode(record); err != nil {
+				return fmt.Errorf("failed to write JSON: %v", err)
+			}
+		}
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(t.columns, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		if err := tw.Flush(); err != nil {
+			return fmt.Errorf("failed to write table: %v", err)
+		}
+	}
+	return nil
+}
+
+func formatTime(t gnss.GPSTime) string {
+	return t.ToUTC().Format(time.RFC3339)
+}
+
+// gpsSeconds returns t as seconds since the GPS epoch, a map key for epochs.
+func gpsSeconds(t gnss.GPSTime) float64 {
+	return float64(t.Week())*gnss.SecondsInWeek + t.TimeOfWeek()
+}
+
+func formatFloat(v float64, decimals int) string {
+	return strconv.FormatFloat(v, 'f', decimals, 64)
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	gnss "github.com/mothergoose31/GNNS-GO/GNSS"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  INFO
+// One block per file: format and header summary, then the time span,
+// satellites and epochs within the -start / -end and -systems selection.
+
+type infoField struct {
+	name  string
+	value string
+}
+
+func runInfo(fs *flag.FlagSet, args []string) error {
+	var c commonFlags
+	c.register(fs, "text", "json")
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		return errUsage
+	}
+	if err := c.parse("text", "json"); err != nil {
+		return err
+	}
+
+	return c.write(func(w io.Writer) error {
+		for i, filename := range fs.Args() {
+			file, err := parse(filename)
+			if err != nil {
+				return err
+			}
+			fields, err := fileInfo(filename, file, &c)
+			if err != nil {
+				return fmt.Errorf("%s: %v", filename, err)
+			}
+			if c.format == "json" {
+				record := make(map[string]string, len(fields))
+				for _, f := range fields {
+					record[f.name] = f.value
+				}
+				if err := json.NewEncoder(w).Encode(record); err != nil {
+					return fmt.Errorf("failed to write JSON: %v", err)
+				}
+				continue
+			}
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			writeInfoText(w, fields)
+		}
+		return nil
+	})
+}
+
+func writeInfoText(w io.Writer, fields []infoField) {
+	width := 0
+	for _, f := range fields {
+		width = max(width, len(f.name))
+	}
+	for _, f := range fields {
+		fmt.Fprintf(w, "%-*s  %s\n", width+1, f.name+":", f.value)
+	}
+}
+
+func fileInfo(filename string, file *gnss.ParsedFile, c *commonFlags) ([]infoField, error) {
+	fields := []infoField{
+		{"file", filename},
+		{"format", file.Format.String()},
+		{"version", file.Version},
+	}
+
+	switch {
+	case file.Navigation != nil:
+		fields = append(fields, navHeaderInfo(file.Navigation)...)
+	case file.Observation != nil:
+		fields = append(fields, observationHeaderInfo(*file.Observation)...)
+	case file.SP3 != nil:
+		header, err := file.SP3.Header()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SP3 header: %v", err)
+		}
+		orbitType, _ := header.OrbitType()
+		frame, _ := header.CoordinateSystem()
+		timeSystem, _ := header.TimeSystem()
+		agency, _ := header.Agency()
+		fields = append(fields,
+			infoField{"agency", agency},
+			infoField{"orbit type", orbitType},
+			infoField{"coordinate system", frame},
+			infoField{"time system", timeSystem},
+			infoField{"interval", fmt.Sprintf("%g s", header.EpochInterval())},
+		)
+	case file.IONEX != nil:
+		// maps carry no satellites
+		fields = append(fields, ionexInfo(file.IONEX)...)
+	}
+
+	if len(file.Warnings) > 0 {
+		fields = append(fields, infoField{"warnings", fmt.Sprint(len(file.Warnings))})
+	}
+	if file.IONEX == nil {
+		keys, err := fileKeys(file)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, contentInfo(filterKeys(keys, c))...)
+	}
+
+	// headers leave most fields blank
+	kept := fields[:0]
+	for _, f := range fields {
+		if f.value != "" {
+			kept = append(kept, f)
+		}
+	}
+	return kept, nil
+}
+
+func ionexInfo(x *gnss.IONEX) []infoField {
+	fields := []infoField{
+		{"program", x.Program},
+		{"agency", x.Agency},
+		{"system", x.System},
+		{"mapping function", x.MappingFunction},
+		{"grid", fmt.Sprintf("lat %g..%g step %g, lon %g..%g step %g, height %g km",
+			x.Latitude.Start, x.Latitude.End, x.Latitude.Step,
+			x.Longitude.Start, x.Longitude.End, x.Longitude.Step, x.Height.Start)},
+		{"tec maps", fmt.Sprint(len(x.TEC))},
+		{"rms maps", fmt.Sprint(len(x.RMS))},
+	}
+	if len(x.TEC) > 0 {
+		fields = append(fields,
+			infoField{"start", x.TEC[0].Epoch.Format(time.RFC3339)},
+			infoField{"end", x.TEC[len(x.TEC)-1].Epoch.Format(time.RFC3339)},
+		)
+	}
+	if x.Interval > 0 {
+		fields = append(fields, infoField{"interval", fmt.Sprintf("%d s", x.Interval)})
+	}
+	if n := len(x.SatelliteBiases) + len(x.StationBiases); n > 0 {
+		fields = append(fields, infoField{"code biases", fmt.Sprintf("%d satellites, %d stations",
+			len(x.SatelliteBiases), len(x.StationBiases))})
+	}
+	return fields
+}
+
+func navHeaderInfo(nav *gnss.NavigationData) []infoField {
+	program, _ := nav.Header.ProgramName()
+	agency, _ := nav.Header.Agency()
+	system, _ := nav.Header.SatelliteSystem()
+	fields := []infoField{
+		{"program", program},
+		{"agency", agency},
+		{"system", system},
+	}
+
+	var corrections []string
+	if list, err := nav.Header.IonosphericCorrections(); err == nil {
+		for i := 0; i < list.Len(); i++ {
+			t, _ := list.At(i).CorrectionType()
+			corrections = append(corrections, t)
+		}
+	}
+	if list, err := nav.Header.TimeSystemCorrections(); err == nil {
+		for i := 0; i < list.Len(); i++ {
+			t, _ := list.At(i).CorrectionType()
+			corrections = append(corrections, t)
+		}
+	}
+	fields = append(fields, infoField{"corrections", strings.Join(corrections, " ")})
+	if leap := nav.Header.LeapSeconds(); leap != 0 {
+		fields = append(fields, infoField{"leap seconds", fmt.Sprint(leap)})
+	}
+	return fields
+}
+
+func observationHeaderInfo(header gnss.ObservationHeader) []infoField {
+	marker, _ := header.MarkerName()
+	receiver, _ := header.ReceiverType()
+	antenna, _ := header.AntennaType()
+	fields := []infoField{
+		{"marker", marker},
+		{"receiver", receiver},
+		{"antenna", antenna},
+	}
+	if position, err := header.ApproximatePosition(); err == nil && position.Len() == 3 {
+		fields = append(fields, infoField{"approximate position", fmt.Sprintf("%.3f %.3f %.3f",
+			position.At(0), position.At(1), position.At(2))})
+	}
+	if interval := header.Interval(); interval > 0 {
+		fields = append(fields, infoField{"interval", fmt.Sprintf("%g s", interval)})
+	}
+	for _, system := range []string{"G", "R", "E", "C", "J", "I", "S"} {
+		if codes := header.Codes(system); len(codes) > 0 {
+			fields = append(fields, infoField{"observations " + system, strings.Join(codes, " ")})
+		}
+	}
+	return fields
+}
+
+// contentInfo summarizes the selected satellite epochs: time span, number
+// of epochs and satellites per system.
+func contentInfo(keys []satelliteEpoch) []infoField {
+	if len(keys) == 0 {
+		return []infoField{{"epochs", "0"}, {"satellites", "0"}}
+	}
+
+	first, last := keys[0].epoch, keys[0].epoch
+	epochs := make(map[float64]bool)
+	satellites := make(map[string]map[string]bool)
+	for _, key := range keys {
+		if key.epoch.Sub(first) < 0 {
+			first = key.epoch
+		}
+		if key.epoch.Sub(last) > 0 {
+			last = key.epoch
+		}
+		epochs[gpsSeconds(key.epoch)] = true
+		system := key.prn[:min(1, len(key.prn))]
+		if satellites[system] == nil {
+			satellites[system] = make(map[string]bool)
+		}
+		satellites[system][key.prn] = true
+	}
+
+	systems := make([]string, 0, len(satellites))
+	total := 0
+	for system, prns := range satellites {
+		systems = append(systems, fmt.Sprintf("%s %d", system, len(prns)))
+		total += len(prns)
+	}
+	sort.Strings(systems)
+	return []infoField{
+		{"start", formatTime(first)},
+		{"end", formatTime(last)},
+		{"epochs", fmt.Sprint(len(epochs))},
+		{"records", fmt.Sprint(len(keys))},
+		{"satellites", fmt.Sprintf("%d (%s)", total, strings.Join(systems, ", "))},
+	}
+}
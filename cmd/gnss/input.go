@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+
+	gnss "github.com/mothergoose31/GNNS-GO/GNSS"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  INPUT FILES
+// Files are parsed by content (see gnss.ParseFile), orbit files go into an
+// EphemerisStore for the positioning commands.
+
+func parse(filename string) (*gnss.ParsedFile, error) {
+	file, err := gnss.ParseFile(filename, gnss.ParseOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return file, nil
+}
+
+// loadOrbits stores the ephemerides of navigation and SP3 files.
+func loadOrbits(filenames []string) (*gnss.EphemerisStore, error) {
+	store := gnss.NewEphemerisStore()
+	for _, filename := range filenames {
+		file, err := parse(filename)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case file.Navigation != nil:
+			store.AddNavigationData(file.Navigation)
+		case file.SP3 != nil:
+			if _, err := store.AddSP3(*file.SP3, filename, gnss.SP3OrbitType(filename)); err != nil {
+				return nil, fmt.Errorf("%s: %v", filename, err)
+			}
+		default:
+			return nil, fmt.Errorf("%s: %s files hold no orbits", filename, file.Format)
+		}
+	}
+	if len(store.PRNs()) == 0 {
+		return nil, fmt.Errorf("no usable orbits in %v", filenames)
+	}
+	return store, nil
+}
+
+// =========================================================================
+
+// =========================================================================
+
+type navRecord interface {
+	BaseEphemeris() (gnss.BaseEphemeris, error)
+}
+
+// filterNav returns the records of nav that pass the window and system
+// flags. The result is not backed by a file message, see gnss.SaveNav.
+func filterNav(nav *gnss.NavigationData, c *commonFlags) *gnss.NavigationData {
+	return &gnss.NavigationData{
+		Header:  nav.Header,
+		GPS:     filterRecords(nav.GPS, c),
+		GLONASS: filterRecords(nav.GLONASS, c),
+		Galileo: filterRecords(nav.Galileo, c),
+		BeiDou:  filterRecords(nav.BeiDou, c),
+		QZSS:    filterRecords(nav.QZSS, c),
+		NavIC:   filterRecords(nav.NavIC, c),
+		SBAS:    filterRecords(nav.SBAS, c),
+	}
+}
+
+func filterRecords[T navRecord](records []T, c *commonFlags) []T {
+	var kept []T
+	for _, record := range records {
+		prn, epoch, ok := recordKey(record)
+		if ok && c.selected(prn) && c.inWindow(epoch) {
+			kept = append(kept, record)
+		}
+	}
+	return kept
+}
+
+// navKeys returns PRN and epoch of every record of nav, SBAS included.
+func navKeys(nav *gnss.NavigationData) []satelliteEpoch {
+	var keys []satelliteEpoch
+	add := func(record navRecord) {
+		if prn, epoch, ok := recordKey(record); ok {
+			keys = append(keys, satelliteEpoch{prn, epoch})
+		}
+	}
+	for _, group := range [][]gnss.GPSEphemeris{nav.GPS, nav.QZSS, nav.NavIC} {
+		for _, eph := range group {
+			add(eph)
+		}
+	}
+	for _, eph := range nav.GLONASS {
+		add(eph)
+	}
+	for _, eph := range nav.Galileo {
+		add(eph)
+	}
+	for _, eph := range nav.BeiDou {
+		add(eph)
+	}
+	for _, eph := range nav.SBAS {
+		add(eph)
+	}
+	return keys
+}
+
+func recordKey(record navRecord) (string, gnss.GPSTime, bool) {
+	base, err := record.BaseEphemeris()
+	if err != nil {
+		return "", gnss.GPSTime{}, false
+	}
+	prn, err := base.PseudoRandomNumber()
+	if err != nil {
+		return "", gnss.GPSTime{}, false
+	}
+	epoch, err := base.Epoch()
+	if err != nil {
+		return "", gnss.GPSTime{}, false
+	}
+	return prn, epoch, true
+}
+
+// =========================================================================
+
+// =========================================================================
+
+// satelliteEpoch is one satellite seen in one epoch: a navigation record,
+// an observation or an SP3 position.
+type satelliteEpoch struct {
+	prn   string
+	epoch gnss.GPSTime
+}
+
+// fileKeys returns the satellite epochs of any parsed file.
+func fileKeys(file *gnss.ParsedFile) ([]satelliteEpoch, error) {
+	switch {
+	case file.Navigation != nil:
+		return navKeys(file.Navigation), nil
+	case file.Observation != nil:
+		var keys []satelliteEpoch
+		for _, epoch := range file.Epochs {
+			t, err := epoch.GpsTime()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get epoch time: %v", err)
+			}
+			satellites, err := epoch.Satellites()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get satellites: %v", err)
+			}
+			for i := 0; i < satellites.Len(); i++ {
+				prn, _ := satellites.At(i).Prn()
+				keys = append(keys, satelliteEpoch{prn, t})
+			}
+		}
+		return keys, nil
+	case file.SP3 != nil:
+		epochs, err := file.SP3.Epochs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SP3 epochs: %v", err)
+		}
+		var keys []satelliteEpoch
+		for i := 0; i < epochs.Len(); i++ {
+			t, err := epochs.At(i).GpsTime()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get epoch time: %v", err)
+			}
+			entries, err := epochs.At(i).Entries()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get SP3 entries: %v", err)
+			}
+			for j := 0; j < entries.Len(); j++ {
+				prn, _ := entries.At(j).SatelliteVehicleNumber()
+				keys = append(keys, satelliteEpoch{prn, t})
+			}
+		}
+		return keys, nil
+	}
+	return nil, fmt.Errorf("%s files are not supported", file.Format)
+}
+
+func filterKeys(keys []satelliteEpoch, c *commonFlags) []satelliteEpoch {
+	var kept []satelliteEpoch
+	for _, key := range keys {
+		if c.selected(key.prn) && c.inWindow(key.epoch) {
+			kept = append(kept, key)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  LIST
+// One row per satellite with its number of epochs and time span, or with
+// -epochs one row per satellite and epoch: navigation records, observed
+// satellites or SP3 positions.
+
+type satelliteSummary struct {
+	File   string    `json:"file"`
+	PRN    string    `json:"prn"`
+	Epochs int       `json:"epochs"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+}
+
+type satelliteEpochRecord struct {
+	File       string    `json:"file"`
+	PRN        string    `json:"prn"`
+	Week       int       `json:"gpsWeek"`
+	TimeOfWeek float64   `json:"gpsTimeOfWeek"`
+	UTC        time.Time `json:"utc"`
+}
+
+func runList(fs *flag.FlagSet, args []string) error {
+	var c commonFlags
+	c.register(fs, "text", "csv", "json")
+	epochs := fs.Bool("epochs", false, "list every epoch instead of one row per satellite")
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		return errUsage
+	}
+	if err := c.parse("text", "csv", "json"); err != nil {
+		return err
+	}
+
+	t := &table{columns: []string{"file", "prn", "epochs", "start", "end"}}
+	if *epochs {
+		t.columns = []string{"file", "prn", "gps_week", "gps_tow_s", "utc"}
+	}
+	for _, filename := range fs.Args() {
+		file, err := parse(filename)
+		if err != nil {
+			return err
+		}
+		keys, err := fileKeys(file)
+		if err != nil {
+			return fmt.Errorf("%s: %v", filename, err)
+		}
+		keys = filterKeys(keys, &c)
+		sort.SliceStable(keys, func(i, j int) bool {
+			if keys[i].prn != keys[j].prn {
+				return keys[i].prn < keys[j].prn
+			}
+			return keys[i].epoch.Sub(keys[j].epoch) < 0
+		})
+
+		if *epochs {
+			for _, key := range keys {
+				r := satelliteEpochRecord{filename, key.prn, int(key.epoch.Week()), key.epoch.TimeOfWeek(), key.epoch.ToUTC()}
+				t.add(r, r.File, r.PRN, fmt.Sprint(r.Week), formatFloat(r.TimeOfWeek, 3), formatTime(key.epoch))
+			}
+			continue
+		}
+		for i := 0; i < len(keys); {
+			j := i
+			for j < len(keys) && keys[j].prn == keys[i].prn {
+				j++
+			}
+			first, last := keys[i].epoch, keys[j-1].epoch
+			r := satelliteSummary{filename, keys[i].prn, j - i, first.ToUTC(), last.ToUTC()}
+			t.add(r, r.File, r.PRN, fmt.Sprint(r.Epochs), formatTime(first), formatTime(last))
+			i = j
+		}
+	}
+
+	return c.write(func(w io.Writer) error { return t.write(w, c.format) })
+}
@@ -0,0 +1,85 @@
+// Command gnss inspects, converts and positions with GNSS files: RINEX
+// navigation and observation files (plain, gzip, compress or Hatanaka
+// compressed) and SP3 orbits.
+//
+//	gnss info     [flags] file...
+//	gnss list     [flags] file...
+//	gnss convert  [flags] file
+//	gnss satpos   [flags] orbit-file...
+//	gnss skyplot  [flags] -location lat,lon,height orbit-file...
+//	gnss solve    [flags] observation-file orbit-file...
+//
+// Orbit files are navigation or SP3 files. Run "gnss <command> -h" for the
+// flags of a command.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(fs *flag.FlagSet, args []string) error
+}
+
+var commands = []command{
+	{"info", "[flags] file...", "summarize the header and content of files", runInfo},
+	{"list", "[flags] file...", "list the satellites and epochs of files", runList},
+	{"convert", "[flags] file", "convert a file to another format", runConvert},
+	{"satpos", "[flags] orbit-file...", "compute satellite ECEF and geodetic positions", runSatPos},
+	{"skyplot", "[flags] -location lat,lon,height orbit-file...", "compute azimuth and elevation of satellites seen from a location", runSkyPlot},
+	{"solve", "[flags] observation-file orbit-file...", "compute receiver positions from an observation file", runSolve},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	if name == "-h" || name == "-help" || name == "--help" || name == "help" {
+		usage()
+		return
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		fs := flag.NewFlagSet(cmd.name, flag.ExitOnError)
+		fs.Usage = func() {
+			fmt.Fprintf(fs.Output(), "usage: gnss %s %s\n\n%s\n\nflags:\n", cmd.name, cmd.usage, cmd.summary)
+			fs.PrintDefaults()
+		}
+		err := cmd.run(fs, os.Args[2:])
+		if errors.Is(err, errUsage) {
+			fs.Usage()
+			os.Exit(2)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gnss %s: %v\n", cmd.name, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "gnss: unknown command %q\n\n", name)
+	usage()
+	os.Exit(2)
+}
+
+// errUsage makes main print the usage of the command.
+var errUsage = errors.New("usage")
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: gnss <command> [flags] [files]\n\ncommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun \"gnss <command> -h\" for the flags of a command.\n")
+}
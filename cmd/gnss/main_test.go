@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gnss "github.com/mothergoose31/GNNS-GO/GNSS"
+)
+
+// testFile returns the path of a bundled file of the repository root.
+func testFile(tb testing.TB, name string) string {
+	tb.Helper()
+	path := filepath.Join("..", "..", name)
+	if _, err := os.Stat(path); err != nil {
+		tb.Skip(err)
+	}
+	return path
+}
+
+// runCommand runs the command name with args and -o set, and returns what it
+// wrote.
+func runCommand(tb testing.TB, name string, args ...string) ([]byte, error) {
+	tb.Helper()
+	output := filepath.Join(tb.TempDir(), "out")
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		fs := flag.NewFlagSet(name, flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		if err := cmd.run(fs, append([]string{"-o", output}, args...)); err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(output)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		return data, nil
+	}
+	tb.Fatalf("no command %s", name)
+	return nil, nil
+}
+
+// jsonLines decodes every line of data into a new T.
+func jsonLines[T any](tb testing.TB, data []byte) []T {
+	tb.Helper()
+	var records []T
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var record T
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			tb.Fatalf("%v: %s", err, scanner.Text())
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func TestList(t *testing.T) {
+	gps, glonass := testFile(t, "abpo2120.24n"), testFile(t, "brdc2050.24g")
+	nav, err := gnss.ParseNavFile(glonass, gnss.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := runCommand(t, "list", "-format", "json", "-systems", "r", gps, glonass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	summaries := jsonLines[satelliteSummary](t, data)
+	epochs := 0
+	for i, s := range summaries {
+		if s.File != glonass || s.PRN[0] != 'R' || s.End.Before(s.Start) {
+			t.Errorf("row %+v", s)
+		}
+		if i > 0 && summaries[i-1].PRN >= s.PRN {
+			t.Errorf("%s listed after %s", s.PRN, summaries[i-1].PRN)
+		}
+		epochs += s.Epochs
+	}
+	if epochs != len(nav.GLONASS) {
+		t.Errorf("%d epochs listed, want %d", epochs, len(nav.GLONASS))
+	}
+
+	start, end := "2024-07-23 06:00", "2024-07-23T12:00:00Z"
+	data, err = runCommand(t, "list", "-format", "json", "-epochs", "-start", start, "-end", end, glonass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := jsonLines[satelliteEpochRecord](t, data)
+	if len(records) == 0 {
+		t.Fatal("no epochs listed in the window")
+	}
+	from, to := time.Date(2024, 7, 23, 6, 0, 0, 0, time.UTC), time.Date(2024, 7, 23, 12, 0, 0, 0, time.UTC)
+	for _, r := range records {
+		if r.UTC.Before(from) || r.UTC.After(to) {
+			t.Errorf("%s epoch %v outside the window", r.PRN, r.UTC)
+		}
+	}
+}
+
+// A file converted to RINEX 3, or through a capnp message, reads back with
+// the same records.
+func TestConvert(t *testing.T) {
+	path := testFile(t, "abpo2120.24n")
+	nav, err := gnss.ParseNavFile(path, gnss.ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	direct, err := runCommand(t, "convert", "-format", "rinex", "-version", "2.11", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v3, err := runCommand(t, "convert", "-format", "rinex", "-version", "3.04", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	converted, err := gnss.ParseNav(bytes.NewReader(v3), gnss.ParseOptions{Strict: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if converted.Header.Version() != 3.04 || len(converted.GPS) != len(nav.GPS) {
+		t.Errorf("RINEX %v with %d GPS records, want %d", converted.Header.Version(), len(converted.GPS), len(nav.GPS))
+	}
+
+	message := filepath.Join(t.TempDir(), "nav.capnp")
+	data, err := runCommand(t, "convert", "-format", "capnp", "-packed", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(message, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	back, err := runCommand(t, "convert", "-from", "nav", "-packed", "-format", "rinex", "-version", "2.11", message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(back, direct) {
+		t.Error("RINEX 2.11 written from the capnp message differs")
+	}
+
+	if _, err := runCommand(t, "convert", "-format", "csv", "../../GNSS/testdata/obs-211.24o"); err == nil {
+		t.Error("observation file converted to CSV")
+	}
+}
+
+func TestSatPos(t *testing.T) {
+	path := testFile(t, "abpo2120.24n")
+	data, err := runCommand(t, "satpos", "-format", "json", "-prn", "g05", "-at", "2024-07-30T01:00:00Z", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	positions := jsonLines[gnss.SatellitePosition](t, data)
+	if len(positions) != 1 {
+		t.Fatalf("%d positions, want 1", len(positions))
+	}
+	p := positions[0]
+	if p.PRN != "G05" || !p.UTC.Equal(time.Date(2024, 7, 30, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("position of %s at %v", p.PRN, p.UTC)
+	}
+	if r := p.Position[0]*p.Position[0] + p.Position[1]*p.Position[1] + p.Position[2]*p.Position[2]; r < 25e6*25e6 || r > 28e6*28e6 {
+		t.Errorf("position %v is not a GPS orbit", p.Position)
+	}
+}
+
+func TestFlagErrors(t *testing.T) {
+	path := testFile(t, "abpo2120.24n")
+	for _, c := range []struct {
+		name string
+		args []string
+	}{
+		{"info", []string{"-format", "csv", path}},
+		{"list", []string{"-start", "2024-07-30", "-end", "2024-07-29", path}},
+		{"list", []string{"-start", "yesterday", path}},
+		{"satpos", []string{"-step", "0", path}},
+		{"skyplot", []string{"-location", "1,2", path}},
+		{"convert", []string{path, path}},
+	} {
+		if _, err := runCommand(t, c.name, c.args...); err == nil {
+			t.Errorf("%s %v succeeded", c.name, c.args)
+		}
+	}
+	if _, err := runCommand(t, "list"); !errors.Is(err, errUsage) {
+		t.Errorf("list without files: %v, want the usage", err)
+	}
+}
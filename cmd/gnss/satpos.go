@@ -0,0 +1,167 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	gnss "github.com/mothergoose31/GNNS-GO/GNSS"
+	"github.com/mothergoose31/GNNS-GO/GNSS/helpers"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  SATPOS / SKYPLOT
+// Satellite states from the best orbit of the given navigation and SP3 files
+// (see gnss.EphemerisStore), every -step seconds over the -start / -end
+// window, or once at -at. The window defaults to the span of the orbits.
+
+type orbitFlags struct {
+	at   string
+	step float64
+	prns string
+}
+
+func (o *orbitFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.at, "at", "", "single time (UTC) instead of the -start / -end window")
+	fs.Float64Var(&o.step, "step", 900, "time step in seconds")
+	fs.StringVar(&o.prns, "prn", "", "comma separated satellites, e.g. G05,E11 (default all)")
+}
+
+// positions loads the orbit files and computes the selected satellite
+// positions.
+func (o *orbitFlags) positions(c *commonFlags, filenames []string) ([]gnss.SatellitePosition, error) {
+	if o.step <= 0 {
+		return nil, fmt.Errorf("step must be positive, got %g", o.step)
+	}
+	store, err := loadOrbits(filenames)
+	if err != nil {
+		return nil, err
+	}
+
+	var prns []string
+	if o.prns != "" {
+		for _, prn := range strings.Split(o.prns, ",") {
+			prns = append(prns, strings.ToUpper(strings.TrimSpace(prn)))
+		}
+	} else {
+		for _, prn := range store.PRNs() {
+			if c.selected(prn) {
+				prns = append(prns, prn)
+			}
+		}
+	}
+
+	var start, end gnss.GPSTime
+	if o.at != "" {
+		t, err := parseTime(o.at)
+		if err != nil {
+			return nil, err
+		}
+		start = gnss.UTCToGPST(t)
+		end = start
+	} else {
+		first, last := orbitSpan(store, prns)
+		start, end = c.window(first, last)
+	}
+	return gnss.SatellitePositions(store, prns, start, end, o.step), nil
+}
+
+// orbitSpan returns the first and last ephemeris epoch of prns.
+func orbitSpan(store *gnss.EphemerisStore, prns []string) (gnss.GPSTime, gnss.GPSTime) {
+	var first, last gnss.GPSTime
+	found := false
+	for _, prn := range prns {
+		ephs := store.Ephemerides(prn)
+		if len(ephs) == 0 {
+			continue
+		}
+		if e := ephs[0].Epoch(); !found || e.Sub(first) < 0 {
+			first = e
+		}
+		if e := ephs[len(ephs)-1].Epoch(); !found || e.Sub(last) > 0 {
+			last = e
+		}
+		found = true
+	}
+	return first, last
+}
+
+// =========================================================================
+
+// =========================================================================
+
+func runSatPos(fs *flag.FlagSet, args []string) error {
+	formats := []string{"text", "csv", "json", "geojson"}
+	var c commonFlags
+	var o orbitFlags
+	c.register(fs, formats...)
+	o.register(fs)
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		return errUsage
+	}
+	if err := c.parse(formats...); err != nil {
+		return err
+	}
+
+	positions, err := o.positions(&c, fs.Args())
+	if err != nil {
+		return err
+	}
+	return c.write(func(w io.Writer) error {
+		switch c.format {
+		case "csv":
+			return gnss.WritePositionsCSV(w, positions)
+		case "json":
+			return gnss.WritePositionsJSON(w, positions)
+		case "geojson":
+			return gnss.WriteGroundTracksGeoJSON(w, positions)
+		}
+		t := &table{columns: []string{"prn", "utc", "x_m", "y_m", "z_m", "latitude", "longitude", "height_m", "clock_bias_us"}}
+		for _, p := range positions {
+			t.add(p, p.PRN, p.UTC.Format("2006-01-02T15:04:05Z"),
+				formatFloat(p.Position[0], 3), formatFloat(p.Position[1], 3), formatFloat(p.Position[2], 3),
+				formatFloat(p.Latitude, 5), formatFloat(p.Longitude, 5), formatFloat(p.Height, 3),
+				formatFloat(p.ClockBias*1e6, 3))
+		}
+		return t.write(w, c.format)
+	})
+}
+
+func runSkyPlot(fs *flag.FlagSet, args []string) error {
+	formats := []string{"text", "csv", "json"}
+	var c commonFlags
+	var o orbitFlags
+	c.register(fs, formats...)
+	o.register(fs)
+	location := fs.String("location", "", "receiver latitude,longitude (degrees),height (m)")
+	mask := fs.Float64("mask", 0, "elevation mask in degrees")
+	fs.Parse(args)
+	if fs.NArg() == 0 || *location == "" {
+		return errUsage
+	}
+	if err := c.parse(formats...); err != nil {
+		return err
+	}
+	geodetic, err := parseFloats(*location, 3)
+	if err != nil {
+		return fmt.Errorf("invalid location: %v", err)
+	}
+	receiver := helpers.GeodeticToECEF([][]float64{geodetic}, false)[0]
+
+	positions, err := o.positions(&c, fs.Args())
+	if err != nil {
+		return err
+	}
+	angles := gnss.LookAngles(receiver, positions, *mask)
+
+	t := &table{columns: []string{"prn", "utc", "azimuth", "elevation", "range_m"}}
+	for _, a := range angles {
+		t.add(a, a.PRN, a.UTC.Format("2006-01-02T15:04:05Z"),
+			formatFloat(a.Azimuth, 2), formatFloat(a.Elevation, 2), formatFloat(a.Range, 1))
+	}
+	return c.write(func(w io.Writer) error { return t.write(w, c.format) })
+}
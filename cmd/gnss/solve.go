@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	gnss "github.com/mothergoose31/GNNS-GO/GNSS"
+)
+
+// =========================================================================
+
+// =========================================================================
+//  SOLVE
+// Single point position of every epoch of an observation file within the
+// -start / -end window, see gnss.SolvePosition. Each solution starts from
+// the previous one, the first from the approximate position of the header.
+// Epochs that cannot be solved are reported on stderr and skipped.
+// -troposphere selects the zenith delay model, gpt reads the GPT2w / GPT3
+// grid of -gpt and maps with VMF1 (GPT2w) or VMF3 (GPT3, which needs the
+// embedded VMF3 coefficients), the others use Niell.
+
+func runSolve(fs *flag.FlagSet, args []string) error {
+	formats := []string{"text", "csv", "json"}
+	var c commonFlags
+	c.register(fs, formats...)
+	mask := fs.Float64("mask", 10, "elevation mask in degrees")
+	troposphere := fs.String("troposphere", "saastamoinen", "tropospheric delay: none, saastamoinen, hopfield, unb3m or gpt")
+	gpt := fs.String("gpt", "", "GPT2w / GPT3 grid file for -troposphere gpt")
+	fs.Parse(args)
+	if fs.NArg() < 2 {
+		return errUsage
+	}
+	if err := c.parse(formats...); err != nil {
+		return err
+	}
+
+	file, err := parse(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if file.Observation == nil {
+		return fmt.Errorf("%s: expected an observation file, got %s", fs.Arg(0), file.Format)
+	}
+	store, err := loadOrbits(fs.Args()[1:])
+	if err != nil {
+		return err
+	}
+
+	opts := gnss.PositionOptions{Systems: c.systems, ElevationMask: *mask}
+	if err := setTroposphere(&opts, *troposphere, *gpt); err != nil {
+		return err
+	}
+	if position, err := file.Observation.ApproximatePosition(); err == nil && position.Len() == 3 {
+		opts.InitialPosition = []float64{position.At(0), position.At(1), position.At(2)}
+	}
+
+	t := &table{columns: []string{"utc", "latitude", "longitude", "height_m", "x_m", "y_m", "z_m", "satellites", "pdop", "rms_m"}}
+	for _, epoch := range file.Epochs {
+		at, err := epoch.GpsTime()
+		if err != nil || !c.inWindow(at) || epoch.EventFlag() > 1 {
+			continue
+		}
+		solution, err := gnss.SolvePosition(store, epoch, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gnss solve: %s: %v\n", formatTime(at), err)
+			continue
+		}
+		opts.InitialPosition = solution.Position[:]
+
+		rms := 0.0
+		for _, r := range solution.Residuals {
+			rms += r * r
+		}
+		rms = math.Sqrt(rms / float64(len(solution.Residuals)))
+		t.add(solution, solution.UTC.Format("2006-01-02T15:04:05Z"),
+			formatFloat(solution.Latitude, 8), formatFloat(solution.Longitude, 8), formatFloat(solution.Height, 3),
+			formatFloat(solution.Position[0], 3), formatFloat(solution.Position[1], 3), formatFloat(solution.Position[2], 3),
+			fmt.Sprint(len(solution.Satellites)), formatFloat(solution.PDOP, 2), formatFloat(rms, 3))
+	}
+	return c.write(func(w io.Writer) error { return t.write(w, c.format) })
+}
+
+// setTroposphere sets the troposphere model of opts from the -troposphere
+// and -gpt flags.
+func setTroposphere(opts *gnss.PositionOptions, model, grid string) error {
+	switch model {
+	case "none":
+	case "saastamoinen":
+		opts.Troposphere = gnss.Saastamoinen{}
+	case "hopfield":
+		opts.Troposphere = gnss.Hopfield{}
+		opts.Mapping = gnss.Hopfield{}
+	case "unb3m":
+		opts.Troposphere = gnss.UNB3m{}
+	case "gpt":
+		if grid == "" {
+			return errors.New("-troposphere gpt needs a -gpt grid file")
+		}
+		g, err := gnss.LoadGPTGrid(grid)
+		if err != nil {
+			return fmt.Errorf("failed to load GPT grid: %v", err)
+		}
+		opts.Troposphere = g
+		opts.Mapping = g
+	default:
+		return fmt.Errorf("unknown troposphere %q, expected none, saastamoinen, hopfield, unb3m or gpt", model)
+	}
+	return nil
+}